@@ -36,6 +36,7 @@ func action() *cobra.Command {
 		cli.NewCommand(actionDocCmd, actionDocRun, nil),
 		cli.NewCommand(actionImportCmd, actionImportRun, nil),
 		cli.NewCommand(actionExportCmd, actionExportRun, nil),
+		actionVersion(),
 		cli.NewCommand(actionBuiltinCmd, nil, []*cobra.Command{
 			cli.NewListCommand(actionBuiltinListCmd, actionBuiltinListRun, nil),
 			cli.NewGetCommand(actionBuiltinShowCmd, actionBuiltinShowRun, nil),
@@ -110,21 +111,24 @@ func actionUsageRun(v cli.Values) (cli.ListResult, error) {
 	}
 
 	type ActionUsageDisplay struct {
-		Type string `cli:"Type"`
-		Path string `cli:"Path"`
+		Type    string `cli:"Type"`
+		Path    string `cli:"Path"`
+		Version string `cli:"Version"`
 	}
 
 	au := []ActionUsageDisplay{}
 	for _, v := range usages.Pipelines {
 		au = append(au, ActionUsageDisplay{
-			Type: "pipeline",
-			Path: strings.Replace(fmt.Sprintf("%s - %s - %s", v.ProjectName, v.PipelineName, v.ActionName), " ", " ", -1),
+			Type:    "pipeline",
+			Version: v.ActionVersion,
+			Path:    strings.Replace(fmt.Sprintf("%s - %s - %s", v.ProjectName, v.PipelineName, v.ActionName), " ", " ", -1),
 		})
 	}
 	for _, v := range usages.Actions {
 		au = append(au, ActionUsageDisplay{
-			Type: "action",
-			Path: fmt.Sprintf("%s/%s", v.GroupName, v.ParentActionName),
+			Type:    "action",
+			Version: v.ActionVersion,
+			Path:    fmt.Sprintf("%s/%s", v.GroupName, v.ParentActionName),
 		})
 	}
 	return cli.AsListResult(au), nil
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var actionVersionCmd = cli.Command{
+	Name:  "version",
+	Short: "Manage CDS action versions",
+}
+
+func actionVersion() *cobra.Command {
+	return cli.NewCommand(actionVersionCmd, nil, []*cobra.Command{
+		cli.NewCommand(actionVersionPublishCmd, actionVersionPublishRun, nil),
+		cli.NewListCommand(actionVersionListCmd, actionVersionListRun, nil),
+		cli.NewCommand(actionVersionDeprecateCmd, actionVersionDeprecateRun, nil),
+	})
+}
+
+var actionVersionPublishCmd = cli.Command{
+	Name:    "publish",
+	Short:   "Publish current content of an action as an immutable version",
+	Example: `cdsctl action version publish my-group/my-action 1.2.0 --changelog "Add target parameter"`,
+	Args: []cli.Arg{
+		{Name: "action-path"},
+		{Name: "version"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "changelog", Usage: "Changes introduced by this version"},
+	},
+}
+
+func actionVersionPublishRun(v cli.Values) error {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return err
+	}
+
+	av := &sdk.ActionVersion{
+		Version:   v.GetString("version"),
+		Changelog: v.GetString("changelog"),
+	}
+	if err := client.ActionVersionPublish(groupName, actionName, av); err != nil {
+		return err
+	}
+
+	fmt.Printf("Version %s of action %s/%s published, use it in a step with %s/%s@%s\n", av.Version, groupName, actionName, groupName, actionName, av.Version)
+	return nil
+}
+
+var actionVersionListCmd = cli.Command{
+	Name:  "list",
+	Short: "List published versions of an action",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+	},
+}
+
+func actionVersionListRun(v cli.Values) (cli.ListResult, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	vs, err := client.ActionVersionList(groupName, actionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.AsListResult(vs), nil
+}
+
+var actionVersionDeprecateCmd = cli.Command{
+	Name:  "deprecate",
+	Short: "Deprecate a version of an action, it will not be used anymore as latest version",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+		{Name: "version"},
+	},
+}
+
+func actionVersionDeprecateRun(v cli.Values) error {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return err
+	}
+
+	return client.ActionVersionDeprecate(groupName, actionName, v.GetString("version"))
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
 )
 
 var adminDatabaseCmd = cli.Command{
@@ -20,6 +24,7 @@ func adminDatabase() *cobra.Command {
 		cli.NewCommand(adminDatabaseSignatureRoll, adminDatabaseSignatureRollFunc, nil),
 		cli.NewGetCommand(adminDatabaseEncryptionResume, adminDatabaseEncryptionResumeFunc, nil),
 		cli.NewCommand(adminDatabaseEncryptionRoll, adminDatabaseEncryptionRollFunc, nil),
+		cli.NewCommand(adminDatabaseRotateSecrets, adminDatabaseRotateSecretsFunc, nil),
 	})
 }
 
@@ -126,3 +131,44 @@ func adminDatabaseEncryptionRollFunc(args cli.Values) error {
 	return nil
 
 }
+
+var adminDatabaseRotateSecrets = cli.Command{
+	Name:  "rotate-secrets",
+	Short: "Encrypt again all secrets in database with the current keys and follow the progress",
+	Long: `The rotation is executed by the API in background, this command displays its progress until the end.
+A key id should be set in the secrets section of the API configuration, with the previous keys.`,
+	Flags: []cli.Flag{
+		{
+			Name:  "status",
+			Type:  cli.FlagBool,
+			Usage: "Only follow the progress of the rotation for the current key without starting it",
+		},
+	},
+}
+
+func adminDatabaseRotateSecretsFunc(v cli.Values) error {
+	if !v.GetBool("status") {
+		if _, err := client.AdminDatabaseRotateSecrets(); err != nil {
+			return err
+		}
+	}
+
+	var lastProgress string
+	for {
+		mig, err := client.AdminDatabaseRotateSecretsStatus()
+		if err != nil {
+			return err
+		}
+		if mig.Progress != lastProgress {
+			fmt.Printf("%s: %s\n", mig.Status, mig.Progress)
+			lastProgress = mig.Progress
+		}
+		if mig.Status != sdk.MigrationStatusInProgress {
+			if mig.Error != "" {
+				return fmt.Errorf("%s", mig.Error)
+			}
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -22,6 +25,7 @@ func consumer() *cobra.Command {
 			cli.NewCommand(authConsumerNewCmd, authConsumerNewRun, nil),
 			cli.NewCommand(authConsumerDeleteCmd, authConsumerDeleteRun, nil),
 			cli.NewCommand(authConsumerRegenCmd, authConsumerRegenRun, nil),
+			cli.NewListCommand(authConsumerActivityCmd, authConsumerActivityRun, nil),
 		},
 	)
 }
@@ -41,6 +45,10 @@ var authConsumerListCmd = cli.Command{
 			ShortHand: "g",
 			Usage:     "filter by group",
 		},
+		{
+			Name:  "stale",
+			Usage: "List only consumers not used since given delay (ex: 12h, 90d)",
+		},
 	},
 }
 
@@ -54,6 +62,22 @@ func authConsumerListRun(v cli.Values) (cli.ListResult, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if stale := v.GetString("stale"); stale != "" {
+		d, err := parseConsumerDelay(stale)
+		if err != nil {
+			return nil, err
+		}
+		since := time.Now().Add(-d)
+		filtered := make(sdk.AuthConsumers, 0, len(consumers))
+		for i := range consumers {
+			if consumers[i].IsStale(since) {
+				filtered = append(filtered, consumers[i])
+			}
+		}
+		consumers = filtered
+	}
+
 	return cli.AsListResult(consumers), nil
 }
 
@@ -84,9 +108,57 @@ var authConsumerNewCmd = cli.Command{
 			Type:  cli.FlagSlice,
 			Usage: "Define the list of scopes for the consumer",
 		},
+		{
+			Name:  "project",
+			Type:  cli.FlagSlice,
+			Usage: "Restrict the consumer to given project keys, an expiration is then required",
+		},
+		{
+			Name:  "workflow",
+			Type:  cli.FlagSlice,
+			Usage: "Restrict the consumer to given workflows formatted as <project_key>/<workflow_name>",
+		},
+		{
+			Name:    "permission",
+			Usage:   "Max permission for a consumer restricted to projects: read, execute or write",
+			Default: "read",
+		},
+		{
+			Name:  "expires",
+			Usage: "Expiration delay of the consumer (ex: 12h, 30d)",
+		},
 	},
 }
 
+// parseConsumerDelay returns the duration for given delay, days are allowed with 'd' suffix.
+func parseConsumerDelay(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, errors.Errorf("invalid given delay: '%s'", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid given delay: '%s'", s)
+	}
+	return d, nil
+}
+
+// parseConsumerExpiration returns the expiration date for given delay.
+func parseConsumerExpiration(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := parseConsumerDelay(s)
+	if err != nil {
+		return nil, err
+	}
+	t := time.Now().Add(d)
+	return &t, nil
+}
+
 func authConsumerNewRun(v cli.Values) error {
 	username := v.GetString("username")
 	if username == "" {
@@ -151,11 +223,39 @@ func authConsumerNewRun(v cli.Values) error {
 		}
 	}
 
+	expireAt, err := parseConsumerExpiration(v.GetString("expires"))
+	if err != nil {
+		return err
+	}
+
+	var restrictions *sdk.AuthConsumerRestrictions
+	if projectKeys := v.GetStringSlice("project"); len(projectKeys) > 0 {
+		restrictions = &sdk.AuthConsumerRestrictions{
+			ProjectKeys: projectKeys,
+			Workflows:   v.GetStringSlice("workflow"),
+		}
+		switch v.GetString("permission") {
+		case "read":
+			restrictions.MaxPermission = sdk.PermissionRead
+		case "execute":
+			restrictions.MaxPermission = sdk.PermissionReadExecute
+		case "write":
+			restrictions.MaxPermission = sdk.PermissionReadWriteExecute
+		default:
+			return errors.Errorf("invalid given permission value: '%s'", v.GetString("permission"))
+		}
+		if expireAt == nil {
+			return errors.New("an expiration delay is required for a consumer restricted to projects")
+		}
+	}
+
 	res, err := client.AuthConsumerCreateForUser(username, sdk.AuthConsumer{
 		Name:         name,
 		Description:  description,
 		GroupIDs:     groupIDs,
 		ScopeDetails: sdk.NewAuthConsumerScopeDetails(scopes...),
+		ExpireAt:     expireAt,
+		Restrictions: restrictions,
 	})
 	if err != nil {
 		return err
@@ -229,3 +329,31 @@ func authConsumerRegenRun(v cli.Values) error {
 
 	return nil
 }
+
+var authConsumerActivityCmd = cli.Command{
+	Name:  "activity",
+	Short: "List ips and user agents from which an auth consumer was used",
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "username",
+		},
+	},
+	Args: []cli.Arg{
+		{
+			Name: "consumer-id",
+		},
+	},
+}
+
+func authConsumerActivityRun(v cli.Values) (cli.ListResult, error) {
+	username := v.GetString("username")
+	if username == "" {
+		username = "me"
+	}
+
+	activities, err := client.AuthConsumerActivity(username, v.GetString("consumer-id"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(activities), nil
+}
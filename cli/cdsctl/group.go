@@ -24,7 +24,9 @@ func group() *cobra.Command {
 		cli.NewDeleteCommand(groupDeleteCmd, groupDeleteRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(groupGrantCmd, groupGrantRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(groupRevokeCmd, groupRevokeRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(groupAuditCmd, groupAuditRun, nil, withAllCommandModifiers()...),
 		groupMember(),
+		groupSync(),
 	})
 }
 
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var groupSyncCmd = cli.Command{
+	Name:  "sync",
+	Short: "Manage group's members synchronization from an external source",
+}
+
+func groupSync() *cobra.Command {
+	return cli.NewCommand(groupSyncCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(groupSyncShowCmd, groupSyncShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(groupSyncSetCmd, groupSyncSetRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(groupSyncDeleteCmd, groupSyncDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var groupSyncShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the external source of a group",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+	},
+}
+
+func groupSyncShowRun(v cli.Values) (interface{}, error) {
+	s, err := client.GroupSyncGet(v.GetString("group-name"))
+	if err != nil {
+		return nil, err
+	}
+	return *s, nil
+}
+
+var groupSyncSetCmd = cli.Command{
+	Name:  "set",
+	Short: "Bind a group to an external source",
+	Long: `Members of the group will be synchronized from the given source, manual changes on members will be forbidden:
+
+	# Bind a group to a LDAP group
+	cdsctl group sync set my-group ldap cn=my-team,ou=groups,dc=myorganization,dc=com
+
+	# Bind a group to an OpenID Connect groups claim value
+	cdsctl group sync set my-group openid-connect my-team
+`,
+	Args: []cli.Arg{
+		{Name: "group-name"},
+		{
+			Name: "type",
+			IsValid: func(t string) bool {
+				return t == string(sdk.ConsumerLDAP) || t == string(sdk.ConsumerOIDC)
+			},
+		},
+		{Name: "value"},
+	},
+}
+
+func groupSyncSetRun(v cli.Values) error {
+	_, err := client.GroupSyncSet(v.GetString("group-name"), sdk.GroupSync{
+		Type:  sdk.AuthConsumerType(v.GetString("type")),
+		Value: v.GetString("value"),
+	})
+	return err
+}
+
+var groupSyncDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Remove the external source of a group, existing members are kept",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+	},
+}
+
+func groupSyncDeleteRun(v cli.Values) error {
+	return client.GroupSyncDelete(v.GetString("group-name"))
+}
+
+var groupAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "List audits of a group",
+	Args: []cli.Arg{
+		{Name: "group-name"},
+	},
+}
+
+func groupAuditRun(v cli.Values) (cli.ListResult, error) {
+	as, err := client.GroupAuditList(v.GetString("group-name"))
+	if err != nil {
+		return nil, err
+	}
+
+	type auditDisplay struct {
+		Created     string `cli:"created"`
+		EventType   string `cli:"event_type"`
+		TriggeredBy string `cli:"triggered_by"`
+		Data        string `cli:"data"`
+	}
+	res := make([]auditDisplay, len(as))
+	for i := range as {
+		res[i] = auditDisplay{
+			Created:     as[i].Created.Format("2006-01-02 15:04:05"),
+			EventType:   as[i].EventType,
+			TriggeredBy: as[i].TriggeredBy,
+			Data:        as[i].DataAfter,
+		}
+	}
+	return cli.AsListResult(res), nil
+}
@@ -52,6 +52,10 @@ var loginCmd = cli.Command{
 			Name:  "token",
 			Usage: "A CDS token that can be used to login with a builtin auth driver.",
 		},
+		{
+			Name:  "totp",
+			Usage: "A TOTP code or a recovery code for local users that enrolled a second factor.",
+		},
 	},
 }
 
@@ -131,6 +135,12 @@ func loginRun(v cli.Values) error {
 	if err != nil {
 		return fmt.Errorf("cannot signin: %v", err)
 	}
+	if driverType == sdk.ConsumerLocal {
+		res, err = loginLocalSecondFactor(client, v, res)
+		if err != nil {
+			return fmt.Errorf("cannot signin: %v", err)
+		}
+	}
 
 	return doAfterLogin(client, v, apiURL, driverType, res)
 }
@@ -156,6 +166,30 @@ func loginRunLocal(v cli.Values) (sdk.AuthConsumerSigninRequest, error) {
 	return req, nil
 }
 
+// loginLocalSecondFactor completes a local signin with a TOTP code if the user enrolled a second factor.
+func loginLocalSecondFactor(client cdsclient.Interface, v cli.Values, res sdk.AuthConsumerSigninResponse) (sdk.AuthConsumerSigninResponse, error) {
+	if res.MFAToken == "" {
+		return res, nil
+	}
+
+	code := v.GetString("totp")
+	if code == "" && !v.GetBool("no-interactive") {
+		code = cli.AskValue("TOTP code (or recovery code)")
+	}
+	if code == "" {
+		return res, fmt.Errorf("A TOTP code is required to signin")
+	}
+
+	req := sdk.AuthConsumerSigninRequest{"mfa_token": res.MFAToken}
+	if strings.Contains(code, "-") {
+		req["recovery_code"] = code
+	} else {
+		req["code"] = code
+	}
+
+	return client.AuthConsumerLocalSigninTOTP(req)
+}
+
 func loginRunLDAP(v cli.Values) (sdk.AuthConsumerSigninRequest, error) {
 	req := sdk.AuthConsumerSigninRequest{
 		"bind":     v.GetString("username"),
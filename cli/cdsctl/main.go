@@ -33,6 +33,7 @@ func main() {
 		health(),
 		login(),
 		reset(),
+		role(),
 		signup(),
 		pipeline(),
 		project(),
@@ -43,6 +44,7 @@ func main() {
 		update(),
 		usr(),
 		session(),
+		totp(),
 		version(),
 		worker(),
 		workflow(),
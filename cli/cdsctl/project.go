@@ -26,6 +26,9 @@ func projectCommands() []*cobra.Command {
 		cli.NewDeleteCommand(projectDeleteCmd, projectDeleteRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectFavoriteCmd, projectFavoriteRun, nil, withAllCommandModifiers()...),
 		projectKey(),
+		projectFreeze(),
+		projectRole(),
+		projectSecretScan(),
 		projectGroup(),
 		projectVariable(),
 		projectIntegration(),
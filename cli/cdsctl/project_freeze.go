@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectFreezeCmd = cli.Command{
+	Name:  "freeze",
+	Short: "Manage CDS project deployment freeze windows",
+}
+
+func projectFreeze() *cobra.Command {
+	return cli.NewCommand(projectFreezeCmd, nil, []*cobra.Command{
+		cli.NewCommand(projectFreezeCreateCmd, projectFreezeCreateRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectFreezeListCmd, projectFreezeListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectFreezeDeleteCmd, projectFreezeDeleteRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectFreezeAuditCmd, projectFreezeAuditRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectFreezeOverrideCmd, projectFreezeOverrideRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectFreezeCreateCmd = cli.Command{
+	Name:  "add",
+	Short: "Add a new freeze window on project",
+	Example: `cdsctl project freeze add MYPROJECT christmas --start 2019-12-20T00:00:00Z --end 2020-01-02T00:00:00Z --reason "Christmas holidays"
+cdsctl project freeze add MYPROJECT weekend --environment production --cron "0 18 * * 5" --duration 62h --timezone Europe/Paris --override-group ops`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "environment", Usage: "Restrict the freeze window to given environment"},
+		{Type: cli.FlagString, Name: "start", Usage: "Start date of the freeze window (RFC3339)"},
+		{Type: cli.FlagString, Name: "end", Usage: "End date of the freeze window (RFC3339)"},
+		{Type: cli.FlagString, Name: "cron", Usage: "Cron expression for a recurring freeze window"},
+		{Type: cli.FlagString, Name: "duration", Usage: "Duration of each recurring freeze period (ie. 2h, 48h)"},
+		{Type: cli.FlagString, Name: "timezone", Usage: "Timezone used to evaluate the cron expression", Default: "UTC"},
+		{Type: cli.FlagString, Name: "override-group", Usage: "Group allowed to override the freeze window"},
+		{Type: cli.FlagString, Name: "reason", Usage: "Reason of the freeze window"},
+	},
+}
+
+func projectFreezeCreateRun(v cli.Values) error {
+	fw := &sdk.FreezeWindow{
+		Name:              v.GetString("name"),
+		EnvironmentName:   v.GetString("environment"),
+		Cron:              v.GetString("cron"),
+		Timezone:          v.GetString("timezone"),
+		OverrideGroupName: v.GetString("override-group"),
+		Reason:            v.GetString("reason"),
+	}
+
+	if s := v.GetString("start"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("invalid start date: %v", err)
+		}
+		fw.Start = &t
+	}
+	if s := v.GetString("end"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("invalid end date: %v", err)
+		}
+		fw.End = &t
+	}
+	if s := v.GetString("duration"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %v", err)
+		}
+		fw.Duration = int64(d.Seconds())
+	}
+
+	if err := client.ProjectFreezeWindowCreate(v.GetString(_ProjectKey), fw); err != nil {
+		return err
+	}
+	fmt.Printf("Freeze window %s created with success in project %s\n", fw.Name, v.GetString(_ProjectKey))
+	return nil
+}
+
+var projectFreezeListCmd = cli.Command{
+	Name:  "list",
+	Short: "List CDS project freeze windows",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectFreezeListRun(v cli.Values) (cli.ListResult, error) {
+	ws, err := client.ProjectFreezeWindowList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ws), nil
+}
+
+var projectFreezeDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete CDS project freeze window, blocked pipelines are released",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectFreezeDeleteRun(v cli.Values) error {
+	return client.ProjectFreezeWindowDelete(v.GetString(_ProjectKey), v.GetString("name"))
+}
+
+var projectFreezeAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "List pipelines blocked by a freeze window and overrides",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectFreezeAuditRun(v cli.Values) (cli.ListResult, error) {
+	bs, err := client.ProjectFreezeWindowAudit(v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(bs), nil
+}
+
+var projectFreezeOverrideCmd = cli.Command{
+	Name:    "override",
+	Short:   "Run a pipeline blocked by a freeze window (emergency only)",
+	Example: `cdsctl project freeze override MYPROJECT myworkflow 5 1234 "hotfix for incident #42"`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "node-run-id"},
+		{Name: "reason"},
+	},
+}
+
+func projectFreezeOverrideRun(v cli.Values) error {
+	runNumber, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+	nodeRunID, err := v.GetInt64("node-run-id")
+	if err != nil {
+		return err
+	}
+	b, err := client.WorkflowNodeRunFreezeOverride(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, nodeRunID, v.GetString("reason"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Pipeline %s from workflow %s #%d has been released by %s\n", b.WorkflowNodeName, b.WorkflowName, b.Number, b.OverrideUsername)
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectRoleCmd = cli.Command{
+	Name:  "role",
+	Short: "Manage roles given to groups on a CDS project",
+}
+
+func projectRole() *cobra.Command {
+	return cli.NewCommand(projectRoleCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectRoleListCmd, projectRoleListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectRoleAddCmd, projectRoleAddRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectRoleDeleteCmd, projectRoleDeleteRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectRoleActionsCmd, projectRoleActionsRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectRoleListCmd = cli.Command{
+	Name:  "list",
+	Short: "List roles given to groups on project and its workflows",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectRoleListRun(v cli.Values) (cli.ListResult, error) {
+	ls, err := client.ProjectRoleLinkList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ls), nil
+}
+
+var projectRoleAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Give a role to a group on project, or on one of its workflows",
+	Long:  "The group should already have a permission on the project, actions of the role are added to the ones given by the group permission level.",
+	Example: `cdsctl project role add MYPROJECT variables-editor my-group
+cdsctl project role add MYPROJECT deployment-approver ops --workflow my-workflow`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "role-name"},
+		{Name: "group-name"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "workflow", Usage: "Give the role on given workflow only"},
+	},
+}
+
+func projectRoleAddRun(v cli.Values) error {
+	l := &sdk.RoleLink{
+		RoleName:     v.GetString("role-name"),
+		GroupName:    v.GetString("group-name"),
+		WorkflowName: v.GetString("workflow"),
+	}
+	if err := client.ProjectRoleLinkAdd(v.GetString(_ProjectKey), l); err != nil {
+		return err
+	}
+	fmt.Printf("Role %s given to group %s with success\n", l.RoleName, l.GroupName)
+	return nil
+}
+
+var projectRoleDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Remove a role given to a group on project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func projectRoleDeleteRun(v cli.Values) error {
+	id, err := v.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	return client.ProjectRoleLinkDelete(v.GetString(_ProjectKey), id)
+}
+
+var projectRoleActionsCmd = cli.Command{
+	Name:  "actions",
+	Short: "Show actions you are allowed to do on project or on one of its workflows",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "workflow", Usage: "Show actions for given workflow"},
+	},
+}
+
+func projectRoleActionsRun(v cli.Values) error {
+	as, err := client.ProjectActions(v.GetString(_ProjectKey), v.GetString("workflow"))
+	if err != nil {
+		return err
+	}
+	for _, a := range as {
+		fmt.Println(a)
+	}
+	return nil
+}
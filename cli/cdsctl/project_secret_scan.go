@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectSecretScanCmd = cli.Command{
+	Name:  "secretscan",
+	Short: "Manage secret scanning of jobs logs and artifacts on a CDS project",
+	Long: `Workers scan logs and artifacts of jobs for credentials like AWS keys, private keys, JWTs, GitHub tokens or high entropy strings.
+Detected secrets are redacted from logs, a warning is added on the step and an event is sent.
+
+Values matching a regex of the project allowlist are not reported.`,
+}
+
+func projectSecretScan() *cobra.Command {
+	return cli.NewCommand(projectSecretScanCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectSecretScanAllowlistCmd, projectSecretScanAllowlistRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectSecretScanAllowCmd, projectSecretScanAllowRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectSecretScanDisallowCmd, projectSecretScanDisallowRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectSecretScanAllowlistCmd = cli.Command{
+	Name:  "allowlist",
+	Short: "List regex of values that are not reported as secrets",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectSecretScanAllowlistRun(v cli.Values) (cli.ListResult, error) {
+	es, err := client.ProjectSecretScanAllowlist(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(es), nil
+}
+
+var projectSecretScanAllowCmd = cli.Command{
+	Name:    "allow",
+	Short:   "Add a regex of values that are not reported as secrets",
+	Example: `cdsctl project secretscan allow MYPROJECT "^AKIA[0-9A-Z]{12}EXAMPLE$" --description "Fake AWS keys used in tests"`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "pattern"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "description", Usage: "Why values matching the pattern are not secrets"},
+	},
+}
+
+func projectSecretScanAllowRun(v cli.Values) error {
+	e := &sdk.SecretScanAllowlistEntry{
+		Pattern:     v.GetString("pattern"),
+		Description: v.GetString("description"),
+	}
+	if err := client.ProjectSecretScanAllowlistAdd(v.GetString(_ProjectKey), e); err != nil {
+		return err
+	}
+	fmt.Printf("Pattern added to allowlist with id %d\n", e.ID)
+	return nil
+}
+
+var projectSecretScanDisallowCmd = cli.Command{
+	Name:  "disallow",
+	Short: "Remove a regex from the allowlist",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func projectSecretScanDisallowRun(v cli.Values) error {
+	id, err := v.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	err = client.ProjectSecretScanAllowlistDelete(v.GetString(_ProjectKey), id)
+	if v.GetBool("force") && sdk.ErrorIs(err, sdk.ErrNotFound) {
+		fmt.Println(err)
+		return nil
+	}
+	return err
+}
@@ -103,6 +103,9 @@ var resetConfirmCmd = cli.Command{
 		}, {
 			Name:      "password",
 			ShortHand: "p",
+		}, {
+			Name:  "totp",
+			Usage: "A TOTP code or a recovery code if you enrolled a second factor.",
 		},
 	},
 }
@@ -138,6 +141,10 @@ func resetConfirmFunc(v cli.Values) error {
 	if err != nil {
 		return err
 	}
+	signupresponse, err = loginLocalSecondFactor(client, v, signupresponse)
+	if err != nil {
+		return err
+	}
 
 	return doAfterLogin(client, v, signupresponse.APIURL, sdk.ConsumerLocal, signupresponse)
 }
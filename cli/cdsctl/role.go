@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var roleCmd = cli.Command{
+	Name:  "role",
+	Short: "Manage CDS permission roles",
+	Long: `A role is a named set of actions that can be granted to a group on a project or a workflow.
+
+Builtin roles read, read-execute and read-write-execute match the permission levels given to groups, available actions are:
+` + strings.Join(sdk.AvailableRoleActions, ", "),
+}
+
+func role() *cobra.Command {
+	return cli.NewCommand(roleCmd, nil, []*cobra.Command{
+		cli.NewListCommand(roleListCmd, roleListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(roleCreateCmd, roleCreateRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(roleUpdateCmd, roleUpdateRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(roleDeleteCmd, roleDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var roleListCmd = cli.Command{
+	Name:  "list",
+	Short: "List CDS permission roles",
+}
+
+func roleListRun(v cli.Values) (cli.ListResult, error) {
+	rs, err := client.RoleList()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(rs), nil
+}
+
+var roleCreateCmd = cli.Command{
+	Name:    "create",
+	Short:   "Create a CDS permission role (admin only)",
+	Example: `cdsctl role create variables-editor read,edit-variables --description "Can edit variables but not pipelines"`,
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "actions"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "description", Usage: "Description of the role"},
+	},
+	Aliases: []string{"add"},
+}
+
+func roleCreateRun(v cli.Values) error {
+	r := &sdk.Role{
+		Name:        v.GetString("name"),
+		Description: v.GetString("description"),
+		Actions:     strings.Split(v.GetString("actions"), ","),
+	}
+	if err := client.RoleCreate(r); err != nil {
+		return err
+	}
+	fmt.Printf("Role %s created with success\n", r.Name)
+	return nil
+}
+
+var roleUpdateCmd = cli.Command{
+	Name:  "update",
+	Short: "Update actions of a CDS permission role (admin only)",
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "actions"},
+	},
+	Flags: []cli.Flag{
+		{Type: cli.FlagString, Name: "description", Usage: "Description of the role"},
+	},
+}
+
+func roleUpdateRun(v cli.Values) error {
+	r := &sdk.Role{
+		Name:        v.GetString("name"),
+		Description: v.GetString("description"),
+		Actions:     strings.Split(v.GetString("actions"), ","),
+	}
+	return client.RoleUpdate(v.GetString("name"), r)
+}
+
+var roleDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a CDS permission role, it's removed from all projects and workflows (admin only)",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func roleDeleteRun(v cli.Values) error {
+	err := client.RoleDelete(v.GetString("name"))
+	if v.GetBool("force") && sdk.ErrorIs(err, sdk.ErrNotFound) {
+		fmt.Println(err)
+		return nil
+	}
+	return err
+}
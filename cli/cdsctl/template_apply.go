@@ -267,34 +267,50 @@ func templateApplyRun(v cli.Values) error {
 
 		// for each param not already fill ask for the value
 		for _, p := range wt.Parameters {
+			// skip params hidden by a condition on previous params values
+			if !p.IsVisible(params) {
+				continue
+			}
 			if _, ok := params[p.Key]; !ok {
 				label := fmt.Sprintf("Value for param '%s' (type: %s, required: %t)", p.Key, p.Type, p.Required)
+				if p.Description != "" {
+					label = fmt.Sprintf("%s - %s", label, p.Description)
+				}
+				if p.Default != "" {
+					label = fmt.Sprintf("%s [default: %s]", label, p.Default)
+				}
 
 				var choice string
-				switch p.Type {
-				case sdk.ParameterTypeRepository:
+				switch {
+				case len(p.Choices) > 0:
+					selected := cli.AskChoice(label, p.Choices...)
+					choice = p.Choices[selected]
+				case p.Type == sdk.ParameterTypeRepository:
 					if localRepoPath != "" && cli.AskConfirm(fmt.Sprintf("Use detected repository '%s' for param '%s'", localRepoPath, p.Key)) {
 						choice = localRepoPath
 					} else if len(listRepositories) > 0 {
 						selected := cli.AskChoice(label, listRepositories...)
 						choice = listRepositories[selected]
 					}
-				case sdk.ParameterTypeSSHKey:
+				case p.Type == sdk.ParameterTypeSSHKey:
 					if len(listSSHKeys) > 0 {
 						selected := cli.AskChoice(label, listSSHKeys...)
 						choice = listSSHKeys[selected]
 					}
-				case sdk.ParameterTypePGPKey:
+				case p.Type == sdk.ParameterTypePGPKey:
 					if len(listPGPKeys) > 0 {
 						selected := cli.AskChoice(label, listPGPKeys...)
 						choice = listPGPKeys[selected]
 					}
-				case sdk.ParameterTypeBoolean:
+				case p.Type == sdk.ParameterTypeBoolean:
 					choice = fmt.Sprintf("%t", cli.AskConfirm(fmt.Sprintf("Set value to 'true' for param '%s'", p.Key)))
 				}
 				if choice == "" {
 					choice = cli.AskValue(label)
 				}
+				if choice == "" && p.Default != "" {
+					choice = p.Default
+				}
 
 				params[p.Key] = choice
 			}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+func totp() *cobra.Command {
+	cmd := cli.Command{
+		Name:  "totp",
+		Short: "Manage TOTP second factor for local users",
+	}
+
+	return cli.NewCommand(cmd, nil,
+		cli.SubCommands{
+			cli.NewGetCommand(totpShowCmd, totpShowRun, nil),
+			cli.NewCommand(totpEnrollCmd, totpEnrollRun, nil),
+			cli.NewCommand(totpVerifyCmd, totpVerifyRun, nil),
+			cli.NewCommand(totpRecoveryCmd, totpRecoveryRun, nil),
+			cli.NewDeleteCommand(totpDeleteCmd, totpDeleteRun, nil),
+		},
+	)
+}
+
+var totpShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show TOTP status for given user",
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "username",
+		},
+	},
+}
+
+func totpShowRun(v cli.Values) (interface{}, error) {
+	username := v.GetString("username")
+	if username == "" {
+		username = "me"
+	}
+
+	t, err := client.AuthTOTPGet(username)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+var totpEnrollCmd = cli.Command{
+	Name:  "enroll",
+	Short: "Enroll a new TOTP device, it should then be verified with a code",
+}
+
+func totpEnrollRun(v cli.Values) error {
+	res, err := client.AuthTOTPEnroll("me")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Add the following secret in your authenticator app:")
+	fmt.Println(res.Secret)
+	fmt.Println("or use the uri:")
+	fmt.Println(res.URI)
+	fmt.Println("Then run 'cdsctl totp verify <code>' with a code generated by the app.")
+
+	return nil
+}
+
+var totpVerifyCmd = cli.Command{
+	Name:  "verify",
+	Short: "Verify an enrolled TOTP device with a code and get recovery codes",
+	Args: []cli.Arg{
+		{
+			Name: "code",
+		},
+	},
+}
+
+func totpVerifyRun(v cli.Values) error {
+	res, err := client.AuthTOTPVerify("me", v.GetString("code"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("TOTP successfully verified, keep the following recovery codes in a safe place:")
+	fmt.Println(strings.Join(res.RecoveryCodes, "\n"))
+
+	return nil
+}
+
+var totpRecoveryCmd = cli.Command{
+	Name:  "recovery",
+	Short: "Generate new recovery codes, previous ones will be revoked",
+	Args: []cli.Arg{
+		{
+			Name: "code",
+		},
+	},
+}
+
+func totpRecoveryRun(v cli.Values) error {
+	res, err := client.AuthTOTPRecoveryCodes("me", v.GetString("code"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.Join(res.RecoveryCodes, "\n"))
+
+	return nil
+}
+
+var totpDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete the TOTP of given user",
+	Long:  "A code is required to delete your own TOTP, an administrator can delete the TOTP of another user.",
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "username",
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "code",
+			Usage: "A code generated by your TOTP device",
+		},
+	},
+}
+
+func totpDeleteRun(v cli.Values) error {
+	username := v.GetString("username")
+	if username == "" {
+		username = "me"
+	}
+
+	if err := client.AuthTOTPDelete(username, v.GetString("code")); err != nil {
+		return err
+	}
+	fmt.Printf("TOTP of user '%s' successfully deleted.\n", username)
+
+	return nil
+}
@@ -6,8 +6,23 @@ function run(call, callback) {
   sdk.Client.success('', callback);
 }
 
+// runStream is used by workers supporting it, run is kept for the older ones
+function runStream(call) {
+  let timer;
+  call.on('cancelled', () => clearTimeout(timer));
+
+  let log = call.request.getOptionsMap().get('log');
+  sdk.Client.log(call, 'This is a test');
+  sdk.Client.progress(call, 50, 'Waiting a bit');
+  timer = setTimeout(() => {
+    sdk.Client.log(call, log);
+    sdk.Client.output(call, 'log', log);
+    sdk.Client.success('', call);
+  }, 1000);
+}
+
 function main() {
-  let client = new sdk.Client(path.join(__dirname, './nodejs.yml'), run);
+  let client = new sdk.Client(path.join(__dirname, './nodejs.yml'), run, runStream);
   client.start();
 }
 
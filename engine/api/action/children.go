@@ -21,6 +21,7 @@ func insertActionChild(db gorp.SqlExecutor, child sdk.Action, actionID int64, ex
 		ChildID:        child.ID,
 		ExecOrder:      int64(execOrder), // TODO exec order can be int 64
 		StepName:       child.StepName,
+		ChildVersion:   child.Version,
 		Optional:       child.Optional,
 		AlwaysExecuted: child.AlwaysExecuted,
 		Enabled:        child.Enabled,
@@ -63,12 +64,39 @@ func CheckChildrenForGroupIDs(ctx context.Context, db gorp.SqlExecutor, a *sdk.A
 	if err != nil {
 		return err
 	}
-	return handleChildrenError(a, children)
+	if err := handleChildrenError(a, children); err != nil {
+		return err
+	}
+	return checkChildrenVersions(ctx, db, a)
 }
 
 // CheckChildrenForGroupIDsWithLoop return an error if given children not found or tree loop detected.
 func CheckChildrenForGroupIDsWithLoop(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action, groupIDs []int64) error {
-	return checkChildrenForGroupIDsWithLoopStep(ctx, db, a, a, groupIDs)
+	if err := checkChildrenForGroupIDsWithLoopStep(ctx, db, a, a, groupIDs); err != nil {
+		return err
+	}
+	return checkChildrenVersions(ctx, db, a)
+}
+
+// checkChildrenVersions returns an error if a version used by a child was not published.
+func checkChildrenVersions(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action) error {
+	for i := range a.Actions {
+		child := a.Actions[i]
+		if child.Version == "" {
+			continue
+		}
+		if child.Type != sdk.DefaultAction && child.Type != "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid version %s for step %s, only custom actions are versioned", child.Version, child.Name)
+		}
+		vs, err := LoadVersionsByActionID(ctx, db, child.ID)
+		if err != nil {
+			return err
+		}
+		if vs.Find(child.Version) == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "version %s of action %s not found", child.Version, child.Name)
+		}
+	}
+	return nil
 }
 
 func checkChildrenForGroupIDsWithLoopStep(ctx context.Context, db gorp.SqlExecutor, root, current *sdk.Action, groupIDs []int64) error {
@@ -39,6 +39,7 @@ type actionEdge struct {
 	Optional       bool   `db:"optional"`
 	AlwaysExecuted bool   `db:"always_executed"`
 	StepName       string `db:"step_name"`
+	ChildVersion   string `db:"child_version"`
 	// aggregates
 	Parameters []actionEdgeParameter `db:"-"`
 	Child      *sdk.Action           `db:"-"`
@@ -78,5 +79,6 @@ func init() {
 		gorpmapping.New(sdk.Requirement{}, "action_requirement", true, "id"),
 		gorpmapping.New(actionEdge{}, "action_edge", true, "id"),
 		gorpmapping.New(actionEdgeParameter{}, "action_edge_parameter", true, "id"),
+		gorpmapping.New(sdk.ActionVersion{}, "action_version", true, "id"),
 	)
 }
@@ -2,6 +2,7 @@ package action
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-gorp/gorp"
 
@@ -153,6 +154,7 @@ func loadChildren(ctx context.Context, db gorp.SqlExecutor, as ...*sdk.Action) e
 			child.StepName = edges[i].StepName
 			child.Optional = edges[i].Optional
 			child.AlwaysExecuted = edges[i].AlwaysExecuted
+			child.Version = edges[i].ChildVersion
 			child.Enabled = edges[i].Enabled
 
 			// replace action parameter with value configured by user when he created the child action
@@ -258,5 +260,51 @@ func loadEdgeChildren(ctx context.Context, db gorp.SqlExecutor, es ...*actionEdg
 		}
 	}
 
+	return loadEdgeChildrenVersions(ctx, db, es...)
+}
+
+// loadEdgeChildrenVersions replaces children with the snapshot of their published version
+// for edges that use a given version, it fails if the version was not published.
+func loadEdgeChildrenVersions(ctx context.Context, db gorp.SqlExecutor, es ...*actionEdge) error {
+	childIDs := make([]int64, 0, len(es))
+	for i := range es {
+		if es[i].ChildVersion != "" {
+			childIDs = append(childIDs, es[i].ChildID)
+		}
+	}
+	if len(childIDs) == 0 {
+		return nil
+	}
+
+	vs, err := loadVersionsByActionIDs(ctx, db, childIDs)
+	if err != nil {
+		return err
+	}
+	m := make(map[int64]sdk.ActionVersions, len(childIDs))
+	for i := range vs {
+		m[vs[i].ActionID] = append(m[vs[i].ActionID], vs[i])
+	}
+
+	for i := range es {
+		if es[i].ChildVersion == "" {
+			continue
+		}
+		v := m[es[i].ChildID].Find(es[i].ChildVersion)
+		// Steps that use the latest version keep running when all versions were deprecated
+		if v == nil && es[i].ChildVersion == sdk.ActionVersionLatest && len(m[es[i].ChildID]) > 0 {
+			v = &m[es[i].ChildID][0]
+		}
+		if v == nil {
+			name := strconv.FormatInt(es[i].ChildID, 10)
+			if es[i].Child != nil {
+				name = es[i].Child.Name
+			}
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of action %s not found", es[i].ChildVersion, name)
+		}
+		child := v.Action
+		child.ID = es[i].ChildID
+		es[i].Child = &child
+	}
+
 	return nil
 }
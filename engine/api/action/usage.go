@@ -14,7 +14,7 @@ func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64)
       pipeline.id, pipeline.name,
       pipeline_stage.id, pipeline_stage.name,
       parent.id, parent.name,
-      action.id, action.name,
+      action.id, action.name, action_edge.child_version,
       CAST((CASE WHEN project_group.role IS NOT NULL OR action.group_id = $1 OR action.group_id IS NULL THEN 0 ELSE 1 END) AS BIT)
 		FROM action
     INNER JOIN action_edge ON action_edge.child_id = action.id
@@ -25,7 +25,7 @@ func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64)
     LEFT JOIN project ON pipeline.project_id = project.id
     LEFT JOIN project_group ON project_group.project_id = project.id AND project_group.group_id = action.group_id
 		WHERE action.id = $2
-		ORDER BY project.projectKey, pipeline.name, action.name;
+		ORDER BY project.projectKey, pipeline.name, action.name, action_edge.child_version;
 	`, sharedInfraGroupID, actionID)
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot load pipeline usages for action with id %d", actionID)
@@ -40,7 +40,7 @@ func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64)
 			&u.PipelineID, &u.PipelineName,
 			&u.StageID, &u.StageName,
 			&u.ActionID, &u.ActionName,
-			&u.JobID, &u.JobName,
+			&u.JobID, &u.JobName, &u.ActionVersion,
 			&u.Warning,
 		); err != nil {
 			return nil, sdk.WrapError(err, "cannot scan sql rows")
@@ -57,14 +57,14 @@ func GetActionUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([
     SELECT DISTINCT
 			"group".id, "group".name,
 			parent.id, parent.name,
-      action.id, action.name,
+      action.id, action.name, action_edge.child_version,
       CAST((CASE WHEN action.group_id = parent.group_id OR action.group_id = $1 OR action.group_id IS NULL THEN 0 ELSE 1 END) AS BIT)
 		FROM action
 		INNER JOIN action_edge ON action_edge.child_id = action.id
 		LEFT JOIN action as parent ON parent.id = action_edge.parent_id
 		LEFT JOIN "group" ON "group".id = parent.group_id
 		WHERE action.id = $2 AND parent.group_id IS NOT NULL
-		ORDER BY parent.name, action.name;
+		ORDER BY parent.name, action.name, action_edge.child_version;
 	`, sharedInfraGroupID, actionID)
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot load pipeline usages for action with id %d", actionID)
@@ -77,7 +77,7 @@ func GetActionUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([
 		if err := rows.Scan(
 			&u.GroupID, &u.GroupName,
 			&u.ParentActionID, &u.ParentActionName,
-			&u.ActionID, &u.ActionName,
+			&u.ActionID, &u.ActionName, &u.ActionVersion,
 			&u.Warning,
 		); err != nil {
 			return nil, sdk.WrapError(err, "cannot scan sql rows")
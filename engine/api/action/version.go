@@ -0,0 +1,52 @@
+package action
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertVersion in database.
+func InsertVersion(db gorp.SqlExecutor, v *sdk.ActionVersion) error {
+	return sdk.WrapError(gorpmapping.Insert(db, v), "unable to insert version %s for action %d", v.Version, v.ActionID)
+}
+
+// UpdateVersion in database.
+func UpdateVersion(db gorp.SqlExecutor, v *sdk.ActionVersion) error {
+	return sdk.WrapError(gorpmapping.Update(db, v), "unable to update version %s for action %d", v.Version, v.ActionID)
+}
+
+func getVersions(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (sdk.ActionVersions, error) {
+	var vs []sdk.ActionVersion
+	if err := gorpmapping.GetAll(ctx, db, q, &vs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get action versions")
+	}
+	res := sdk.ActionVersions(vs)
+	res.Sort()
+	return res, nil
+}
+
+// LoadVersionsByActionID returns all published versions of an action, sorted from the most recent.
+func LoadVersionsByActionID(ctx context.Context, db gorp.SqlExecutor, actionID int64) (sdk.ActionVersions, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM action_version WHERE action_id = $1").Args(actionID)
+	return getVersions(ctx, db, query)
+}
+
+func loadVersionsByActionIDs(ctx context.Context, db gorp.SqlExecutor, actionIDs []int64) (sdk.ActionVersions, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action_version WHERE action_id = ANY(string_to_array($1, ',')::int[])",
+	).Args(gorpmapping.IDsToQueryString(actionIDs))
+	return getVersions(ctx, db, query)
+}
+
+// LoadVersionByActionIDAndVersion returns a published version of an action, version can be "latest".
+func LoadVersionByActionIDAndVersion(ctx context.Context, db gorp.SqlExecutor, actionID int64, version string) (*sdk.ActionVersion, error) {
+	vs, err := LoadVersionsByActionID(ctx, db, actionID)
+	if err != nil {
+		return nil, err
+	}
+	return vs.Find(version), nil
+}
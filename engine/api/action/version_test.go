@@ -0,0 +1,95 @@
+package action_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestVersions(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	grp := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	defer assets.DeleteTestGroup(t, db, grp)
+
+	child := sdk.Action{
+		GroupID:    &grp.ID,
+		Type:       sdk.DefaultAction,
+		Name:       sdk.RandomString(10),
+		Parameters: []sdk.Parameter{{Name: "target", Type: sdk.StringParameter, Value: "linux"}},
+	}
+	require.NoError(t, action.Insert(db, &child))
+
+	current, err := action.LoadByID(context.TODO(), db, child.ID, action.LoadOptions.Default)
+	require.NoError(t, err)
+	v1 := sdk.ActionVersion{ActionID: child.ID, Version: "1.0.0", Changelog: "First version", Action: *current}
+	require.NoError(t, action.InsertVersion(db, &v1))
+
+	// Update the action after publication
+	child.Parameters[0].Value = "windows"
+	require.NoError(t, action.Update(db, &child))
+
+	parent := sdk.Action{
+		GroupID: &grp.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+		Actions: []sdk.Action{
+			{ID: child.ID, Type: sdk.DefaultAction, Name: child.Name, Enabled: true, Version: "1.0.0"},
+			{ID: child.ID, Type: sdk.DefaultAction, Name: child.Name, Enabled: true, Version: sdk.ActionVersionLatest},
+			{ID: child.ID, Type: sdk.DefaultAction, Name: child.Name, Enabled: true},
+		},
+	}
+	require.NoError(t, action.CheckChildrenForGroupIDs(context.TODO(), db, &parent, []int64{grp.ID}))
+	require.NoError(t, action.Insert(db, &parent))
+
+	result, err := action.LoadByID(context.TODO(), db, parent.ID, action.LoadOptions.Default)
+	require.NoError(t, err)
+	require.Len(t, result.Actions, 3)
+	assert.Equal(t, "1.0.0", result.Actions[0].Version)
+	assert.Equal(t, "linux", result.Actions[0].Parameters[0].Value)
+	assert.Equal(t, sdk.ActionVersionLatest, result.Actions[1].Version)
+	assert.Equal(t, "linux", result.Actions[1].Parameters[0].Value)
+	assert.Equal(t, "windows", result.Actions[2].Parameters[0].Value)
+
+	// Latest version should not be a deprecated one
+	v1.Deprecated = true
+	require.NoError(t, action.UpdateVersion(db, &v1))
+	parent.Actions = parent.Actions[1:2]
+	assert.Error(t, action.CheckChildrenForGroupIDs(context.TODO(), db, &parent, []int64{grp.ID}))
+
+	// Existing steps that use the latest version keep the most recent deprecated one
+	result, err = action.LoadByID(context.TODO(), db, parent.ID, action.LoadOptions.Default)
+	require.NoError(t, err)
+	assert.Equal(t, "linux", result.Actions[1].Parameters[0].Value)
+
+	// A step that uses an unknown version can't be loaded
+	unknown := sdk.Action{
+		GroupID: &grp.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+		Actions: []sdk.Action{
+			{ID: child.ID, Type: sdk.DefaultAction, Name: child.Name, Enabled: true, Version: "2.0.0"},
+		},
+	}
+	require.NoError(t, action.Insert(db, &unknown))
+	_, err = action.LoadByID(context.TODO(), db, unknown.ID, action.LoadOptions.Default)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+	require.NoError(t, action.Delete(db, &unknown))
+
+	usages, err := action.GetActionUsages(db, 0, child.ID)
+	require.NoError(t, err)
+	versions := make([]string, len(usages))
+	for i := range usages {
+		versions[i] = usages[i].ActionVersion
+	}
+	assert.ElementsMatch(t, []string{"", "1.0.0", sdk.ActionVersionLatest}, versions)
+}
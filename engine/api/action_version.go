@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getActionVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), vars["permActionName"], g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		vs, err := action.LoadVersionsByActionID(ctx, api.mustDB(), a.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+func (api *API) getActionVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), vars["permActionName"], g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		v, err := action.LoadVersionByActionIDAndVersion(ctx, api.mustDB(), a.ID, vars["version"])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of action %s not found", vars["version"], a.Name)
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+func (api *API) postActionVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		var data sdk.ActionVersion
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+		if err := data.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot begin transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, tx, vars["permActionName"], g.ID, action.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		vs, err := action.LoadVersionsByActionID(ctx, tx, a.ID)
+		if err != nil {
+			return err
+		}
+		if vs.Find(data.Version) != nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "version %s of action %s already published", data.Version, a.Name)
+		}
+
+		// a published version is an immutable snapshot of the current action
+		v := sdk.ActionVersion{
+			ActionID:  a.ID,
+			Version:   data.Version,
+			Changelog: data.Changelog,
+			Created:   time.Now(),
+			Author:    getAPIConsumer(ctx).GetUsername(),
+			Action:    *a,
+		}
+		if err := action.InsertVersion(tx, &v); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, v, http.StatusCreated)
+	}
+}
+
+func (api *API) postActionVersionDeprecateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), vars["permActionName"], g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		if vars["version"] == sdk.ActionVersionLatest {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a version should be given to deprecate")
+		}
+		v, err := action.LoadVersionByActionIDAndVersion(ctx, api.mustDB(), a.ID, vars["version"])
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of action %s not found", vars["version"], a.Name)
+		}
+
+		v.Deprecated = true
+		if err := action.UpdateVersion(api.mustDB(), v); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
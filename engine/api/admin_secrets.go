@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/migrate"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/secret"
+	"github.com/ovh/cds/engine/api/workermodel"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	secretsRotationLockDuration = 5 * time.Minute
+	secretsRotationBatchSize    = 50
+)
+
+var secretsRotationLockKey = cache.Key("api", "secrets", "rotation")
+
+// secretsRotationName returns the name of the cds migration that tracks the rotation for given key id.
+func secretsRotationName(keyID string) string {
+	return "RotateSecrets-" + keyID
+}
+
+// secretsRotationStep walks all the tuples of an encrypted column to encrypt them again with the current key.
+type secretsRotationStep struct {
+	name    string
+	loadIDs func(db gorp.SqlExecutor) ([]string, error)
+	rotate  func(db gorp.SqlExecutor, id string) error
+	// countOtherKeyID is only set for data encrypted with the secret key
+	countOtherKeyID func(db gorp.SqlExecutor, keyID string) (int, error)
+}
+
+func int64IDsToStrings(ids []int64, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, len(ids))
+	for i := range ids {
+		res[i] = strconv.FormatInt(ids[i], 10)
+	}
+	return res, nil
+}
+
+// secretsRotationSteps returns steps for data encrypted with the secret key, then for all encrypted entities
+// that are encrypted again with the latest database encryption key.
+func secretsRotationSteps() []secretsRotationStep {
+	steps := []secretsRotationStep{
+		{
+			name: "project.vcs_servers",
+			loadIDs: func(db gorp.SqlExecutor) ([]string, error) {
+				return int64IDsToStrings(repositoriesmanager.LoadProjectIDsWithVCSServers(db))
+			},
+			rotate: func(db gorp.SqlExecutor, id string) error {
+				projectID, _ := strconv.ParseInt(id, 10, 64)
+				_, err := repositoriesmanager.RotateVCSServersSecretForProject(db, projectID)
+				return err
+			},
+			countOtherKeyID: repositoriesmanager.CountVCSServersWithOtherSecretKey,
+		},
+		{
+			name: "worker_model.password",
+			loadIDs: func(db gorp.SqlExecutor) ([]string, error) {
+				return int64IDsToStrings(workermodel.LoadIDsWithPassword(db))
+			},
+			rotate: func(db gorp.SqlExecutor, id string) error {
+				modelID, _ := strconv.ParseInt(id, 10, 64)
+				_, err := workermodel.RotatePasswordSecret(db, modelID)
+				return err
+			},
+			countOtherKeyID: workermodel.CountPasswordsWithOtherSecretKey,
+		},
+	}
+
+	entities := gorpmapping.ListEncryptedEntities()
+	sort.Strings(entities)
+	for _, e := range entities {
+		entity := e
+		steps = append(steps, secretsRotationStep{
+			name: entity,
+			loadIDs: func(db gorp.SqlExecutor) ([]string, error) {
+				return gorpmapping.ListTuplesByEntity(db, entity)
+			},
+			rotate: func(db gorp.SqlExecutor, id string) error {
+				return gorpmapping.RollEncryptedTupleByPrimaryKey(db, entity, id)
+			},
+		})
+	}
+
+	return steps
+}
+
+// rotateSecrets runs all rotation steps and saves the progress in given migration.
+func (api *API) rotateSecrets(ctx context.Context, mig *sdk.Migration) {
+	db := api.mustDB()
+
+	saveProgress := func(progress []string) {
+		mig.Progress = strings.Join(progress, ", ")
+		if err := migrate.Update(db, mig); err != nil {
+			log.Error(ctx, "rotateSecrets> %v", err)
+		}
+		if err := api.Cache.UpdateTTL(secretsRotationLockKey, int(secretsRotationLockDuration.Seconds())); err != nil {
+			log.Error(ctx, "rotateSecrets> %v", err)
+		}
+	}
+
+	var progress []string
+	var errorsCount int
+	for _, step := range secretsRotationSteps() {
+		ids, err := step.loadIDs(db)
+		if err != nil {
+			log.Error(ctx, "rotateSecrets> cannot load tuples for %s: %v", step.name, err)
+			errorsCount++
+			progress = append(progress, fmt.Sprintf("%s: error", step.name))
+			continue
+		}
+
+		for i, id := range ids {
+			if ctx.Err() != nil {
+				mig.Status = sdk.MigrationStatusTodo
+				mig.Error = fmt.Sprintf("rotation interrupted: %v", ctx.Err())
+				saveProgress(progress)
+				return
+			}
+			if err := step.rotate(db, id); err != nil {
+				log.Error(ctx, "rotateSecrets> cannot rotate %s %s: %v", step.name, id, err)
+				errorsCount++
+			}
+			if (i+1)%secretsRotationBatchSize == 0 {
+				saveProgress(append(progress, fmt.Sprintf("%s: %d/%d", step.name, i+1, len(ids))))
+			}
+		}
+
+		progress = append(progress, fmt.Sprintf("%s: %d/%d", step.name, len(ids), len(ids)))
+		saveProgress(progress)
+	}
+
+	// Previous keys are still required to decrypt tuples that were not rotated, the rotation should be run again
+	mig.Status = sdk.MigrationStatusDone
+	mig.Done = time.Now()
+	if errorsCount > 0 {
+		mig.Status = sdk.MigrationStatusError
+		mig.Error = fmt.Sprintf("%d tuples could not be rotated, see api logs for details", errorsCount)
+	}
+	saveProgress(progress)
+	log.Info(ctx, "rotateSecrets> rotation for key %s done with %d errors", secret.CurrentKeyID(), errorsCount)
+}
+
+// checkSecretsRotation returns an error if previous secret keys were removed from the configuration while
+// the rotation for the current key was not done without error. If the rotation was never started, it is
+// considered as not done when stored data was encrypted with another key.
+func checkSecretsRotation(db gorp.SqlExecutor, keyID string, previousKeys []SecretKeyConfig) error {
+	if keyID == "" || len(previousKeys) > 0 {
+		return nil
+	}
+	mig, err := migrate.GetByName(db, secretsRotationName(keyID))
+	if err != nil {
+		return err
+	}
+	if mig != nil {
+		if mig.Status != sdk.MigrationStatusDone {
+			return fmt.Errorf("previous secret keys are required until secrets rotation for key %s is done without error (status: %s)", keyID, mig.Status)
+		}
+		return nil
+	}
+
+	for _, step := range secretsRotationSteps() {
+		if step.countOtherKeyID == nil {
+			continue
+		}
+		count, err := step.countOtherKeyID(db, keyID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("previous secret keys are required until secrets rotation for key %s is done without error (%s: %d tuples encrypted with another key)", keyID, step.name, count)
+		}
+	}
+	return nil
+}
+
+func (api *API) postAdminDatabaseRotateSecretsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		keyID := secret.CurrentKeyID()
+		if keyID == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a key id should be set in secrets configuration to rotate secrets")
+		}
+
+		locked, err := api.Cache.Lock(secretsRotationLockKey, secretsRotationLockDuration, -1, 1)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "a secrets rotation is already in progress")
+		}
+
+		mig, err := migrate.GetByName(api.mustDB(), secretsRotationName(keyID))
+		if err != nil {
+			_ = api.Cache.Unlock(secretsRotationLockKey)
+			return err
+		}
+		if mig == nil {
+			mig = &sdk.Migration{Name: secretsRotationName(keyID)}
+		}
+		mig.Status = sdk.MigrationStatusInProgress
+		mig.Progress = "Begin"
+		mig.Error = ""
+		if mig.ID == 0 {
+			err = migrate.Insert(api.mustDB(), mig)
+		} else {
+			err = migrate.Update(api.mustDB(), mig)
+		}
+		if err != nil {
+			_ = api.Cache.Unlock(secretsRotationLockKey)
+			return err
+		}
+
+		sdk.GoRoutine(api.Router.Background, "api.rotateSecrets", func(ctx context.Context) {
+			defer api.Cache.Unlock(secretsRotationLockKey) // nolint
+			api.rotateSecrets(ctx, mig)
+		}, api.PanicDump())
+
+		return service.WriteJSON(w, mig, http.StatusAccepted)
+	}
+}
+
+func (api *API) getAdminDatabaseRotateSecretsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		mig, err := migrate.GetByName(api.mustDB(), secretsRotationName(secret.CurrentKeyID()))
+		if err != nil {
+			return err
+		}
+		if mig == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no secrets rotation found for current key")
+		}
+
+		return service.WriteJSON(w, mig, http.StatusOK)
+	}
+}
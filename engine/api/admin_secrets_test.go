@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/migrate"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_checkSecretsRotation(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	keyID := sdk.RandomString(10)
+	previousKeys := []SecretKeyConfig{{ID: "", Key: sdk.RandomString(32)}}
+
+	// Data encrypted with the test key
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, key, key)
+	require.NoError(t, repositoriesmanager.InsertForProject(db, proj, &sdk.ProjectVCSServer{
+		Name: "github",
+		Data: map[string]string{"token": "foo", "secret": "bar"},
+	}))
+
+	// No rotation was started for the key while data was encrypted with another key
+	assert.Error(t, checkSecretsRotation(db, keyID, nil))
+	assert.NoError(t, checkSecretsRotation(db, keyID, previousKeys))
+
+	mig := sdk.Migration{Name: secretsRotationName(keyID), Status: sdk.MigrationStatusError}
+	require.NoError(t, migrate.Insert(db, &mig))
+
+	// Previous keys are required while the rotation is not done without error
+	assert.Error(t, checkSecretsRotation(db, keyID, nil))
+	assert.NoError(t, checkSecretsRotation(db, keyID, previousKeys))
+
+	mig.Status = sdk.MigrationStatusDone
+	require.NoError(t, migrate.Update(db, &mig))
+	assert.NoError(t, checkSecretsRotation(db, keyID, nil))
+}
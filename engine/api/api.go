@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -28,6 +29,7 @@ import (
 	"github.com/ovh/cds/engine/api/authentication/gitlab"
 	"github.com/ovh/cds/engine/api/authentication/ldap"
 	"github.com/ovh/cds/engine/api/authentication/local"
+	"github.com/ovh/cds/engine/api/authentication/oidc"
 	"github.com/ovh/cds/engine/api/bootstrap"
 	"github.com/ovh/cds/engine/api/broadcast"
 	"github.com/ovh/cds/engine/api/cache"
@@ -65,12 +67,25 @@ type Configuration struct {
 		UI  string `toml:"ui" default:"http://localhost:8080" json:"ui"`
 	} `toml:"url" comment:"#####################\n CDS URLs Settings \n####################" json:"url"`
 	HTTP struct {
-		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
-		Port int    `toml:"port" default:"8081" json:"port"`
+		Addr           string   `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
+		Port           int      `toml:"port" default:"8081" json:"port"`
+		TrustedProxies []string `toml:"trustedProxies" comment:"Ips or networks of the reverse proxies allowed to give the client ip with X-Forwarded-For and X-Real-IP headers, example: [\"10.0.0.0/8\"]" json:"trustedProxies"`
 	} `toml:"http" json:"http"`
 	Secrets struct {
-		Key string `toml:"key" json:"-"`
+		Key          string            `toml:"key" json:"-"`
+		KeyID        string            `toml:"keyID" default:"" comment:"Identifier of the key, it is stored with encrypted data and is required to rotate the key" json:"-"`
+		PreviousKeys []SecretKeyConfig `toml:"previousKeys" comment:"Previous keys only used to decrypt existing data until secrets are rotated, data encrypted before key identifiers were set uses the key with an empty id" json:"-"`
 	} `toml:"secrets" json:"secrets"`
+	Vault struct {
+		Address     string   `toml:"address" default:"" commented:"true" comment:"Vault address used to resolve vault variables, example: https://vault.mydomain.net:8200" json:"address"`
+		Token       string   `toml:"token" default:"" commented:"true" comment:"Vault token, it should be allowed to read secrets referenced by vault variables" json:"-"`
+		Mounts      []string `toml:"mounts" comment:"KV engine mounts in which vault variables can reference secrets, example: [\"secret\"]" json:"mounts"`
+		ProjectPath string   `toml:"projectPath" default:"cds/{{.ProjectKey}}" comment:"Path of the secrets of a project in allowed mounts, vault variables of a project can only reference secrets under this path" json:"projectPath"`
+	} `toml:"vault" comment:"###########################\n Vault variables settings \n##########################" json:"vault"`
+	SecretScan struct {
+		Disabled bool                 `toml:"disabled" default:"false" comment:"Disable scanning of jobs logs and artifacts by workers" json:"disabled"`
+		Rules    []sdk.SecretScanRule `toml:"rules" comment:"Rules used to detect secrets, default rules for AWS keys, private keys, JWTs, GitHub tokens and high entropy strings are used if empty" json:"rules"`
+	} `toml:"secretScan" comment:"###########################\n Secret scanning settings \n##########################" json:"secret_scan"`
 	Database database.DBConfiguration `toml:"database" comment:"################################\n Postgresql Database settings \n###############################" json:"database"`
 	Cache    struct {
 		TTL   int `toml:"ttl" default:"60" json:"ttl"`
@@ -83,9 +98,15 @@ type Configuration struct {
 		Download string `toml:"download" default:"/var/lib/cds-engine" json:"download"`
 	} `toml:"directories" json:"directories"`
 	Auth struct {
-		DefaultGroup  string `toml:"defaultGroup" default:"" comment:"The default group is the group in which every new user will be granted at signup" json:"defaultGroup"`
-		RSAPrivateKey string `toml:"rsaPrivateKey" default:"" comment:"The RSA Private Key used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-"`
-		LDAP          struct {
+		DefaultGroup            string `toml:"defaultGroup" default:"" comment:"The default group is the group in which every new user will be granted at signup" json:"defaultGroup"`
+		RSAPrivateKey           string `toml:"rsaPrivateKey" default:"" comment:"The RSA Private Key used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-"`
+		GroupsSyncInterval      int    `toml:"groupsSyncInterval" default:"60" comment:"Interval in minutes between two synchronizations of groups bound to an external source (LDAP only, other sources are synchronized at signin)\nSet 0 to disable" json:"groupsSyncInterval"`
+		GroupsSyncAllowEmpty    bool   `toml:"groupsSyncAllowEmpty" default:"false" comment:"Apply empty results of groups synchronization, by default an empty member list or a user without external group is ignored to avoid removing all members on an error of the external source" json:"groupsSyncAllowEmpty"`
+		ConsumerIdleDays        int    `toml:"consumerIdleDays" default:"0" comment:"Number of days after which a builtin consumer that was not used is automatically disabled\nSet 0 to disable" json:"consumerIdleDays"`
+		ConsumerActivityDays    int    `toml:"consumerActivityDays" default:"90" comment:"Number of days consumers activity is kept, the last activity of each consumer is always kept\nSet 0 to keep all activities" json:"consumerActivityDays"`
+		JobIDTokenDuration      int    `toml:"jobIDTokenDuration" default:"10" comment:"Validity in minutes of the identity tokens issued for running jobs, they are signed with jobIDTokenRSAPrivateKey\nSet 0 to disable" json:"jobIDTokenDuration"`
+		JobIDTokenRSAPrivateKey string `toml:"jobIDTokenRSAPrivateKey" default:"" comment:"The RSA Private Key used to sign the identity tokens issued for running jobs, its public key is published at /auth/jwks\nIt must be different from rsaPrivateKey. Jobs identity tokens are disabled if not set" json:"-"`
+		LDAP                    struct {
 			Enabled         bool   `toml:"enabled" default:"false" json:"enabled"`
 			SignupDisabled  bool   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
 			Host            string `toml:"host" json:"host"`
@@ -102,6 +123,7 @@ type Configuration struct {
 			Enabled              bool   `toml:"enabled" default:"true" json:"enabled"`
 			SignupDisabled       bool   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
 			SignupAllowedDomains string `toml:"signupAllowedDomains" default:"" comment:"Allow signup from selected domains only - comma separated. Example: your-domain.com,another-domain.com" commented:"true" json:"signupAllowedDomains"`
+			MFAPolicy            string `toml:"mfaPolicy" default:"optional" comment:"Second factor (TOTP) policy for local users: optional, admins (required for admins) or all (required for everyone)" json:"mfaPolicy"`
 		} `toml:"local" json:"local"`
 		CorporateSSO struct {
 			Enabled        bool   `json:"enabled" default:"false" toml:"enabled"`
@@ -133,6 +155,18 @@ type Configuration struct {
 			ApplicationID  string `toml:"applicationID" json:"-" comment:"#######\n Gitlab OAuth Application ID"`
 			Secret         string `toml:"secret" json:"-"  comment:"Gitlab OAuth Application Secret"`
 		} `toml:"gitlab" json:"gitlab"`
+		OIDC struct {
+			Enabled        bool     `toml:"enabled" default:"false" json:"enabled"`
+			SignupDisabled bool     `toml:"signupDisabled" default:"false" json:"signupDisabled"`
+			IssuerURL      string   `toml:"issuerURL" json:"issuerURL" comment:"#######\n OpenID Connect issuer URL, discovery document should be available at <issuerURL>/.well-known/openid-configuration"`
+			ClientID       string   `toml:"clientID" json:"-" comment:"#######\n OpenID Connect Client ID"`
+			ClientSecret   string   `toml:"clientSecret" json:"-" comment:"OpenID Connect Client Secret, can be empty for public clients"`
+			Scopes         []string `toml:"scopes" json:"scopes" comment:"Additional scopes, openid, profile and email are always requested"`
+			UsernameClaim  string   `toml:"usernameClaim" default:"preferred_username" json:"usernameClaim"`
+			EmailClaim     string   `toml:"emailClaim" default:"email" json:"emailClaim"`
+			FullnameClaim  string   `toml:"fullnameClaim" default:"name" json:"fullnameClaim"`
+			GroupsClaim    string   `toml:"groupsClaim" json:"groupsClaim" comment:"If set, at signin the user is added to and removed from CDS groups synchronized with the claim values"`
+		} `toml:"oidc" json:"oidc"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings#\n#############################" json:"auth"`
 	SMTP struct {
 		Disable  bool   `toml:"disable" default:"true" json:"disable" comment:"Set to false to enable the internal SMTP client"`
@@ -193,9 +227,19 @@ type Configuration struct {
 		StepMaxSize    int64 `toml:"stepMaxSize" default:"15728640" comment:"Max step logs size in bytes (default: 15MB)" json:"stepMaxSize"`
 		ServiceMaxSize int64 `toml:"serviceMaxSize" default:"15728640" comment:"Max service logs size in bytes (default: 15MB)" json:"serviceMaxSize"`
 	} `toml:"log" json:"log" comment:"###########################\n Log settings.\n##########################"`
+	AsCode struct {
+		TrustedBranches []string `toml:"trustedBranches" comment:"Branches allowed to change hooks and permissions of as code workflows in addition to the default branch, glob patterns are supported (example: release/*)" json:"trustedBranches"`
+		CacheTTL        int      `toml:"cacheTTL" default:"3600" comment:"Duration in seconds during which files read from a commit to build an as code workflow are kept in cache\nSet 0 to disable" json:"cacheTTL"`
+	} `toml:"ascode" json:"ascode" comment:"###########################\n As code workflows settings.\n##########################"`
 	CDN cdn.Configuration `toml:"cdn" json:"cdn" comment:"###########################\n CDN settings.\n##########################"`
 }
 
+// SecretKeyConfig is an identified key used to decrypt secrets.
+type SecretKeyConfig struct {
+	ID  string `toml:"id" json:"-"`
+	Key string `toml:"key" json:"-"`
+}
+
 // DefaultValues is the struc for API Default configuration default values
 type DefaultValues struct {
 	ServerSecretsKey     string
@@ -262,6 +306,7 @@ type API struct {
 		DatabaseConns            *stats.Int64Measure
 	}
 	AuthenticationDrivers map[sdk.AuthConsumerType]sdk.AuthDriver
+	trustedProxies        []*net.IPNet
 }
 
 // ApplyConfiguration apply an object of type api.Configuration after checking it
@@ -276,6 +321,8 @@ func (a *API) ApplyConfiguration(config interface{}) error {
 		return fmt.Errorf("Invalid configuration")
 	}
 
+	a.trustedProxies, _ = parseTrustedProxies(a.Config.HTTP.TrustedProxies)
+
 	a.Common.ServiceType = services.TypeAPI
 	a.Common.ServiceName = a.Config.Name
 	return nil
@@ -302,6 +349,10 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		}
 	}
 
+	if _, err := parseTrustedProxies(aConfig.HTTP.TrustedProxies); err != nil {
+		return err
+	}
+
 	if aConfig.Directories.Download == "" {
 		return fmt.Errorf("Invalid download directory (empty)")
 	}
@@ -335,9 +386,26 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		}
 	}
 
+	if aConfig.Auth.Local.Enabled && aConfig.Auth.Local.MFAPolicy != "" && !local.IsValidMFAPolicy(aConfig.Auth.Local.MFAPolicy) {
+		return fmt.Errorf("Invalid local auth mfa policy %s", aConfig.Auth.Local.MFAPolicy)
+	}
+
 	if len(aConfig.Secrets.Key) != 32 {
 		return fmt.Errorf("Invalid secret key. It should be 32 bits (%d)", len(aConfig.Secrets.Key))
 	}
+	if strings.Contains(aConfig.Secrets.KeyID, ":") {
+		return fmt.Errorf("Invalid secret key id %s. It should not contain ':'", aConfig.Secrets.KeyID)
+	}
+	previousKeyIDs := make(map[string]struct{}, len(aConfig.Secrets.PreviousKeys))
+	for _, k := range aConfig.Secrets.PreviousKeys {
+		if len(k.Key) != 32 {
+			return fmt.Errorf("Invalid previous secret key %s. It should be 32 bits (%d)", k.ID, len(k.Key))
+		}
+		if _, ok := previousKeyIDs[k.ID]; ok || k.ID == aConfig.Secrets.KeyID {
+			return fmt.Errorf("Invalid previous secret key %s. Key ids should be unique", k.ID)
+		}
+		previousKeyIDs[k.ID] = struct{}{}
+	}
 
 	if aConfig.DefaultArch == "" {
 		log.Warning(context.Background(), `You should add a default architecture in your configuration (example: defaultArch: "amd64"). It means if there is no model and os/arch requirement on your job then spawn on a worker based on this architecture`)
@@ -350,6 +418,21 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		return fmt.Errorf("You can't specify just defaultArch without defaultOS in your configuration and vice versa")
 	}
 
+	secretScanRuleNames := make(map[string]struct{}, len(aConfig.SecretScan.Rules))
+	for _, r := range aConfig.SecretScan.Rules {
+		if err := r.IsValid(); err != nil {
+			return fmt.Errorf("Invalid secret scan configuration: %v", err)
+		}
+		if _, ok := secretScanRuleNames[r.Name]; ok {
+			return fmt.Errorf("Invalid secret scan configuration, rule %s is defined twice", r.Name)
+		}
+		secretScanRuleNames[r.Name] = struct{}{}
+	}
+
+	if aConfig.Vault.Address != "" && len(aConfig.Vault.Mounts) == 0 {
+		return fmt.Errorf("Invalid vault configuration, at least one mount should be given")
+	}
+
 	if aConfig.Auth.RSAPrivateKey == "" {
 		return errors.New("invalid given authentication rsa private key")
 	}
@@ -402,12 +485,28 @@ func (a *API) Serve(ctx context.Context) error {
 	}
 
 	// Initialize secret driver
-	secret.Init(a.Config.Secrets.Key)
+	previousSecretKeys := make(map[string]string, len(a.Config.Secrets.PreviousKeys))
+	for _, k := range a.Config.Secrets.PreviousKeys {
+		previousSecretKeys[k.ID] = k.Key
+	}
+	secret.InitWithKeys(a.Config.Secrets.Key, a.Config.Secrets.KeyID, previousSecretKeys)
+
+	// Initialize the vault client used for vault variables
+	if a.Config.Vault.Address != "" {
+		if err := secret.InitVault(a.Config.Vault.Address, a.Config.Vault.Token, a.Config.Vault.Mounts, a.Config.Vault.ProjectPath); err != nil {
+			return err
+		}
+	}
 
 	// Initialize the jwt layer
 	if err := authentication.Init(a.ServiceName, []byte(a.Config.Auth.RSAPrivateKey)); err != nil {
 		return sdk.WrapError(err, "unable to initialize the JWT Layer")
 	}
+	if a.Config.Auth.JobIDTokenRSAPrivateKey != "" {
+		if err := authentication.InitJobIDTokenKey([]byte(a.Config.Auth.JobIDTokenRSAPrivateKey)); err != nil {
+			return sdk.WrapError(err, "unable to initialize the jobs identity tokens key")
+		}
+	}
 
 	// Initialize mail package
 	log.Info(ctx, "Initializing mail driver...")
@@ -502,6 +601,10 @@ func (a *API) Serve(ctx context.Context) error {
 		return fmt.Errorf("cannot connect to database: %v", err)
 	}
 
+	if err := checkSecretsRotation(a.mustDB(), a.Config.Secrets.KeyID, a.Config.Secrets.PreviousKeys); err != nil {
+		return err
+	}
+
 	log.Info(ctx, "Setting up database keys...")
 	encryptionKeyConfig := a.Config.Database.EncryptionKey.GetKeys(gorpmapping.KeyEcnryptionIdentifier)
 	signatureKeyConfig := a.Config.Database.SignatureKey.GetKeys(gorpmapping.KeySignIdentifier)
@@ -547,6 +650,7 @@ func (a *API) Serve(ctx context.Context) error {
 			a.Config.Auth.Local.SignupDisabled,
 			a.Config.URL.UI,
 			a.Config.Auth.Local.SignupAllowedDomains,
+			a.Config.Auth.Local.MFAPolicy,
 		)
 	}
 
@@ -590,6 +694,27 @@ func (a *API) Serve(ctx context.Context) error {
 		)
 	}
 
+	if a.Config.Auth.OIDC.Enabled {
+		a.AuthenticationDrivers[sdk.ConsumerOIDC], err = oidc.NewDriver(
+			ctx,
+			a.Config.Auth.OIDC.SignupDisabled,
+			a.Config.URL.UI,
+			oidc.Config{
+				IssuerURL:     a.Config.Auth.OIDC.IssuerURL,
+				ClientID:      a.Config.Auth.OIDC.ClientID,
+				ClientSecret:  a.Config.Auth.OIDC.ClientSecret,
+				Scopes:        a.Config.Auth.OIDC.Scopes,
+				UsernameClaim: a.Config.Auth.OIDC.UsernameClaim,
+				EmailClaim:    a.Config.Auth.OIDC.EmailClaim,
+				FullnameClaim: a.Config.Auth.OIDC.FullnameClaim,
+				GroupsClaim:   a.Config.Auth.OIDC.GroupsClaim,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	if a.Config.Auth.CorporateSSO.Enabled {
 		driverConfig := corpsso.Config{
 			MailDomain: a.Config.Auth.CorporateSSO.MailDomain,
@@ -657,6 +782,24 @@ func (a *API) Serve(ctx context.Context) error {
 	sdk.GoRoutine(ctx, "authentication.SessionCleaner", func(ctx context.Context) {
 		authentication.SessionCleaner(ctx, a.mustDB)
 	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.releaseExpiredFreezeWindowBlocks", func(ctx context.Context) {
+		a.releaseExpiredFreezeWindowBlocks(ctx)
+	}, a.PanicDump())
+	if a.Config.Auth.GroupsSyncInterval > 0 {
+		sdk.GoRoutine(ctx, "api.groupSyncRoutine", func(ctx context.Context) {
+			a.groupSyncRoutine(ctx, time.Duration(a.Config.Auth.GroupsSyncInterval)*time.Minute)
+		}, a.PanicDump())
+	}
+	if a.Config.Auth.ConsumerIdleDays > 0 {
+		sdk.GoRoutine(ctx, "api.consumerIdleRoutine", func(ctx context.Context) {
+			a.consumerIdleRoutine(ctx, time.Duration(a.Config.Auth.ConsumerIdleDays)*24*time.Hour)
+		}, a.PanicDump())
+	}
+	if a.Config.Auth.ConsumerActivityDays > 0 {
+		sdk.GoRoutine(ctx, "api.consumerActivityPurgeRoutine", func(ctx context.Context) {
+			a.consumerActivityPurgeRoutine(ctx, time.Duration(a.Config.Auth.ConsumerActivityDays)*24*time.Hour)
+		}, a.PanicDump())
+	}
 
 	migrate.Add(ctx, sdk.Migration{Name: "RefactorGroupMembership", Release: "0.44.0", Blocker: true, Automatic: true, ExecFunc: func(ctx context.Context) error {
 		return migrate.RefactorGroupMembership(ctx, a.DBConnectionFactory.GetDBMap())
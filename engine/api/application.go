@@ -15,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/ascode"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/environment"
 	"github.com/ovh/cds/engine/api/event"
@@ -393,6 +394,115 @@ func cloneApplication(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 	return nil
 }
 
+// updateAsCodeApplicationHandler update an ascode application, this will create a pull request to target repository.
+func (api *API) updateAsCodeApplicationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		applicationName := vars["applicationName"]
+
+		var appPost sdk.Application
+		if err := service.UnmarshalBody(r, &appPost); err != nil {
+			return err
+		}
+
+		ope, err := api.updateAsCodeApplication(ctx, projectKey, applicationName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+			return applyApplicationUpdate(app, appPost)
+		})
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ope, http.StatusOK)
+	}
+}
+
+// updateAsCodeApplication pushes an as code application modified by the given func to its repository,
+// this will create a pull request or a commit if the given branch is the default branch of the repository.
+func (api *API) updateAsCodeApplication(ctx context.Context, projectKey, applicationName, branch, message string, update func(app *sdk.Application) error) (*sdk.Operation, error) {
+	proj, err := project.Load(api.mustDB(), projectKey,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// The application is loaded twice because the export encrypts the deployment strategies in place
+	loadOpts := []application.LoadOptionFunc{
+		application.LoadOptions.WithVariablesWithClearPassword,
+		application.LoadOptions.WithClearKeys,
+		application.LoadOptions.WithClearDeploymentStrategies,
+	}
+	old, err := application.LoadByNameWithClearVCSStrategyPassword(api.mustDB(), projectKey, applicationName, loadOpts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load application %s", applicationName)
+	}
+	if old.FromRepository == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "current application is not ascode")
+	}
+	app, err := application.LoadByNameWithClearVCSStrategyPassword(api.mustDB(), projectKey, applicationName, loadOpts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load application %s", applicationName)
+	}
+
+	if err := update(app); err != nil {
+		return nil, err
+	}
+
+	rootApp, err := api.loadAsCodeRootApplication(ctx, *proj, old.FromRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	u := getAPIConsumer(ctx)
+	ope, err := application.UpdateApplicationAsCode(ctx, api.Cache, api.mustDB(), *proj, *app, *old, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, project.EncryptWithBuiltinKeyRenewToken, project.LoadEncryptedToken, u)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodeApplicationHandler-%s", ope.UUID), func(ctx context.Context) {
+		ed := ascode.EntityData{
+			FromRepo:  old.FromRepository,
+			Type:      ascode.AsCodeApplication,
+			ID:        old.ID,
+			Name:      old.Name,
+			Operation: ope,
+		}
+		asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *proj, *rootApp, ed, u)
+		if asCodeEvent != nil {
+			event.PublishAsCodeEvent(ctx, proj.Key, *asCodeEvent, u)
+		}
+	}, api.PanicDump())
+
+	return ope, nil
+}
+
+// applyApplicationUpdate sets the editable fields of the application from the posted one
+func applyApplicationUpdate(app *sdk.Application, appPost sdk.Application) error {
+	// check application name pattern
+	regexp := sdk.NamePatternRegex
+	if !regexp.MatchString(appPost.Name) {
+		return sdk.WrapError(sdk.ErrInvalidApplicationPattern, "Application name %s do not respect pattern %s", appPost.Name, sdk.NamePattern)
+	}
+
+	if appPost.RepositoryStrategy.Password == sdk.PasswordPlaceholder {
+		appPost.RepositoryStrategy.Password = app.RepositoryStrategy.Password
+	}
+
+	app.Name = appPost.Name
+	app.Description = appPost.Description
+	if appPost.Icon != "" {
+		app.Icon = appPost.Icon
+	}
+	app.Metadata = appPost.Metadata
+	app.RepositoryStrategy = appPost.RepositoryStrategy
+	app.RepositoryStrategy.SSHKeyContent = ""
+	return nil
+}
+
 func (api *API) updateApplicationHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -409,36 +519,28 @@ func (api *API) updateApplicationHandler() service.Handler {
 			return sdk.WrapError(err, "cannot load application %s", applicationName)
 		}
 
-		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
-
 		var appPost sdk.Application
 		if err := service.UnmarshalBody(r, &appPost); err != nil {
 			return err
 		}
 
-		// check application name pattern
-		regexp := sdk.NamePatternRegex
-		if !regexp.MatchString(appPost.Name) {
-			return sdk.WrapError(sdk.ErrInvalidApplicationPattern, "updateApplicationHandler> Application name %s do not respect pattern %s", appPost.Name, sdk.NamePattern)
-		}
-
-		if appPost.RepositoryStrategy.Password == sdk.PasswordPlaceholder {
-			appPost.RepositoryStrategy.Password = app.RepositoryStrategy.Password
+		// Updates of an as code application are pushed to its repository
+		if app.FromRepository != "" {
+			ope, err := api.updateAsCodeApplication(ctx, projectKey, applicationName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				return applyApplicationUpdate(app, appPost)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, appPost)
 		}
 
 		old := *app
 
 		//Update name and Metadata
-		app.Name = appPost.Name
-		app.Description = appPost.Description
-		if appPost.Icon != "" {
-			app.Icon = appPost.Icon
+		if err := applyApplicationUpdate(app, appPost); err != nil {
+			return err
 		}
-		app.Metadata = appPost.Metadata
-		app.RepositoryStrategy = appPost.RepositoryStrategy
-		app.RepositoryStrategy.SSHKeyContent = ""
 
 		tx, err := api.mustDB().Begin()
 		if err != nil {
@@ -471,9 +573,6 @@ func (api *API) postApplicationMetadataHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "postApplicationMetadataHandler")
 		}
-		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
 		oldApp := *app
 
 		m := vars["metadata"]
@@ -483,6 +582,23 @@ func (api *API) postApplicationMetadataHandler() service.Handler {
 		}
 		defer r.Body.Close()
 
+		// Updates of an as code application are pushed to its repository
+		if app.FromRepository != "" {
+			ope, err := api.updateAsCodeApplication(ctx, projectKey, applicationName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				if app.Metadata == nil {
+					app.Metadata = sdk.Metadata{}
+				}
+				app.Metadata[m] = string(v)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			w.Header().Add(sdk.ResponseAsCodeOperationHeader, ope.UUID)
+			w.WriteHeader(http.StatusAccepted)
+			return nil
+		}
+
 		app.Metadata[m] = string(v)
 		tx, err := api.mustDB().Begin()
 		if err != nil {
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// UpdateApplicationAsCode update an as code application, old application is used to detect conflicts with the repository.
+// Given applications must be loaded with clear variables, keys and deployment strategies, the old one is exported with
+// previousEncryptFunc that must not alter the stored encrypted data.
+func UpdateApplicationAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, app, old sdk.Application, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, encryptFunc, previousEncryptFunc sdk.EncryptFunc, u sdk.Identifiable) (*sdk.Operation, error) {
+	// The old application is exported first with the stored tokens, the export of the new one renews the tokens of the updated secrets
+	previous, err := ExportApplication(db, old, previousEncryptFunc)
+	if err != nil {
+		return nil, err
+	}
+	ea, err := ExportApplication(db, app, encryptFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("chore: Update application %s [@%s]", app.Name, u.GetUsername())
+	}
+	return operation.PushOperation(ctx, db, store, proj,
+		exportentities.WorkflowComponents{Applications: []exportentities.Application{ea}},
+		exportentities.WorkflowComponents{Applications: []exportentities.Application{previous}},
+		vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
+}
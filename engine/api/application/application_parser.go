@@ -150,6 +150,10 @@ func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store,
 		app.RepositoryStrategy.Password = clearPWD
 	}
 
+	if eapp.GerritSettings != nil {
+		app.GerritSettings = *eapp.GerritSettings
+	}
+
 	// deployment strategies
 	deploymentStrategies := make(map[string]sdk.IntegrationConfig)
 	for pfName, pfConfig := range eapp.DeploymentStrategies {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -165,6 +166,13 @@ func InsertVariable(db gorp.SqlExecutor, appID int64, v *sdk.Variable, u sdk.Ide
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*v); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, appID, *v); err != nil {
+		return err
+	}
+
 	dbVar := newDBApplicationVariable(*v, appID)
 	err := gorpmapping.InsertAndSign(context.Background(), db, &dbVar)
 	if err != nil && strings.Contains(err.Error(), "application_variable_pkey") {
@@ -199,6 +207,13 @@ func UpdateVariable(db gorp.SqlExecutor, appID int64, variable *sdk.Variable, va
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*variable); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, appID, *variable); err != nil {
+		return err
+	}
+
 	dbVar := newDBApplicationVariable(*variable, appID)
 
 	if err := gorpmapping.UpdateAndSign(context.Background(), db, &dbVar); err != nil {
@@ -259,3 +274,15 @@ func DeleteVariable(db gorp.SqlExecutor, appID int64, variable *sdk.Variable, u
 	}
 	return nil
 }
+
+// checkVaultVariable checks that a vault variable references a secret allowed for the project.
+func checkVaultVariable(db gorp.SqlExecutor, appID int64, v sdk.Variable) error {
+	if v.Type != sdk.VaultVariable {
+		return nil
+	}
+	projectKey, err := db.SelectStr("SELECT project.projectkey FROM application JOIN project ON project.id = application.project_id WHERE application.id = $1", appID)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return secret.CheckVaultVariable(projectKey, v)
+}
@@ -72,19 +72,31 @@ func (api *API) postApplicationDeploymentStrategyConfigHandler() service.Handler
 		if err != nil {
 			return sdk.WrapError(err, "unable to load application")
 		}
-		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
 
-		oldPfConfig, has := app.DeploymentStrategies[pfName]
-		if !has {
-			if pf.Model.DeploymentDefaultConfig != nil {
-				oldPfConfig = pf.Model.DeploymentDefaultConfig
-			} else {
-				oldPfConfig = sdk.IntegrationConfig{}
+		// Updates of an as code application are pushed to its repository
+		if app.FromRepository != "" {
+			var cfg sdk.IntegrationConfig
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				if app.DeploymentStrategies == nil {
+					app.DeploymentStrategies = make(map[string]sdk.IntegrationConfig)
+				}
+				cfg = mergeDeploymentStrategyConfig(app.DeploymentStrategies, *pf, pfConfig)
+				app.DeploymentStrategies[pfName] = cfg
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			app, err = application.LoadByName(api.mustDB(), key, appName, application.LoadOptions.WithDeploymentStrategies)
+			if err != nil {
+				return sdk.WrapError(err, "unable to load application")
 			}
+			cfg.HideSecrets()
+			app.DeploymentStrategies[pfName] = cfg
+			return writeAsCodeAccepted(w, ope, app)
 		}
-		oldPfConfig.MergeWith(pfConfig)
+
+		oldPfConfig := mergeDeploymentStrategyConfig(app.DeploymentStrategies, *pf, pfConfig)
 
 		if err := application.SetDeploymentStrategy(tx, proj.ID, app.ID, pf.Model.ID, pfName, oldPfConfig); err != nil {
 			return sdk.WrapError(err, "postApplicationDeploymentStrategyConfigHandler")
@@ -141,9 +153,6 @@ func (api *API) deleteApplicationDeploymentStrategyConfigHandler() service.Handl
 		if err != nil {
 			return sdk.WrapError(err, "unable to load application")
 		}
-		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
 
 		isUsed, err := workflow.IsDeploymentIntegrationUsed(tx, proj.ID, app.ID, pfName)
 		if err != nil {
@@ -158,6 +167,19 @@ func (api *API) deleteApplicationDeploymentStrategyConfigHandler() service.Handl
 			return sdk.WrapError(sdk.ErrNotFound, "deleteApplicationDeploymentStrategyConfigHandler> unable to find strategy")
 		}
 
+		// Updates of an as code application are pushed to its repository
+		if app.FromRepository != "" {
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				delete(app.DeploymentStrategies, pfName)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			delete(app.DeploymentStrategies, pfName)
+			return writeAsCodeAccepted(w, ope, app)
+		}
+
 		delete(app.DeploymentStrategies, pfName)
 		if err := application.DeleteDeploymentStrategy(tx, proj.ID, app.ID, pf.ID); err != nil {
 			return sdk.WrapError(err, "deleteApplicationDeploymentStrategyConfigHandler")
@@ -171,6 +193,20 @@ func (api *API) deleteApplicationDeploymentStrategyConfigHandler() service.Handl
 	}
 }
 
+// mergeDeploymentStrategyConfig returns the current config of the integration, or its default one, updated with the given config
+func mergeDeploymentStrategyConfig(strategies map[string]sdk.IntegrationConfig, pf sdk.ProjectIntegration, pfConfig sdk.IntegrationConfig) sdk.IntegrationConfig {
+	cfg, has := strategies[pf.Name]
+	if !has {
+		if pf.Model.DeploymentDefaultConfig != nil {
+			cfg = pf.Model.DeploymentDefaultConfig
+		} else {
+			cfg = sdk.IntegrationConfig{}
+		}
+	}
+	cfg.MergeWith(pfConfig)
+	return cfg
+}
+
 func (api *API) getApplicationDeploymentStrategyConfigHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
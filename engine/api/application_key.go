@@ -40,8 +40,22 @@ func (api *API) deleteKeyInApplicationHandler() service.Handler {
 		if errA != nil {
 			return sdk.WrapError(errA, "deleteKeyInApplicationHandler> Cannot load application")
 		}
+
+		// Updates of an as code application are pushed to its repository
 		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				for i := range app.Keys {
+					if app.Keys[i].Name == keyName {
+						app.Keys = append(app.Keys[:i], app.Keys[i+1:]...)
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrKeyNotFound, "key %s not found on application %s", keyName, appName)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, nil)
 		}
 
 		tx, errT := api.mustDB().Begin()
@@ -96,10 +110,6 @@ func (api *API) addKeyInApplicationHandler() service.Handler {
 		}
 		newKey.ApplicationID = app.ID
 
-		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
-
 		if !strings.HasPrefix(newKey.Name, "app-") {
 			newKey.Name = "app-" + newKey.Name
 		}
@@ -124,6 +134,23 @@ func (api *API) addKeyInApplicationHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrUnknownKeyType, "addKeyInApplicationHandler> unknown key of type: %s", newKey.Type)
 		}
 
+		// Updates of an as code application are pushed to its repository
+		if app.FromRepository != "" {
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				for i := range app.Keys {
+					if app.Keys[i].Name == newKey.Name {
+						return sdk.WithStack(sdk.ErrKeyAlreadyExist)
+					}
+				}
+				app.Keys = append(app.Keys, newKey)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newKey)
+		}
+
 		tx, errT := api.mustDB().Begin()
 		if errT != nil {
 			return sdk.WrapError(errT, "addKeyInApplicationHandler> Cannot start transaction")
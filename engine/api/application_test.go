@@ -1,9 +1,18 @@
 package api
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-gorp/gorp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -11,8 +20,13 @@ import (
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
 	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
@@ -61,3 +75,266 @@ func Test_postApplicationMetadataHandler_AsProvider(t *testing.T) {
 	test.NoError(t, err)
 	assert.Equal(t, 1, len(apps))
 }
+
+func TestUpdateAsCodeApplicationHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	u, pass := assets.InsertAdminUser(t, db)
+
+	UUID := sdk.UUID()
+	var app sdk.Application
+	proj, wkf, endServices := insertAsCodeApplication(t, api, db, UUID, &app, func(ope sdk.Operation, _ map[string]string) {
+		// the application known by CDS is sent to detect conflicts
+		assert.Contains(t, ope.Setup.Push.Previous, app.Name+".app.yml")
+		assert.Contains(t, ope.Setup.Push.FromBranch, "cdsAsCode-")
+	})
+	defer endServices()
+
+	// Update the application without branch, a pull request is created from a new branch
+	app.Description = "my new description"
+	uri := api.Router.GetRoute("PUT", api.updateApplicationHandler, map[string]string{
+		"permProjectKey":  proj.Key,
+		"applicationName": app.Name,
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, pass, "PUT", uri, app)
+
+	// Do the request
+	wr := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wr, req)
+	assert.Equal(t, 202, wr.Code)
+	var appResult sdk.Application
+	test.NoError(t, json.Unmarshal(wr.Body.Bytes(), &appResult))
+	assert.Equal(t, "my new description", appResult.Description)
+	opeUUID := wr.Header().Get(sdk.ResponseAsCodeOperationHeader)
+	assert.NotEmpty(t, opeUUID)
+
+	cpt := 0
+	for {
+		if cpt >= 10 {
+			t.Fail()
+			return
+		}
+
+		// Get operation
+		uriGET := api.Router.GetRoute("GET", api.getWorkflowAsCodeHandler, map[string]string{
+			"key":              proj.Key,
+			"permWorkflowName": wkf.Name,
+			"uuid":             opeUUID,
+		})
+		reqGET, err := http.NewRequest("GET", uriGET, nil)
+		test.NoError(t, err)
+		assets.AuthentifyRequest(t, reqGET, u, pass)
+		wrGet := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(wrGet, reqGET)
+		assert.Equal(t, 200, wrGet.Code)
+		myOpeGet := new(sdk.Operation)
+		err = json.Unmarshal(wrGet.Body.Bytes(), myOpeGet)
+		assert.NoError(t, err)
+
+		if myOpeGet.Status < sdk.OperationStatusDone {
+			cpt++
+			time.Sleep(1 * time.Second)
+			continue
+		}
+		test.NoError(t, json.Unmarshal(wrGet.Body.Bytes(), myOpeGet))
+		assert.Equal(t, "myURL", myOpeGet.Setup.Push.PRLink)
+		assert.Empty(t, myOpeGet.Setup.Push.Previous)
+		break
+	}
+}
+
+func TestUpdateAsCodeApplicationSecretVariableHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	u, pass := assets.InsertAdminUser(t, db)
+
+	var app sdk.Application
+	var pushed bool
+	proj, _, endServices := insertAsCodeApplication(t, api, db, sdk.UUID(), &app, func(ope sdk.Operation, files map[string]string) {
+		pushed = true
+		filename := app.Name + ".app.yml"
+		require.Contains(t, files, filename)
+		require.Contains(t, ope.Setup.Push.Previous, filename)
+		assert.NotEqual(t, ope.Setup.Push.Previous[filename], files[filename])
+	})
+	defer endServices()
+
+	// The application is in the repository with the old value of the secret
+	v := sdk.Variable{Name: "mysecret", Type: sdk.SecretVariable, Value: "old value"}
+	require.NoError(t, application.InsertVariable(db, app.ID, &v, u))
+	_, err := application.Export(db, api.Cache, proj.Key, app.Name, project.EncryptWithBuiltinKey)
+	require.NoError(t, err)
+
+	v.Value = "new value"
+	uri := api.Router.GetRoute("PUT", api.updateVariableInApplicationHandler, map[string]string{
+		"permProjectKey":  proj.Key,
+		"applicationName": app.Name,
+		"name":            v.Name,
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, pass, "PUT", uri, v)
+	wr := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(wr, req)
+	assert.Equal(t, 202, wr.Code)
+	assert.True(t, pushed)
+}
+
+// insertAsCodeApplication mocks the services used to push an as code application then inserts the application with its
+// workflow. The operation and the files sent to the repositories service are given to checkPush.
+func insertAsCodeApplication(t *testing.T, api *API, db *gorp.DbMap, UUID string, app *sdk.Application, checkPush func(ope sdk.Operation, files map[string]string)) (*sdk.Project, *sdk.Workflow, func()) {
+	svcs, errS := services.LoadAll(context.TODO(), db)
+	assert.NoError(t, errS)
+	for _, s := range svcs {
+		_ = services.Delete(db, &s) // nolint
+	}
+
+	a, _ := assets.InsertService(t, db, test.GetTestName(t), services.TypeVCS)
+	b, _ := assets.InsertService(t, db, test.GetTestName(t), services.TypeRepositories)
+	c, _ := assets.InsertService(t, db, test.GetTestName(t), services.TypeHooks)
+	endServices := func() {
+		_ = services.Delete(db, a) // nolint
+		_ = services.Delete(db, b) // nolint
+		_ = services.Delete(db, c) // nolint
+	}
+	//This is a mock for the repositories service
+	services.HTTPClient = mock(
+		func(r *http.Request) (*http.Response, error) {
+			body := new(bytes.Buffer)
+			w := new(http.Response)
+			enc := json.NewEncoder(body)
+			w.Body = ioutil.NopCloser(body)
+			w.StatusCode = http.StatusOK
+			switch r.URL.String() {
+			case "/operations":
+				// the application known by CDS is sent to detect conflicts
+				require.NoError(t, r.ParseMultipartForm(100000))
+				var opeIn sdk.Operation
+				require.NoError(t, json.Unmarshal([]byte(r.FormValue("dataJSON")), &opeIn))
+				require.Len(t, r.MultipartForm.File["dataFiles"], 1)
+				f, err := r.MultipartForm.File["dataFiles"][0].Open()
+				require.NoError(t, err)
+				files := make(map[string]string)
+				tr := tar.NewReader(f)
+				for {
+					hdr, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					require.NoError(t, err)
+					content, err := ioutil.ReadAll(tr)
+					require.NoError(t, err)
+					files[hdr.Name] = string(content)
+				}
+				checkPush(opeIn, files)
+				ope := new(sdk.Operation)
+				ope.UUID = UUID
+				ope.Status = sdk.OperationStatusProcessing
+				if err := enc.Encode(ope); err != nil {
+					return writeError(w, err)
+				}
+			case "/operations/" + UUID:
+				ope := new(sdk.Operation)
+				ope.UUID = UUID
+				ope.Status = sdk.OperationStatusDone
+				if err := enc.Encode(ope); err != nil {
+					return writeError(w, err)
+				}
+			case "/vcs/github/webhooks":
+				hookInfo := repositoriesmanager.WebhooksInfos{
+					WebhooksSupported: true,
+					WebhooksDisabled:  false,
+				}
+				if err := enc.Encode(hookInfo); err != nil {
+					return writeError(w, err)
+				}
+			case "/vcs/github/repos/foo/myrepo":
+				vcsRepo := sdk.VCSRepo{
+					Name:         "foo/myrepo",
+					SSHCloneURL:  "git:foo",
+					HTTPCloneURL: "https:foo",
+				}
+				if err := enc.Encode(vcsRepo); err != nil {
+					return writeError(w, err)
+				}
+			case "/vcs/github/repos/foo/myrepo/hooks":
+				hook := sdk.VCSHook{
+					ID: "myod",
+				}
+				if err := enc.Encode(hook); err != nil {
+					return writeError(w, err)
+				}
+			case "/vcs/github/repos/foo/myrepo/pullrequests":
+				vcsPR := sdk.VCSPullRequest{
+					URL: "myURL",
+				}
+				if err := enc.Encode(vcsPR); err != nil {
+					return writeError(w, err)
+				}
+			case "/task/bulk":
+				var hooks map[string]sdk.NodeHook
+				bts, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					return writeError(w, err)
+				}
+				if err := json.Unmarshal(bts, &hooks); err != nil {
+					return writeError(w, err)
+				}
+				if err := enc.Encode(hooks); err != nil {
+					return writeError(w, err)
+				}
+			default:
+				t.Logf("[WRONG ROUTE] %s", r.URL.String())
+				w.StatusCode = http.StatusNotFound
+			}
+
+			return w, nil
+		},
+	)
+
+	assert.NoError(t, workflow.CreateBuiltinWorkflowHookModels(db))
+
+	// Create Project
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey)
+	assert.NoError(t, repositoriesmanager.InsertForProject(db, proj, &sdk.ProjectVCSServer{
+		Name: "github",
+		Data: map[string]string{
+			"token":  "foo",
+			"secret": "bar",
+		},
+	}))
+	wkf := assets.InsertTestWorkflow(t, db, api.Cache, proj, sdk.RandomString(10))
+
+	pip := sdk.Pipeline{
+		Name:           sdk.RandomString(10),
+		ProjectID:      proj.ID,
+		FromRepository: "myrepofrom",
+	}
+	assert.NoError(t, pipeline.InsertPipeline(db, &pip))
+
+	pip.Stages = []sdk.Stage{
+		{
+			Name:       "mystage",
+			BuildOrder: 1,
+			Enabled:    true,
+		},
+	}
+
+	app.Name = sdk.RandomString(10)
+	app.ProjectID = proj.ID
+	app.RepositoryFullname = "foo/myrepo"
+	app.VCSServer = "github"
+	app.FromRepository = "myrepofrom"
+	assert.NoError(t, application.Insert(db, *proj, app))
+	assert.NoError(t, repositoriesmanager.InsertForApplication(db, app, proj.Key))
+
+	repoModel, err := workflow.LoadHookModelByName(db, sdk.RepositoryWebHookModelName)
+	assert.NoError(t, err)
+
+	wk := initWorkflow(t, db, proj, app, &pip, repoModel)
+	wk.FromRepository = "myrepofrom"
+	require.NoError(t, workflow.Insert(context.Background(), db, api.Cache, *proj, wk))
+
+	return proj, wkf, endServices
+}
@@ -100,8 +100,18 @@ func (api *API) deleteVariableFromApplicationHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Cannot load application: %s", appName)
 		}
+
+		// Updates of an as code application are pushed to its repository
 		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				var err error
+				app.Variables, err = deleteAsCodeVariable(app.Variables, varName)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, nil)
 		}
 
 		tx, err := api.mustDB().Begin()
@@ -148,8 +158,16 @@ func (api *API) updateVariableInApplicationHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Cannot load application: %s", appName)
 		}
+
+		// Updates of an as code application are pushed to its repository
 		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				return updateAsCodeVariable(key, app.Variables, varName, &newVar)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newVar)
 		}
 
 		variableBefore, err := application.LoadVariableWithDecryption(api.mustDB(), app.ID, newVar.ID, varName)
@@ -197,8 +215,18 @@ func (api *API) addVariableInApplicationHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Cannot load application %s ", appName)
 		}
+
+		// Updates of an as code application are pushed to its repository
 		if app.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeApplication(ctx, key, appName, FormString(r, "branch"), FormString(r, "message"), func(app *sdk.Application) error {
+				var err error
+				app.Variables, err = addAsCodeVariable(key, app.Variables, newVar)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newVar)
 		}
 
 		if !sdk.IsInArray(newVar.Type, sdk.AvailableVariableType) {
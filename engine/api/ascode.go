@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/ovh/cds/engine/api/operation"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/api/workflowtemplate"
 	"github.com/ovh/cds/engine/service"
@@ -243,3 +245,99 @@ func (api *API) postResyncPRAsCodeHandler() service.Handler {
 		return nil
 	}
 }
+
+// loadAsCodeRootApplication returns the root application of the workflow that holds the as code entities
+// of a repository, its vcs configuration is used to push the updates of these entities.
+func (api *API) loadAsCodeRootApplication(ctx context.Context, proj sdk.Project, fromRepository string) (*sdk.Application, error) {
+	wkHolder, err := workflow.LoadByRepo(ctx, api.Cache, api.mustDB(), proj, fromRepository, workflow.LoadOptions{
+		WithTemplate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if wkHolder.TemplateInstance != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "cannot edit an entity that was generated by a template")
+	}
+
+	var rootApp *sdk.Application
+	if wkHolder.WorkflowData.Node.Context != nil && wkHolder.WorkflowData.Node.Context.ApplicationID != 0 {
+		rootApp, err = application.LoadByIDWithClearVCSStrategyPassword(api.mustDB(), wkHolder.WorkflowData.Node.Context.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rootApp == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow %s that hold the entity", wkHolder.Name)
+	}
+	return rootApp, nil
+}
+
+// writeAsCodeAccepted writes the response of an update of an as code entity. The body is unchanged for the clients
+// but the update is only applied once the pushed changes are merged in the repository: the status is
+// 202 Accepted and the push operation can be followed with the uuid given in a header.
+func writeAsCodeAccepted(w http.ResponseWriter, ope *sdk.Operation, i interface{}) error {
+	w.Header().Add(sdk.ResponseAsCodeOperationHeader, ope.UUID)
+	return service.WriteJSON(w, i, http.StatusAccepted)
+}
+
+// addAsCodeVariable adds a variable to the variables of an as code entity
+func addAsCodeVariable(projectKey string, vs []sdk.Variable, v sdk.Variable) ([]sdk.Variable, error) {
+	if !sdk.IsInArray(v.Type, sdk.AvailableVariableType) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid variable type %s", v.Type)
+	}
+	if err := checkAsCodeVariable(projectKey, v); err != nil {
+		return nil, err
+	}
+	for i := range vs {
+		if vs[i].Name == v.Name {
+			return nil, sdk.WithStack(sdk.ErrVariableExists)
+		}
+	}
+	return append(vs, v), nil
+}
+
+// updateAsCodeVariable replaces the variable with the given name in the variables of an as code entity
+func updateAsCodeVariable(projectKey string, vs []sdk.Variable, name string, v *sdk.Variable) error {
+	if err := checkAsCodeVariable(projectKey, *v); err != nil {
+		return err
+	}
+	for i := range vs {
+		if vs[i].Name != name {
+			continue
+		}
+		if v.Type == sdk.SecretVariable && v.Value == sdk.PasswordPlaceholder {
+			v.Value = vs[i].Value
+		}
+		vs[i] = *v
+		return nil
+	}
+	return sdk.WithStack(sdk.ErrNoVariable)
+}
+
+// deleteAsCodeVariable removes the variable with the given name from the variables of an as code entity
+func deleteAsCodeVariable(vs []sdk.Variable, name string) ([]sdk.Variable, error) {
+	for i := range vs {
+		if vs[i].Name == name {
+			return append(vs[:i], vs[i+1:]...), nil
+		}
+	}
+	return nil, sdk.WithStack(sdk.ErrNoVariable)
+}
+
+func checkAsCodeVariable(projectKey string, v sdk.Variable) error {
+	if !sdk.NamePatternRegex.MatchString(v.Name) {
+		return sdk.NewErrorFrom(sdk.ErrInvalidName, "invalid variable name, it should match %s", sdk.NamePattern)
+	}
+	if err := sdk.CheckVariableValue(v); err != nil {
+		return err
+	}
+	return secret.CheckVaultVariable(projectKey, v)
+}
+
+// asCodeBranchOptions returns the options used to read as code workflows from the branch being built.
+func (api *API) asCodeBranchOptions() workflow.AsCodeBranchOptions {
+	return workflow.AsCodeBranchOptions{
+		TrustedBranches: api.Config.AsCode.TrustedBranches,
+		CacheTTL:        time.Duration(api.Config.AsCode.CacheTTL) * time.Second,
+	}
+}
@@ -14,8 +14,10 @@ import (
 )
 
 const (
-	AsCodePipeline = "pipeline"
-	AsCodeWorkflow = "workflow"
+	AsCodePipeline    = "pipeline"
+	AsCodeWorkflow    = "workflow"
+	AsCodeApplication = "application"
+	AsCodeEnvironment = "environment"
 )
 
 type EntityData struct {
@@ -33,6 +35,7 @@ func UpdateAsCodeResult(ctx context.Context, db *gorp.DbMap, store cache.Store,
 	defer func() {
 		cancel()
 		ed.Operation.RepositoryStrategy.SSHKeyContent = ""
+		ed.Operation.Setup.Push.Previous = nil
 		_ = store.SetWithTTL(cache.Key(operation.CacheOperationKey, ed.Operation.UUID), ed.Operation, 300)
 	}()
 forLoop:
@@ -52,6 +55,16 @@ forLoop:
 				log.Error(ctx, "operation in error %s: %s", ed.Operation.UUID, ed.Operation.Error)
 				break forLoop
 			}
+			// Files were committed on the target branch, there is no pull request to follow
+			if ed.Operation.Status == sdk.OperationStatusDone && ed.Operation.Setup.Push.IsDirect() {
+				return &sdk.AsCodeEvent{
+					Username:   u.GetUsername(),
+					CreateDate: time.Now(),
+					FromRepo:   ed.FromRepo,
+					Status:     sdk.AsCodeEventStatusCommitted,
+					Data:       ed.eventData(sdk.AsCodeEventData{}),
+				}
+			}
 			if ed.Operation.Status == sdk.OperationStatusDone {
 				vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
 				if vcsServer == nil {
@@ -124,36 +137,15 @@ forLoop:
 					}
 				}
 
-				switch ed.Type {
-				case AsCodeWorkflow:
-					if asCodeEvent.Data.Workflows == nil {
-						asCodeEvent.Data.Workflows = make(map[int64]string)
-					}
-					found := false
-					for k := range asCodeEvent.Data.Workflows {
-						if k == ed.ID {
-							found = true
-							break
-						}
-					}
-					if !found {
-						asCodeEvent.Data.Workflows[ed.ID] = ed.Name
-					}
-				case AsCodePipeline:
-					if asCodeEvent.Data.Pipelines == nil {
-						asCodeEvent.Data.Pipelines = make(map[int64]string)
-					}
-					found := false
-					for k := range asCodeEvent.Data.Pipelines {
-						if k == ed.ID {
-							found = true
-							break
-						}
-					}
-					if !found {
-						asCodeEvent.Data.Pipelines[ed.ID] = ed.Name
-					}
+				asCodeEvent.Data = ed.eventData(asCodeEvent.Data)
+
+				// Conflicts are computed by the repositories service against the target branch
+				asCodeEvent.Data.Conflicts = ed.Operation.Setup.Push.Conflicts
+				asCodeEvent.Status = sdk.AsCodeEventStatusOpened
+				if len(asCodeEvent.Data.Conflicts) > 0 {
+					asCodeEvent.Status = sdk.AsCodeEventStatusConflict
 				}
+
 				if err := InsertOrUpdateAsCodeEvent(db, &asCodeEvent); err != nil {
 					log.Error(ctx, "postWorkflowAsCodeHandler> unable to insert as code event: %v", err)
 					ed.Operation.Status = sdk.OperationStatusError
@@ -166,3 +158,27 @@ forLoop:
 	}
 	return nil
 }
+
+// eventData adds the entity to given as code event data
+func (ed EntityData) eventData(data sdk.AsCodeEventData) sdk.AsCodeEventData {
+	var value *sdk.AsCodeEventDataValue
+	switch ed.Type {
+	case AsCodeWorkflow:
+		value = &data.Workflows
+	case AsCodePipeline:
+		value = &data.Pipelines
+	case AsCodeApplication:
+		value = &data.Applications
+	case AsCodeEnvironment:
+		value = &data.Environments
+	default:
+		return data
+	}
+	if *value == nil {
+		*value = make(map[int64]string)
+	}
+	if _, has := (*value)[ed.ID]; !has {
+		(*value)[ed.ID] = ed.Name
+	}
+	return data
+}
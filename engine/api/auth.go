@@ -277,6 +277,11 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			return err
 		}
 
+		// Reconcile members of groups synchronized from the driver's external groups
+		if err := syncUserGroups(ctx, tx, consumerType, usr, userInfo.Groups, api.Config.Auth.GroupsSyncAllowEmpty); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"net/http"
+	"time"
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
@@ -52,8 +53,16 @@ func (api *API) postAuthBuiltinSigninHandler() service.Handler {
 			return err
 		}
 
-		// Generate a new session for consumer
-		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), false)
+		if consumer.IsExpired() {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "consumer is expired")
+		}
+
+		// Generate a new session for consumer, it can't last after the consumer expiration
+		sessionDuration := driver.GetSessionDuration()
+		if consumer.ExpireAt != nil && time.Until(*consumer.ExpireAt) < sessionDuration {
+			sessionDuration = time.Until(*consumer.ExpireAt)
+		}
+		session, err := authentication.NewSession(ctx, tx, consumer, sessionDuration, false)
 		if err != nil {
 			return err
 		}
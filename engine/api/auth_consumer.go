@@ -3,13 +3,16 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/sdk"
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
+	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 )
 
@@ -31,7 +34,7 @@ func (api *API) getConsumersByUserHandler() service.Handler {
 		}
 
 		cs, err := authentication.LoadConsumersByUserID(ctx, api.mustDB(), u.ID,
-			authentication.LoadConsumerOptions.Default)
+			authentication.LoadConsumerOptions.Default, authentication.LoadConsumerOptions.WithLastUsed)
 		if err != nil {
 			return err
 		}
@@ -72,16 +75,59 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 			return err
 		}
 
+		// A consumer can only be restricted to projects that the user can read
+		if reqData.Restrictions != nil {
+			perms, err := permission.LoadProjectMaxLevelPermission(ctx, api.mustDB(), reqData.Restrictions.ProjectKeys, consumer.GetGroupIDs())
+			if err != nil {
+				return err
+			}
+			for _, key := range reqData.Restrictions.ProjectKeys {
+				if perms.Level(key) < sdk.PermissionRead && !isMaintainer(ctx) {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given project %s for consumer restrictions", key)
+				}
+			}
+			for _, w := range reqData.Restrictions.Workflows {
+				ss := strings.SplitN(w, "/", 2)
+				exists, err := workflow.Exists(api.mustDB(), ss[0], ss[1])
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given workflow %s for consumer restrictions", w)
+				}
+			}
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
 		// Create the new built in consumer from request data
-		newConsumer, token, err := builtin.NewConsumer(ctx, api.mustDB(), reqData.Name, reqData.Description,
+		newConsumer, token, err := builtin.NewConsumer(ctx, tx, reqData.Name, reqData.Description,
 			consumer, reqData.GroupIDs, reqData.ScopeDetails)
 		if err != nil {
 			return err
 		}
-		if err := authentication.LoadConsumerOptions.Default(ctx, api.mustDB(), newConsumer); err != nil {
+
+		// Set expiration and restrictions on the new consumer
+		if reqData.ExpireAt != nil || reqData.Restrictions != nil {
+			newConsumer.ExpireAt = reqData.ExpireAt
+			newConsumer.Restrictions = reqData.Restrictions
+			if err := authentication.UpdateConsumer(ctx, tx, newConsumer); err != nil {
+				return err
+			}
+		}
+
+		if err := authentication.LoadConsumerOptions.Default(ctx, tx, newConsumer); err != nil {
 			return err
 		}
 
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
 		return service.WriteJSON(w, sdk.AuthConsumerCreateResponse{
 			Token:    token,
 			Consumer: newConsumer,
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// recordConsumerActivity saves the usage of given consumer for current request, errors are only logged
+// to not break the request. An event is sent if a consumer is used from a new network.
+func (api *API) recordConsumerActivity(ctx context.Context, req *http.Request, consumer *sdk.AuthConsumer) {
+	ip := requestRemoteIP(req, api.trustedProxies)
+	activity, newNetwork, err := authentication.RecordConsumerActivity(ctx, api.mustDB(), api.Cache, consumer.ID, ip, req.UserAgent())
+	if err != nil {
+		log.Error(ctx, "recordConsumerActivity> cannot record activity for consumer %s: %v", consumer.ID, err)
+		return
+	}
+
+	// Workers are started on many hosts, there is no need to alert on their network changes
+	if activity == nil || !newNetwork || consumer.Worker != nil {
+		return
+	}
+
+	network := authentication.ConsumerActivityNetwork(ip)
+	log.Warning(ctx, "recordConsumerActivity> consumer %s (%s) used from new network %s", consumer.ID, consumer.Name, network)
+	event.PublishConsumerNewNetwork(ctx, *consumer, *activity, network, consumer.AuthentifiedUser)
+}
+
+func (api *API) consumerIdleRoutine(ctx context.Context, idle time.Duration) {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting consumerIdleRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := api.disableIdleConsumers(ctx, time.Now().Add(-idle)); err != nil {
+				log.Error(ctx, "consumerIdleRoutine> %v", err)
+			}
+		}
+	}
+}
+
+// disableIdleConsumers disables all builtin consumers that were not used since given date. Nothing is disabled
+// until consumers activity was recorded for the whole idle delay, as consumers used before can't be told apart
+// from never used ones.
+func (api *API) disableIdleConsumers(ctx context.Context, since time.Time) error {
+	start, err := authentication.LoadConsumerActivityStartDate(api.mustDB())
+	if err != nil {
+		return err
+	}
+	if start.After(since) {
+		log.Debug("disableIdleConsumers> consumers activity recorded since %v, skipped", start)
+		return nil
+	}
+
+	cs, err := authentication.LoadConsumersByType(ctx, api.mustDB(), sdk.ConsumerBuiltin,
+		authentication.LoadConsumerOptions.WithAuthentifiedUser,
+		authentication.LoadConsumerOptions.WithLastUsed)
+	if err != nil {
+		return err
+	}
+
+	for i := range cs {
+		if cs[i].Disabled || !cs[i].IsStale(since) {
+			continue
+		}
+
+		cs[i].Disabled = true
+		if err := authentication.UpdateConsumer(ctx, api.mustDB(), &cs[i]); err != nil {
+			log.Error(ctx, "disableIdleConsumers> cannot disable consumer %s: %v", cs[i].ID, err)
+			continue
+		}
+		log.Info(ctx, "disableIdleConsumers> consumer %s (%s) disabled after being idle", cs[i].ID, cs[i].Name)
+		event.PublishConsumerIdleDisabled(ctx, cs[i], cs[i].AuthentifiedUser)
+	}
+
+	return nil
+}
+
+func (api *API) consumerActivityPurgeRoutine(ctx context.Context, retention time.Duration) {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting consumerActivityPurgeRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			n, err := authentication.DeleteConsumerActivitiesBefore(api.mustDB(), time.Now().Add(-retention))
+			if err != nil {
+				log.Error(ctx, "consumerActivityPurgeRoutine> %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Info(ctx, "consumerActivityPurgeRoutine> %d consumer activities deleted", n)
+			}
+		}
+	}
+}
+
+func (api *API) getConsumerActivityByUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		consumerID := vars["permConsumerID"]
+
+		as, err := authentication.LoadConsumerActivitiesByConsumerID(ctx, api.mustDB(), consumerID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, as, http.StatusOK)
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_requestRemoteIP(t *testing.T) {
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	_, err = parseTrustedProxies([]string{"invalid"})
+	assert.Error(t, err)
+
+	req := &http.Request{RemoteAddr: "1.2.3.4:1234", Header: http.Header{}}
+	req.Header.Set("X-Forwarded-For", "5.6.7.8")
+	req.Header.Set("X-Real-IP", "5.6.7.8")
+
+	// Headers are ignored for requests that don't come from a trusted proxy
+	assert.Equal(t, "1.2.3.4", requestRemoteIP(req, nil))
+	assert.Equal(t, "1.2.3.4", requestRemoteIP(req, proxies))
+
+	// The client ip is the last address that is not a trusted proxy
+	req.RemoteAddr = "10.1.2.3:1234"
+	req.Header.Set("X-Forwarded-For", "6.6.6.6, 5.6.7.8, 192.168.1.1")
+	assert.Equal(t, "5.6.7.8", requestRemoteIP(req, proxies))
+
+	req.Header.Del("X-Forwarded-For")
+	assert.Equal(t, "5.6.7.8", requestRemoteIP(req, proxies))
+
+	req.Header.Del("X-Real-IP")
+	assert.Equal(t, "10.1.2.3", requestRemoteIP(req, proxies))
+
+	// Values that are not ips are ignored
+	req.Header.Set("X-Forwarded-For", "5.6.7.8, "+strings.Repeat("a", 100))
+	assert.Equal(t, "10.1.2.3", requestRemoteIP(req, proxies))
+	req.Header.Del("X-Forwarded-For")
+	req.Header.Set("X-Real-IP", "unknown")
+	assert.Equal(t, "10.1.2.3", requestRemoteIP(req, proxies))
+
+	// Ips are normalized
+	req.Header.Set("X-Forwarded-For", " 2001:DB8:0:0::1 ")
+	assert.Equal(t, "2001:db8::1", requestRemoteIP(req, proxies))
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// jobIDTokenIssuer returns the issuer of jobs identity tokens, it's the API public url so the
+// OpenID configuration can be discovered by external systems.
+func (api *API) jobIDTokenIssuer() string {
+	return strings.TrimSuffix(api.Config.URL.API, "/")
+}
+
+func (api *API) getAuthJWKSHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, authentication.JSONWebKeySet(), http.StatusOK)
+	}
+}
+
+func (api *API) getAuthOpenIDConfigurationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		issuer := api.jobIDTokenIssuer()
+		return service.WriteJSON(w, sdk.OpenIDConfiguration{
+			Issuer:                           issuer,
+			JWKSURI:                          issuer + "/auth/jwks",
+			ResponseTypesSupported:           []string{"id_token"},
+			SubjectTypesSupported:            []string{"public"},
+			IDTokenSigningAlgValuesSupported: []string{"RS256"},
+			ClaimsSupported: []string{"sub", "aud", "exp", "iat", "iss", "jti", "nbf",
+				"job_id", "project_key", "workflow", "node", "branch", "environment", "run_number"},
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobIDTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		if api.Config.Auth.JobIDTokenDuration <= 0 || !authentication.JobIDTokenEnabled() {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "jobs identity tokens are disabled on this CDS instance")
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		j, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if j.Status != sdk.StatusBuilding {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "an identity token can only be issued for a building job")
+		}
+
+		audience := QueryString(r, "audience")
+		if audience == "" {
+			audience = api.jobIDTokenIssuer()
+		}
+
+		claims := authentication.NewJobIDTokenClaims(api.jobIDTokenIssuer(), audience, *j,
+			time.Duration(api.Config.Auth.JobIDTokenDuration)*time.Minute)
+		token, err := authentication.NewJobIDTokenJWT(claims)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.JobIDToken{
+			Token:    token,
+			ExpireAt: time.Unix(claims.ExpiresAt, 0),
+		}, http.StatusOK)
+	}
+}
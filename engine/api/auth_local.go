@@ -196,6 +196,18 @@ func (api *API) postAuthLocalSigninHandler() service.Handler {
 			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
 		}
 
+		// If the user enrolled a second factor, the session will be created once a valid code is given
+		mfaToken, err := api.newLocalMFAToken(ctx, tx, consumer)
+		if err != nil {
+			return err
+		}
+		if mfaToken != "" {
+			return service.WriteJSON(w, sdk.AuthConsumerSigninResponse{
+				MFAToken: mfaToken,
+				APIURL:   api.Config.URL.API,
+			}, http.StatusOK)
+		}
+
 		// Generate a new session for consumer
 		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), false)
 		if err != nil {
@@ -490,6 +502,22 @@ func (api *API) postAuthLocalResetHandler() service.Handler {
 			return err
 		}
 
+		// If the user enrolled a second factor, the password is updated but a signin with a code is required
+		mfaToken, err := api.newLocalMFAToken(ctx, tx, consumer)
+		if err != nil {
+			return err
+		}
+		if mfaToken != "" {
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			local.CleanResetConsumerToken(api.Cache, consumer.ID)
+			return service.WriteJSON(w, sdk.AuthConsumerSigninResponse{
+				MFAToken: mfaToken,
+				APIURL:   api.Config.URL.API,
+			}, http.StatusOK)
+		}
+
 		// Generate a new session for consumer
 		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), false)
 		if err != nil {
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/authentication/local"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// newLocalMFAToken returns a second factor token if given consumer's user enrolled a TOTP, or an empty string.
+func (api *API) newLocalMFAToken(ctx context.Context, db gorp.SqlExecutor, consumer *sdk.AuthConsumer) (string, error) {
+	t, err := local.LoadTOTPByUserID(ctx, db, consumer.AuthentifiedUserID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return "", err
+	}
+	if t == nil || !t.Verified {
+		return "", nil
+	}
+	return local.NewMFAConsumerToken(api.Cache, consumer.ID)
+}
+
+func (api *API) postAuthLocalSigninTOTPHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		driver, okDriver := api.AuthenticationDrivers[sdk.ConsumerLocal]
+		if !okDriver {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var reqData sdk.AuthConsumerSigninRequest
+		if err := service.UnmarshalBody(r, &reqData); err != nil {
+			return err
+		}
+		if reqData["mfa_token"] == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing second factor token")
+		}
+		if reqData["code"] == "" && reqData["recovery_code"] == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing totp code or recovery code")
+		}
+
+		consumerID, err := local.CheckMFAConsumerToken(api.Cache, reqData["mfa_token"])
+		if err != nil {
+			return err
+		}
+
+		// The token can only be used once, a new signin with password is required after an invalid code
+		defer local.CleanMFAConsumerToken(api.Cache, consumerID)
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		consumer, err := authentication.LoadConsumerByID(ctx, tx, consumerID)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+		}
+		if consumer.Type != sdk.ConsumerLocal {
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		}
+
+		t, err := local.LoadTOTPByUserID(ctx, tx, consumer.AuthentifiedUserID)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+		}
+		if !t.Verified {
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		}
+
+		if reqData["code"] != "" {
+			err = local.CheckTOTPCode(t, reqData["code"], time.Now())
+		} else {
+			err = local.UseRecoveryCode(t, reqData["recovery_code"])
+		}
+		if err != nil {
+			return err
+		}
+		if err := local.UpdateTOTP(ctx, tx, t); err != nil {
+			return err
+		}
+
+		// Generate a new session for consumer with second factor
+		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), true)
+		if err != nil {
+			return err
+		}
+
+		// Generate a jwt for current session
+		jwt, err := authentication.NewSessionJWT(session)
+		if err != nil {
+			return err
+		}
+
+		usr, err := user.LoadByID(ctx, tx, consumer.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		// Set a cookie with the jwt token
+		api.SetCookie(w, jwtCookieName, jwt, session.ExpireAt)
+
+		// Prepare http response
+		resp := sdk.AuthConsumerSigninResponse{
+			Token:  jwt,
+			User:   usr,
+			APIURL: api.Config.URL.API,
+		}
+
+		return service.WriteJSON(w, resp, http.StatusOK)
+	}
+}
+
+// loadTOTPUser returns the user for given route username, some actions are only allowed for the user itself.
+func (api *API) loadTOTPUser(ctx context.Context, db gorp.SqlExecutor, username string, onlyItself bool) (*sdk.AuthentifiedUser, error) {
+	var u *sdk.AuthentifiedUser
+	var err error
+	if username == "me" {
+		u, err = user.LoadByID(ctx, db, getAPIConsumer(ctx).AuthentifiedUserID)
+	} else {
+		u, err = user.LoadByUsername(ctx, db, username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if onlyItself && u.ID != getAPIConsumer(ctx).AuthentifiedUserID {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "a totp can only be managed by its owner")
+	}
+
+	return u, nil
+}
+
+func (api *API) getUserTOTPHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		u, err := api.loadTOTPUser(ctx, api.mustDB(), vars["permUsername"], false)
+		if err != nil {
+			return err
+		}
+
+		t, err := local.LoadTOTPByUserID(ctx, api.mustDB(), u.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, t, http.StatusOK)
+	}
+}
+
+func (api *API) postUserTOTPHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		if _, ok := api.AuthenticationDrivers[sdk.ConsumerLocal]; !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := api.loadTOTPUser(ctx, tx, vars["permUsername"], true)
+		if err != nil {
+			return err
+		}
+
+		// A verified TOTP should be deleted before enrolling a new one, an unverified one is replaced
+		existing, err := local.LoadTOTPByUserID(ctx, tx, u.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if existing.Verified {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "a totp is already enrolled for user %s", u.Username)
+			}
+			if err := local.DeleteTOTPByUserID(tx, u.ID); err != nil {
+				return err
+			}
+		}
+
+		t, err := local.NewTOTP(u.ID)
+		if err != nil {
+			return err
+		}
+		if err := local.InsertTOTP(ctx, tx, t); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalTOTPEnrollResponse{
+			Secret: t.Secret,
+			URI:    local.TOTPURI(*t, u.Username),
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) postUserTOTPVerifyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var reqData sdk.AuthConsumerSigninRequest
+		if err := service.UnmarshalBody(r, &reqData); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := api.loadTOTPUser(ctx, tx, vars["permUsername"], true)
+		if err != nil {
+			return err
+		}
+
+		t, err := local.LoadTOTPByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		if t.Verified {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "totp is already verified")
+		}
+		if err := local.CheckTOTPCode(t, reqData["code"], time.Now()); err != nil {
+			return err
+		}
+
+		t.Verified = true
+		codes, err := local.GenerateRecoveryCodes(t)
+		if err != nil {
+			return err
+		}
+		if err := local.UpdateTOTP(ctx, tx, t); err != nil {
+			return err
+		}
+
+		// The current session was just validated with a second factor
+		if session := getAuthSession(ctx); session != nil && getAPIConsumer(ctx).Type == sdk.ConsumerLocal {
+			session.MFA = true
+			if err := authentication.UpdateSession(ctx, tx, session); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalTOTPRecoveryCodesResponse{RecoveryCodes: codes}, http.StatusOK)
+	}
+}
+
+func (api *API) postUserTOTPRecoveryCodesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var reqData sdk.AuthConsumerSigninRequest
+		if err := service.UnmarshalBody(r, &reqData); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := api.loadTOTPUser(ctx, tx, vars["permUsername"], true)
+		if err != nil {
+			return err
+		}
+
+		t, err := local.LoadTOTPByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		if !t.Verified {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "totp is not verified")
+		}
+		if err := local.CheckTOTPCode(t, reqData["code"], time.Now()); err != nil {
+			return err
+		}
+
+		codes, err := local.GenerateRecoveryCodes(t)
+		if err != nil {
+			return err
+		}
+		if err := local.UpdateTOTP(ctx, tx, t); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalTOTPRecoveryCodesResponse{RecoveryCodes: codes}, http.StatusOK)
+	}
+}
+
+func (api *API) deleteUserTOTPHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := api.loadTOTPUser(ctx, tx, vars["permUsername"], false)
+		if err != nil {
+			return err
+		}
+
+		t, err := local.LoadTOTPByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		// A user should give a valid code to remove its own verified TOTP, an admin can remove it for a user that lost its device
+		if t.Verified && u.ID == getAPIConsumer(ctx).AuthentifiedUserID {
+			if err := local.CheckTOTPCode(t, QueryString(r, "code"), time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if err := local.DeleteTOTPByUserID(tx, u.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -0,0 +1,84 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// ConsumerActivityThrottle is the minimal delay between two records of the same consumer activity.
+var ConsumerActivityThrottle = 5 * time.Minute
+
+const (
+	consumerActivityIPMaxLength        = 64
+	consumerActivityUserAgentMaxLength = 256
+)
+
+// ConsumerActivityNetwork returns the network of given ip used to detect a consumer usage from a new location,
+// /24 for IPv4 and /64 for IPv6. Invalid ips are returned as is.
+func ConsumerActivityNetwork(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		n := net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+		return n.String()
+	}
+	n := net.IPNet{IP: parsed.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}
+	return n.String()
+}
+
+// RecordConsumerActivity saves a usage of given consumer from given ip and user agent. To limit writes in database,
+// a same activity is recorded once per throttle delay, in this case the returned activity is nil.
+// The returned boolean is true when the consumer was already used but never from the network of given ip.
+func RecordConsumerActivity(ctx context.Context, db gorp.SqlExecutor, store cache.Store, consumerID, ip, userAgent string) (*sdk.AuthConsumerActivity, bool, error) {
+	if len(ip) > consumerActivityIPMaxLength {
+		ip = ip[:consumerActivityIPMaxLength]
+	}
+	if len(userAgent) > consumerActivityUserAgentMaxLength {
+		userAgent = userAgent[:consumerActivityUserAgentMaxLength]
+	}
+
+	sum := sha1.Sum([]byte(ip + userAgent))
+	k := cache.Key("auth", "consumer", "activity", consumerID, hex.EncodeToString(sum[:]))
+	if exists, _ := store.Get(k, new(bool)); exists {
+		return nil, false, nil
+	}
+	if err := store.SetWithDuration(k, true, ConsumerActivityThrottle); err != nil {
+		return nil, false, err
+	}
+
+	existing, err := LoadConsumerActivitiesByConsumerID(ctx, db, consumerID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	network := ConsumerActivityNetwork(ip)
+	knownNetwork := false
+	for i := range existing {
+		if ConsumerActivityNetwork(existing[i].IP) == network {
+			knownNetwork = true
+			break
+		}
+	}
+
+	a := sdk.AuthConsumerActivity{
+		ConsumerID: consumerID,
+		IP:         ip,
+		UserAgent:  userAgent,
+		LastSeen:   time.Now(),
+	}
+	if err := UpsertConsumerActivity(db, &a); err != nil {
+		return nil, false, err
+	}
+
+	return &a, len(existing) > 0 && !knownNetwork, nil
+}
@@ -0,0 +1,110 @@
+package authentication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestConsumerActivityNetwork(t *testing.T) {
+	assert.Equal(t, "10.1.2.0/24", authentication.ConsumerActivityNetwork("10.1.2.3"))
+	assert.Equal(t, "10.1.2.0/24", authentication.ConsumerActivityNetwork("10.1.2.254"))
+	assert.Equal(t, "2001:db8:1:2::/64", authentication.ConsumerActivityNetwork("2001:db8:1:2:3:4:5:6"))
+	assert.Equal(t, "invalid", authentication.ConsumerActivityNetwork("invalid"))
+}
+
+func TestRecordConsumerActivity(t *testing.T) {
+	db, store, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	u := sdk.AuthentifiedUser{
+		Username: sdk.RandomString(10),
+	}
+	require.NoError(t, user.Insert(context.TODO(), db, &u))
+
+	c := sdk.AuthConsumer{
+		Name:               sdk.RandomString(10),
+		Type:               sdk.ConsumerBuiltin,
+		AuthentifiedUserID: u.ID,
+		IssuedAt:           time.Now(),
+	}
+	require.NoError(t, authentication.InsertConsumer(context.TODO(), db, &c))
+
+	// First usage is not considered as a new network
+	a, newNetwork, err := authentication.RecordConsumerActivity(context.TODO(), db, store, c.ID, "10.1.2.3", "cdsctl")
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.False(t, newNetwork)
+	assert.Equal(t, int64(1), a.Count)
+
+	// Same activity is throttled
+	a, _, err = authentication.RecordConsumerActivity(context.TODO(), db, store, c.ID, "10.1.2.3", "cdsctl")
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	// Another ip in the same network
+	a, newNetwork, err = authentication.RecordConsumerActivity(context.TODO(), db, store, c.ID, "10.1.2.4", "cdsctl")
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.False(t, newNetwork)
+
+	// An ip from another network
+	a, newNetwork, err = authentication.RecordConsumerActivity(context.TODO(), db, store, c.ID, "192.168.1.1", "cdsctl")
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.True(t, newNetwork)
+
+	as, err := authentication.LoadConsumerActivitiesByConsumerID(context.TODO(), db, c.ID)
+	require.NoError(t, err)
+	require.Len(t, as, 3)
+
+	res, err := authentication.LoadConsumerByID(context.TODO(), db, c.ID, authentication.LoadConsumerOptions.WithLastUsed)
+	require.NoError(t, err)
+	require.NotNil(t, res.LastUsed)
+	assert.False(t, res.IsStale(time.Now().Add(-time.Hour)))
+}
+
+func TestDeleteConsumerActivitiesBefore(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	u := sdk.AuthentifiedUser{
+		Username: sdk.RandomString(10),
+	}
+	require.NoError(t, user.Insert(context.TODO(), db, &u))
+
+	c := sdk.AuthConsumer{
+		Name:               sdk.RandomString(10),
+		Type:               sdk.ConsumerBuiltin,
+		AuthentifiedUserID: u.ID,
+		IssuedAt:           time.Now(),
+	}
+	require.NoError(t, authentication.InsertConsumer(context.TODO(), db, &c))
+
+	for i, ip := range []string{"10.1.2.3", "10.1.2.4", "10.1.2.5"} {
+		require.NoError(t, authentication.UpsertConsumerActivity(db, &sdk.AuthConsumerActivity{
+			ConsumerID: c.ID,
+			IP:         ip,
+			UserAgent:  "cdsctl",
+			LastSeen:   time.Now().Add(-time.Duration(100-i) * 24 * time.Hour),
+		}))
+	}
+
+	// Old activities are deleted except the last one of the consumer
+	_, err := authentication.DeleteConsumerActivitiesBefore(db, time.Now().Add(-90*24*time.Hour))
+	require.NoError(t, err)
+
+	as, err := authentication.LoadConsumerActivitiesByConsumerID(context.TODO(), db, c.ID)
+	require.NoError(t, err)
+	require.Len(t, as, 1)
+	assert.Equal(t, "10.1.2.5", as[0].IP)
+}
@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -91,6 +92,12 @@ func LoadConsumersByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID in
 	return getConsumers(ctx, db, query, opts...)
 }
 
+// LoadConsumersByType returns all auth consumers from database for given type.
+func LoadConsumersByType(ctx context.Context, db gorp.SqlExecutor, consumerType sdk.AuthConsumerType, opts ...LoadConsumerOptionFunc) (sdk.AuthConsumers, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE type = $1 ORDER BY created ASC").Args(consumerType)
+	return getConsumers(ctx, db, query, opts...)
+}
+
 // LoadConsumerByID returns an auth consumer from database.
 func LoadConsumerByID(ctx context.Context, db gorp.SqlExecutor, id string, opts ...LoadConsumerOptionFunc) (*sdk.AuthConsumer, error) {
 	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE id = $1").Args(id)
@@ -109,6 +116,12 @@ func LoadConsumerByTypeAndUserExternalID(ctx context.Context, db gorp.SqlExecuto
 	return getConsumer(ctx, db, query, opts...)
 }
 
+// LoadConsumersByTypeAndUserExternalIDs returns auth consumers from database for given type and user external ids.
+func LoadConsumersByTypeAndUserExternalIDs(ctx context.Context, db gorp.SqlExecutor, consumerType sdk.AuthConsumerType, userExternalIDs []string, opts ...LoadConsumerOptionFunc) (sdk.AuthConsumers, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE type = $1 AND (data->>'external_id')::text = ANY(string_to_array($2, ',')::text[])").Args(consumerType, strings.Join(userExternalIDs, ","))
+	return getConsumers(ctx, db, query, opts...)
+}
+
 // InsertConsumer in database.
 func InsertConsumer(ctx context.Context, db gorp.SqlExecutor, ac *sdk.AuthConsumer) error {
 	if ac.ID == "" {
@@ -0,0 +1,85 @@
+package authentication
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadConsumerActivitiesByConsumerID returns all activities for given consumer id, last seen first.
+func LoadConsumerActivitiesByConsumerID(ctx context.Context, db gorp.SqlExecutor, consumerID string) ([]sdk.AuthConsumerActivity, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer_activity WHERE consumer_id = $1 ORDER BY last_seen DESC").Args(consumerID)
+	var as []authConsumerActivity
+	if err := gorpmapping.GetAll(ctx, db, query, &as); err != nil {
+		return nil, sdk.WrapError(err, "cannot get auth consumer activities")
+	}
+	res := make([]sdk.AuthConsumerActivity, len(as))
+	for i := range as {
+		res[i] = as[i].AuthConsumerActivity
+	}
+	return res, nil
+}
+
+// LoadConsumersLastUsed returns the last usage date for given consumer ids, consumers never used are not in the result.
+func LoadConsumersLastUsed(db gorp.SqlExecutor, consumerIDs []string) (map[string]time.Time, error) {
+	var rows []struct {
+		ConsumerID string    `db:"consumer_id"`
+		LastUsed   time.Time `db:"last_used"`
+	}
+	if _, err := db.Select(&rows, `SELECT consumer_id, MAX(last_seen) AS last_used
+	FROM auth_consumer_activity
+	WHERE consumer_id = ANY(string_to_array($1, ',')::text[])
+	GROUP BY consumer_id`, strings.Join(consumerIDs, ",")); err != nil {
+		return nil, sdk.WrapError(err, "cannot get auth consumers last usage")
+	}
+
+	res := make(map[string]time.Time, len(rows))
+	for i := range rows {
+		res[rows[i].ConsumerID] = rows[i].LastUsed
+	}
+	return res, nil
+}
+
+// UpsertConsumerActivity inserts a new activity or increments the existing one for given consumer, ip and user agent.
+func UpsertConsumerActivity(db gorp.SqlExecutor, a *sdk.AuthConsumerActivity) error {
+	query := `INSERT INTO auth_consumer_activity (consumer_id, ip, user_agent, first_seen, last_seen, count)
+	VALUES ($1, $2, $3, $4, $4, 1)
+	ON CONFLICT (consumer_id, ip, user_agent) DO UPDATE SET last_seen = $4, count = auth_consumer_activity.count + 1
+	RETURNING id, first_seen, count`
+	if err := db.QueryRow(query, a.ConsumerID, a.IP, a.UserAgent, a.LastSeen).Scan(&a.ID, &a.FirstSeen, &a.Count); err != nil {
+		return sdk.WrapError(err, "unable to upsert auth consumer activity")
+	}
+	return nil
+}
+
+// consumerActivityMigrationID is the id of the database migration that created the activity table.
+const consumerActivityMigrationID = "209_auth_consumer_activity.sql"
+
+// LoadConsumerActivityStartDate returns the date from which consumers activity is recorded.
+func LoadConsumerActivityStartDate(db gorp.SqlExecutor) (time.Time, error) {
+	var appliedAt time.Time
+	if err := db.SelectOne(&appliedAt, "SELECT applied_at FROM gorp_migrations WHERE id = $1", consumerActivityMigrationID); err != nil {
+		return appliedAt, sdk.WrapError(err, "cannot get auth consumer activity start date")
+	}
+	return appliedAt, nil
+}
+
+// DeleteConsumerActivitiesBefore removes activities last seen before given date, the last activity of each
+// consumer is kept to know when it was used for the last time.
+func DeleteConsumerActivitiesBefore(db gorp.SqlExecutor, before time.Time) (int64, error) {
+	res, err := db.Exec(`DELETE FROM auth_consumer_activity
+	WHERE last_seen < $1
+	AND id NOT IN (
+		SELECT DISTINCT ON (consumer_id) id FROM auth_consumer_activity ORDER BY consumer_id, last_seen DESC
+	)`, before)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to delete auth consumer activities")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -13,8 +13,9 @@ type authConsumer struct {
 }
 
 func (c authConsumer) Canonical() gorpmapping.CanonicalForms {
-	_ = []interface{}{c.ID, c.AuthentifiedUserID, c.Type, c.Data, c.Created, c.GroupIDs, c.Scopes, c.ScopeDetails, c.Disabled} // Checks that fields exists at compilation
+	_ = []interface{}{c.ID, c.AuthentifiedUserID, c.Type, c.Data, c.Created, c.GroupIDs, c.Scopes, c.ScopeDetails, c.Disabled, c.ExpireAt, c.Restrictions} // Checks that fields exists at compilation
 	return []gorpmapping.CanonicalForm{
+		"{{.ID}}{{.AuthentifiedUserID}}{{print .Type}}{{print .Data}}{{printDate .Created}}{{print .GroupIDs}}{{print .ScopeDetails}}{{print .Disabled}}{{if .ExpireAt}}{{printDate .ExpireAt}}{{end}}{{print .Restrictions}}",
 		"{{.ID}}{{.AuthentifiedUserID}}{{print .Type}}{{print .Data}}{{printDate .Created}}{{print .GroupIDs}}{{print .ScopeDetails}}{{print .Disabled}}",
 		"{{.ID}}{{.AuthentifiedUserID}}{{print .Type}}{{print .Data}}{{printDate .Created}}{{print .GroupIDs}}{{print .Scopes}}{{print .Disabled}}",
 	}
@@ -32,9 +33,14 @@ func (s authSession) Canonical() gorpmapping.CanonicalForms {
 	}
 }
 
+type authConsumerActivity struct {
+	sdk.AuthConsumerActivity
+}
+
 func init() {
 	gorpmapping.Register(
 		gorpmapping.New(authConsumer{}, "auth_consumer", false, "id"),
 		gorpmapping.New(authSession{}, "auth_session", false, "id"),
+		gorpmapping.New(authConsumerActivity{}, "auth_consumer_activity", true, "id"),
 	)
 }
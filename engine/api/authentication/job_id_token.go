@@ -0,0 +1,112 @@
+package authentication
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// jobIDTokenSigningKey is the key used to sign jobs identity tokens, it's distinct from the key that signs CDS JWTs
+// so external systems that trust jobs identity tokens don't trust CDS sessions.
+var jobIDTokenSigningKey *rsa.PrivateKey
+
+// InitJobIDTokenKey sets the key used to sign jobs identity tokens, it should be called after Init.
+func InitJobIDTokenKey(k []byte) error {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(k)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if signingKey != nil && key.PublicKey.N.Cmp(signingKey.PublicKey.N) == 0 {
+		return sdk.WithStack(fmt.Errorf("jobs identity tokens can't be signed with the key used to sign CDS JWTs"))
+	}
+	jobIDTokenSigningKey = key
+	return nil
+}
+
+// JobIDTokenEnabled returns true if a key was given to sign jobs identity tokens.
+func JobIDTokenEnabled() bool {
+	return jobIDTokenSigningKey != nil
+}
+
+// JobIDTokenKeyID returns the identifier of the key that signs jobs identity tokens, it's the JWK thumbprint of the public key (RFC 7638).
+func JobIDTokenKeyID() string {
+	k := jobIDTokenSigningKey.PublicKey
+	thumbprint := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, encodeRSAExponent(k.E), encodeRSAModulus(k.N))
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// JSONWebKeySet returns the public key used to verify jobs identity tokens in JWKS format.
+func JSONWebKeySet() sdk.JSONWebKeySet {
+	if jobIDTokenSigningKey == nil {
+		return sdk.JSONWebKeySet{Keys: []sdk.JSONWebKey{}}
+	}
+	k := jobIDTokenSigningKey.PublicKey
+	return sdk.JSONWebKeySet{
+		Keys: []sdk.JSONWebKey{{
+			KeyType:   "RSA",
+			KeyID:     JobIDTokenKeyID(),
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			Modulus:   encodeRSAModulus(k.N),
+			Exponent:  encodeRSAExponent(k.E),
+		}},
+	}
+}
+
+func encodeRSAModulus(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func encodeRSAExponent(e int) string {
+	return base64.RawURLEncoding.EncodeToString(big.NewInt(int64(e)).Bytes())
+}
+
+// NewJobIDTokenClaims returns identity token claims for given job, values are read from job parameters.
+func NewJobIDTokenClaims(issuer, audience string, j sdk.WorkflowNodeJobRun, duration time.Duration) sdk.JobIDTokenClaims {
+	params := sdk.ParametersToMap(j.Parameters)
+	runNumber, _ := strconv.ParseInt(params["cds.run.number"], 10, 64)
+	now := time.Now()
+
+	c := sdk.JobIDTokenClaims{
+		JobID:       j.ID,
+		ProjectKey:  params["cds.project"],
+		Workflow:    params["cds.workflow"],
+		Node:        params["cds.node"],
+		Branch:      params["git.branch"],
+		Environment: params["cds.environment"],
+		RunNumber:   runNumber,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    issuer,
+			Audience:  audience,
+			Id:        sdk.UUID(),
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(duration).Unix(),
+		},
+	}
+	c.Subject = fmt.Sprintf("project:%s:workflow:%s:node:%s", c.ProjectKey, c.Workflow, c.Node)
+	return c
+}
+
+// NewJobIDTokenJWT returns a signed identity token for given claims.
+func NewJobIDTokenJWT(c sdk.JobIDTokenClaims) (string, error) {
+	if jobIDTokenSigningKey == nil {
+		return "", sdk.NewErrorFrom(sdk.ErrForbidden, "no key is set to sign jobs identity tokens")
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	jwtToken.Header["kid"] = JobIDTokenKeyID()
+	ss, err := jwtToken.SignedString(jobIDTokenSigningKey)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return ss, nil
+}
@@ -0,0 +1,85 @@
+package authentication_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestNewJobIDTokenJWT(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	kPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(k),
+	})
+	require.NoError(t, authentication.Init("cds-api-test", kPEM))
+
+	// Jobs identity tokens can't be signed with the key of CDS JWTs
+	assert.Error(t, authentication.InitJobIDTokenKey(kPEM))
+	jobKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	require.NoError(t, authentication.InitJobIDTokenKey(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(jobKey),
+	})))
+
+	j := sdk.WorkflowNodeJobRun{ID: 42}
+	sdk.AddParameter(&j.Parameters, "cds.project", sdk.StringParameter, "PROJ")
+	sdk.AddParameter(&j.Parameters, "cds.workflow", sdk.StringParameter, "my-workflow")
+	sdk.AddParameter(&j.Parameters, "cds.node", sdk.StringParameter, "deploy")
+	sdk.AddParameter(&j.Parameters, "cds.environment", sdk.StringParameter, "prod")
+	sdk.AddParameter(&j.Parameters, "git.branch", sdk.StringParameter, "master")
+	sdk.AddParameter(&j.Parameters, "cds.run.number", sdk.StringParameter, "12")
+
+	claims := authentication.NewJobIDTokenClaims("https://cds.local", "vault", j, 5*time.Minute)
+	assert.Equal(t, "project:PROJ:workflow:my-workflow:node:deploy", claims.Subject)
+	assert.Equal(t, int64(12), claims.RunNumber)
+
+	token, err := authentication.NewJobIDTokenJWT(claims)
+	require.NoError(t, err)
+
+	// The token should be verifiable with the key published in the JWKS
+	jwks := authentication.JSONWebKeySet()
+	require.Len(t, jwks.Keys, 1)
+	n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].Modulus)
+	require.NoError(t, err)
+	e, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].Exponent)
+	require.NoError(t, err)
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	assert.Equal(t, 0, jobKey.PublicKey.N.Cmp(pub.N))
+	assert.NotEqual(t, 0, k.PublicKey.N.Cmp(pub.N))
+
+	var res sdk.JobIDTokenClaims
+	parsed, err := jwt.ParseWithClaims(token, &res, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, jwks.Keys[0].KeyID, token.Header["kid"])
+		return pub, nil
+	})
+	require.NoError(t, err)
+	require.True(t, parsed.Valid)
+	assert.Equal(t, "PROJ", res.ProjectKey)
+	assert.Equal(t, "my-workflow", res.Workflow)
+	assert.Equal(t, "deploy", res.Node)
+	assert.Equal(t, "prod", res.Environment)
+	assert.Equal(t, "master", res.Branch)
+	assert.Equal(t, int64(42), res.JobID)
+	assert.Equal(t, "vault", res.Audience)
+
+	// CDS JWTs can't be verified with the published key
+	sessionToken, err := authentication.SignJWT(jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{Subject: "session"}))
+	require.NoError(t, err)
+	_, err = jwt.Parse(sessionToken, func(token *jwt.Token) (interface{}, error) { return pub, nil })
+	assert.Error(t, err)
+}
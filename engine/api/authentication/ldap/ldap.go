@@ -17,6 +17,7 @@ import (
 )
 
 var _ sdk.AuthDriver = new(AuthDriver)
+var _ sdk.AuthDriverWithGroupsSync = new(AuthDriver)
 
 const errUserNotFound = "ldap::user not found"
 
@@ -113,10 +114,53 @@ func (d AuthDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninR
 	userInfo.Email = entry[0].Attributes["mail"]
 	userInfo.ExternalID = entry[0].Attributes["uid"]
 	userInfo.Username = req["bind"]
+	userInfo.Groups = entry[0].MemberOf
 
 	return userInfo, nil
 }
 
+// GetGroupMembers returns uids of all users that are member of given group DN.
+func (d AuthDriver) GetGroupMembers(ctx context.Context, groupDN string) ([]string, error) {
+	userSearch := strings.Replace(d.conf.UserSearch, "{0}", "*", 1)
+	filter := fmt.Sprintf("(&(%s)(memberOf=%s))", userSearch, ldap.EscapeFilter(groupDN))
+
+	log.Debug("LDAP> Search group members %s", filter)
+	searchRequest := ldap.NewSearchRequest(
+		d.conf.RootDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		[]string{"uid"},
+		nil,
+	)
+
+	sr, err := d.conn.SearchWithPaging(searchRequest, 500)
+	if err != nil {
+		if !shoudRetry(ctx, err) {
+			return nil, sdk.WithStack(err)
+		}
+		if err := d.openLDAP(ctx, d.conf); err != nil {
+			return nil, err
+		}
+		sr, err = d.conn.SearchWithPaging(searchRequest, 500)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+
+	uids := make([]string, 0, len(sr.Entries))
+	for _, e := range sr.Entries {
+		if uid := e.GetAttributeValue("uid"); uid != "" {
+			uids = append(uids, uid)
+		}
+	}
+
+	return uids, nil
+}
+
 func (d *AuthDriver) openLDAP(ctx context.Context, conf Config) error {
 	if d.conn != nil {
 		d.conn.Close()
@@ -237,6 +281,7 @@ func (d *AuthDriver) search(ctx context.Context, term string, attributes ...stri
 		for _, a := range attributes {
 			entry.Attributes[a] = e.GetAttributeValue(a)
 		}
+		entry.MemberOf = e.GetAttributeValues("memberOf")
 		entries = append(entries, entry)
 	}
 
@@ -27,4 +27,5 @@ func shoudRetry(ctx context.Context, err error) bool {
 type Entry struct {
 	DN         string
 	Attributes map[string]string
+	MemberOf   []string
 }
@@ -18,10 +18,12 @@ var LoadConsumerOptions = struct {
 	Default              LoadConsumerOptionFunc
 	WithAuthentifiedUser LoadConsumerOptionFunc
 	WithConsumerGroups   LoadConsumerOptionFunc
+	WithLastUsed         LoadConsumerOptionFunc
 }{
 	Default:              loadDefault,
 	WithAuthentifiedUser: loadAuthentifiedUser,
 	WithConsumerGroups:   loadConsumerGroups,
+	WithLastUsed:         loadLastUsed,
 }
 
 func loadDefault(ctx context.Context, db gorp.SqlExecutor, cs ...*sdk.AuthConsumer) error {
@@ -102,3 +104,23 @@ func loadConsumerGroups(ctx context.Context, db gorp.SqlExecutor, cs ...*sdk.Aut
 
 	return nil
 }
+
+func loadLastUsed(ctx context.Context, db gorp.SqlExecutor, cs ...*sdk.AuthConsumer) error {
+	ids := make([]string, len(cs))
+	for i := range cs {
+		ids[i] = cs[i].ID
+	}
+
+	lastUsed, err := LoadConsumersLastUsed(db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range cs {
+		if t, ok := lastUsed[cs[i].ID]; ok {
+			cs[i].LastUsed = &t
+		}
+	}
+
+	return nil
+}
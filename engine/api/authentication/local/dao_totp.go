@@ -0,0 +1,70 @@
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// LoadTOTPByUserID returns the TOTP of given user from database with its decrypted secret.
+func LoadTOTPByUserID(ctx context.Context, db gorp.SqlExecutor, userID string) (*sdk.AuthLocalTOTP, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_local_totp WHERE authentified_user_id = $1").Args(userID)
+
+	var t authLocalTOTP
+	found, err := gorpmapping.Get(ctx, db, query, &t, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get totp")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+
+	isValid, err := gorpmapping.CheckSignature(t, t.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !isValid {
+		log.Error(ctx, "local.LoadTOTPByUserID> totp %s data corrupted", t.ID)
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+
+	t.Secret = t.CipherSecret
+	t.RecoveryCodesLeft = len(t.RecoveryCodes)
+	return &t.AuthLocalTOTP, nil
+}
+
+// InsertTOTP in database.
+func InsertTOTP(ctx context.Context, db gorp.SqlExecutor, t *sdk.AuthLocalTOTP) error {
+	t.ID = sdk.UUID()
+	t.Created = time.Now()
+	if t.RecoveryCodes == nil {
+		t.RecoveryCodes = sdk.StringSlice{}
+	}
+	dbT := authLocalTOTP{AuthLocalTOTP: *t, CipherSecret: t.Secret}
+	if err := gorpmapping.InsertAndSign(ctx, db, &dbT); err != nil {
+		return sdk.WrapError(err, "unable to insert totp")
+	}
+	t.RecoveryCodesLeft = len(t.RecoveryCodes)
+	return nil
+}
+
+// UpdateTOTP in database.
+func UpdateTOTP(ctx context.Context, db gorp.SqlExecutor, t *sdk.AuthLocalTOTP) error {
+	dbT := authLocalTOTP{AuthLocalTOTP: *t, CipherSecret: t.Secret}
+	if err := gorpmapping.UpdateAndSign(ctx, db, &dbT); err != nil {
+		return sdk.WrapError(err, "unable to update totp with id: %s", t.ID)
+	}
+	t.RecoveryCodesLeft = len(t.RecoveryCodes)
+	return nil
+}
+
+// DeleteTOTPByUserID removes the TOTP of given user in database.
+func DeleteTOTPByUserID(db gorp.SqlExecutor, userID string) error {
+	_, err := db.Exec("DELETE FROM auth_local_totp WHERE authentified_user_id = $1", userID)
+	return sdk.WrapError(err, "unable to delete totp for user %s", userID)
+}
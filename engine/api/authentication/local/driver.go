@@ -10,8 +10,24 @@ import (
 
 var _ sdk.AuthDriver = new(AuthDriver)
 
+// Second factor policies for local users.
+const (
+	MFAPolicyOptional = "optional"
+	MFAPolicyAdmins   = "admins"
+	MFAPolicyAll      = "all"
+)
+
+// IsValidMFAPolicy returns true if given value is a known second factor policy.
+func IsValidMFAPolicy(policy string) bool {
+	switch policy {
+	case MFAPolicyOptional, MFAPolicyAdmins, MFAPolicyAll:
+		return true
+	}
+	return false
+}
+
 // NewDriver returns a new initialized driver for local authentication.
-func NewDriver(ctx context.Context, signupDisabled bool, uiURL, allowedDomains string) sdk.AuthDriver {
+func NewDriver(ctx context.Context, signupDisabled bool, uiURL, allowedDomains, mfaPolicy string) sdk.AuthDriver {
 	var domains []string
 
 	if allowedDomains != "" {
@@ -21,6 +37,7 @@ func NewDriver(ctx context.Context, signupDisabled bool, uiURL, allowedDomains s
 	return &AuthDriver{
 		signupDisabled: signupDisabled,
 		allowedDomains: domains,
+		mfaPolicy:      mfaPolicy,
 	}
 }
 
@@ -28,6 +45,18 @@ func NewDriver(ctx context.Context, signupDisabled bool, uiURL, allowedDomains s
 type AuthDriver struct {
 	signupDisabled bool
 	allowedDomains []string
+	mfaPolicy      string
+}
+
+// IsMFARequired returns true if given user should signin with a second factor.
+func (d AuthDriver) IsMFARequired(u *sdk.AuthentifiedUser) bool {
+	switch d.mfaPolicy {
+	case MFAPolicyAll:
+		return true
+	case MFAPolicyAdmins:
+		return u != nil && u.Ring == sdk.UserRingAdmin
+	}
+	return false
 }
 
 // GetManifest .
@@ -17,8 +17,22 @@ func (u userRegistration) Canonical() gorpmapping.CanonicalForms {
 	}
 }
 
+type authLocalTOTP struct {
+	sdk.AuthLocalTOTP
+	gorpmapping.SignedEntity
+	CipherSecret string `db:"cipher_secret" gorpmapping:"encrypted,ID,AuthentifiedUserID"`
+}
+
+func (t authLocalTOTP) Canonical() gorpmapping.CanonicalForms {
+	_ = []interface{}{t.ID, t.AuthentifiedUserID, t.Verified, t.Created, t.LastCounter, t.RecoveryCodes} // Checks that fields exists at compilation
+	return []gorpmapping.CanonicalForm{
+		"{{.ID}}{{.AuthentifiedUserID}}{{print .Verified}}{{printDate .Created}}{{print .LastCounter}}{{print .RecoveryCodes}}",
+	}
+}
+
 func init() {
 	gorpmapping.Register(
 		gorpmapping.New(userRegistration{}, "user_registration", false, "id"),
+		gorpmapping.New(authLocalTOTP{}, "auth_local_totp", false, "id"),
 	)
 }
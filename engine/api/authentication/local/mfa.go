@@ -0,0 +1,54 @@
+package local
+
+import (
+	"time"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const mfaLocalConsumerTokenDuration time.Duration = time.Minute * 5
+
+// mfaLocalConsumerToken is given after a valid password signin, it should be sent with a second factor code to get a session.
+type mfaLocalConsumerToken struct {
+	ConsumerID string `json:"consumer_id"`
+	Nonce      string `json:"nonce"`
+}
+
+// NewMFAConsumerToken returns a new second factor token for given consumer id.
+func NewMFAConsumerToken(store cache.Store, consumerID string) (string, error) {
+	payload := mfaLocalConsumerToken{
+		ConsumerID: consumerID,
+		Nonce:      sdk.UUID(),
+	}
+
+	cacheKey := cache.Key("authentication:consumer:mfa", consumerID)
+	if err := store.SetWithDuration(cacheKey, payload.Nonce, mfaLocalConsumerTokenDuration); err != nil {
+		return "", err
+	}
+
+	return authentication.SignJWS(payload, mfaLocalConsumerTokenDuration)
+}
+
+// CheckMFAConsumerToken checks that the given signature is a valid second factor token.
+func CheckMFAConsumerToken(store cache.Store, signature string) (string, error) {
+	var payload mfaLocalConsumerToken
+	if err := authentication.VerifyJWS(signature, &payload); err != nil {
+		return "", err
+	}
+
+	cacheKey := cache.Key("authentication:consumer:mfa", payload.ConsumerID)
+	var nonce string
+	if ok, _ := store.Get(cacheKey, &nonce); !ok || nonce != payload.Nonce {
+		return "", sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid given second factor token")
+	}
+
+	return payload.ConsumerID, nil
+}
+
+// CleanMFAConsumerToken deletes a second factor token from cache if exists.
+func CleanMFAConsumerToken(store cache.Store, consumerID string) {
+	cacheKey := cache.Key("authentication:consumer:mfa", consumerID)
+	_ = store.Delete(cacheKey)
+}
@@ -0,0 +1,132 @@
+package local
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	totpIssuer            = "CDS"
+	totpPeriod            = 30
+	totpDigits            = 6
+	totpSkew              = 1 // number of periods accepted before and after current time
+	totpRecoveryCodeCount = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTP returns a new unverified TOTP with a random secret for given user.
+func NewTOTP(userID string) (*sdk.AuthLocalTOTP, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, sdk.WrapError(err, "cannot generate totp secret")
+	}
+
+	return &sdk.AuthLocalTOTP{
+		AuthentifiedUserID: userID,
+		Secret:             totpEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// TOTPURI returns the otpauth uri for given TOTP, it can be displayed as a QR code for authenticator apps.
+func TOTPURI(t sdk.AuthLocalTOTP, username string) string {
+	q := url.Values{}
+	q.Set("secret", t.Secret)
+	q.Set("issuer", totpIssuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprintf("%d", totpDigits))
+	q.Set("period", fmt.Sprintf("%d", totpPeriod))
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", totpIssuer, url.PathEscape(username), q.Encode())
+}
+
+// generateTOTPCode computes the code for given secret and time step counter (RFC 6238).
+func generateTOTPCode(secret string, counter int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", sdk.WrapError(err, "invalid totp secret")
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:]) // nolint
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// CheckTOTPCode checks given code for given TOTP at given time. A code can't be used twice so the last
+// used counter is updated on success, the TOTP should then be saved by the caller.
+func CheckTOTPCode(t *sdk.AuthLocalTOTP, code string, now time.Time) error {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid given totp code")
+	}
+
+	current := now.Unix() / totpPeriod
+	for counter := current - totpSkew; counter <= current+totpSkew; counter++ {
+		if counter <= t.LastCounter {
+			continue
+		}
+		expected, err := generateTOTPCode(t.Secret, counter)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			t.LastCounter = counter
+			return nil
+		}
+	}
+
+	return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid given totp code")
+}
+
+// GenerateRecoveryCodes replaces recovery codes of given TOTP and returns the new codes.
+// Only hashes are stored, the TOTP should then be saved by the caller.
+func GenerateRecoveryCodes(t *sdk.AuthLocalTOTP) ([]string, error) {
+	codes := make([]string, totpRecoveryCodeCount)
+	t.RecoveryCodes = make(sdk.StringSlice, totpRecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, sdk.WrapError(err, "cannot generate recovery code")
+		}
+		s := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes[i] = s[:4] + "-" + s[4:]
+		t.RecoveryCodes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, nil
+}
+
+// UseRecoveryCode checks given recovery code for given TOTP and removes it if valid.
+// The TOTP should then be saved by the caller.
+func UseRecoveryCode(t *sdk.AuthLocalTOTP, code string) error {
+	hash := hashRecoveryCode(code)
+	for i := range t.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(t.RecoveryCodes[i]), []byte(hash)) == 1 {
+			t.RecoveryCodes = append(t.RecoveryCodes[:i], t.RecoveryCodes[i+1:]...)
+			return nil
+		}
+	}
+	return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid given recovery code")
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package local
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestGenerateTOTPCode(t *testing.T) {
+	// Test vectors from RFC 6238 for SHA1, truncated to 6 digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		time int64
+		code string
+	}{
+		{time: 59, code: "287082"},
+		{time: 1111111109, code: "081804"},
+		{time: 1234567890, code: "005924"},
+		{time: 2000000000, code: "279037"},
+	}
+	for _, tt := range tests {
+		code, err := generateTOTPCode(secret, tt.time/totpPeriod)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code)
+	}
+}
+
+func TestCheckTOTPCode(t *testing.T) {
+	totp, err := NewTOTP(sdk.UUID())
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := generateTOTPCode(totp.Secret, now.Unix()/totpPeriod)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckTOTPCode(totp, code, now))
+	assert.Equal(t, now.Unix()/totpPeriod, totp.LastCounter)
+
+	// A code can't be used twice
+	assert.Error(t, CheckTOTPCode(totp, code, now))
+
+	// A code from previous period is still valid but not older ones
+	totp.LastCounter = 0
+	require.NoError(t, CheckTOTPCode(totp, code, now.Add(totpPeriod*time.Second)))
+	totp.LastCounter = 0
+	assert.Error(t, CheckTOTPCode(totp, code, now.Add(2*totpPeriod*time.Second)))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	var totp sdk.AuthLocalTOTP
+	codes, err := GenerateRecoveryCodes(&totp)
+	require.NoError(t, err)
+	require.Len(t, codes, totpRecoveryCodeCount)
+	require.Len(t, totp.RecoveryCodes, totpRecoveryCodeCount)
+
+	require.NoError(t, UseRecoveryCode(&totp, codes[3]))
+	assert.Len(t, totp.RecoveryCodes, totpRecoveryCodeCount-1)
+	assert.Error(t, UseRecoveryCode(&totp, codes[3]))
+	assert.Error(t, UseRecoveryCode(&totp, "invalid"))
+}
+
+func TestIsMFARequired(t *testing.T) {
+	admin := &sdk.AuthentifiedUser{Ring: sdk.UserRingAdmin}
+	usr := &sdk.AuthentifiedUser{Ring: sdk.UserRingUser}
+
+	assert.False(t, AuthDriver{mfaPolicy: MFAPolicyOptional}.IsMFARequired(admin))
+	assert.True(t, AuthDriver{mfaPolicy: MFAPolicyAdmins}.IsMFARequired(admin))
+	assert.False(t, AuthDriver{mfaPolicy: MFAPolicyAdmins}.IsMFARequired(usr))
+	assert.True(t, AuthDriver{mfaPolicy: MFAPolicyAll}.IsMFARequired(usr))
+}
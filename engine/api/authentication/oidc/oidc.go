@@ -0,0 +1,329 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/sdk"
+)
+
+var _ sdk.AuthDriverWithRedirect = new(authDriver)
+var _ sdk.AuthDriverWithSigninStateToken = new(authDriver)
+
+// Config for OpenID Connect driver.
+type Config struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+	UsernameClaim string
+	EmailClaim    string
+	FullnameClaim string
+	GroupsClaim   string
+}
+
+// Default claims used to get user info from the id token.
+const (
+	DefaultUsernameClaim = "preferred_username"
+	DefaultEmailClaim    = "email"
+	DefaultFullnameClaim = "name"
+)
+
+// discovery contains the provider metadata returned by the discovery endpoint.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewDriver returns a new OpenID Connect auth driver for given config,
+// the provider configuration is loaded from the issuer discovery endpoint.
+func NewDriver(ctx context.Context, signupDisabled bool, cdsURL string, cfg Config) (sdk.AuthDriver, error) {
+	if cfg.IssuerURL == "" || cfg.ClientID == "" {
+		return nil, sdk.WithStack(fmt.Errorf("invalid openid connect configuration, issuer url and client id are required"))
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = DefaultUsernameClaim
+	}
+	if cfg.EmailClaim == "" {
+		cfg.EmailClaim = DefaultEmailClaim
+	}
+	if cfg.FullnameClaim == "" {
+		cfg.FullnameClaim = DefaultFullnameClaim
+	}
+
+	d := &authDriver{
+		signupDisabled: signupDisabled,
+		cdsURL:         cdsURL,
+		cfg:            cfg,
+		httpClient:     &http.Client{Timeout: 30 * time.Second},
+	}
+
+	discoveryURL := strings.TrimSuffix(cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := d.getJSON(ctx, discoveryURL, &d.provider); err != nil {
+		return nil, sdk.WrapError(err, "cannot get openid connect discovery document")
+	}
+	if d.provider.Issuer != cfg.IssuerURL {
+		return nil, sdk.WithStack(fmt.Errorf("issuer %q returned by discovery does not match configured issuer %q", d.provider.Issuer, cfg.IssuerURL))
+	}
+	if d.provider.AuthorizationEndpoint == "" || d.provider.TokenEndpoint == "" || d.provider.JWKSURI == "" {
+		return nil, sdk.WithStack(fmt.Errorf("invalid openid connect discovery document"))
+	}
+
+	return d, nil
+}
+
+type authDriver struct {
+	signupDisabled bool
+	cdsURL         string
+	cfg            Config
+	provider       discovery
+	httpClient     *http.Client
+}
+
+func (d authDriver) GetManifest() sdk.AuthDriverManifest {
+	return sdk.AuthDriverManifest{
+		Type:           sdk.ConsumerOIDC,
+		SignupDisabled: d.signupDisabled,
+	}
+}
+
+func (d authDriver) redirectURI() string {
+	return d.cdsURL + "/auth/callback/" + string(sdk.ConsumerOIDC)
+}
+
+func (d authDriver) scopes() []string {
+	scopes := []string{"openid", "profile", "email"}
+	for _, s := range d.cfg.Scopes {
+		if !sdk.IsInArray(s, scopes) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func (d authDriver) GetSigninURI(signinState sdk.AuthSigninConsumerToken) (sdk.AuthDriverSigningRedirect, error) {
+	var result sdk.AuthDriverSigningRedirect
+
+	// Generate a new state value for the auth signin request
+	state, err := authentication.NewDefaultSigninStateToken(signinState.Origin,
+		signinState.RedirectURI, signinState.IsFirstConnection)
+	if err != nil {
+		return result, err
+	}
+
+	// PKCE code verifier and nonce are derived from the state so they can be computed again on callback
+	verifier, err := deriveFromState("code_verifier", state)
+	if err != nil {
+		return result, err
+	}
+	nonce, err := deriveFromState("nonce", state)
+	if err != nil {
+		return result, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", d.cfg.ClientID)
+	params.Set("redirect_uri", d.redirectURI())
+	params.Set("scope", strings.Join(d.scopes(), " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(d.provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	result = sdk.AuthDriverSigningRedirect{
+		Method: http.MethodGet,
+		URL:    d.provider.AuthorizationEndpoint + sep + params.Encode(),
+	}
+
+	return result, nil
+}
+
+func (d authDriver) GetSessionDuration() time.Duration {
+	return time.Hour * 24 * 30 // 1 month session
+}
+
+func (d authDriver) CheckSigninRequest(req sdk.AuthConsumerSigninRequest) error {
+	if code, ok := req["code"]; !ok || code == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing or invalid openid connect code")
+	}
+	return nil
+}
+
+func (d authDriver) CheckSigninStateToken(req sdk.AuthConsumerSigninRequest) error {
+	// Check if state is given and if its valid
+	state, okState := req["state"]
+	if !okState {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing state value")
+	}
+	return authentication.CheckDefaultSigninStateToken(state)
+}
+
+func (d authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninRequest) (sdk.AuthDriverUserInfo, error) {
+	var info sdk.AuthDriverUserInfo
+
+	verifier, err := deriveFromState("code_verifier", req["state"])
+	if err != nil {
+		return info, err
+	}
+
+	config := &oauth2.Config{
+		ClientID:     d.cfg.ClientID,
+		ClientSecret: d.cfg.ClientSecret,
+		RedirectURL:  d.redirectURI(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  d.provider.AuthorizationEndpoint,
+			TokenURL: d.provider.TokenEndpoint,
+		},
+	}
+
+	ctx2 := context.WithValue(ctx, oauth2.HTTPClient, d.httpClient)
+	t, err := config.Exchange(ctx2, req["code"], oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		return info, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "cannot get openid connect token with given code"))
+	}
+
+	rawIDToken, ok := t.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return info, sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing id token in openid connect token response")
+	}
+
+	claims, err := d.verifyIDToken(ctx, rawIDToken, req["state"])
+	if err != nil {
+		return info, err
+	}
+
+	info.ExternalID = claims.getString("sub")
+	info.Username = claims.getString(d.cfg.UsernameClaim)
+	info.Email = claims.getString(d.cfg.EmailClaim)
+	info.Fullname = claims.getString(d.cfg.FullnameClaim)
+	info.MFA = sdk.IsInArray("mfa", claims.getStrings("amr"))
+	if d.cfg.GroupsClaim != "" {
+		info.Groups = claims.getStrings(d.cfg.GroupsClaim)
+	}
+
+	if info.ExternalID == "" || info.Username == "" || info.Email == "" {
+		return info, sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing required claims in openid connect id token")
+	}
+	if info.Fullname == "" {
+		info.Fullname = info.Username
+	}
+
+	return info, nil
+}
+
+// verifyIDToken checks id token signature with provider keys then validates its claims.
+func (d authDriver) verifyIDToken(ctx context.Context, rawIDToken, state string) (idTokenClaims, error) {
+	token, err := jwt.ParseSigned(rawIDToken)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid openid connect id token"))
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := d.getJSON(ctx, d.provider.JWKSURI, &keys); err != nil {
+		return nil, sdk.WrapError(err, "cannot get openid connect provider keys")
+	}
+
+	var std jwt.Claims
+	var claims idTokenClaims
+	if err := token.Claims(&keys, &std, &claims); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid openid connect id token signature"))
+	}
+
+	if err := std.ValidateWithLeeway(jwt.Expected{
+		Issuer:   d.provider.Issuer,
+		Audience: jwt.Audience{d.cfg.ClientID},
+		Time:     time.Now(),
+	}, time.Minute); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid openid connect id token claims"))
+	}
+
+	nonce, err := deriveFromState("nonce", state)
+	if err != nil {
+		return nil, err
+	}
+	if claims.getString("nonce") != nonce {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid openid connect id token nonce")
+	}
+
+	return claims, nil
+}
+
+func (d authDriver) getJSON(ctx context.Context, url string, i interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	res, err := d.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer res.Body.Close() // nolint
+	if res.StatusCode != http.StatusOK {
+		return sdk.WithStack(fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url))
+	}
+	return sdk.WithStack(json.NewDecoder(res.Body).Decode(i))
+}
+
+// deriveFromState returns a value that can only be computed by CDS for given state.
+func deriveFromState(purpose, state string) (string, error) {
+	if state == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing state value")
+	}
+	hash := sha256.Sum256([]byte(purpose + ":" + state))
+	// PKCS1v15 signature is deterministic so the same value is returned for the same state
+	sig, err := rsa.SignPKCS1v15(rand.Reader, authentication.GetSigningKey(), crypto.SHA256, hash[:])
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	res := sha256.Sum256(sig)
+	return base64.RawURLEncoding.EncodeToString(res[:]), nil
+}
+
+type idTokenClaims map[string]interface{}
+
+func (c idTokenClaims) getString(key string) string {
+	if s, ok := c[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func (c idTokenClaims) getStrings(key string) []string {
+	switch v := c[key].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for i := range v {
+			if s, ok := v[i].(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
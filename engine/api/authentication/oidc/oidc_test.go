@@ -0,0 +1,176 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/authentication/oidc"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+// mockProvider is a minimal OpenID Connect provider that issues an id token for a single authorization code.
+type mockProvider struct {
+	t         *testing.T
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	code      string
+	challenge string
+	nonce     string
+	claims    map[string]interface{}
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockProvider{t: t, key: key, code: "my-code"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &m.key.PublicKey,
+			KeyID:     "my-key",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != m.code || base64.RawURLEncoding.EncodeToString(verifier[:]) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: m.key},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "my-key"))
+		require.NoError(t, err)
+		claims := map[string]interface{}{"nonce": m.nonce}
+		for k, v := range m.claims {
+			claims[k] = v
+		}
+		idToken, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   m.server.URL,
+			Subject:  "123456",
+			Audience: jwt.Audience{"cds"},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}).Claims(claims).CompactSerialize()
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "my-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+	m.server = httptest.NewServer(mux)
+
+	return m
+}
+
+// authorize simulates the user consent on the provider by reading signin uri parameters.
+func (m *mockProvider) authorize(signinURI string) string {
+	u, err := url.Parse(signinURI)
+	require.NoError(m.t, err)
+	assert.Equal(m.t, m.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	assert.Equal(m.t, "code", q.Get("response_type"))
+	assert.Equal(m.t, "cds", q.Get("client_id"))
+	assert.Equal(m.t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(m.t, "openid profile email groups", q.Get("scope"))
+	m.challenge = q.Get("code_challenge")
+	m.nonce = q.Get("nonce")
+	return q.Get("state")
+}
+
+func TestOIDCDriver(t *testing.T) {
+	require.NoError(t, authentication.Init("cds_test", test.SigningKey))
+
+	m := newMockProvider(t)
+	defer m.server.Close()
+	m.claims = map[string]interface{}{
+		"preferred_username": "fry",
+		"email":              "fry@planet-express.futurama",
+		"name":               "Philip J. Fry",
+		"groups":             []string{"delivery", "crew"},
+	}
+
+	driver, err := oidc.NewDriver(context.TODO(), false, "http://cds.local", oidc.Config{
+		IssuerURL:   m.server.URL,
+		ClientID:    "cds",
+		Scopes:      []string{"groups"},
+		GroupsClaim: "groups",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.ConsumerOIDC, driver.GetManifest().Type)
+
+	redirect, err := driver.(sdk.AuthDriverWithRedirect).GetSigninURI(sdk.AuthSigninConsumerToken{})
+	require.NoError(t, err)
+	state := m.authorize(redirect.URL)
+
+	req := sdk.AuthConsumerSigninRequest{"code": "my-code", "state": state}
+	require.NoError(t, driver.CheckSigninRequest(req))
+	require.NoError(t, driver.(sdk.AuthDriverWithSigninStateToken).CheckSigninStateToken(req))
+
+	info, err := driver.GetUserInfo(context.TODO(), req)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.AuthDriverUserInfo{
+		ExternalID: "123456",
+		Username:   "fry",
+		Email:      "fry@planet-express.futurama",
+		Fullname:   "Philip J. Fry",
+		Groups:     []string{"delivery", "crew"},
+	}, info)
+
+	// A state from another signin request should not match the PKCE code challenge
+	other, err := driver.(sdk.AuthDriverWithRedirect).GetSigninURI(sdk.AuthSigninConsumerToken{})
+	require.NoError(t, err)
+	otherURL, err := url.Parse(other.URL)
+	require.NoError(t, err)
+	_, err = driver.GetUserInfo(context.TODO(), sdk.AuthConsumerSigninRequest{"code": "my-code", "state": otherURL.Query().Get("state")})
+	assert.Error(t, err)
+
+	// Invalid nonce in id token should be rejected
+	m.nonce = "invalid"
+	_, err = driver.GetUserInfo(context.TODO(), req)
+	assert.Error(t, err)
+}
+
+func TestOIDCDriverInvalidIssuer(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+
+	_, err := oidc.NewDriver(context.TODO(), false, "http://cds.local", oidc.Config{
+		IssuerURL: m.server.URL + "/other",
+		ClientID:  "cds",
+	})
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ func NewSession(ctx context.Context, db gorp.SqlExecutor, c *sdk.AuthConsumer, d
 	s := sdk.AuthSession{
 		ConsumerID: c.ID,
 		ExpireAt:   time.Now().Add(duration),
+		MFA:        mfaEnable,
 	}
 
 	if err := InsertSession(ctx, db, &s); err != nil {
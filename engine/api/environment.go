@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/ascode"
 	"github.com/ovh/cds/engine/api/environment"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/project"
@@ -194,6 +196,102 @@ func (api *API) deleteEnvironmentHandler() service.Handler {
 	}
 }
 
+// updateAsCodeEnvironmentHandler update an ascode environment, this will create a pull request to target repository.
+func (api *API) updateAsCodeEnvironmentHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars[permProjectKey]
+		environmentName := vars["environmentName"]
+
+		var envPost sdk.Environment
+		if err := service.UnmarshalBody(r, &envPost); err != nil {
+			return err
+		}
+
+		ope, err := api.updateAsCodeEnvironment(ctx, projectKey, environmentName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+			return applyEnvironmentUpdate(env, envPost)
+		})
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ope, http.StatusOK)
+	}
+}
+
+// updateAsCodeEnvironment pushes an as code environment modified by the given func to its repository,
+// this will create a pull request or a commit if the given branch is the default branch of the repository.
+func (api *API) updateAsCodeEnvironment(ctx context.Context, projectKey, environmentName, branch, message string, update func(env *sdk.Environment) error) (*sdk.Operation, error) {
+	proj, err := project.Load(api.mustDB(), projectKey,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := environment.LoadEnvironmentByName(api.mustDB(), projectKey, environmentName)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load environment %s", environmentName)
+	}
+	if old.FromRepository == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "current environment is not ascode")
+	}
+	old.Variables, err = environment.LoadAllVariablesWithDecrytion(api.mustDB(), old.ID)
+	if err != nil {
+		return nil, err
+	}
+	old.Keys, err = environment.LoadAllKeysWithPrivateContent(api.mustDB(), old.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	env := *old
+	env.Variables = append([]sdk.Variable{}, old.Variables...)
+	env.Keys = append([]sdk.EnvironmentKey{}, old.Keys...)
+	if err := update(&env); err != nil {
+		return nil, err
+	}
+
+	rootApp, err := api.loadAsCodeRootApplication(ctx, *proj, old.FromRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	u := getAPIConsumer(ctx)
+	ope, err := environment.UpdateEnvironmentAsCode(ctx, api.Cache, api.mustDB(), *proj, env, *old, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, project.EncryptWithBuiltinKeyRenewToken, project.LoadEncryptedToken, u)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodeEnvironmentHandler-%s", ope.UUID), func(ctx context.Context) {
+		ed := ascode.EntityData{
+			FromRepo:  old.FromRepository,
+			Type:      ascode.AsCodeEnvironment,
+			ID:        old.ID,
+			Name:      old.Name,
+			Operation: ope,
+		}
+		asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *proj, *rootApp, ed, u)
+		if asCodeEvent != nil {
+			event.PublishAsCodeEvent(ctx, proj.Key, *asCodeEvent, u)
+		}
+	}, api.PanicDump())
+
+	return ope, nil
+}
+
+// applyEnvironmentUpdate sets the editable fields of the environment from the posted one
+func applyEnvironmentUpdate(env *sdk.Environment, envPost sdk.Environment) error {
+	if !sdk.NamePatternRegex.MatchString(envPost.Name) {
+		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid environment name. It should match %s", sdk.NamePattern))
+	}
+	env.Name = envPost.Name
+	return nil
+}
+
 func (api *API) updateEnvironmentHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		// Get pipeline and action name in URL
@@ -206,10 +304,6 @@ func (api *API) updateEnvironmentHandler() service.Handler {
 			return sdk.WrapError(errEnv, "updateEnvironmentHandler> Cannot load environment %s", environmentName)
 		}
 
-		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
-
 		p, errProj := project.Load(api.mustDB(), projectKey)
 		if errProj != nil {
 			return sdk.WrapError(errProj, "updateEnvironmentHandler> Cannot load project %s", projectKey)
@@ -220,8 +314,25 @@ func (api *API) updateEnvironmentHandler() service.Handler {
 			return err
 		}
 
-		oldEnv := env
-		env.Name = envPost.Name
+		// Updates of an as code environment are pushed to its repository
+		if env.FromRepository != "" {
+			ope, err := api.updateAsCodeEnvironment(ctx, projectKey, environmentName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				return applyEnvironmentUpdate(env, envPost)
+			})
+			if err != nil {
+				return err
+			}
+			p.Environments, err = environment.LoadEnvironments(api.mustDB(), p.Key)
+			if err != nil {
+				return sdk.WrapError(err, "updateEnvironmentHandler> Cannot load environments")
+			}
+			return writeAsCodeAccepted(w, ope, p)
+		}
+
+		oldEnv := *env
+		if err := applyEnvironmentUpdate(env, envPost); err != nil {
+			return err
+		}
 
 		tx, errBegin := api.mustDB().Begin()
 		if errBegin != nil {
@@ -237,7 +348,7 @@ func (api *API) updateEnvironmentHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
-		event.PublishEnvironmentUpdate(ctx, p.Key, *env, *oldEnv, getAPIConsumer(ctx))
+		event.PublishEnvironmentUpdate(ctx, p.Key, *env, oldEnv, getAPIConsumer(ctx))
 
 		var errEnvs error
 		p.Environments, errEnvs = environment.LoadEnvironments(api.mustDB(), p.Key)
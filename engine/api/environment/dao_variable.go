@@ -8,6 +8,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -111,6 +112,13 @@ func InsertVariable(db gorp.SqlExecutor, envID int64, v *sdk.Variable, u sdk.Ide
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*v); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, envID, *v); err != nil {
+		return err
+	}
+
 	if sdk.NeedPlaceholder(v.Type) && v.Value == sdk.PasswordPlaceholder {
 		return fmt.Errorf("You try to insert a placeholder for new variable %s", v.Name)
 	}
@@ -144,6 +152,13 @@ func UpdateVariable(db gorp.SqlExecutor, envID int64, variable *sdk.Variable, va
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*variable); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, envID, *variable); err != nil {
+		return err
+	}
+
 	dbVar := newdbEnvironmentVariable(*variable, envID)
 
 	if err := gorpmapping.UpdateAndSign(context.Background(), db, &dbVar); err != nil {
@@ -204,3 +219,15 @@ func DeleteVariable(db gorp.SqlExecutor, envID int64, variable *sdk.Variable, u
 	}
 	return nil
 }
+
+// checkVaultVariable checks that a vault variable references a secret allowed for the project.
+func checkVaultVariable(db gorp.SqlExecutor, envID int64, v sdk.Variable) error {
+	if v.Type != sdk.VaultVariable {
+		return nil
+	}
+	projectKey, err := db.SelectStr("SELECT project.projectkey FROM environment JOIN project ON project.id = environment.project_id WHERE environment.id = $1", envID)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return secret.CheckVaultVariable(projectKey, v)
+}
@@ -0,0 +1,36 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// UpdateEnvironmentAsCode update an as code environment, old environment is used to detect conflicts with the repository.
+// Given environments must be loaded with clear variables and keys, the old one is exported with previousEncryptFunc that
+// must not alter the stored encrypted data.
+func UpdateEnvironmentAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, env, old sdk.Environment, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, encryptFunc, previousEncryptFunc sdk.EncryptFunc, u sdk.Identifiable) (*sdk.Operation, error) {
+	// The old environment is exported first with the stored tokens, the export of the new one renews the tokens of the updated secrets
+	previous, err := ExportEnvironment(db, old, previousEncryptFunc)
+	if err != nil {
+		return nil, err
+	}
+	ee, err := ExportEnvironment(db, env, encryptFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("chore: Update environment %s [@%s]", env.Name, u.GetUsername())
+	}
+	return operation.PushOperation(ctx, db, store, proj,
+		exportentities.WorkflowComponents{Environments: []exportentities.Environment{ee}},
+		exportentities.WorkflowComponents{Environments: []exportentities.Environment{previous}},
+		vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
+}
@@ -42,8 +42,22 @@ func (api *API) deleteKeyInEnvironmentHandler() service.Handler {
 		if errE != nil {
 			return sdk.WrapError(errE, "deleteKeyInEnvironmentHandler> Cannot load environment")
 		}
+
+		// Updates of an as code environment are pushed to its repository
 		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeEnvironment(ctx, key, envName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				for i := range env.Keys {
+					if env.Keys[i].Name == keyName {
+						env.Keys = append(env.Keys[:i], env.Keys[i+1:]...)
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrKeyNotFound, "key %s not found on environment %s", keyName, envName)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, nil)
 		}
 
 		tx, errT := api.mustDB().Begin()
@@ -94,10 +108,6 @@ func (api *API) addKeyInEnvironmentHandler() service.Handler {
 		}
 		newKey.EnvironmentID = env.ID
 
-		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
-
 		if !strings.HasPrefix(newKey.Name, "env-") {
 			newKey.Name = "env-" + newKey.Name
 		}
@@ -125,6 +135,23 @@ func (api *API) addKeyInEnvironmentHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrUnknownKeyType, "addKeyInEnvironmentHandler> unknown key of type: %s", newKey.Type)
 		}
 
+		// Updates of an as code environment are pushed to its repository
+		if env.FromRepository != "" {
+			ope, err := api.updateAsCodeEnvironment(ctx, key, envName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				for i := range env.Keys {
+					if env.Keys[i].Name == newKey.Name {
+						return sdk.WithStack(sdk.ErrKeyAlreadyExist)
+					}
+				}
+				env.Keys = append(env.Keys, newKey)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newKey)
+		}
+
 		tx, errT := api.mustDB().Begin()
 		if errT != nil {
 			return sdk.WrapError(errT, "addKeyInEnvironmentHandler> Cannot start transaction")
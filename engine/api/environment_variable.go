@@ -90,8 +90,18 @@ func (api *API) deleteVariableFromEnvironmentHandler() service.Handler {
 		if errEnv != nil {
 			return sdk.WrapError(errEnv, "deleteVariableFromEnvironmentHandler: Cannot load environment %s", envName)
 		}
+
+		// Updates of an as code environment are pushed to its repository
 		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeEnvironment(ctx, key, envName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				var err error
+				env.Variables, err = deleteAsCodeVariable(env.Variables, varName)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, nil)
 		}
 
 		tx, errBegin := api.mustDB().Begin()
@@ -138,8 +148,19 @@ func (api *API) updateVariableInEnvironmentHandler() service.Handler {
 		if errEnv != nil {
 			return sdk.WrapError(errEnv, "updateVariableInEnvironmentHandler: cannot load environment %s", envName)
 		}
+
+		// Updates of an as code environment are pushed to its repository
 		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeEnvironment(ctx, key, envName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				return updateAsCodeVariable(key, env.Variables, varName, &newVar)
+			})
+			if err != nil {
+				return err
+			}
+			if sdk.NeedPlaceholder(newVar.Type) {
+				newVar.Value = sdk.PasswordPlaceholder
+			}
+			return writeAsCodeAccepted(w, ope, newVar)
 		}
 
 		tx, errBegin := api.mustDB().Begin()
@@ -191,8 +212,21 @@ func (api *API) addVariableInEnvironmentHandler() service.Handler {
 		if errEnv != nil {
 			return sdk.WrapError(errEnv, "addVariableInEnvironmentHandler: Cannot load environment %s", envName)
 		}
+
+		// Updates of an as code environment are pushed to its repository
 		if env.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodeEnvironment(ctx, key, envName, FormString(r, "branch"), FormString(r, "message"), func(env *sdk.Environment) error {
+				var err error
+				env.Variables, err = addAsCodeVariable(key, env.Variables, newVar)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if sdk.NeedPlaceholder(newVar.Type) {
+				newVar.Value = sdk.PasswordPlaceholder
+			}
+			return writeAsCodeAccepted(w, ope, newVar)
 		}
 
 		tx, errBegin := api.mustDB().Begin()
@@ -0,0 +1,50 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func publishConsumerEvent(ctx context.Context, payload interface{}, u sdk.Identifiable) {
+	bts, _ := json.Marshal(payload)
+
+	event := sdk.Event{
+		Timestamp: time.Now(),
+		Hostname:  hostname,
+		CDSName:   cdsname,
+		EventType: fmt.Sprintf("%T", payload),
+		Payload:   bts,
+	}
+	if u != nil {
+		event.Username = u.GetUsername()
+		event.UserMail = u.GetEmail()
+	}
+	publishEvent(ctx, event)
+}
+
+// PublishConsumerNewNetwork publish event when an auth consumer is used from a new network
+func PublishConsumerNewNetwork(ctx context.Context, c sdk.AuthConsumer, activity sdk.AuthConsumerActivity, network string, u sdk.Identifiable) {
+	e := sdk.EventConsumerNewNetwork{
+		ConsumerID:   c.ID,
+		ConsumerName: c.Name,
+		ConsumerType: string(c.Type),
+		IP:           activity.IP,
+		Network:      network,
+		UserAgent:    activity.UserAgent,
+	}
+	publishConsumerEvent(ctx, e, u)
+}
+
+// PublishConsumerIdleDisabled publish event when an idle auth consumer is disabled
+func PublishConsumerIdleDisabled(ctx context.Context, c sdk.AuthConsumer, u sdk.Identifiable) {
+	e := sdk.EventConsumerIdleDisabled{
+		ConsumerID:   c.ID,
+		ConsumerName: c.Name,
+		LastUsed:     c.LastUsed,
+	}
+	publishConsumerEvent(ctx, e, u)
+}
@@ -221,3 +221,23 @@ func PublishWorkflowNodeJobRun(ctx context.Context, pkey string, wr sdk.Workflow
 	}
 	publishRunWorkflow(ctx, e, data)
 }
+
+// PublishWorkflowNodeJobRunSecretDetected publish an event when secrets are detected in logs or artifacts of a job
+func PublishWorkflowNodeJobRunSecretDetected(ctx context.Context, pkey string, wr sdk.WorkflowRun, jr sdk.WorkflowNodeJobRun, findings []sdk.SecretScanFinding) {
+	e := sdk.EventRunWorkflowJobSecretDetected{
+		ID:         jr.ID,
+		WorkerName: jr.Job.WorkerName,
+		Findings:   findings,
+	}
+	data := publishWorkflowRunData{
+		projectKey:        pkey,
+		workflowName:      wr.Workflow.Name,
+		workflowRunNum:    wr.Number,
+		workflowRunSubNum: wr.LastSubNumber,
+		status:            jr.Status,
+		workflowRunTags:   wr.Tags,
+		eventIntegrations: wr.Workflow.EventIntegrations,
+		workflowNodeRunID: jr.WorkflowNodeRunID,
+	}
+	publishRunWorkflow(ctx, e, data)
+}
@@ -0,0 +1,482 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getFreezeWindowsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		windows, err := freezewindow.LoadAllByProjectID(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, windows, http.StatusOK)
+	}
+}
+
+func (api *API) getFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["freezeWindowName"]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		fw, err := freezewindow.LoadByProjectIDAndName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, fw, http.StatusOK)
+	}
+}
+
+func (api *API) getFreezeWindowAuditHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["freezeWindowName"]
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		// Blocks of deleted freeze windows are kept, so the audit is available even if the window doesn't exist anymore
+		blocks, err := freezewindow.LoadBlocksByProjectIDAndFreezeWindowName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+		if len(blocks) == 0 {
+			if _, err := freezewindow.LoadByProjectIDAndName(ctx, api.mustDB(), p.ID, name); err != nil {
+				return err
+			}
+		}
+
+		return service.WriteJSON(w, blocks, http.StatusOK)
+	}
+}
+
+// fillFreezeWindow checks given freeze window and set environment and group ids from their names.
+func (api *API) fillFreezeWindow(ctx context.Context, db gorp.SqlExecutor, p *sdk.Project, fw *sdk.FreezeWindow) error {
+	if err := fw.IsValid(); err != nil {
+		return err
+	}
+
+	fw.ProjectID = p.ID
+	fw.EnvironmentID = nil
+	if fw.EnvironmentName != "" {
+		env, err := environment.LoadEnvironmentByName(db, p.Key, fw.EnvironmentName)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "environment %s not found", fw.EnvironmentName)
+		}
+		fw.EnvironmentID = &env.ID
+	}
+
+	fw.OverrideGroupID = nil
+	if fw.OverrideGroupName != "" {
+		g, err := group.LoadByName(ctx, db, fw.OverrideGroupName)
+		if err != nil {
+			return err
+		}
+		fw.OverrideGroupID = &g.ID
+	}
+
+	return nil
+}
+
+func (api *API) postFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var fw sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &fw); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		if err := api.fillFreezeWindow(ctx, tx, p, &fw); err != nil {
+			return err
+		}
+
+		if _, err := freezewindow.LoadByProjectIDAndName(ctx, tx, p.ID, fw.Name); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "freeze window %s already exists", fw.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		fw.Author = getAPIConsumer(ctx).GetUsername()
+		if err := freezewindow.Insert(tx, &fw); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, fw, http.StatusCreated)
+	}
+}
+
+func (api *API) putFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["freezeWindowName"]
+
+		var fw sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &fw); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		old, err := freezewindow.LoadByProjectIDAndName(ctx, tx, p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		// Updating the window could release the blocked node runs
+		blocks, err := freezewindow.LoadPendingBlocksByFreezeWindowID(ctx, tx, old.ID)
+		if err != nil {
+			return err
+		}
+		if len(blocks) > 0 {
+			if err := checkFreezeWindowRelease(ctx, *old); err != nil {
+				return err
+			}
+		}
+
+		if err := api.fillFreezeWindow(ctx, tx, p, &fw); err != nil {
+			return err
+		}
+
+		fw.ID = old.ID
+		fw.Created = old.Created
+		fw.Author = getAPIConsumer(ctx).GetUsername()
+		if err := freezewindow.Update(tx, &fw); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, fw, http.StatusOK)
+	}
+}
+
+func (api *API) deleteFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["freezeWindowName"]
+
+		p, err := project.Load(api.mustDB(), key, project.LoadOptions.WithVariables, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return err
+		}
+
+		fw, err := freezewindow.LoadByProjectIDAndName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		// Release all node runs blocked by the window before removing it
+		blocks, err := freezewindow.LoadPendingBlocksByFreezeWindowID(ctx, api.mustDB(), fw.ID)
+		if err != nil {
+			return err
+		}
+		if len(blocks) > 0 {
+			if err := checkFreezeWindowRelease(ctx, *fw); err != nil {
+				return err
+			}
+		}
+		for i := range blocks {
+			blocks[i].OverrideUsername = getAPIConsumer(ctx).GetUsername()
+			if err := api.releaseFreezeWindowBlock(ctx, *p, *fw, &blocks[i], sdk.FreezeWindowReleaseDeleted, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := freezewindow.Delete(api.mustDB(), *fw); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postFreezeWindowOverrideHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		var override sdk.FreezeWindowOverride
+		if err := service.UnmarshalBody(r, &override); err != nil {
+			return err
+		}
+		if err := override.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), key, project.LoadOptions.WithVariables, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+
+		block, err := freezewindow.LoadPendingBlockByNodeRunID(ctx, api.mustDB(), nodeRun.ID)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow node run is not blocked by a freeze window")
+			}
+			return err
+		}
+
+		if block.FreezeWindowID == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "freeze window %s has been deleted", block.FreezeWindowName)
+		}
+		fw, err := freezewindow.LoadByID(ctx, api.mustDB(), *block.FreezeWindowID)
+		if err != nil {
+			return err
+		}
+
+		// Only administrators, members of the override group or consumers with a role allowing to approve
+		// deployments on the workflow are allowed to bypass a freeze window
+		consumer := getAPIConsumer(ctx)
+		if !isAdmin(ctx) {
+			if fw.OverrideGroupID == nil {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "freeze window %s can't be overridden", fw.Name)
+			}
+			g := sdk.Group{ID: *fw.OverrideGroupID}
+			if !g.IsMember(consumer.GetGroupIDs()) {
+				canApprove, err := api.hasWorkflowAction(ctx, key, name, sdk.RoleActionApproveDeployments)
+				if err != nil {
+					return err
+				}
+				if !canApprove {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "only members of group %s can override freeze window %s", fw.OverrideGroupName, fw.Name)
+				}
+			}
+		}
+
+		block.OverrideUsername = consumer.GetUsername()
+		block.OverrideReason = override.Reason
+		if err := api.releaseFreezeWindowBlock(ctx, *p, *fw, block, sdk.FreezeWindowReleaseOverride, nodeRun); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, block, http.StatusOK)
+	}
+}
+
+// checkFreezeWindowRelease checks that the consumer is allowed to release the node runs blocked by the freeze window,
+// only administrators and members of its override group can.
+func checkFreezeWindowRelease(ctx context.Context, fw sdk.FreezeWindow) error {
+	if isAdmin(ctx) {
+		return nil
+	}
+	if fw.OverrideGroupID != nil {
+		g := sdk.Group{ID: *fw.OverrideGroupID}
+		if g.IsMember(getAPIConsumer(ctx).GetGroupIDs()) {
+			return nil
+		}
+	}
+	return sdk.NewErrorFrom(sdk.ErrForbidden, "freeze window %s is blocking workflow runs, only administrators or members of its override group can release them", fw.Name)
+}
+
+// releaseFreezeWindowBlock marks the block as released and executes the blocked node run if it is still waiting.
+func (api *API) releaseFreezeWindowBlock(ctx context.Context, p sdk.Project, fw sdk.FreezeWindow, block *sdk.FreezeWindowBlock, releaseType string, nodeRun *sdk.WorkflowNodeRun) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	locked, err := freezewindow.LockPendingBlock(tx, block.ID)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow node run is not blocked by freeze window %s anymore", fw.Name)
+	}
+
+	if nodeRun == nil {
+		nodeRun, err = workflow.LoadNodeRunByID(tx, block.WorkflowNodeRunID, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The node run could have been stopped meanwhile
+	if nodeRun.Status != sdk.StatusWaiting {
+		if err := freezewindow.ReleaseBlock(tx, block, sdk.FreezeWindowReleaseStopped); err != nil {
+			return err
+		}
+		return sdk.WithStack(tx.Commit())
+	}
+
+	if err := freezewindow.ReleaseBlock(tx, block, releaseType); err != nil {
+		return err
+	}
+
+	wr, err := workflow.LoadRunByID(tx, nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return err
+	}
+
+	msg := sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeFreezeRelease.ID,
+		Args: []interface{}{fw.Name, nodeRun.WorkflowNodeName},
+		Type: sdk.MsgWorkflowNodeFreezeRelease.Type,
+	}
+	if releaseType == sdk.FreezeWindowReleaseOverride {
+		msg = sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeFreezeOverride.ID,
+			Args: []interface{}{fw.Name, block.OverrideUsername, nodeRun.WorkflowNodeName, block.OverrideReason},
+			Type: sdk.MsgWorkflowNodeFreezeOverride.Type,
+		}
+		log.Warning(ctx, "freeze window %s on project %s overridden by %s for %s #%d: %s", fw.Name, p.Key, block.OverrideUsername, wr.Workflow.Name, wr.Number, block.OverrideReason)
+	}
+	if releaseType == sdk.FreezeWindowReleaseDeleted {
+		msg = sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeFreezeDeleted.ID,
+			Args: []interface{}{fw.Name, block.OverrideUsername, nodeRun.WorkflowNodeName},
+			Type: sdk.MsgWorkflowNodeFreezeDeleted.Type,
+		}
+		log.Warning(ctx, "freeze window %s on project %s deleted by %s, releasing %s #%d", fw.Name, p.Key, block.OverrideUsername, wr.Workflow.Name, wr.Number)
+	}
+
+	report, err := workflow.ReleaseFrozenNodeRun(ctx, tx, api.Cache, p, wr, nodeRun, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, p, report)
+
+	return nil
+}
+
+// releaseExpiredFreezeWindowBlocks executes node runs for which the freeze window is over.
+func (api *API) releaseExpiredFreezeWindowBlocks(ctx context.Context) {
+	tick := time.NewTicker(time.Minute)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "releaseExpiredFreezeWindowBlocks> Exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := api.releaseExpiredFreezeWindowBlocksOnce(ctx); err != nil {
+				log.Error(ctx, "releaseExpiredFreezeWindowBlocks> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) releaseExpiredFreezeWindowBlocksOnce(ctx context.Context) error {
+	blocks, err := freezewindow.LoadPendingBlocks(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	windows := make(map[int64]*sdk.FreezeWindow)
+	projects := make(map[int64]*sdk.Project)
+	for i := range blocks {
+		if blocks[i].FreezeWindowID == nil {
+			continue
+		}
+		fw, ok := windows[*blocks[i].FreezeWindowID]
+		if !ok {
+			fw, err = freezewindow.LoadByID(ctx, api.mustDB(), *blocks[i].FreezeWindowID)
+			if err != nil {
+				return err
+			}
+			windows[fw.ID] = fw
+		}
+		if fw.IsActive(now) {
+			continue
+		}
+
+		p, ok := projects[fw.ProjectID]
+		if !ok {
+			p, err = project.LoadByID(api.mustDB(), fw.ProjectID, project.LoadOptions.WithVariables, project.LoadOptions.WithIntegrations)
+			if err != nil {
+				return err
+			}
+			projects[p.ID] = p
+		}
+
+		if err := api.releaseFreezeWindowBlock(ctx, *p, *fw, &blocks[i], sdk.FreezeWindowReleaseExpired, nil); err != nil && !sdk.ErrorIs(err, sdk.ErrWrongRequest) {
+			log.Error(ctx, "releaseExpiredFreezeWindowBlocks> unable to release workflow node run %d: %v", blocks[i].WorkflowNodeRunID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package freezewindow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/sdk"
+)
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (sdk.FreezeWindows, error) {
+	var dbWindows []dbFreezeWindow
+	if err := gorpmapping.GetAll(ctx, db, q, &dbWindows); err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze windows")
+	}
+
+	windows := make(sdk.FreezeWindows, len(dbWindows))
+	for i := range dbWindows {
+		windows[i] = sdk.FreezeWindow(dbWindows[i])
+	}
+
+	if err := loadNames(ctx, db, windows); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func get(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (*sdk.FreezeWindow, error) {
+	var dbWindow dbFreezeWindow
+	found, err := gorpmapping.Get(ctx, db, q, &dbWindow)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze window")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+
+	windows := sdk.FreezeWindows{sdk.FreezeWindow(dbWindow)}
+	if err := loadNames(ctx, db, windows); err != nil {
+		return nil, err
+	}
+
+	return &windows[0], nil
+}
+
+// loadNames sets environment and override group names on given windows.
+func loadNames(ctx context.Context, db gorp.SqlExecutor, windows sdk.FreezeWindows) error {
+	var groupIDs []int64
+	for i := range windows {
+		if windows[i].OverrideGroupID != nil {
+			groupIDs = append(groupIDs, *windows[i].OverrideGroupID)
+		}
+	}
+	if len(groupIDs) > 0 {
+		gs, err := group.LoadAllByIDs(ctx, db, groupIDs)
+		if err != nil {
+			return err
+		}
+		for i := range windows {
+			if windows[i].OverrideGroupID == nil {
+				continue
+			}
+			for j := range gs {
+				if gs[j].ID == *windows[i].OverrideGroupID {
+					windows[i].OverrideGroupName = gs[j].Name
+					break
+				}
+			}
+		}
+	}
+
+	envNames := make(map[int64]string)
+	for i := range windows {
+		if windows[i].EnvironmentID == nil {
+			continue
+		}
+		envID := *windows[i].EnvironmentID
+		if _, ok := envNames[envID]; !ok {
+			env, err := environment.LoadEnvironmentByID(db, envID)
+			if err != nil {
+				return err
+			}
+			envNames[envID] = env.Name
+		}
+		windows[i].EnvironmentName = envNames[envID]
+	}
+
+	return nil
+}
+
+// LoadAllByProjectID returns all freeze windows for given project, including
+// the ones defined on its environments.
+func LoadAllByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) (sdk.FreezeWindows, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window
+		WHERE project_id = $1
+		ORDER BY name
+	`).Args(projectID)
+	return getAll(ctx, db, query)
+}
+
+// LoadByProjectIDAndName returns a freeze window from database.
+func LoadByProjectIDAndName(ctx context.Context, db gorp.SqlExecutor, projectID int64, name string) (*sdk.FreezeWindow, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window
+		WHERE project_id = $1 AND name = $2
+	`).Args(projectID, name)
+	return get(ctx, db, query)
+}
+
+// LoadByID returns a freeze window from database.
+func LoadByID(ctx context.Context, db gorp.SqlExecutor, id int64) (*sdk.FreezeWindow, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM freeze_window WHERE id = $1`).Args(id)
+	return get(ctx, db, query)
+}
+
+// Insert a freeze window in database.
+func Insert(db gorp.SqlExecutor, fw *sdk.FreezeWindow) error {
+	fw.Created = time.Now()
+	dbWindow := dbFreezeWindow(*fw)
+	if err := gorpmapping.Insert(db, &dbWindow); err != nil {
+		return sdk.WrapError(err, "unable to insert freeze window %s", fw.Name)
+	}
+	fw.ID = dbWindow.ID
+	return nil
+}
+
+// Update a freeze window in database.
+func Update(db gorp.SqlExecutor, fw *sdk.FreezeWindow) error {
+	dbWindow := dbFreezeWindow(*fw)
+	if err := gorpmapping.Update(db, &dbWindow); err != nil {
+		return sdk.WrapError(err, "unable to update freeze window %s", fw.Name)
+	}
+	// Keep the audit of the window under its new name
+	if _, err := db.Exec("UPDATE freeze_window_block SET freeze_window_name = $1 WHERE freeze_window_id = $2", fw.Name, fw.ID); err != nil {
+		return sdk.WrapError(err, "unable to rename blocks of freeze window %s", fw.Name)
+	}
+	return nil
+}
+
+// Delete a freeze window in database.
+func Delete(db gorp.SqlExecutor, fw sdk.FreezeWindow) error {
+	dbWindow := dbFreezeWindow(fw)
+	if err := gorpmapping.Delete(db, &dbWindow); err != nil {
+		return sdk.WrapError(err, "unable to delete freeze window %s", fw.Name)
+	}
+	return nil
+}
+
+func getAllBlocks(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.FreezeWindowBlock, error) {
+	var dbBlocks []dbFreezeWindowBlock
+	if err := gorpmapping.GetAll(ctx, db, q, &dbBlocks); err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze window blocks")
+	}
+	blocks := make([]sdk.FreezeWindowBlock, len(dbBlocks))
+	for i := range dbBlocks {
+		blocks[i] = sdk.FreezeWindowBlock(dbBlocks[i])
+	}
+	return blocks, nil
+}
+
+// LoadBlocksByProjectIDAndFreezeWindowName returns all blocks for given project and freeze window name,
+// including the ones of deleted freeze windows.
+func LoadBlocksByProjectIDAndFreezeWindowName(ctx context.Context, db gorp.SqlExecutor, projectID int64, name string) ([]sdk.FreezeWindowBlock, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window_block
+		WHERE project_id = $1 AND freeze_window_name = $2
+		ORDER BY created DESC
+	`).Args(projectID, name)
+	return getAllBlocks(ctx, db, query)
+}
+
+// LoadPendingBlocks returns all blocks that were not released.
+func LoadPendingBlocks(ctx context.Context, db gorp.SqlExecutor) ([]sdk.FreezeWindowBlock, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window_block
+		WHERE released IS NULL
+		ORDER BY created
+	`)
+	return getAllBlocks(ctx, db, query)
+}
+
+// LoadPendingBlocksByFreezeWindowID returns blocks that were not released for given freeze window.
+func LoadPendingBlocksByFreezeWindowID(ctx context.Context, db gorp.SqlExecutor, freezeWindowID int64) ([]sdk.FreezeWindowBlock, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window_block
+		WHERE freeze_window_id = $1 AND released IS NULL
+		ORDER BY created
+	`).Args(freezeWindowID)
+	return getAllBlocks(ctx, db, query)
+}
+
+// LoadPendingBlockByNodeRunID returns the block that was not released for given workflow node run.
+func LoadPendingBlockByNodeRunID(ctx context.Context, db gorp.SqlExecutor, nodeRunID int64) (*sdk.FreezeWindowBlock, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM freeze_window_block
+		WHERE workflow_node_run_id = $1 AND released IS NULL
+	`).Args(nodeRunID)
+	var dbBlock dbFreezeWindowBlock
+	found, err := gorpmapping.Get(ctx, db, query, &dbBlock)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze window block")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	b := sdk.FreezeWindowBlock(dbBlock)
+	return &b, nil
+}
+
+// InsertBlock in database.
+func InsertBlock(db gorp.SqlExecutor, b *sdk.FreezeWindowBlock) error {
+	b.Created = time.Now()
+	dbBlock := dbFreezeWindowBlock(*b)
+	if err := gorpmapping.Insert(db, &dbBlock); err != nil {
+		return sdk.WrapError(err, "unable to insert freeze window block")
+	}
+	b.ID = dbBlock.ID
+	return nil
+}
+
+// ReleaseBlock marks given block as released in database.
+func ReleaseBlock(db gorp.SqlExecutor, b *sdk.FreezeWindowBlock, releaseType string) error {
+	now := time.Now()
+	b.Released = &now
+	b.ReleaseType = releaseType
+	dbBlock := dbFreezeWindowBlock(*b)
+	if err := gorpmapping.Update(db, &dbBlock); err != nil {
+		return sdk.WrapError(err, "unable to release freeze window block %d", b.ID)
+	}
+	return nil
+}
+
+// LockPendingBlock locks given block in database, it returns false if the block was
+// already released or is locked by another transaction.
+func LockPendingBlock(db gorp.SqlExecutor, id int64) (bool, error) {
+	query := `SELECT id FROM freeze_window_block WHERE id = $1 AND released IS NULL FOR UPDATE SKIP LOCKED`
+	res, err := db.SelectNullInt(query, id)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to lock freeze window block %d", id)
+	}
+	return res.Valid, nil
+}
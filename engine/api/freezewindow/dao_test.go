@@ -0,0 +1,73 @@
+package freezewindow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestDAO(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+
+	start := time.Now().Add(-time.Hour)
+	stop := time.Now().Add(time.Hour)
+	fw := sdk.FreezeWindow{
+		Name:      "christmas",
+		ProjectID: proj.ID,
+		Start:     &start,
+		End:       &stop,
+	}
+	require.NoError(t, freezewindow.Insert(db, &fw))
+
+	ws, err := freezewindow.LoadAllByProjectID(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, ws, 1)
+	assert.True(t, ws[0].IsActive(time.Now()))
+
+	fw.Reason = "holidays"
+	require.NoError(t, freezewindow.Update(db, &fw))
+	res, err := freezewindow.LoadByProjectIDAndName(context.TODO(), db, proj.ID, fw.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "holidays", res.Reason)
+
+	u, _ := assets.InsertLambdaUser(t, db)
+	wf := assets.InsertTestWorkflow(t, db, cache, proj, sdk.RandomString(10))
+	wr, err := workflow.CreateRun(db, wf, nil, u)
+	require.NoError(t, err)
+
+	block := sdk.FreezeWindowBlock{
+		ProjectID:        proj.ID,
+		FreezeWindowID:   &fw.ID,
+		FreezeWindowName: fw.Name,
+		WorkflowRunID:    wr.ID,
+		WorkflowName:     wf.Name,
+		OverrideUsername: "admin",
+		OverrideReason:   "hotfix",
+	}
+	require.NoError(t, freezewindow.InsertBlock(db, &block))
+
+	require.NoError(t, freezewindow.Delete(db, fw))
+	_, err = freezewindow.LoadByID(context.TODO(), db, fw.ID)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	// The audit of overrides is kept when the window is deleted
+	blocks, err := freezewindow.LoadBlocksByProjectIDAndFreezeWindowName(context.TODO(), db, proj.ID, fw.Name)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Nil(t, blocks[0].FreezeWindowID)
+	assert.Equal(t, "hotfix", blocks[0].OverrideReason)
+}
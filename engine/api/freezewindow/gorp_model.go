@@ -0,0 +1,16 @@
+package freezewindow
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbFreezeWindow sdk.FreezeWindow
+type dbFreezeWindowBlock sdk.FreezeWindowBlock
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(dbFreezeWindow{}, "freeze_window", true, "id"),
+		gorpmapping.New(dbFreezeWindowBlock{}, "freeze_window_block", true, "id"),
+	)
+}
@@ -224,6 +224,9 @@ func (api *API) postGroupUserHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot load group with name: %s", groupName)
 		}
+		if err := checkGroupNotSynchronized(ctx, tx, g); err != nil {
+			return err
+		}
 
 		var u *sdk.AuthentifiedUser
 		if data.ID != "" {
@@ -357,6 +360,9 @@ func (api *API) deleteGroupUserHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot load group with name: %s", groupName)
 		}
+		if err := checkGroupNotSynchronized(ctx, tx, g); err != nil {
+			return err
+		}
 
 		u, err := user.LoadByUsername(ctx, tx, username)
 		if err != nil {
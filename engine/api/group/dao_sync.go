@@ -0,0 +1,95 @@
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getSyncs(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.GroupSync, error) {
+	var ss []sdk.GroupSync
+	if err := gorpmapping.GetAll(ctx, db, q, &ss); err != nil {
+		return nil, sdk.WrapError(err, "cannot get group syncs")
+	}
+	return ss, nil
+}
+
+// LoadSyncsByType returns all group syncs for given type.
+func LoadSyncsByType(ctx context.Context, db gorp.SqlExecutor, t sdk.AuthConsumerType) ([]sdk.GroupSync, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM group_sync
+    WHERE type = $1
+    ORDER BY id
+  `).Args(t)
+	return getSyncs(ctx, db, query)
+}
+
+// LoadSyncsByGroupIDs returns all group syncs for given group ids.
+func LoadSyncsByGroupIDs(ctx context.Context, db gorp.SqlExecutor, groupIDs []int64) ([]sdk.GroupSync, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM group_sync
+    WHERE group_id = ANY(string_to_array($1, ',')::int[])
+  `).Args(gorpmapping.IDsToQueryString(groupIDs))
+	return getSyncs(ctx, db, query)
+}
+
+// LoadSyncByGroupID returns the sync for given group id.
+func LoadSyncByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID int64) (*sdk.GroupSync, error) {
+	var s sdk.GroupSync
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM group_sync
+    WHERE group_id = $1
+  `).Args(groupID)
+	found, err := gorpmapping.Get(ctx, db, query, &s)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get group sync")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &s, nil
+}
+
+// InsertSync inserts given group sync into database.
+func InsertSync(db gorp.SqlExecutor, s *sdk.GroupSync) error {
+	s.Created = time.Now()
+	s.LastSync = s.Created
+	return sdk.WrapError(gorpmapping.Insert(db, s), "unable to insert group sync for group %d", s.GroupID)
+}
+
+// UpdateSync updates given group sync into database.
+func UpdateSync(db gorp.SqlExecutor, s *sdk.GroupSync) error {
+	return sdk.WrapError(gorpmapping.Update(db, s), "unable to update group sync %d", s.ID)
+}
+
+// DeleteSync removes given group sync from database.
+func DeleteSync(db gorp.SqlExecutor, s *sdk.GroupSync) error {
+	return sdk.WrapError(gorpmapping.Delete(db, s), "unable to delete group sync %d", s.ID)
+}
+
+// InsertAudit for group in database.
+func InsertAudit(db gorp.SqlExecutor, a *sdk.AuditGroup) error {
+	return sdk.WrapError(gorpmapping.Insert(db, a), "unable to insert audit for group %d", a.GroupID)
+}
+
+// LoadAuditsByGroupID returns all audits for given group id.
+func LoadAuditsByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID int64) ([]sdk.AuditGroup, error) {
+	var as []sdk.AuditGroup
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM group_audit
+    WHERE group_id = $1
+    ORDER BY created DESC
+  `).Args(groupID)
+	if err := gorpmapping.GetAll(ctx, db, query, &as); err != nil {
+		return nil, sdk.WrapError(err, "cannot get group audits")
+	}
+	return as, nil
+}
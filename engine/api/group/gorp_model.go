@@ -99,5 +99,7 @@ func init() {
 		gorpmapping.New(group{}, "group", true, "id"),
 		gorpmapping.New(LinkGroupUser{}, "group_authentified_user", true, "id"),
 		gorpmapping.New(LinkGroupProject{}, "project_group", true, "id"),
+		gorpmapping.New(sdk.GroupSync{}, "group_sync", true, "id"),
+		gorpmapping.New(sdk.AuditGroup{}, "group_audit", true, "id"),
 	)
 }
@@ -16,13 +16,37 @@ type LoadOptionFunc func(context.Context, gorp.SqlExecutor, ...*sdk.Group) error
 var LoadOptions = struct {
 	Default     LoadOptionFunc
 	WithMembers LoadOptionFunc
+	WithSync    LoadOptionFunc
 }{
 	Default:     loadDefault,
 	WithMembers: loadMembers,
+	WithSync:    loadSync,
 }
 
 func loadDefault(ctx context.Context, db gorp.SqlExecutor, gs ...*sdk.Group) error {
-	return loadMembers(ctx, db, gs...)
+	if err := loadMembers(ctx, db, gs...); err != nil {
+		return err
+	}
+	return loadSync(ctx, db, gs...)
+}
+
+func loadSync(ctx context.Context, db gorp.SqlExecutor, gs ...*sdk.Group) error {
+	ss, err := LoadSyncsByGroupIDs(ctx, db, sdk.GroupPointersToIDs(gs))
+	if err != nil {
+		return err
+	}
+
+	m := make(map[int64]sdk.GroupSync, len(ss))
+	for i := range ss {
+		m[ss[i].GroupID] = ss[i]
+	}
+	for _, g := range gs {
+		if s, ok := m[g.ID]; ok {
+			g.Sync = &s
+		}
+	}
+
+	return nil
 }
 
 func loadMembers(ctx context.Context, db gorp.SqlExecutor, gs ...*sdk.Group) error {
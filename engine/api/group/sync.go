@@ -0,0 +1,206 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+// SyncResult contains changes made on a group members by a synchronization.
+type SyncResult struct {
+	GroupID int64
+	Added   []string
+	Removed []string
+	// KeptAdmins are admins that should have been removed but were kept because the group needs an admin
+	KeptAdmins []string
+}
+
+// HasChanges returns true if members were added or removed.
+func (r SyncResult) HasChanges() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0
+}
+
+// SyncMembers reconciles members of the group bound to given sync with given user ids.
+func SyncMembers(ctx context.Context, db gorp.SqlExecutor, s *sdk.GroupSync, userIDs []string, triggeredBy string) (SyncResult, error) {
+	res := SyncResult{GroupID: s.GroupID}
+
+	links, err := LoadLinksGroupUserForGroupIDs(ctx, db, []int64{s.GroupID})
+	if err != nil {
+		return res, err
+	}
+
+	// Admins are only removed if at least one admin remains in the group
+	var adminRemains bool
+	for i := range links {
+		if links[i].Admin && sdk.IsInArray(links[i].AuthentifiedUserID, userIDs) {
+			adminRemains = true
+			break
+		}
+	}
+
+	for i := range links {
+		if sdk.IsInArray(links[i].AuthentifiedUserID, userIDs) {
+			continue
+		}
+		if links[i].Admin && !adminRemains {
+			res.KeptAdmins = append(res.KeptAdmins, links[i].AuthentifiedUserID)
+			continue
+		}
+		if err := DeleteLinkGroupUser(db, &links[i]); err != nil {
+			return res, err
+		}
+		res.Removed = append(res.Removed, links[i].AuthentifiedUserID)
+	}
+
+	existing := links.ToUserIDs()
+	for _, id := range userIDs {
+		if sdk.IsInArray(id, existing) || sdk.IsInArray(id, res.Added) {
+			continue
+		}
+		if err := InsertLinkGroupUser(ctx, db, &LinkGroupUser{
+			GroupID:            s.GroupID,
+			AuthentifiedUserID: id,
+			Admin:              false,
+		}); err != nil {
+			return res, err
+		}
+		res.Added = append(res.Added, id)
+	}
+
+	s.LastSync = time.Now()
+	s.LastSyncInfo = fmt.Sprintf("%d member(s), %d added, %d removed", len(existing)+len(res.Added)-len(res.Removed), len(res.Added), len(res.Removed))
+	if len(res.KeptAdmins) > 0 {
+		s.LastSyncInfo += fmt.Sprintf(", %d admin(s) kept", len(res.KeptAdmins))
+	}
+	if err := UpdateSync(db, s); err != nil {
+		return res, err
+	}
+
+	if res.HasChanges() {
+		if err := createAuditSync(ctx, db, *s, res, triggeredBy); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// SyncUserGroups reconciles the membership of given user for all groups bound to given type.
+// The user should be member of groups which sync value is in given values.
+func SyncUserGroups(ctx context.Context, db gorp.SqlExecutor, userID string, t sdk.AuthConsumerType, values []string, triggeredBy string) ([]SyncResult, error) {
+	ss, err := LoadSyncsByType(ctx, db, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SyncResult
+	for i := range ss {
+		res := SyncResult{GroupID: ss[i].GroupID}
+
+		link, err := LoadLinkGroupUserForGroupIDAndUserID(ctx, db, ss[i].GroupID, userID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+
+		shouldBeMember := sdk.IsInArray(ss[i].Value, values)
+		switch {
+		case shouldBeMember && link == nil:
+			if err := InsertLinkGroupUser(ctx, db, &LinkGroupUser{
+				GroupID:            ss[i].GroupID,
+				AuthentifiedUserID: userID,
+				Admin:              false,
+			}); err != nil {
+				return nil, err
+			}
+			res.Added = []string{userID}
+		case !shouldBeMember && link != nil:
+			if link.Admin {
+				lastAdmin, err := isLastAdmin(ctx, db, ss[i].GroupID, userID)
+				if err != nil {
+					return nil, err
+				}
+				// The last admin of a group is never removed by a synchronization
+				if lastAdmin {
+					continue
+				}
+			}
+			if err := DeleteLinkGroupUser(db, link); err != nil {
+				return nil, err
+			}
+			res.Removed = []string{userID}
+		default:
+			continue
+		}
+
+		if err := createAuditSync(ctx, db, ss[i], res, triggeredBy); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+func isLastAdmin(ctx context.Context, db gorp.SqlExecutor, groupID int64, userID string) (bool, error) {
+	links, err := LoadLinksGroupUserForGroupIDs(ctx, db, []int64{groupID})
+	if err != nil {
+		return false, err
+	}
+	for i := range links {
+		if links[i].AuthentifiedUserID != userID && links[i].Admin {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type auditSyncData struct {
+	Type    sdk.AuthConsumerType `json:"type"`
+	Value   string               `json:"value"`
+	Added   []string             `json:"added,omitempty"`
+	Removed []string             `json:"removed,omitempty"`
+}
+
+func createAuditSync(ctx context.Context, db gorp.SqlExecutor, s sdk.GroupSync, res SyncResult, triggeredBy string) error {
+	users, err := user.LoadAllByIDs(ctx, db, append(append([]string{}, res.Added...), res.Removed...))
+	if err != nil {
+		return err
+	}
+	mUsers := users.ToMapByID()
+	usernames := func(ids []string) []string {
+		res := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if u, ok := mUsers[id]; ok {
+				res = append(res, u.Username)
+			}
+		}
+		return res
+	}
+
+	b, err := json.Marshal(auditSyncData{
+		Type:    s.Type,
+		Value:   s.Value,
+		Added:   usernames(res.Added),
+		Removed: usernames(res.Removed),
+	})
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal group sync result")
+	}
+
+	return InsertAudit(db, &sdk.AuditGroup{
+		AuditCommon: sdk.AuditCommon{
+			EventType:   "GroupSync",
+			Created:     time.Now(),
+			TriggeredBy: triggeredBy,
+		},
+		GroupID:   s.GroupID,
+		DataType:  "json",
+		DataAfter: string(b),
+	})
+}
@@ -0,0 +1,104 @@
+package group_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSyncMembers(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	g := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	u1, _ := assets.InsertLambdaUser(t, db, g)
+	u2, _ := assets.InsertLambdaUser(t, db)
+	u3, _ := assets.InsertLambdaUser(t, db)
+
+	s := sdk.GroupSync{GroupID: g.ID, Type: sdk.ConsumerLDAP, Value: "cn=my-team,ou=groups,dc=cds"}
+	require.NoError(t, group.InsertSync(db, &s))
+
+	res, err := group.SyncMembers(context.TODO(), db, &s, []string{u2.ID, u3.ID}, "cds.api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{u2.ID, u3.ID}, res.Added)
+	assert.Equal(t, []string{u1.ID}, res.Removed)
+
+	result, err := group.LoadByID(context.TODO(), db, g.ID, group.LoadOptions.Default)
+	require.NoError(t, err)
+	assert.Len(t, result.Members, 2)
+	require.NotNil(t, result.Sync)
+	assert.Equal(t, "2 member(s), 2 added, 1 removed", result.Sync.LastSyncInfo)
+
+	// Nothing changed so no audit should be added
+	res, err = group.SyncMembers(context.TODO(), db, &s, []string{u2.ID, u3.ID}, "cds.api")
+	require.NoError(t, err)
+	assert.False(t, res.HasChanges())
+
+	audits, err := group.LoadAuditsByGroupID(context.TODO(), db, g.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, "GroupSync", audits[0].EventType)
+	assert.Equal(t, "cds.api", audits[0].TriggeredBy)
+}
+
+func TestSyncUserGroups(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	g1 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	g2 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	u, _ := assets.InsertLambdaUser(t, db, g2)
+
+	s1 := sdk.GroupSync{GroupID: g1.ID, Type: sdk.ConsumerOIDC, Value: sdk.RandomString(10)}
+	require.NoError(t, group.InsertSync(db, &s1))
+	s2 := sdk.GroupSync{GroupID: g2.ID, Type: sdk.ConsumerOIDC, Value: sdk.RandomString(10)}
+	require.NoError(t, group.InsertSync(db, &s2))
+
+	results, err := group.SyncUserGroups(context.TODO(), db, u.ID, sdk.ConsumerOIDC, []string{s1.Value}, u.Username)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	links, err := group.LoadLinksGroupUserForUserIDs(context.TODO(), db, []string{u.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{g1.ID}, links.ToGroupIDs())
+}
+
+func TestSyncKeepsLastAdmin(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	g := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	admin, _ := assets.InsertLambdaUser(t, db)
+	require.NoError(t, group.InsertLinkGroupUser(context.TODO(), db, &group.LinkGroupUser{
+		GroupID:            g.ID,
+		AuthentifiedUserID: admin.ID,
+		Admin:              true,
+	}))
+	u, _ := assets.InsertLambdaUser(t, db)
+
+	s := sdk.GroupSync{GroupID: g.ID, Type: sdk.ConsumerOIDC, Value: sdk.RandomString(10)}
+	require.NoError(t, group.InsertSync(db, &s))
+
+	// The admin is not member of the external group but it's the last admin of the group
+	res, err := group.SyncMembers(context.TODO(), db, &s, []string{u.ID}, "cds.api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{u.ID}, res.Added)
+	assert.Empty(t, res.Removed)
+	assert.Equal(t, []string{admin.ID}, res.KeptAdmins)
+
+	results, err := group.SyncUserGroups(context.TODO(), db, admin.ID, sdk.ConsumerOIDC, nil, admin.Username)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	link, err := group.LoadLinkGroupUserForGroupIDAndUserID(context.TODO(), db, g.ID, admin.ID)
+	require.NoError(t, err)
+	assert.True(t, link.Admin)
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getGroupSyncHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		s, err := group.LoadSyncByGroupID(ctx, api.mustDB(), g.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+func (api *API) putGroupSyncHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var data sdk.GroupSync
+		if err := service.UnmarshalBody(r, &data); err != nil {
+			return err
+		}
+		if err := data.IsValid(); err != nil {
+			return err
+		}
+		if _, ok := api.AuthenticationDrivers[data.Type]; !ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "authentication driver %s is not enabled", data.Type)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := group.LoadByName(ctx, tx, vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+		if group.IsDefaultGroupID(g.ID) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "members of the default group can't be synchronized")
+		}
+
+		s, err := group.LoadSyncByGroupID(ctx, tx, g.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if s == nil {
+			s = &sdk.GroupSync{GroupID: g.ID, Type: data.Type, Value: data.Value}
+			if err := group.InsertSync(tx, s); err != nil {
+				return err
+			}
+		} else {
+			s.Type = data.Type
+			s.Value = data.Value
+			if err := group.UpdateSync(tx, s); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+func (api *API) deleteGroupSyncHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		s, err := group.LoadSyncByGroupID(ctx, api.mustDB(), g.ID)
+		if err != nil {
+			return err
+		}
+
+		// Existing members are kept, the group can then be managed manually
+		if err := group.DeleteSync(api.mustDB(), s); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getGroupAuditsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+		if err != nil {
+			return err
+		}
+
+		as, err := group.LoadAuditsByGroupID(ctx, api.mustDB(), g.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, as, http.StatusOK)
+	}
+}
+
+// checkGroupNotSynchronized returns an error if members of given group are synchronized from an external source.
+func checkGroupNotSynchronized(ctx context.Context, db gorp.SqlExecutor, g *sdk.Group) error {
+	s, err := group.LoadSyncByGroupID(ctx, db, g.ID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if s != nil {
+		return sdk.NewErrorFrom(sdk.ErrGroupSynchronized, "members of group %s are synchronized from %s", g.Name, s.Type)
+	}
+	return nil
+}
+
+// applyGroupSyncResults updates consumers of users that were added or removed from groups by a synchronization.
+func applyGroupSyncResults(ctx context.Context, db gorp.SqlExecutor, results []group.SyncResult) error {
+	for _, res := range results {
+		if !res.HasChanges() {
+			continue
+		}
+
+		g, err := group.LoadByID(ctx, db, res.GroupID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range res.Added {
+			if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, db, g.ID, id); err != nil {
+				return err
+			}
+		}
+
+		if len(res.Removed) == 0 {
+			continue
+		}
+		us, err := user.LoadAllByIDs(ctx, db, res.Removed)
+		if err != nil {
+			return err
+		}
+		for i := range us {
+			if err := authentication.ConsumerInvalidateGroupForUser(ctx, db, g, &us[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncUserGroups reconciles groups of given user with external groups returned by an auth driver at signin.
+// If the driver returned no group, the user is only removed from synchronized groups if allowEmpty is set.
+func syncUserGroups(ctx context.Context, db gorp.SqlExecutor, consumerType sdk.AuthConsumerType, u *sdk.AuthentifiedUser, externalGroups []string, allowEmpty bool) error {
+	switch consumerType {
+	case sdk.ConsumerLDAP, sdk.ConsumerOIDC:
+	default:
+		return nil
+	}
+	if len(externalGroups) == 0 && !allowEmpty {
+		log.Warning(ctx, "syncUserGroups> no external group returned for user %s, synchronization skipped", u.Username)
+		return nil
+	}
+
+	results, err := group.SyncUserGroups(ctx, db, u.ID, consumerType, externalGroups, u.Username)
+	if err != nil {
+		return err
+	}
+
+	return applyGroupSyncResults(ctx, db, results)
+}
+
+// groupSyncRoutine periodically reconciles members of groups bound to a driver that can list external group members.
+func (api *API) groupSyncRoutine(ctx context.Context, interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting groupSyncRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			for consumerType, driver := range api.AuthenticationDrivers {
+				d, ok := driver.(sdk.AuthDriverWithGroupsSync)
+				if !ok {
+					continue
+				}
+				if err := api.syncGroups(ctx, consumerType, d); err != nil {
+					log.Error(ctx, "groupSyncRoutine> %v", err)
+				}
+			}
+		}
+	}
+}
+
+func (api *API) syncGroups(ctx context.Context, consumerType sdk.AuthConsumerType, driver sdk.AuthDriverWithGroupsSync) error {
+	ss, err := group.LoadSyncsByType(ctx, api.mustDB(), consumerType)
+	if err != nil {
+		return err
+	}
+
+	for i := range ss {
+		externalIDs, err := driver.GetGroupMembers(ctx, ss[i].Value)
+		if err != nil {
+			log.Error(ctx, "syncGroups> cannot get members of %s for group %d: %v", ss[i].Value, ss[i].GroupID, err)
+			continue
+		}
+
+		if err := api.syncGroup(ctx, &ss[i], externalIDs); err != nil {
+			log.Error(ctx, "syncGroups> cannot sync group %d: %v", ss[i].GroupID, err)
+		}
+	}
+
+	return nil
+}
+
+func (api *API) syncGroup(ctx context.Context, s *sdk.GroupSync, externalIDs []string) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	// An empty member list is more likely an error of the external source than a team without any member,
+	// applying it would remove all members of the group
+	if len(externalIDs) == 0 && !api.Config.Auth.GroupsSyncAllowEmpty {
+		s.LastSync = time.Now()
+		s.LastSyncInfo = "skipped, no member returned by " + string(s.Type)
+		if err := group.UpdateSync(tx, s); err != nil {
+			return err
+		}
+		return sdk.WithStack(tx.Commit())
+	}
+
+	// Only users that already signed in with the driver can be matched
+	cs, err := authentication.LoadConsumersByTypeAndUserExternalIDs(ctx, tx, s.Type, externalIDs)
+	if err != nil {
+		return err
+	}
+	userIDs := make([]string, len(cs))
+	for i := range cs {
+		userIDs[i] = cs[i].AuthentifiedUserID
+	}
+
+	res, err := group.SyncMembers(ctx, tx, s, userIDs, "cds.api")
+	if err != nil {
+		return err
+	}
+	if err := applyGroupSyncResults(ctx, tx, []group.SyncResult{res}); err != nil {
+		return err
+	}
+
+	return sdk.WithStack(tx.Commit())
+}
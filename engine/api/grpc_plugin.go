@@ -27,6 +27,9 @@ func (api *API) postGRPCluginHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 		p.Binaries = nil
+		if err := p.IsValid(); err != nil {
+			return err
+		}
 
 		tx, err := db.Begin()
 		if err != nil {
@@ -112,6 +115,9 @@ func (api *API) putGRPCluginHandler() service.Handler {
 
 		p.ID = old.ID
 		p.Binaries = old.Binaries
+		if err := p.IsValid(); err != nil {
+			return err
+		}
 
 		tx, err := db.Begin()
 		if err != nil {
@@ -184,6 +190,9 @@ func (api *API) postGRPCluginBinaryHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "postGRPCluginBinaryHandler")
 		}
+		if p.IsRemote() {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "plugin %s is remote, it does not have binaries", p.Name)
+		}
 
 		buff := bytes.NewBuffer(b.FileContent)
 
@@ -277,6 +286,20 @@ func (api *API) getGRPCluginBinaryInfosHandler() service.Handler {
 	}
 }
 
+// getGRPCluginRemoteHandler returns the address of a remote plugin, it is empty for plugins run by the worker.
+func (api *API) getGRPCluginRemoteHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := mux.Vars(r)["name"]
+
+		p, err := plugin.LoadByName(api.mustDB(), name)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, p.Remote, http.StatusOK)
+	}
+}
+
 func (api *API) deleteGRPCluginBinaryHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		return nil
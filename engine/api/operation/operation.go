@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
@@ -17,7 +18,9 @@ import (
 
 var CacheOperationKey = cache.Key("repositories", "operation", "push")
 
-func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wp exportentities.WorkflowComponents, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, isUpdate bool, u sdk.Identifiable) (*sdk.Operation, error) {
+// PushOperation pushes the given files to the repository, previous files are used by the repositories service
+// to detect the files updated in the repository since the last synchronization
+func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wp, previous exportentities.WorkflowComponents, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, isUpdate bool, u sdk.Identifiable) (*sdk.Operation, error) {
 	if vcsStrategy.SSHKey != "" {
 		key := proj.GetSSHKey(vcsStrategy.SSHKey)
 		if key == nil {
@@ -26,6 +29,10 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		vcsStrategy.SSHKeyContent = key.Private
 	}
 
+	if branch == "" {
+		branch = fmt.Sprintf("cdsAsCode-%d", time.Now().Unix())
+	}
+
 	// Create VCS Operation
 	ope := sdk.Operation{
 		VCSServer:          vcsServerName,
@@ -40,6 +47,17 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			},
 		},
 	}
+	previousFiles, err := previous.Files()
+	if err != nil {
+		return nil, err
+	}
+	if len(previousFiles) > 0 {
+		ope.Setup.Push.Previous = make(map[string]string, len(previousFiles))
+		for k, v := range previousFiles {
+			ope.Setup.Push.Previous[k] = string(v)
+		}
+	}
+
 	ope.User.Email = u.GetEmail()
 	ope.User.Username = u.GetFullname()
 	ope.User.Username = u.GetUsername()
@@ -77,6 +95,7 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		return nil, sdk.WrapError(err, "unable to post repository operation")
 	}
 	ope.RepositoryStrategy.SSHKeyContent = ""
+	ope.Setup.Push.Previous = nil
 	_ = store.SetWithTTL(cache.Key(CacheOperationKey, ope.UUID), ope, 300)
 	return &ope, nil
 }
@@ -88,6 +107,7 @@ func PostRepositoryOperation(ctx context.Context, db gorp.SqlExecutor, prj sdk.P
 		return sdk.WrapError(err, "Unable to found repositories service")
 	}
 
+	ope.ProjectKey = prj.Key
 	if ope.RepositoryStrategy.ConnectionType == "ssh" {
 		found := false
 		for _, k := range prj.Keys {
@@ -0,0 +1,92 @@
+package permission
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadProjectActions returns actions granted to given groups on a project. Actions are given by the builtin
+// role matching groups max permission level and by the roles linked to groups at project level.
+func LoadProjectActions(ctx context.Context, db gorp.SqlExecutor, projectKey string, groupIDs []int64) (sdk.RoleActions, error) {
+	ctx, end := observability.Span(ctx, "permission.LoadProjectActions")
+	defer end()
+
+	perms, err := LoadProjectMaxLevelPermission(ctx, db, []string{projectKey}, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := sdk.RoleActions{}
+	if r := sdk.BuiltinRoleForLevel(perms.Level(projectKey)); r != nil {
+		actions.Add(r.Actions...)
+	}
+
+	query := `
+		SELECT permission_role.actions
+		FROM permission_role_link
+		JOIN permission_role ON permission_role.id = permission_role_link.role_id
+		JOIN project ON project.id = permission_role_link.project_id
+		WHERE project.projectkey = $1
+		AND permission_role_link.workflow_id IS NULL
+		AND permission_role_link.group_id = ANY(string_to_array($2, ',')::int[])`
+	if err := addRoleActions(db, actions, query, projectKey, gorpmapping.IDsToQueryString(groupIDs)); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// LoadWorkflowActions returns actions granted to given groups on a workflow. Actions are given by the builtin
+// role matching groups max permission level on the workflow and by the roles linked to groups at project
+// or workflow level.
+func LoadWorkflowActions(ctx context.Context, db gorp.SqlExecutor, projectKey, workflowName string, groupIDs []int64) (sdk.RoleActions, error) {
+	ctx, end := observability.Span(ctx, "permission.LoadWorkflowActions")
+	defer end()
+
+	perms, err := LoadWorkflowMaxLevelPermission(ctx, db, projectKey, []string{workflowName}, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := sdk.RoleActions{}
+	if r := sdk.BuiltinRoleForLevel(perms.Level(workflowName)); r != nil {
+		actions.Add(r.Actions...)
+	}
+
+	query := `
+		SELECT permission_role.actions
+		FROM permission_role_link
+		JOIN permission_role ON permission_role.id = permission_role_link.role_id
+		JOIN project ON project.id = permission_role_link.project_id
+		LEFT JOIN workflow ON workflow.id = permission_role_link.workflow_id
+		WHERE project.projectkey = $1
+		AND (permission_role_link.workflow_id IS NULL OR workflow.name = $2)
+		AND permission_role_link.group_id = ANY(string_to_array($3, ',')::int[])`
+	if err := addRoleActions(db, actions, query, projectKey, workflowName, gorpmapping.IDsToQueryString(groupIDs)); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func addRoleActions(db gorp.SqlExecutor, actions sdk.RoleActions, query string, args ...interface{}) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var as sdk.StringSlice
+		if err := rows.Scan(&as); err != nil {
+			return sdk.WithStack(err)
+		}
+		actions.Add(as...)
+	}
+	return sdk.WithStack(rows.Err())
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/gorilla/mux"
 
-	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/ascode"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/pipeline"
@@ -40,69 +39,76 @@ func (api *API) updateAsCodePipelineHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrInvalidPipelinePattern, "updateAsCodePipelineHandler: Pipeline name %s do not respect pattern", p.Name)
 		}
 
-		proj, err := project.Load(api.mustDB(), key,
-			project.LoadOptions.WithApplicationWithDeploymentStrategies,
-			project.LoadOptions.WithPipelines,
-			project.LoadOptions.WithEnvironments,
-			project.LoadOptions.WithIntegrations,
-			project.LoadOptions.WithClearKeys)
+		ope, err := api.updateAsCodePipeline(ctx, key, name, branch, message, func(pip *sdk.Pipeline) error {
+			*pip = p
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		pipelineDB, err := pipeline.LoadPipeline(ctx, api.mustDB(), key, name, true)
-		if err != nil {
-			return sdk.WrapError(err, "cannot load pipeline %s", name)
-		}
-
-		if pipelineDB.FromRepository == "" {
-			return sdk.NewErrorFrom(sdk.ErrForbidden, "current pipeline is not ascode")
-		}
+		return service.WriteJSON(w, ope, http.StatusOK)
+	}
+}
 
-		wkHolder, err := workflow.LoadByRepo(ctx, api.Cache, api.mustDB(), *proj, pipelineDB.FromRepository, workflow.LoadOptions{
-			WithTemplate: true,
-		})
-		if err != nil {
-			return err
-		}
-		if wkHolder.TemplateInstance != nil {
-			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot edit a pipeline that was generated by a template")
-		}
+// updateAsCodePipeline applies the given update on the as code pipeline then pushes it to its repository,
+// this will create a pull request or commit on the given branch if it is the default branch of the repository.
+func (api *API) updateAsCodePipeline(ctx context.Context, key, name, branch, message string, update func(p *sdk.Pipeline) error) (*sdk.Operation, error) {
+	proj, err := project.Load(api.mustDB(), key,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return nil, err
+	}
 
-		var rootApp *sdk.Application
-		if wkHolder.WorkflowData.Node.Context != nil && wkHolder.WorkflowData.Node.Context.ApplicationID != 0 {
-			rootApp, err = application.LoadByIDWithClearVCSStrategyPassword(api.mustDB(), wkHolder.WorkflowData.Node.Context.ApplicationID)
-			if err != nil {
-				return err
-			}
-		}
-		if rootApp == nil {
-			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow %s that hold the pipeline", wkHolder.Name)
-		}
+	// The pipeline is loaded twice to prevent the update from altering the stages and jobs of the old one
+	pipelineDB, err := pipeline.LoadPipeline(ctx, api.mustDB(), key, name, true)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load pipeline %s", name)
+	}
+	if pipelineDB.FromRepository == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "current pipeline is not ascode")
+	}
+	p, err := pipeline.LoadPipeline(ctx, api.mustDB(), key, name, true)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load pipeline %s", name)
+	}
 
-		u := getAPIConsumer(ctx)
-		ope, err := pipeline.UpdatePipelineAsCode(ctx, api.Cache, api.mustDB(), *proj, p, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, u)
-		if err != nil {
-			return err
-		}
+	if err := update(p); err != nil {
+		return nil, err
+	}
 
-		sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodePipelineHandler-%s", ope.UUID), func(ctx context.Context) {
-			ed := ascode.EntityData{
-				FromRepo:  pipelineDB.FromRepository,
-				Type:      ascode.AsCodePipeline,
-				ID:        pipelineDB.ID,
-				Name:      pipelineDB.Name,
-				Operation: ope,
-			}
-			asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *proj, *rootApp, ed, u)
-			if asCodeEvent != nil {
-				event.PublishAsCodeEvent(ctx, proj.Key, *asCodeEvent, u)
-			}
-		}, api.PanicDump())
+	rootApp, err := api.loadAsCodeRootApplication(ctx, *proj, pipelineDB.FromRepository)
+	if err != nil {
+		return nil, err
+	}
 
-		return service.WriteJSON(w, ope, http.StatusOK)
+	u := getAPIConsumer(ctx)
+	ope, err := pipeline.UpdatePipelineAsCode(ctx, api.Cache, api.mustDB(), *proj, *p, *pipelineDB, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, u)
+	if err != nil {
+		return nil, err
 	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodePipelineHandler-%s", ope.UUID), func(ctx context.Context) {
+		ed := ascode.EntityData{
+			FromRepo:  pipelineDB.FromRepository,
+			Type:      ascode.AsCodePipeline,
+			ID:        pipelineDB.ID,
+			Name:      pipelineDB.Name,
+			Operation: ope,
+		}
+		asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *proj, *rootApp, ed, u)
+		if asCodeEvent != nil {
+			event.PublishAsCodeEvent(ctx, proj.Key, *asCodeEvent, u)
+		}
+	}, api.PanicDump())
+
+	return ope, nil
 }
+
 func (api *API) updatePipelineHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		// Get project name in URL
@@ -126,8 +132,19 @@ func (api *API) updatePipelineHandler() service.Handler {
 			return sdk.WrapError(err, "cannot load pipeline %s", name)
 		}
 
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineDB.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, key, name, FormString(r, "branch"), FormString(r, "message"), func(pip *sdk.Pipeline) error {
+				pip.Name = p.Name
+				pip.Description = p.Description
+				asCodePip = pip
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		tx, errB := api.mustDB().Begin()
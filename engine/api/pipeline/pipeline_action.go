@@ -125,13 +125,26 @@ func CheckJob(ctx context.Context, db gorp.SqlExecutor, job *sdk.Job) error {
 		}
 		job.Action.Actions[i].ID = a.ID
 
+		// Parameters of a pinned step are the ones of the published version
+		params := a.Parameters
+		if step.Version != "" {
+			v, err := action.LoadVersionByActionIDAndVersion(ctx, db, a.ID, step.Version)
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "version %s of action %s not found", step.Version, step.Name)
+			}
+			params = v.Action.Parameters
+		}
+
 		// FIXME better check for params
 		for x := range step.Parameters {
 			sp := &step.Parameters[x]
 			log.Debug("CheckJob> Checking step parameter %s = %s", sp.Name, sp.Value)
 			var found bool
-			for y := range a.Parameters {
-				ap := a.Parameters[y]
+			for y := range params {
+				ap := params[y]
 				if strings.ToLower(sp.Name) == strings.ToLower(ap.Name) {
 					found = true
 					break
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-gorp/gorp"
 
@@ -11,11 +12,16 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 )
 
-// UpdateWorkflowAsCode update an as code workflow
-func UpdatePipelineAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, p sdk.Pipeline, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, u sdk.Identifiable) (*sdk.Operation, error) {
-	wpi := exportentities.NewPipelineV1(p)
+// UpdatePipelineAsCode update an as code pipeline, old pipeline is used to detect conflicts with the repository
+func UpdatePipelineAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, p, old sdk.Pipeline, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, u sdk.Identifiable) (*sdk.Operation, error) {
 	wp := exportentities.WorkflowComponents{
-		Pipelines: []exportentities.PipelineV1{wpi},
+		Pipelines: []exportentities.PipelineV1{exportentities.NewPipelineV1(p)},
 	}
-	return operation.PushOperation(ctx, db, store, proj, wp, vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
+	previous := exportentities.WorkflowComponents{
+		Pipelines: []exportentities.PipelineV1{exportentities.NewPipelineV1(old)},
+	}
+	if message == "" {
+		message = fmt.Sprintf("chore: Update pipeline %s [@%s]", p.Name, u.GetUsername())
+	}
+	return operation.PushOperation(ctx, db, store, proj, wp, previous, vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
 }
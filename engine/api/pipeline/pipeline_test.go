@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/bootstrap"
 	"github.com/ovh/cds/engine/api/group"
@@ -296,3 +297,46 @@ func TestLoadByWorkerModel(t *testing.T) {
 	}
 	assert.Equal(t, pip3.Name, pips[0].Name)
 }
+
+func TestCheckJobWithActionVersion(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	grp := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	defer assets.DeleteTestGroup(t, db, grp)
+
+	a := sdk.Action{
+		GroupID:    &grp.ID,
+		Type:       sdk.DefaultAction,
+		Name:       sdk.RandomString(10),
+		Parameters: []sdk.Parameter{{Name: "target", Type: sdk.StringParameter}},
+	}
+	require.NoError(t, action.Insert(db, &a))
+	current, err := action.LoadByID(context.TODO(), db, a.ID, action.LoadOptions.Default)
+	require.NoError(t, err)
+	require.NoError(t, action.InsertVersion(db, &sdk.ActionVersion{ActionID: a.ID, Version: "1.0.0", Action: *current}))
+
+	// Rename the parameter after publication
+	a.Parameters = []sdk.Parameter{{Name: "arch", Type: sdk.StringParameter}}
+	require.NoError(t, action.Update(db, &a))
+
+	newJob := func(version, param string) *sdk.Job {
+		return &sdk.Job{
+			Action: sdk.Action{
+				Name: "job",
+				Actions: []sdk.Action{{
+					Name:       a.Name,
+					Group:      grp,
+					Version:    version,
+					Parameters: []sdk.Parameter{{Name: param, Type: sdk.StringParameter}},
+				}},
+			},
+		}
+	}
+
+	assert.NoError(t, pipeline.CheckJob(context.TODO(), db, newJob("", "arch")))
+	assert.Error(t, pipeline.CheckJob(context.TODO(), db, newJob("", "target")))
+	assert.NoError(t, pipeline.CheckJob(context.TODO(), db, newJob("1.0.0", "target")))
+	assert.Error(t, pipeline.CheckJob(context.TODO(), db, newJob("1.0.0", "arch")))
+	assert.Error(t, pipeline.CheckJob(context.TODO(), db, newJob("2.0.0", "target")))
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/action"
@@ -44,8 +45,29 @@ func (api *API) addJobToStageHandler() service.Handler {
 		if errl != nil {
 			return sdk.WrapError(sdk.ErrPipelineNotFound, "addJobToStageHandler> Cannot load pipeline %s for project %s: %s", pipelineName, projectKey, errl)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pip.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			if err := checkJobActionGroups(ctx, api.mustDB(), pip.ProjectKey, &job); err != nil {
+				return err
+			}
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, projectKey, pipelineName, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				for i := range p.Stages {
+					if p.Stages[i].ID == stageID {
+						job.Action.Enabled = true
+						job.Enabled = true
+						p.Stages[i].Jobs = append(p.Stages[i].Jobs, job)
+						asCodePip = p
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrNotFound, "stage not found")
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		if err := pipeline.LoadPipelineStage(ctx, api.mustDB(), pip); err != nil {
@@ -70,17 +92,7 @@ func (api *API) addJobToStageHandler() service.Handler {
 		}
 		defer tx.Rollback() // nolint
 
-		// check that action used by job can be used by pipeline's project
-		project, err := project.Load(tx, pip.ProjectKey, project.LoadOptions.WithGroups)
-		if err != nil {
-			return sdk.WithStack(err)
-		}
-		groupIDs := make([]int64, 0, len(project.ProjectGroups)+1)
-		groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
-		for i := range project.ProjectGroups {
-			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
-		}
-		if err := action.CheckChildrenForGroupIDs(ctx, tx, &job.Action, groupIDs); err != nil {
+		if err := checkJobActionGroups(ctx, tx, pip.ProjectKey, &job); err != nil {
 			return err
 		}
 
@@ -157,9 +169,34 @@ func (api *API) updateJobHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot load pipeline %s", pipName)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			if err := checkJobActionGroups(ctx, api.mustDB(), pipelineData.ProjectKey, &job); err != nil {
+				return err
+			}
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, key, pipName, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				for i := range p.Stages {
+					if p.Stages[i].ID != stageID {
+						continue
+					}
+					for j := range p.Stages[i].Jobs {
+						if p.Stages[i].Jobs[j].PipelineActionID == jobID {
+							p.Stages[i].Jobs[j] = job
+							asCodePip = p
+							return nil
+						}
+					}
+				}
+				return sdk.WrapError(sdk.ErrNotFound, "job not found in pipeline")
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
+
 		if err := pipeline.LoadPipelineStage(ctx, api.mustDB(), pipelineData); err != nil {
 			return sdk.WrapError(err, "cannot load pipeline stages")
 		}
@@ -194,17 +231,7 @@ func (api *API) updateJobHandler() service.Handler {
 		}
 		defer tx.Rollback() // nolint
 
-		// check that action used by job can be used by pipeline's project
-		project, err := project.Load(tx, pipelineData.ProjectKey, project.LoadOptions.WithGroups)
-		if err != nil {
-			return sdk.WithStack(err)
-		}
-		groupIDs := make([]int64, 0, len(project.ProjectGroups)+1)
-		groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
-		for i := range project.ProjectGroups {
-			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
-		}
-		if err := action.CheckChildrenForGroupIDs(ctx, tx, &job.Action, groupIDs); err != nil {
+		if err := checkJobActionGroups(ctx, tx, pipelineData.ProjectKey, &job); err != nil {
 			return err
 		}
 
@@ -259,8 +286,26 @@ func (api *API) deleteJobHandler() service.Handler {
 		if errl != nil {
 			return sdk.WrapError(errl, "deleteJobHandler>Cannot load pipeline %s", pipName)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, key, pipName, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				for i := range p.Stages {
+					for j := range p.Stages[i].Jobs {
+						if p.Stages[i].Jobs[j].PipelineActionID == jobID {
+							p.Stages[i].Jobs = append(p.Stages[i].Jobs[:j], p.Stages[i].Jobs[j+1:]...)
+							asCodePip = p
+							return nil
+						}
+					}
+				}
+				return sdk.WrapError(sdk.ErrNotFound, "deleteJobHandler>Job not found")
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		if err := pipeline.LoadPipelineStage(ctx, api.mustDB(), pipelineData); err != nil {
@@ -318,3 +363,17 @@ func (api *API) deleteJobHandler() service.Handler {
 		return service.WriteJSON(w, pipelineData, http.StatusOK)
 	}
 }
+
+// checkJobActionGroups checks that the actions used by the job can be used by the pipeline's project
+func checkJobActionGroups(ctx context.Context, db gorp.SqlExecutor, projectKey string, job *sdk.Job) error {
+	proj, err := project.Load(db, projectKey, project.LoadOptions.WithGroups)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	groupIDs := make([]int64, 0, len(proj.ProjectGroups)+1)
+	groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
+	for i := range proj.ProjectGroups {
+		groupIDs = append(groupIDs, proj.ProjectGroups[i].Group.ID)
+	}
+	return action.CheckChildrenForGroupIDs(ctx, db, &job.Action, groupIDs)
+}
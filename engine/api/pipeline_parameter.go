@@ -43,8 +43,22 @@ func (api *API) deleteParameterFromPipelineHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "deleteParameterFromPipelineHandler: Cannot load %s", pipelineName)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if p.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodePipeline(ctx, key, pipelineName, FormString(r, "branch"), FormString(r, "message"), func(pip *sdk.Pipeline) error {
+				for i := range pip.Parameter {
+					if pip.Parameter[i].Name == paramName {
+						pip.Parameter = append(pip.Parameter[:i], pip.Parameter[i+1:]...)
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrParameterNotExists, "unable to find parameter %s", paramName)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, nil)
 		}
 
 		tx, err := api.mustDB().Begin()
@@ -83,8 +97,23 @@ func (api *API) updateParameterInPipelineHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "updateParameterInPipelineHandler: Cannot load %s", pipelineName)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if p.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodePipeline(ctx, key, pipelineName, FormString(r, "branch"), FormString(r, "message"), func(pip *sdk.Pipeline) error {
+				for i := range pip.Parameter {
+					if pip.Parameter[i].Name == paramName {
+						newParam.ID = pip.Parameter[i].ID
+						pip.Parameter[i] = newParam
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrParameterNotExists, "unable to find parameter %s", paramName)
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newParam)
 		}
 
 		oldParam := sdk.ParameterFind(p.Parameter, paramName)
@@ -132,8 +161,20 @@ func (api *API) addParameterInPipelineHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "addParameterInPipelineHandler: Cannot load %s", pipelineName)
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if p.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			ope, err := api.updateAsCodePipeline(ctx, key, pipelineName, FormString(r, "branch"), FormString(r, "message"), func(pip *sdk.Pipeline) error {
+				if sdk.ParameterFind(pip.Parameter, paramName) != nil {
+					return sdk.WrapError(sdk.ErrParameterExists, "parameter %s is already in the pipeline %s", paramName, pipelineName)
+				}
+				pip.Parameter = append(pip.Parameter, newParam)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, newParam)
 		}
 
 		paramInProject, err := pipeline.CheckParameterInPipeline(api.mustDB(), p.ID, paramName)
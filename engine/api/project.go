@@ -108,7 +108,30 @@ func (api *API) getProjectsHandler_FilterByRepo(ctx context.Context, w http.Resp
 		projects = res
 	}
 
-	return service.WriteJSON(w, projects, http.StatusOK)
+	return service.WriteJSON(w, filterProjectsByConsumerRestrictions(getAPIConsumer(ctx), projects), http.StatusOK)
+}
+
+// filterProjectsByConsumerRestrictions removes projects and workflows that are not allowed for a restricted consumer.
+func filterProjectsByConsumerRestrictions(c *sdk.AuthConsumer, projects []sdk.Project) []sdk.Project {
+	if c == nil || c.Restrictions == nil {
+		return projects
+	}
+
+	res := make([]sdk.Project, 0, len(projects))
+	for _, p := range projects {
+		if !c.Restrictions.AllowProject(p.Key) {
+			continue
+		}
+		ws := make([]sdk.Workflow, 0, len(p.Workflows))
+		for _, w := range p.Workflows {
+			if c.Restrictions.AllowWorkflow(p.Key, w.Name) {
+				ws = append(ws, w)
+			}
+		}
+		p.Workflows = ws
+		res = append(res, p)
+	}
+	return res
 }
 
 func (api *API) getProjectsHandler() service.Handler {
@@ -209,7 +232,7 @@ func (api *API) getProjectsHandler() service.Handler {
 			projects = res
 		}
 
-		return service.WriteJSON(w, projects, http.StatusOK)
+		return service.WriteJSON(w, filterProjectsByConsumerRestrictions(getAPIConsumer(ctx), projects), http.StatusOK)
 	}
 }
 
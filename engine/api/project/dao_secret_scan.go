@@ -0,0 +1,55 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadSecretScanAllowlist returns all secret scan allowlist entries for given project.
+func LoadSecretScanAllowlist(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.SecretScanAllowlistEntry, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM project_secret_scan_allowlist
+    WHERE project_id = $1
+    ORDER BY id
+  `).Args(projectID)
+	var es []sdk.SecretScanAllowlistEntry
+	if err := gorpmapping.GetAll(ctx, db, query, &es); err != nil {
+		return nil, sdk.WrapError(err, "cannot get secret scan allowlist for project %d", projectID)
+	}
+	return es, nil
+}
+
+// LoadSecretScanAllowlistEntryByID returns a secret scan allowlist entry for given project and id.
+func LoadSecretScanAllowlistEntryByID(ctx context.Context, db gorp.SqlExecutor, projectID, id int64) (*sdk.SecretScanAllowlistEntry, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM project_secret_scan_allowlist
+    WHERE project_id = $1 AND id = $2
+  `).Args(projectID, id)
+	var e sdk.SecretScanAllowlistEntry
+	found, err := gorpmapping.Get(ctx, db, query, &e)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get secret scan allowlist entry %d", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &e, nil
+}
+
+// InsertSecretScanAllowlistEntry inserts given entry into database.
+func InsertSecretScanAllowlistEntry(db gorp.SqlExecutor, e *sdk.SecretScanAllowlistEntry) error {
+	e.Created = time.Now()
+	return sdk.WrapError(gorpmapping.Insert(db, e), "unable to insert secret scan allowlist entry for project %d", e.ProjectID)
+}
+
+// DeleteSecretScanAllowlistEntry removes given entry from database.
+func DeleteSecretScanAllowlistEntry(db gorp.SqlExecutor, e *sdk.SecretScanAllowlistEntry) error {
+	return sdk.WrapError(gorpmapping.Delete(db, e), "unable to delete secret scan allowlist entry %d", e.ID)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -111,6 +112,13 @@ func InsertVariable(db gorp.SqlExecutor, projID int64, v *sdk.Variable, u sdk.Id
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*v); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, projID, *v); err != nil {
+		return err
+	}
+
 	if sdk.NeedPlaceholder(v.Type) && v.Value == sdk.PasswordPlaceholder {
 		return fmt.Errorf("You try to insert a placeholder for new variable %s", v.Name)
 	}
@@ -144,6 +152,13 @@ func UpdateVariable(db gorp.SqlExecutor, projID int64, variable *sdk.Variable, v
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVariableValue(*variable); err != nil {
+		return err
+	}
+	if err := checkVaultVariable(db, projID, *variable); err != nil {
+		return err
+	}
+
 	dbVar := newDBProjectVariable(*variable, projID)
 
 	if err := gorpmapping.UpdateAndSign(context.Background(), db, &dbVar); err != nil {
@@ -204,3 +219,15 @@ func DeleteVariable(db gorp.SqlExecutor, projID int64, variable *sdk.Variable, u
 	}
 	return nil
 }
+
+// checkVaultVariable checks that a vault variable references a secret allowed for the project.
+func checkVaultVariable(db gorp.SqlExecutor, projID int64, v sdk.Variable) error {
+	if v.Type != sdk.VaultVariable {
+		return nil
+	}
+	projectKey, err := db.SelectStr("SELECT projectkey FROM project WHERE id = $1", projID)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return secret.CheckVaultVariable(projectKey, v)
+}
@@ -90,6 +90,7 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbProjectKey{}, "project_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbLabel{}, "project_label", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectVariable{}, "project_variable", true, "id"))
+	gorpmapping.Register(gorpmapping.New(sdk.SecretScanAllowlistEntry{}, "project_secret_scan_allowlist", true, "id"))
 }
 
 // PostGet is a db hook
@@ -93,6 +93,40 @@ func EncryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, name, content s
 	return bded.Token, nil
 }
 
+// EncryptWithBuiltinKeyRenewToken encrypt a content with the builtin gpg key like EncryptWithBuiltinKey but returns a new token
+// if the content is not the stored one. The previous data is kept with its token so it can still be decrypted.
+func EncryptWithBuiltinKeyRenewToken(db gorp.SqlExecutor, projectID int64, name, content string) (string, error) {
+	var existing dbEncryptedData
+	if err := db.SelectOne(&existing, "select * from encrypted_data where project_id = $1 and content_name = $2", projectID, name); err != nil {
+		if err == sql.ErrNoRows {
+			return EncryptWithBuiltinKey(db, projectID, name, content)
+		}
+		return "", sdk.WrapError(err, "Unable to request encrypted_data")
+	}
+
+	clearContent, err := DecryptWithBuiltinKey(db, projectID, existing.Token)
+	if err == nil && clearContent == content {
+		return existing.Token, nil
+	}
+
+	// Rename the previous data so the content name can be used by the new token
+	if _, err := db.Exec("update encrypted_data set content_name = $1 where project_id = $2 and content_name = $3",
+		fmt.Sprintf("%s:%s", name, existing.Token), projectID, name); err != nil {
+		return "", sdk.WrapError(err, "Unable to update encrypted_data")
+	}
+	return EncryptWithBuiltinKey(db, projectID, name, content)
+}
+
+// LoadEncryptedToken returns the token of the stored data for given name without encrypting the content, an empty
+// token is returned if there is no stored data.
+func LoadEncryptedToken(db gorp.SqlExecutor, projectID int64, name, _ string) (string, error) {
+	token, err := db.SelectStr("select token from encrypted_data where project_id = $1 and content_name = $2", projectID, name)
+	if err != nil && err != sql.ErrNoRows {
+		return "", sdk.WrapError(err, "Unable to request encrypted_data")
+	}
+	return token, nil
+}
+
 // DecryptWithBuiltinKey decrypt a base64-ed, gzipped, content
 func DecryptWithBuiltinKey(db gorp.SqlExecutor, projectID int64, token string) (string, error) {
 	dbed := dbEncryptedData{}
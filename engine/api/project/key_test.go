@@ -32,3 +32,39 @@ func TestEncryptWithBuiltinKey(t *testing.T) {
 	t.Logf("%s => %s", content, encryptedContent2)
 	assert.Equal(t, encryptedContent, encryptedContent2)
 }
+
+func TestEncryptWithBuiltinKeyRenewToken(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+
+	token, err := project.EncryptWithBuiltinKey(db, proj.ID, "test", "old content")
+	test.NoError(t, err)
+
+	// The stored token is loaded without changing the data
+	loaded, err := project.LoadEncryptedToken(db, proj.ID, "test", "new content")
+	test.NoError(t, err)
+	assert.Equal(t, token, loaded)
+
+	// Same content keeps the token
+	sameToken, err := project.EncryptWithBuiltinKeyRenewToken(db, proj.ID, "test", "old content")
+	test.NoError(t, err)
+	assert.Equal(t, token, sameToken)
+
+	// New content gives a new token, the previous one can still be decrypted
+	newToken, err := project.EncryptWithBuiltinKeyRenewToken(db, proj.ID, "test", "new content")
+	test.NoError(t, err)
+	assert.NotEqual(t, token, newToken)
+
+	decryptedContent, err := project.DecryptWithBuiltinKey(db, proj.ID, token)
+	test.NoError(t, err)
+	assert.Equal(t, "old content", decryptedContent)
+	decryptedContent, err = project.DecryptWithBuiltinKey(db, proj.ID, newToken)
+	test.NoError(t, err)
+	assert.Equal(t, "new content", decryptedContent)
+
+	loaded, err = project.LoadEncryptedToken(db, proj.ID, "test", "")
+	test.NoError(t, err)
+	assert.Equal(t, newToken, loaded)
+}
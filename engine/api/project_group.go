@@ -11,6 +11,7 @@ import (
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/role"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -48,6 +49,11 @@ func (api *API) deleteGroupFromProjectHandler() service.Handler {
 			return sdk.WrapError(err, "cannot delete group %s from project %s", grp.Name, proj.Name)
 		}
 
+		// Roles given to the group on the project and its workflows are removed with its permission
+		if err := role.DeleteLinksByGroupIDAndProjectID(tx, grp.ID, proj.ID); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -154,6 +154,71 @@ func LoadForProject(db gorp.SqlExecutor, projectKey, rmName string) (*sdk.Projec
 	return nil, sdk.WithStack(sdk.ErrNotFound)
 }
 
+// CountVCSServersWithOtherSecretKey returns the number of projects with repositories managers links
+// that were not encrypted with given secret key.
+func CountVCSServersWithOtherSecretKey(db gorp.SqlExecutor, keyID string) (int, error) {
+	rows, err := db.Query("select vcs_servers from project where vcs_servers is not null")
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	defer rows.Close() // nolint
+
+	var count int
+	for rows.Next() {
+		var vcsServerStr []byte
+		if err := rows.Scan(&vcsServerStr); err != nil {
+			return 0, sdk.WithStack(err)
+		}
+		if id, encrypted := secret.KeyID(vcsServerStr); encrypted && id != keyID {
+			count++
+		}
+	}
+	return count, sdk.WithStack(rows.Err())
+}
+
+// LoadProjectIDsWithVCSServers returns ids of all projects linked to repositories managers.
+func LoadProjectIDsWithVCSServers(db gorp.SqlExecutor) ([]int64, error) {
+	var ids []int64
+	if _, err := db.Select(&ids, "select id from project where vcs_servers is not null order by id"); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return ids, nil
+}
+
+// RotateVCSServersSecretForProject encrypts again repositories managers links for given project
+// if they were encrypted with a previous secret key. Returns true if data was updated.
+func RotateVCSServersSecretForProject(db gorp.SqlExecutor, projectID int64) (bool, error) {
+	vcsServerStr := []byte{}
+	if err := db.QueryRow("select vcs_servers from project where id = $1", projectID).Scan(&vcsServerStr); err != nil {
+		return false, sdk.WithStack(err)
+	}
+
+	if !secret.NeedRotation(vcsServerStr) {
+		return false, nil
+	}
+
+	clearVCSServer, err := secret.Decrypt(vcsServerStr)
+	if err != nil {
+		return false, err
+	}
+	encryptedVCSServerStr, err := secret.Encrypt(clearVCSServer)
+	if err != nil {
+		return false, err
+	}
+
+	// Data is only updated if it was not changed since it was loaded
+	res, err := db.Exec("update project set vcs_servers = $3 where id = $1 and vcs_servers = $2", projectID, vcsServerStr, encryptedVCSServerStr)
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+
+	return n > 0, nil
+}
+
 //InsertForApplication associates a repositories manager with an application
 func InsertForApplication(db gorp.SqlExecutor, app *sdk.Application, projectKey string) error {
 	query := `UPDATE application SET vcs_server = $1, repo_fullname = $2 WHERE id = $3`
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/role"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getRolesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		rs, err := role.LoadAll(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		roles := make([]sdk.Role, 0, len(sdk.BuiltinRoles)+len(rs))
+		roles = append(roles, sdk.BuiltinRoles...)
+		roles = append(roles, rs...)
+
+		return service.WriteJSON(w, roles, http.StatusOK)
+	}
+}
+
+func (api *API) postRoleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var ro sdk.Role
+		if err := service.UnmarshalBody(r, &ro); err != nil {
+			return err
+		}
+		if err := ro.IsValid(); err != nil {
+			return err
+		}
+
+		if err := role.Insert(api.mustDB(), &ro); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ro, http.StatusCreated)
+	}
+}
+
+func (api *API) putRoleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		old, err := role.LoadByName(ctx, api.mustDB(), vars["roleName"])
+		if err != nil {
+			return err
+		}
+
+		var ro sdk.Role
+		if err := service.UnmarshalBody(r, &ro); err != nil {
+			return err
+		}
+		if err := ro.IsValid(); err != nil {
+			return err
+		}
+		ro.ID = old.ID
+		ro.Created = old.Created
+
+		if err := role.Update(api.mustDB(), &ro); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ro, http.StatusOK)
+	}
+}
+
+func (api *API) deleteRoleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		ro, err := role.LoadByName(ctx, api.mustDB(), vars["roleName"])
+		if err != nil {
+			return err
+		}
+
+		if err := role.Delete(api.mustDB(), ro); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectRoleLinksHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		p, err := project.Load(api.mustDB(), vars["permProjectKey"])
+		if err != nil {
+			return err
+		}
+
+		ls, err := role.LoadLinksByProjectID(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ls, http.StatusOK)
+	}
+}
+
+func (api *API) postProjectRoleLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var l sdk.RoleLink
+		if err := service.UnmarshalBody(r, &l); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		p, err := project.Load(tx, vars["permProjectKey"])
+		if err != nil {
+			return err
+		}
+
+		ro, err := role.LoadByName(ctx, tx, l.RoleName)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "role %q not found, builtin roles are given by group permission levels", l.RoleName)
+			}
+			return err
+		}
+
+		g, err := group.LoadByName(ctx, tx, l.GroupName)
+		if err != nil {
+			return err
+		}
+
+		// A role can only be given to a group that is already linked to the project
+		if _, err := group.LoadLinkGroupProjectForGroupIDAndProjectID(ctx, tx, g.ID, p.ID); err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "group %s should have a permission on project %s", g.Name, p.Key)
+			}
+			return err
+		}
+
+		newLink := sdk.RoleLink{
+			RoleID:    ro.ID,
+			GroupID:   g.ID,
+			ProjectID: p.ID,
+		}
+		if l.WorkflowName != "" {
+			wf, err := workflow.Load(ctx, tx, api.Cache, *p, l.WorkflowName, workflow.LoadOptions{Minimal: true})
+			if err != nil {
+				return err
+			}
+			newLink.WorkflowID = &wf.ID
+		}
+
+		if err := role.InsertLink(tx, &newLink); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		newLink.RoleName = ro.Name
+		newLink.GroupName = g.Name
+		newLink.WorkflowName = l.WorkflowName
+
+		return service.WriteJSON(w, newLink, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteProjectRoleLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		id, err := requestVarInt(r, "roleLinkID")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), vars["permProjectKey"])
+		if err != nil {
+			return err
+		}
+
+		l, err := role.LoadLinkByID(ctx, api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := role.DeleteLink(api.mustDB(), l); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectActionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["permProjectKey"]
+
+		var actions sdk.RoleActions
+		var err error
+		if workflowName := QueryString(r, "workflow"); workflowName != "" {
+			actions, err = permission.LoadWorkflowActions(ctx, api.mustDB(), key, workflowName, getAPIConsumer(ctx).GetGroupIDs())
+		} else {
+			actions, err = permission.LoadProjectActions(ctx, api.mustDB(), key, getAPIConsumer(ctx).GetGroupIDs())
+		}
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, actions.List(), http.StatusOK)
+	}
+}
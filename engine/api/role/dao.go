@@ -0,0 +1,69 @@
+package role
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.Role, error) {
+	var rs []sdk.Role
+	if err := gorpmapping.GetAll(ctx, db, q, &rs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get roles")
+	}
+	return rs, nil
+}
+
+func get(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (*sdk.Role, error) {
+	var r sdk.Role
+	found, err := gorpmapping.Get(ctx, db, q, &r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get role")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &r, nil
+}
+
+// LoadAll returns all custom roles.
+func LoadAll(ctx context.Context, db gorp.SqlExecutor) ([]sdk.Role, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM permission_role
+    ORDER BY name
+  `)
+	return getAll(ctx, db, query)
+}
+
+// LoadByName returns a custom role by its name.
+func LoadByName(ctx context.Context, db gorp.SqlExecutor, name string) (*sdk.Role, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM permission_role
+    WHERE name = $1
+  `).Args(name)
+	return get(ctx, db, query)
+}
+
+// Insert given role into database.
+func Insert(db gorp.SqlExecutor, r *sdk.Role) error {
+	r.Created = time.Now()
+	r.LastModified = r.Created
+	return sdk.WrapError(gorpmapping.Insert(db, r), "unable to insert role %s", r.Name)
+}
+
+// Update given role into database.
+func Update(db gorp.SqlExecutor, r *sdk.Role) error {
+	r.LastModified = time.Now()
+	return sdk.WrapError(gorpmapping.Update(db, r), "unable to update role %d", r.ID)
+}
+
+// Delete given role from database, links to groups are also removed.
+func Delete(db gorp.SqlExecutor, r *sdk.Role) error {
+	return sdk.WrapError(gorpmapping.Delete(db, r), "unable to delete role %d", r.ID)
+}
@@ -0,0 +1,105 @@
+package role
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getLinks(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.RoleLink, error) {
+	var ls []sdk.RoleLink
+	if err := gorpmapping.GetAll(ctx, db, q, &ls); err != nil {
+		return nil, sdk.WrapError(err, "cannot get role links")
+	}
+	if err := loadLinksNames(db, ls); err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+// loadLinksNames sets role, group and workflow names on given links.
+func loadLinksNames(db gorp.SqlExecutor, ls []sdk.RoleLink) error {
+	for i := range ls {
+		names := struct {
+			Role     string  `db:"role_name"`
+			Group    string  `db:"group_name"`
+			Workflow *string `db:"workflow_name"`
+		}{}
+		if err := db.SelectOne(&names, `
+      SELECT permission_role.name AS role_name, "group".name AS group_name, workflow.name AS workflow_name
+      FROM permission_role_link
+      JOIN permission_role ON permission_role.id = permission_role_link.role_id
+      JOIN "group" ON "group".id = permission_role_link.group_id
+      LEFT JOIN workflow ON workflow.id = permission_role_link.workflow_id
+      WHERE permission_role_link.id = $1
+    `, ls[i].ID); err != nil {
+			return sdk.WrapError(err, "cannot load names for role link %d", ls[i].ID)
+		}
+		ls[i].RoleName = names.Role
+		ls[i].GroupName = names.Group
+		if names.Workflow != nil {
+			ls[i].WorkflowName = *names.Workflow
+		}
+	}
+	return nil
+}
+
+// LoadLinksByProjectID returns all role links for given project, including links on its workflows.
+func LoadLinksByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.RoleLink, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM permission_role_link
+    WHERE project_id = $1
+    ORDER BY workflow_id NULLS FIRST, id
+  `).Args(projectID)
+	return getLinks(ctx, db, query)
+}
+
+// LoadLinksByWorkflowID returns all role links for given workflow.
+func LoadLinksByWorkflowID(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.RoleLink, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM permission_role_link
+    WHERE workflow_id = $1
+    ORDER BY id
+  `).Args(workflowID)
+	return getLinks(ctx, db, query)
+}
+
+// LoadLinkByID returns a role link for given project and id.
+func LoadLinkByID(ctx context.Context, db gorp.SqlExecutor, projectID, id int64) (*sdk.RoleLink, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM permission_role_link
+    WHERE project_id = $1 AND id = $2
+  `).Args(projectID, id)
+	ls, err := getLinks(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(ls) == 0 {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &ls[0], nil
+}
+
+// InsertLink inserts given role link into database.
+func InsertLink(db gorp.SqlExecutor, l *sdk.RoleLink) error {
+	l.Created = time.Now()
+	return sdk.WrapError(gorpmapping.Insert(db, l), "unable to insert role link for role %d and group %d", l.RoleID, l.GroupID)
+}
+
+// DeleteLink removes given role link from database.
+func DeleteLink(db gorp.SqlExecutor, l *sdk.RoleLink) error {
+	return sdk.WrapError(gorpmapping.Delete(db, l), "unable to delete role link %d", l.ID)
+}
+
+// DeleteLinksByGroupIDAndProjectID removes all role links of given group on a project and its workflows.
+func DeleteLinksByGroupIDAndProjectID(db gorp.SqlExecutor, groupID, projectID int64) error {
+	_, err := db.Exec("DELETE FROM permission_role_link WHERE group_id = $1 AND project_id = $2", groupID, projectID)
+	return sdk.WrapError(err, "unable to delete role links for group %d on project %d", groupID, projectID)
+}
@@ -0,0 +1,13 @@
+package role
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(sdk.Role{}, "permission_role", true, "id"),
+		gorpmapping.New(sdk.RoleLink{}, "permission_role_link", true, "id"),
+	)
+}
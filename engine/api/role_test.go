@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/role"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_projectRoleActions(t *testing.T) {
+	api, db, router, end := newTestAPI(t)
+	defer end()
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	g := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	require.NoError(t, group.InsertLinkGroupProject(context.TODO(), db, &group.LinkGroupProject{
+		GroupID:   g.ID,
+		ProjectID: proj.ID,
+		Role:      sdk.PermissionRead,
+	}))
+	u, pass := assets.InsertLambdaUser(t, db, g)
+	admin, adminPass := assets.InsertAdminUser(t, db)
+
+	addVariable := func() int {
+		v := sdk.Variable{Name: sdk.RandomString(10), Type: sdk.StringVariable, Value: "value"}
+		uri := router.GetRoute("POST", api.addVariableInProjectHandler, map[string]string{
+			"permProjectKey": proj.Key,
+			"name":           v.Name,
+		})
+		require.NotEmpty(t, uri)
+		req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, v)
+		w := httptest.NewRecorder()
+		router.Mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// A group with read permission can't edit variables
+	assert.Equal(t, 403, addVariable())
+
+	// Actions of a role given to the group are allowed
+	ro := sdk.Role{Name: sdk.RandomString(10), Actions: sdk.StringSlice{sdk.RoleActionRead, sdk.RoleActionEditVariables}}
+	require.NoError(t, role.Insert(db, &ro))
+	require.NoError(t, role.InsertLink(db, &sdk.RoleLink{RoleID: ro.ID, GroupID: g.ID, ProjectID: proj.ID}))
+	assert.Equal(t, 200, addVariable())
+
+	// Roles are removed with the group permission on the project
+	uri := router.GetRoute("DELETE", api.deleteGroupFromProjectHandler, map[string]string{
+		"permProjectKey": proj.Key,
+		"groupName":      g.Name,
+	})
+	require.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, admin, adminPass, "DELETE", uri, nil)
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	ls, err := role.LoadLinksByProjectID(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	assert.Len(t, ls, 0)
+	assert.NotEqual(t, 200, addVariable())
+}
@@ -172,6 +172,7 @@ var headers = []string{
 	http.CanonicalHeaderKey(sdk.WorkflowAsCodeHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowIDHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowNameHeader),
+	http.CanonicalHeaderKey(sdk.ResponseAsCodeOperationHeader),
 }
 
 // DefaultHeaders is a set of default header for the router
@@ -621,6 +622,32 @@ func Auth(v bool) HandlerConfigParam {
 	return f
 }
 
+// AllowWithoutMFA allows access to the route for sessions without second factor even if required by the policy
+func AllowWithoutMFA() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.AllowWithoutMFA = true
+	}
+	return f
+}
+
+// AllowRestricted allows access to a route that is not related to a project for consumers restricted to some projects,
+// the handler should filter returned data with consumer restrictions.
+func AllowRestricted() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.AllowRestricted = true
+	}
+	return f
+}
+
+// RequireAction sets the role action required on project and workflow for the route, by default the action
+// matching the route permission level is required.
+func RequireAction(action string) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.PermissionAction = action
+	}
+	return f
+}
+
 // MaintenanceAware route need CDS maintenance off
 func MaintenanceAware() HandlerConfigParam {
 	f := func(rc *service.HandlerConfig) {
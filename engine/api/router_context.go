@@ -13,6 +13,7 @@ const (
 	contextJWTRaw
 	contextDate
 	contextJWTFromCookie
+	contextPermissionAction
 )
 
 // ContextValues retuns auth values of a context
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/authentication/local"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/user"
@@ -74,6 +75,10 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 		if c.Disabled {
 			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is disabled", c.ID)
 		}
+		// If the consumer is expired, return an error
+		if c.IsExpired() {
+			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is expired", c.ID)
+		}
 		// If the driver was disabled for the consumer that was found, ignore it
 		if _, ok := api.AuthenticationDrivers[c.Type]; ok {
 			// Add contacts for consumer's user
@@ -131,8 +136,15 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 			}
 		}
 
+		// If the second factor is required by the local driver policy, sessions without it can only access to enrollment routes
+		if consumer.Type == sdk.ConsumerLocal && session != nil && !session.MFA && !rc.AllowWithoutMFA {
+			if d, ok := api.AuthenticationDrivers[sdk.ConsumerLocal].(*local.AuthDriver); ok && d.IsMFARequired(consumer.AuthentifiedUser) {
+				return ctx, sdk.WithStack(sdk.ErrMFARequired)
+			}
+		}
+
 		// Check that permission are valid for current route and consumer
-		if err := api.checkPermission(ctx, mux.Vars(req), rc.PermissionLevel); err != nil {
+		if err := api.checkPermission(ctx, rc, mux.Vars(req)); err != nil {
 			return ctx, err
 		}
 
@@ -144,6 +156,8 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 				return ctx, err
 			}
 		}
+
+		api.recordConsumerActivity(ctx, req, consumer)
 	}
 
 	// If we set Auth(false) on a handler, with should have a consumer in the context if a valid JWT is given
@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
 
 	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/group"
@@ -41,10 +42,18 @@ func permissionFunc(api *API) map[string]PermCheckFunc {
 	}
 }
 
-func (api *API) checkPermission(ctx context.Context, routeVar map[string]string, permission int) error {
+func (api *API) checkPermission(ctx context.Context, rc *service.HandlerConfig, routeVar map[string]string) error {
+	if err := checkConsumerRestrictions(getAPIConsumer(ctx), rc, routeVar); err != nil {
+		return err
+	}
+
+	if rc.PermissionAction != "" {
+		ctx = context.WithValue(ctx, contextPermissionAction, rc.PermissionAction)
+	}
+
 	for key, value := range routeVar {
 		if permFunc, ok := permissionFunc(api)[key]; ok {
-			if err := permFunc(ctx, value, permission, routeVar); err != nil {
+			if err := permFunc(ctx, value, rc.PermissionLevel, routeVar); err != nil {
 				return err
 			}
 		}
@@ -52,6 +61,43 @@ func (api *API) checkPermission(ctx context.Context, routeVar map[string]string,
 	return nil
 }
 
+// checkConsumerRestrictions checks that a consumer restricted to some projects and workflows is allowed on the route.
+// The route permission level should not be greater than the consumer max permission, and routes that are not
+// related to a project are denied unless explicitly allowed for restricted consumers.
+func checkConsumerRestrictions(c *sdk.AuthConsumer, rc *service.HandlerConfig, routeVars map[string]string) error {
+	if c == nil || c.Restrictions == nil {
+		return nil
+	}
+
+	if rc.PermissionLevel > c.Restrictions.MaxPermission {
+		return sdk.WrapError(sdk.ErrForbidden, "permission level %d not allowed for restricted consumer %s", rc.PermissionLevel, c.ID)
+	}
+
+	projectKey := routeVars["permProjectKey"]
+	if projectKey == "" {
+		projectKey = routeVars["key"]
+	}
+	if projectKey == "" {
+		if !rc.AllowRestricted {
+			return sdk.WrapError(sdk.ErrForbidden, "route %s %s not allowed for restricted consumer %s", rc.Method, rc.CleanURL, c.ID)
+		}
+		return nil
+	}
+	if !c.Restrictions.AllowProject(projectKey) {
+		return sdk.WrapError(sdk.ErrForbidden, "project %s not allowed for restricted consumer %s", projectKey, c.ID)
+	}
+
+	workflowName := routeVars["permWorkflowName"]
+	if workflowName == "" {
+		workflowName = routeVars["workflowName"]
+	}
+	if workflowName != "" && !c.Restrictions.AllowWorkflow(projectKey, workflowName) {
+		return sdk.WrapError(sdk.ErrForbidden, "workflow %s/%s not allowed for restricted consumer %s", projectKey, workflowName, c.ID)
+	}
+
+	return nil
+}
+
 func (api *API) checkJobIDPermissions(ctx context.Context, jobID string, perm int, routeVars map[string]string) error {
 	ctx, end := observability.Span(ctx, "api.checkJobIDPermissions")
 	defer end()
@@ -100,29 +146,41 @@ func (api *API) checkJobIDPermissions(ctx context.Context, jobID string, perm in
 	return nil
 }
 
+// getPermissionAction returns the action required by current route, if no specific action was set on the
+// route it's the action matching the route permission level.
+func getPermissionAction(ctx context.Context, perm int) string {
+	if action, ok := ctx.Value(contextPermissionAction).(string); ok && action != "" {
+		return action
+	}
+	return sdk.RoleActionForLevel(perm)
+}
+
 func (api *API) checkProjectPermissions(ctx context.Context, projectKey string, requiredPerm int, routeVars map[string]string) error {
 	ctx, end := observability.Span(ctx, "api.checkProjectPermissions")
 	defer end()
 
-	if _, err := project.Load(api.mustDB(),  projectKey); err != nil {
+	if _, err := project.Load(api.mustDB(), projectKey); err != nil {
 		return err
 	}
 
-	perms, err := permission.LoadProjectMaxLevelPermission(ctx, api.mustDB(), []string{projectKey}, getAPIConsumer(ctx).GetGroupIDs())
+	requiredAction := getPermissionAction(ctx, requiredPerm)
+	actions, err := permission.LoadProjectActions(ctx, api.mustDB(), projectKey, getAPIConsumer(ctx).GetGroupIDs())
 	if err != nil {
-		return sdk.WrapError(err, "cannot get max project permissions for %s", projectKey)
+		return sdk.WrapError(err, "cannot get project actions for %s", projectKey)
 	}
 
-	callerPermission := perms.Level(projectKey)
-	// If the caller based on its group doesn't have enough permission level
-	if callerPermission < requiredPerm {
-		log.Debug("checkProjectPermissions> callerPermission=%d ", callerPermission)
+	// If the caller based on its groups and roles is not allowed to do the action
+	if !actions.Has(requiredAction) {
+		log.Debug("checkProjectPermissions> caller actions=%v", actions.List())
 		// If it's about READ: we have to check if the user is a maintainer or an admin
-		if requiredPerm == sdk.PermissionRead {
+		if sdk.IsReadOnlyRoleAction(requiredAction) {
 			if !isMaintainer(ctx) {
-				// The caller doesn't enough permission level from its groups and is neither a maintainer nor an admin
+				// The caller doesn't have the action from its groups and is neither a maintainer nor an admin
 				log.Debug("checkProjectPermissions> %s(%s) is not authorized to %s", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey)
-				return sdk.WrapError(sdk.ErrNoProject, "not authorized for project %s", projectKey)
+				if !actions.Has(sdk.RoleActionRead) {
+					return sdk.WrapError(sdk.ErrNoProject, "not authorized for project %s", projectKey)
+				}
+				return sdk.WrapError(sdk.ErrForbidden, "not authorized to %s on project %s", requiredAction, projectKey)
 			}
 			log.Debug("checkProjectPermissions> %s(%s) access granted to %s because is maintainer", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey)
 			observability.Current(ctx, observability.Tag(observability.TagPermission, "is_maintainer"))
@@ -131,15 +189,15 @@ func (api *API) checkProjectPermissions(ctx context.Context, projectKey string,
 
 		// If it's about Execute of Write: we have to check if the user is an admin
 		if !isAdmin(ctx) {
-			// The caller doesn't enough permission level from its groups and is not an admin
+			// The caller doesn't have the action from its groups and is not an admin
 			log.Debug("checkProjectPermissions> %s(%s) is not authorized to %s", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey)
-			return sdk.WrapError(sdk.ErrForbidden, "not authorized for project %s", projectKey)
+			return sdk.WrapError(sdk.ErrForbidden, "not authorized to %s on project %s", requiredAction, projectKey)
 		}
 		log.Debug("checkProjectPermissions> %s(%s) access granted to %s because is admin", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey)
 		observability.Current(ctx, observability.Tag(observability.TagPermission, "is_admin"))
 		return nil
 	}
-	log.Debug("checkWorkflowPermissions> %s(%s) access granted to %s because has permission (max permission = %d)", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey, callerPermission)
+	log.Debug("checkProjectPermissions> %s(%s) access granted to %s because has action %s", getAPIConsumer(ctx).Name, getAPIConsumer(ctx).ID, projectKey, requiredAction)
 	observability.Current(ctx, observability.Tag(observability.TagPermission, "is_granted"))
 	return nil
 }
@@ -168,20 +226,19 @@ func (api *API) checkWorkflowPermissions(ctx context.Context, workflowName strin
 		return sdk.WithStack(sdk.ErrNotFound)
 	}
 
-	perms, err := permission.LoadWorkflowMaxLevelPermission(ctx, api.mustDB(), projectKey, []string{workflowName}, getAPIConsumer(ctx).GetGroupIDs())
+	requiredAction := getPermissionAction(ctx, perm)
+	actions, err := permission.LoadWorkflowActions(ctx, api.mustDB(), projectKey, workflowName, getAPIConsumer(ctx).GetGroupIDs())
 	if err != nil {
 		return sdk.NewError(sdk.ErrForbidden, err)
 	}
 
-	maxLevelPermission := perms.Level(workflowName)
-
-	if maxLevelPermission < perm { // If the caller based on its group doesn have enough permission level
+	if !actions.Has(requiredAction) { // If the caller based on its groups and roles is not allowed to do the action
 		// If it's about READ: we have to check if the user is a maintainer or an admin
-		if perm < sdk.PermissionReadExecute {
+		if sdk.IsReadOnlyRoleAction(requiredAction) {
 			if !isMaintainer(ctx) {
-				// The caller doesn't enough permission level from its groups and is neither a maintainer nor an admin
+				// The caller doesn't have the action from its groups and is neither a maintainer nor an admin
 				log.Debug("checkWorkflowPermissions> %s is not authorized to %s/%s", getAPIConsumer(ctx).ID, projectKey, workflowName)
-				return sdk.WrapError(sdk.ErrForbidden, "not authorized for workflow %s/%s", projectKey, workflowName)
+				return sdk.WrapError(sdk.ErrForbidden, "not authorized to %s on workflow %s/%s", requiredAction, projectKey, workflowName)
 			}
 			log.Debug("checkWorkflowPermissions> %s access granted to %s/%s because is maintainer", getAPIConsumer(ctx).ID, projectKey, workflowName)
 			observability.Current(ctx, observability.Tag(observability.TagPermission, "is_maintainer"))
@@ -190,20 +247,29 @@ func (api *API) checkWorkflowPermissions(ctx context.Context, workflowName strin
 
 		// If it's about Execute of Write: we have to check if the user is an admin
 		if !isAdmin(ctx) {
-			// The caller doesn't enough permission level from its groups and is not an admin
+			// The caller doesn't have the action from its groups and is not an admin
 			log.Debug("checkWorkflowPermissions> %s is not authorized to %s/%s", getAPIConsumer(ctx).ID, projectKey, workflowName)
-			return sdk.WrapError(sdk.ErrForbidden, "not authorized for workflow %s/%s", projectKey, workflowName)
+			return sdk.WrapError(sdk.ErrForbidden, "not authorized to %s on workflow %s/%s", requiredAction, projectKey, workflowName)
 		}
 		log.Debug("checkWorkflowPermissions> %s access granted to %s/%s because is admin", getAPIConsumer(ctx).ID, projectKey, workflowName)
 		observability.Current(ctx, observability.Tag(observability.TagPermission, "is_admin"))
 		return nil
 
 	}
-	log.Debug("checkWorkflowPermissions> %s access granted to %s/%s because has permission (max permission = %d)", getAPIConsumer(ctx).ID, projectKey, workflowName, maxLevelPermission)
+	log.Debug("checkWorkflowPermissions> %s access granted to %s/%s because has action %s", getAPIConsumer(ctx).ID, projectKey, workflowName, requiredAction)
 	observability.Current(ctx, observability.Tag(observability.TagPermission, "is_granted"))
 	return nil
 }
 
+// hasWorkflowAction returns true if current consumer is allowed to do given action on a workflow.
+func (api *API) hasWorkflowAction(ctx context.Context, projectKey, workflowName, action string) (bool, error) {
+	actions, err := permission.LoadWorkflowActions(ctx, api.mustDB(), projectKey, workflowName, getAPIConsumer(ctx).GetGroupIDs())
+	if err != nil {
+		return false, err
+	}
+	return actions.Has(action), nil
+}
+
 func (api *API) checkGroupPermissions(ctx context.Context, groupName string, permissionValue int, routeVars map[string]string) error {
 	if groupName == "" {
 		return sdk.WrapError(sdk.ErrWrongRequest, "invalid given group name")
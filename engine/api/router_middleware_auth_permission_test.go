@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -1089,3 +1091,62 @@ func Test_checkActionBuiltinPermissions(t *testing.T) {
 	assert.Error(t, api.checkActionBuiltinPermissions(context.TODO(), sdk.RandomString(10), sdk.PermissionRead, nil), "error should be returned for random action name")
 	assert.NoError(t, api.checkActionBuiltinPermissions(context.TODO(), scriptAction.Name, sdk.PermissionRead, nil), "no error should be returned for valid action name")
 }
+
+func Test_checkConsumerRestrictions(t *testing.T) {
+	c := &sdk.AuthConsumer{
+		ID: sdk.UUID(),
+		Restrictions: &sdk.AuthConsumerRestrictions{
+			ProjectKeys:   []string{"PROJ1", "PROJ2"},
+			Workflows:     []string{"PROJ2/wf1"},
+			MaxPermission: sdk.PermissionReadExecute,
+		},
+	}
+
+	read := &service.HandlerConfig{Method: http.MethodGet, PermissionLevel: sdk.PermissionRead}
+	readExecute := &service.HandlerConfig{Method: http.MethodPost, PermissionLevel: sdk.PermissionReadExecute}
+	readWriteExecute := &service.HandlerConfig{Method: http.MethodPut, PermissionLevel: sdk.PermissionReadWriteExecute}
+
+	// Consumer without restrictions
+	assert.NoError(t, checkConsumerRestrictions(&sdk.AuthConsumer{}, readWriteExecute, map[string]string{"permProjectKey": "PROJ3"}))
+
+	// Allowed projects and workflows
+	assert.NoError(t, checkConsumerRestrictions(c, read, map[string]string{"permProjectKey": "PROJ1"}))
+	assert.NoError(t, checkConsumerRestrictions(c, readExecute, map[string]string{"key": "PROJ1", "permWorkflowName": "any"}))
+	assert.NoError(t, checkConsumerRestrictions(c, readExecute, map[string]string{"permProjectKey": "PROJ2", "permWorkflowName": "wf1"}))
+
+	// Not allowed projects and workflows
+	assert.Error(t, checkConsumerRestrictions(c, read, map[string]string{"permProjectKey": "PROJ3"}))
+	assert.Error(t, checkConsumerRestrictions(c, read, map[string]string{"permProjectKey": "PROJ2", "permWorkflowName": "wf2"}))
+
+	// Permission level greater than max permission
+	assert.Error(t, checkConsumerRestrictions(c, readWriteExecute, map[string]string{"permProjectKey": "PROJ1"}))
+
+	// Routes outside of projects are denied unless explicitly allowed
+	assert.Error(t, checkConsumerRestrictions(c, read, map[string]string{"permGroupName": "my-group"}))
+	assert.Error(t, checkConsumerRestrictions(c, readExecute, map[string]string{"permGroupName": "my-group"}))
+	assert.Error(t, checkConsumerRestrictions(c, read, map[string]string{"permJobID": "1"}))
+	assert.Error(t, checkConsumerRestrictions(c, read, nil))
+	assert.NoError(t, checkConsumerRestrictions(c, &service.HandlerConfig{Method: http.MethodGet, PermissionLevel: sdk.PermissionRead, AllowRestricted: true}, nil))
+}
+
+func Test_filterProjectsByConsumerRestrictions(t *testing.T) {
+	projects := []sdk.Project{
+		{Key: "PROJ1", Workflows: []sdk.Workflow{{Name: "wf1"}, {Name: "wf2"}}},
+		{Key: "PROJ2", Workflows: []sdk.Workflow{{Name: "wf1"}, {Name: "wf2"}}},
+		{Key: "PROJ3"},
+	}
+
+	assert.Len(t, filterProjectsByConsumerRestrictions(&sdk.AuthConsumer{}, projects), 3)
+
+	res := filterProjectsByConsumerRestrictions(&sdk.AuthConsumer{Restrictions: &sdk.AuthConsumerRestrictions{
+		ProjectKeys:   []string{"PROJ1", "PROJ2"},
+		Workflows:     []string{"PROJ2/wf1"},
+		MaxPermission: sdk.PermissionRead,
+	}}, projects)
+	require.Len(t, res, 2)
+	assert.Equal(t, "PROJ1", res[0].Key)
+	assert.Len(t, res[0].Workflows, 2)
+	assert.Equal(t, "PROJ2", res[1].Key)
+	require.Len(t, res[1].Workflows, 1)
+	assert.Equal(t, "wf1", res[1].Workflows[0].Name)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -197,3 +198,71 @@ func translate(r *http.Request, msgList []sdk.Message) []string {
 	}
 	return msgListString
 }
+
+// requestRemoteIP returns the ip of the client that sent the request. X-Forwarded-For and X-Real-IP headers
+// are only read if the request comes from a trusted proxy, the client ip is then the last address in X-Forwarded-For
+// that is not a trusted proxy. Header values that are not valid ips are ignored and the remote address is used.
+func requestRemoteIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remoteIP := net.ParseIP(host)
+	if remoteIP == nil {
+		return host
+	}
+	if !isTrustedProxy(remoteIP, trustedProxies) {
+		return remoteIP.String()
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ips := strings.Split(forwarded, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(ips[i]))
+			if ip == nil {
+				return remoteIP.String()
+			}
+			if i == 0 || !isTrustedProxy(ip, trustedProxies) {
+				return ip.String()
+			}
+		}
+	}
+	if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+	return remoteIP.String()
+}
+
+func isTrustedProxy(ip net.IP, trustedProxies []*net.IPNet) bool {
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies returns networks for given list of ips or CIDR networks.
+func parseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	res := make([]*net.IPNet, 0, len(values))
+	for _, v := range values {
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %s", v)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			res = append(res, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %v", v, err)
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
@@ -25,14 +25,76 @@ const (
 )
 
 var (
-	key    []byte
-	prefix = "3DICC3It"
+	key          []byte
+	keyID        string
+	previousKeys = map[string][]byte{}
+	prefix       = "3DICC3It"
+	// prefix for data encrypted with an identified key, it is followed by the key id and a separator
+	keyIDPrefix    = "3DICC3Ik"
+	keyIDSeparator = ":"
 )
 
 // Init secrets: cipherKey
 // cipherKey is set from viper configuration
 func Init(cipherKey string) {
+	InitWithKeys(cipherKey, "", nil)
+}
+
+// InitWithKeys secrets with an identified key used for encryption and previous keys by id
+// that are only used to decrypt existing data. Data encrypted before key ids were introduced
+// are decrypted with the key that has an empty id.
+func InitWithKeys(cipherKey, cipherKeyID string, previous map[string]string) {
 	key = []byte(cipherKey)
+	keyID = cipherKeyID
+	previousKeys = make(map[string][]byte, len(previous))
+	for id, k := range previous {
+		previousKeys[id] = []byte(k)
+	}
+}
+
+// CurrentKeyID returns the id of the key used for encryption.
+func CurrentKeyID() string {
+	return keyID
+}
+
+// KeyID returns the id of the key used to encrypt given data, the boolean is false if data is not encrypted.
+func KeyID(data []byte) (string, bool) {
+	id, _, encrypted := splitKeyID(data)
+	return id, encrypted
+}
+
+// NeedRotation returns true if given data was encrypted with another key than the current one.
+func NeedRotation(data []byte) bool {
+	id, encrypted := KeyID(data)
+	return encrypted && id != keyID
+}
+
+// splitKeyID returns the key id and the encrypted payload for given data.
+func splitKeyID(data []byte) (string, []byte, bool) {
+	s := string(data)
+	switch {
+	case strings.HasPrefix(s, keyIDPrefix):
+		s = strings.TrimPrefix(s, keyIDPrefix)
+		i := strings.Index(s, keyIDSeparator)
+		if i < 0 {
+			return "", nil, false
+		}
+		return s[:i], []byte(s[i+len(keyIDSeparator):]), true
+	case strings.HasPrefix(s, prefix):
+		return "", []byte(strings.TrimPrefix(s, prefix)), true
+	}
+	return "", nil, false
+}
+
+// getKey returns the key for given id.
+func getKey(id string) ([]byte, error) {
+	if id == keyID {
+		return key, nil
+	}
+	if k, ok := previousKeys[id]; ok {
+		return k, nil
+	}
+	return nil, sdk.WrapError(sdk.ErrSecretKeyFetchFailed, "unknown secret key with id %q", id)
 }
 
 // Encrypt data using aes+hmac algorithm
@@ -63,17 +125,25 @@ func Encrypt(data []byte) ([]byte, error) {
 	h.Write(ct)
 	ct = h.Sum(ct)
 
-	return append([]byte(prefix), ct...), nil
+	if keyID == "" {
+		return append([]byte(prefix), ct...), nil
+	}
+	return append([]byte(keyIDPrefix+keyID+keyIDSeparator), ct...), nil
 }
 
 // Decrypt data using aes+hmac algorithm
 // Init() must be called before any decryption
 func Decrypt(data []byte) ([]byte, error) {
-	if !strings.HasPrefix(string(data), prefix) {
+	id, payload, encrypted := splitKeyID(data)
+	if !encrypted {
 		return data, nil
 	}
-	data = []byte(strings.TrimPrefix(string(data), prefix))
+	data = payload
 
+	key, err := getKey(id)
+	if err != nil {
+		return nil, err
+	}
 	if key == nil {
 		log.Error(context.TODO(), "Missing key, init failed?")
 		return nil, sdk.WithStack(sdk.ErrSecretKeyFetchFailed)
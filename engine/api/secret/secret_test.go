@@ -99,3 +99,56 @@ func TestDecryptS(t *testing.T) {
 	}
 
 }
+
+func TestRotation(t *testing.T) {
+	oldKey := "78eKVxCGLm6gwoH9LAQ15ZD5AOABo1Xb"
+	newKey := "9fh3Kd8sLq2mZx7cVb4nT6yR1wE5uI0o"
+	data := []byte("Hello world !")
+
+	Init(oldKey)
+	legacy, err := Encrypt(data)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %s", err)
+	}
+	if id, encrypted := KeyID(legacy); !encrypted || id != "" {
+		t.Fatalf("Fail: legacy data should be encrypted without key id, got '%s'", id)
+	}
+
+	InitWithKeys(newKey, "2020-01", map[string]string{"": oldKey})
+	defer Init(oldKey)
+
+	if !NeedRotation(legacy) {
+		t.Fatalf("Fail: legacy data should need rotation")
+	}
+	clear, err := Decrypt(legacy)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %s", err)
+	}
+	if !bytes.Equal(clear, data) {
+		t.Fatalf("Fail: Expected '%s', got '%s'", data, clear)
+	}
+
+	ct, err := Encrypt(data)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %s", err)
+	}
+	if !bytes.HasPrefix(ct, []byte(keyIDPrefix+"2020-01"+keyIDSeparator)) {
+		t.Fatalf("Fail: encrypted data should contain the key id")
+	}
+	if NeedRotation(ct) {
+		t.Fatalf("Fail: data encrypted with current key should not need rotation")
+	}
+	clear, err = Decrypt(ct)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %s", err)
+	}
+	if !bytes.Equal(clear, data) {
+		t.Fatalf("Fail: Expected '%s', got '%s'", data, clear)
+	}
+
+	// Data encrypted with an unknown key can't be decrypted
+	InitWithKeys(oldKey, "2021-01", nil)
+	if _, err := Decrypt(ct); err == nil {
+		t.Fatalf("Decrypt should have failed with unknown key id")
+	}
+}
@@ -0,0 +1,166 @@
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	vaultClient      *vaultapi.Client
+	vaultMounts      []string
+	vaultProjectPath *template.Template
+)
+
+// InitVault initializes the client used to resolve vault variables. Variables can only reference secrets in given
+// KV engine mounts, under the path computed for their project from given template (ex: cds/{{.ProjectKey}}).
+func InitVault(addr, token string, mounts []string, projectPath string) error {
+	if len(mounts) == 0 {
+		return sdk.WithStack(fmt.Errorf("at least one vault mount should be given"))
+	}
+	tmpl, err := template.New("projectPath").Option("missingkey=error").Parse(projectPath)
+	if err != nil {
+		return sdk.WrapError(err, "invalid vault project path")
+	}
+	if !strings.Contains(projectPath, "{{") {
+		return sdk.WithStack(fmt.Errorf("vault project path %s should contain the project key", projectPath))
+	}
+
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return sdk.WrapError(err, "cannot create vault client")
+	}
+	if err := client.SetAddress(addr); err != nil {
+		return sdk.WrapError(err, "invalid vault address")
+	}
+	client.SetToken(token)
+	vaultClient = client
+	vaultMounts = make([]string, len(mounts))
+	for i := range mounts {
+		vaultMounts[i] = strings.Trim(mounts[i], "/")
+	}
+	vaultProjectPath = tmpl
+	return nil
+}
+
+// CheckVaultVariable returns an error if given vault variable references a secret outside of the paths
+// allowed for the project.
+func CheckVaultVariable(projectKey string, v sdk.Variable) error {
+	if v.Type != sdk.VaultVariable {
+		return nil
+	}
+	_, err := vaultSecretReference(projectKey, v)
+	return err
+}
+
+func vaultSecretReference(projectKey string, v sdk.Variable) (sdk.VaultSecretReference, error) {
+	ref, err := sdk.ParseVaultSecretReference(v.Value)
+	if err != nil {
+		return ref, err
+	}
+	if vaultClient == nil {
+		return ref, sdk.NewErrorFrom(sdk.ErrWrongRequest, "vault is not configured on this CDS instance")
+	}
+
+	var buf bytes.Buffer
+	if err := vaultProjectPath.Execute(&buf, struct{ ProjectKey string }{projectKey}); err != nil {
+		return ref, sdk.WrapError(err, "cannot compute vault path for project %s", projectKey)
+	}
+	projectPath := strings.Trim(buf.String(), "/")
+
+	for _, s := range strings.Split(ref.Path, "/") {
+		if s == "" || s == "." || s == ".." {
+			return ref, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid vault secret path %s", ref.Path)
+		}
+	}
+	for _, m := range vaultMounts {
+		// The data segment is given for KV version 2 engines
+		for _, prefix := range []string{m + "/" + projectPath + "/", m + "/data/" + projectPath + "/"} {
+			if strings.HasPrefix(ref.Path, prefix) {
+				return ref, nil
+			}
+		}
+	}
+	return ref, sdk.NewErrorFrom(sdk.ErrWrongRequest, "vault secret %s is not allowed, secrets of project %s should be stored under %s in one of the mounts %s",
+		ref.Path, projectKey, projectPath, strings.Join(vaultMounts, ", "))
+}
+
+// ResolveVaultVariable reads the secret referenced by given vault variable of a project and replaces its value.
+// The variable type is set to secret so the value will be masked like other secrets.
+func ResolveVaultVariable(projectKey string, v *sdk.Variable) error {
+	ref, err := vaultSecretReference(projectKey, *v)
+	if err != nil {
+		return err
+	}
+
+	data, err := readVaultSecret(ref)
+	if err != nil {
+		return err
+	}
+
+	field := ref.Field
+	if field == "" {
+		if len(data) != 1 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a field should be given for vault secret %s that contains %d fields", ref.Path, len(data))
+		}
+		for k := range data {
+			field = k
+		}
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "field %s not found in vault secret %s", field, ref.Path)
+	}
+
+	v.Value = fmt.Sprintf("%v", value)
+	v.Type = sdk.SecretVariable
+	return nil
+}
+
+// readVaultSecret returns the fields of the secret for given reference, both KV version 1 and 2 engines are supported.
+func readVaultSecret(ref sdk.VaultSecretReference) (map[string]interface{}, error) {
+	r := vaultClient.NewRequest(http.MethodGet, "/v1/"+ref.Path)
+	if ref.Version > 0 {
+		r.Params.Set("version", strconv.Itoa(ref.Version))
+	}
+
+	resp, err := vaultClient.RawRequest(r)
+	if resp != nil {
+		defer resp.Body.Close() // nolint
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "vault secret %s not found", ref)
+	}
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot read vault secret %s", ref)
+	}
+
+	s, err := vaultapi.ParseSecret(resp.Body)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot parse vault secret %s", ref)
+	}
+	if s == nil || s.Data == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "vault secret %s not found", ref)
+	}
+
+	// KV version 2 engine wraps secret fields in a data key next to metadata
+	if data, ok := s.Data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := s.Data["metadata"]; hasMetadata {
+			return data, nil
+		}
+	}
+	if data, ok := s.Data["data"]; ok && data == nil {
+		// Deleted version on a KV version 2 engine
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "vault secret %s not found", ref)
+	}
+
+	return s.Data, nil
+}
@@ -0,0 +1,116 @@
+package secret
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func newTestVaultServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/cds/PROJ/my-app":
+			value := "v2-latest"
+			if r.URL.Query().Get("version") == "1" {
+				value = "v2-first"
+			}
+			w.Write([]byte(`{"data":{"data":{"password":"` + value + `","user":"cds"},"metadata":{"version":2}}}`)) // nolint
+		case "/v1/kv/cds/PROJ/my-app", "/v1/kv/cds/OTHER/my-app":
+			w.Write([]byte(`{"data":{"password":"v1"}}`)) // nolint
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`)) // nolint
+		}
+	}))
+}
+
+func TestResolveVaultVariable(t *testing.T) {
+	vaultClient = nil
+	v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: "kv/cds/PROJ/my-app"}
+	if err := ResolveVaultVariable("PROJ", &v); err == nil {
+		t.Fatalf("ResolveVaultVariable should fail when vault is not configured")
+	}
+
+	srv := newTestVaultServer(t)
+	defer srv.Close()
+	if err := InitVault(srv.URL, "my-token", nil, "cds/{{.ProjectKey}}"); err == nil {
+		t.Fatalf("InitVault should fail without mount")
+	}
+	if err := InitVault(srv.URL, "my-token", []string{"secret"}, "cds"); err == nil {
+		t.Fatalf("InitVault should fail with a project path without project key")
+	}
+	if err := InitVault(srv.URL, "my-token", []string{"secret", "/kv/"}, "cds/{{.ProjectKey}}"); err != nil {
+		t.Fatalf("InitVault failed: %v", err)
+	}
+	defer func() { vaultClient = nil }()
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "secret/data/cds/PROJ/my-app#password", want: "v2-latest"},
+		{value: "secret/data/cds/PROJ/my-app#password@1", want: "v2-first"},
+		{value: "secret/data/cds/PROJ/my-app", wantErr: true},
+		{value: "secret/data/cds/PROJ/my-app#unknown", wantErr: true},
+		{value: "kv/cds/PROJ/my-app", want: "v1"},
+		{value: "kv/cds/PROJ/unknown#password", wantErr: true},
+		// Secrets outside of the project path or of allowed mounts are rejected
+		{value: "kv/cds/OTHER/my-app", wantErr: true},
+		{value: "kv/cds/PROJ/../OTHER/my-app", wantErr: true},
+		{value: "sys/policy/root", wantErr: true},
+		{value: "other/cds/PROJ/my-app", wantErr: true},
+	}
+	for _, tt := range tests {
+		v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: tt.value}
+		err := ResolveVaultVariable("PROJ", &v)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ResolveVaultVariable(%s) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if tt.wantErr {
+			continue
+		}
+		if v.Value != tt.want {
+			t.Errorf("ResolveVaultVariable(%s) = %s, want %s", tt.value, v.Value, tt.want)
+		}
+		if v.Type != sdk.SecretVariable {
+			t.Errorf("ResolveVaultVariable(%s) type = %s, want %s", tt.value, v.Type, sdk.SecretVariable)
+		}
+	}
+
+	if err := CheckVaultVariable("PROJ", sdk.Variable{Type: sdk.VaultVariable, Value: "kv/cds/PROJ/my-app"}); err != nil {
+		t.Errorf("CheckVaultVariable failed: %v", err)
+	}
+	if err := CheckVaultVariable("PROJ", sdk.Variable{Type: sdk.VaultVariable, Value: "kv/cds/OTHER/my-app"}); err == nil {
+		t.Errorf("CheckVaultVariable should fail for a secret of another project")
+	}
+}
+
+// TestResolveVaultVariableDevServer runs against a vault started with 'vault server -dev' and
+// a secret created with 'vault kv put secret/cds/PROJ/app password=my-password'.
+func TestResolveVaultVariableDevServer(t *testing.T) {
+	addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		t.Skip("VAULT_ADDR and VAULT_TOKEN should be set to run this test")
+	}
+	if err := InitVault(addr, token, []string{"secret"}, "cds/{{.ProjectKey}}"); err != nil {
+		t.Fatalf("InitVault failed: %v", err)
+	}
+	defer func() { vaultClient = nil }()
+
+	v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: "secret/data/cds/PROJ/app#password"}
+	if err := ResolveVaultVariable("PROJ", &v); err != nil {
+		t.Fatalf("ResolveVaultVariable failed: %v", err)
+	}
+	if v.Value != "my-password" {
+		t.Errorf("ResolveVaultVariable = %s, want my-password", v.Value)
+	}
+}
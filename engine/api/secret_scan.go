@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// secretScanConfig returns the configuration given to the worker to scan logs and artifacts of a job of given project.
+func (api *API) secretScanConfig(ctx context.Context, p *sdk.Project) (*sdk.SecretScanConfig, error) {
+	allowlist, err := project.LoadSecretScanAllowlist(ctx, api.mustDB(), p.ID)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &sdk.SecretScanConfig{
+		Rules: api.Config.SecretScan.Rules,
+	}
+	if len(cfg.Rules) == 0 {
+		cfg.Rules = sdk.DefaultSecretScanRules
+	}
+	for _, e := range allowlist {
+		cfg.Allowlist = append(cfg.Allowlist, e.Pattern)
+	}
+	return cfg, nil
+}
+
+func (api *API) getSecretScanAllowlistHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+
+		es, err := project.LoadSecretScanAllowlist(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, es, http.StatusOK)
+	}
+}
+
+func (api *API) postSecretScanAllowlistEntryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var e sdk.SecretScanAllowlistEntry
+		if err := service.UnmarshalBody(r, &e); err != nil {
+			return err
+		}
+		if err := e.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+		e.ProjectID = p.ID
+
+		if err := project.InsertSecretScanAllowlistEntry(api.mustDB(), &e); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, e, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteSecretScanAllowlistEntryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), vars[permProjectKey])
+		if err != nil {
+			return err
+		}
+
+		e, err := project.LoadSecretScanAllowlistEntryByID(ctx, api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := project.DeleteSecretScanAllowlistEntry(api.mustDB(), e); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postWorkflowJobSecretScanHandler receives secrets detected by a worker, it adds a warning on steps and spawn infos on job.
+func (api *API) postWorkflowJobSecretScanHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if ok := isWorker(ctx); !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		observability.Current(ctx, observability.Tag(observability.TagWorkflowNodeJobRun, id))
+
+		var findings []sdk.SecretScanFinding
+		if err := service.UnmarshalBody(r, &findings); err != nil {
+			return err
+		}
+		if len(findings) == 0 {
+			return nil
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		jobRun, err := workflow.LoadAndLockNodeJobRunWait(ctx, tx, api.Cache, id)
+		if err != nil {
+			return err
+		}
+
+		infos := make([]sdk.SpawnInfo, 0, len(findings))
+		for _, f := range findings {
+			where := f.Source
+			if f.Filename != "" {
+				where = fmt.Sprintf("%s %s", f.Source, f.Filename)
+			}
+			for i := range jobRun.Job.StepStatus {
+				if jobRun.Job.StepStatus[i].StepOrder == f.StepOrder {
+					jobRun.Job.StepStatus[i].Warnings = append(jobRun.Job.StepStatus[i].Warnings,
+						fmt.Sprintf("%d secret(s) matching rule %s detected in %s", f.Count, f.Rule, where))
+				}
+			}
+			infos = append(infos, sdk.SpawnInfo{
+				RemoteTime: getRemoteTime(ctx),
+				Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoSecretDetected.ID, Args: []interface{}{f.Count, f.Rule, where, f.StepOrder + 1}},
+			})
+		}
+
+		if err := workflow.UpdateNodeJobRun(ctx, tx, jobRun); err != nil {
+			return err
+		}
+		if err := workflow.AddSpawnInfosNodeJobRun(tx, jobRun.WorkflowNodeRunID, jobRun.ID, infos); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), jobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			log.Warning(ctx, "postWorkflowJobSecretScanHandler> Unable to load node run for event: %v", err)
+			return nil
+		}
+		wr, err := workflow.LoadRunByID(api.mustDB(), nodeRun.WorkflowRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			log.Warning(ctx, "postWorkflowJobSecretScanHandler> Unable to load workflow run for event: %v", err)
+			return nil
+		}
+		event.PublishWorkflowNodeJobRunSecretDetected(ctx, wr.Workflow.ProjectKey, *wr, *jobRun, findings)
+
+		return nil
+	}
+}
@@ -30,8 +30,22 @@ func (api *API) addStageHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "addStageHandler")
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, projectKey, pipelineKey, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				stageData.BuildOrder = len(p.Stages) + 1
+				stageData.PipelineID = p.ID
+				stageData.Enabled = true
+				p.Stages = append(p.Stages, *stageData)
+				asCodePip = p
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		stageData.BuildOrder = len(pipelineData.Stages) + 1
@@ -119,8 +133,21 @@ func (api *API) moveStageHandler() service.Handler {
 		if err != nil {
 			return err
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, projectKey, pipelineKey, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				if err := moveAsCodeStage(p, stageData.ID, stageData.BuildOrder); err != nil {
+					return err
+				}
+				asCodePip = p
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		// count stage for this pipeline
@@ -196,8 +223,27 @@ func (api *API) updateStageHandler() service.Handler {
 		if err != nil {
 			return err
 		}
+
+		// Updates of an as code pipeline are pushed to its repository
 		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, projectKey, pipelineKey, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				for i := range p.Stages {
+					if p.Stages[i].ID == stageData.ID {
+						stageData.PipelineID = p.ID
+						stageData.BuildOrder = p.Stages[i].BuildOrder
+						stageData.Jobs = p.Stages[i].Jobs
+						p.Stages[i] = *stageData
+						asCodePip = p
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrNotFound, "Cannot Load stage")
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
 		}
 
 		// check if stage exist
@@ -252,15 +298,34 @@ func (api *API) deleteStageHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Cannot load pipeline %s", pipelineKey)
 		}
-		if pipelineData.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
 
 		stageID, err := strconv.ParseInt(stageIDString, 10, 60)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrInvalidID, "deleteStageHandler> Stage ID must be an int: %s", err)
 		}
 
+		// Updates of an as code pipeline are pushed to its repository
+		if pipelineData.FromRepository != "" {
+			var asCodePip *sdk.Pipeline
+			ope, err := api.updateAsCodePipeline(ctx, projectKey, pipelineKey, FormString(r, "branch"), FormString(r, "message"), func(p *sdk.Pipeline) error {
+				for i := range p.Stages {
+					if p.Stages[i].ID == stageID {
+						p.Stages = append(p.Stages[:i], p.Stages[i+1:]...)
+						for j := range p.Stages {
+							p.Stages[j].BuildOrder = j + 1
+						}
+						asCodePip = p
+						return nil
+					}
+				}
+				return sdk.WrapError(sdk.ErrNotFound, "Cannot Load stage")
+			})
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, asCodePip)
+		}
+
 		// check if stage exist
 		s, err := pipeline.LoadStage(api.mustDB(), pipelineData.ID, stageID)
 		if err != nil {
@@ -334,3 +399,29 @@ func (api *API) getStageConditionsHandler() service.Handler {
 		return service.WriteJSON(w, data, http.StatusOK)
 	}
 }
+
+// moveAsCodeStage moves the given stage of an as code pipeline to the given build order
+func moveAsCodeStage(p *sdk.Pipeline, stageID int64, buildOrder int) error {
+	index := -1
+	for i := range p.Stages {
+		if p.Stages[i].ID == stageID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return sdk.WrapError(sdk.ErrNotFound, "Cannot load stage")
+	}
+	if buildOrder > len(p.Stages) {
+		return nil
+	}
+
+	stage := p.Stages[index]
+	stages := append(p.Stages[:index:index], p.Stages[index+1:]...)
+	stages = append(stages[:buildOrder-1], append([]sdk.Stage{stage}, stages[buildOrder-1:]...)...)
+	for i := range stages {
+		stages[i].BuildOrder = i + 1
+	}
+	p.Stages = stages
+	return nil
+}
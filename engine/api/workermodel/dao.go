@@ -2,6 +2,7 @@ package workermodel
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"sort"
 	"time"
@@ -336,6 +337,77 @@ func Delete(db gorp.SqlExecutor, ID int64) error {
 	return nil
 }
 
+// CountPasswordsWithOtherSecretKey returns the number of private docker worker models with a password
+// that was not encrypted with given secret key.
+func CountPasswordsWithOtherSecretKey(db gorp.SqlExecutor, keyID string) (int, error) {
+	var passwords []string
+	if _, err := db.Select(&passwords, "select model->>'password' from worker_model where type = $1 and coalesce(model->>'password', '') <> ''", sdk.Docker); err != nil {
+		return 0, sdk.WithStack(err)
+	}
+
+	var count int
+	for _, password := range passwords {
+		encrypted, err := base64.StdEncoding.DecodeString(password)
+		if err != nil {
+			continue
+		}
+		if id, ok := secret.KeyID(encrypted); ok && id != keyID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// LoadIDsWithPassword returns ids of all private docker worker models with a password.
+func LoadIDsWithPassword(db gorp.SqlExecutor) ([]int64, error) {
+	var ids []int64
+	if _, err := db.Select(&ids, "select id from worker_model where type = $1 and coalesce(model->>'password', '') <> '' order by id", sdk.Docker); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return ids, nil
+}
+
+// RotatePasswordSecret encrypts again the docker password of given worker model if it was encrypted with a
+// previous secret key. Returns true if data was updated.
+func RotatePasswordSecret(db gorp.SqlExecutor, id int64) (bool, error) {
+	var password string
+	if err := db.QueryRow("select coalesce(model->>'password', '') from worker_model where id = $1", id).Scan(&password); err != nil {
+		return false, sdk.WithStack(err)
+	}
+	if password == "" {
+		return false, nil
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(password)
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot decode password for worker model %d", id)
+	}
+	if !secret.NeedRotation(encrypted) {
+		return false, nil
+	}
+
+	clearPassword, err := secret.DecryptValue(password)
+	if err != nil {
+		return false, err
+	}
+	newPassword, err := secret.EncryptValue(clearPassword)
+	if err != nil {
+		return false, err
+	}
+
+	// Data is only updated if it was not changed since it was loaded
+	res, err := db.Exec("update worker_model set model = jsonb_set(model, '{password}', to_jsonb($3::text)) where id = $1 and model->>'password' = $2", id, password, newPassword)
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+
+	return n > 0, nil
+}
+
 // LoadCapabilities retrieves capabilities of given worker model.
 func LoadCapabilities(db gorp.SqlExecutor, workerID int64) (sdk.RequirementList, error) {
 	query := `
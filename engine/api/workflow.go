@@ -439,15 +439,20 @@ func (api *API) putWorkflowHandler() service.Handler {
 			return sdk.WrapError(err, "cannot load Workflow %s", key)
 		}
 
-		if oldW.FromRepository != "" {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
-
 		var wf sdk.Workflow
 		if err := service.UnmarshalBody(r, &wf); err != nil {
 			return sdk.WrapError(err, "cannot read body")
 		}
 
+		// Updates of an as code workflow are pushed to its repository
+		if oldW.FromRepository != "" {
+			ope, err := api.updateAsCodeWorkflow(ctx, key, name, wf, FormString(r, "branch"), FormString(r, "message"))
+			if err != nil {
+				return err
+			}
+			return writeAsCodeAccepted(w, ope, wf)
+		}
+
 		if err := workflow.RenameNode(ctx, api.mustDB(), &wf); err != nil {
 			return sdk.WrapError(err, "cannot check pipeline name")
 		}
@@ -15,8 +15,8 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 )
 
-// UpdateWorkflowAsCode update an as code workflow.
-func UpdateWorkflowAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, wf sdk.Workflow, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, u *sdk.AuthentifiedUser) (*sdk.Operation, error) {
+// UpdateWorkflowAsCode update an as code workflow, old workflow is used to detect conflicts with the repository.
+func UpdateWorkflowAsCode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj sdk.Project, wf, old sdk.Workflow, vcsServerName, repoFullname, branch, message string, vcsStrategy sdk.RepositoryStrategy, u *sdk.AuthentifiedUser) (*sdk.Operation, error) {
 	if err := RenameNode(ctx, db, &wf); err != nil {
 		return nil, err
 	}
@@ -35,7 +35,16 @@ func UpdateWorkflowAsCode(ctx context.Context, store cache.Store, db gorp.SqlExe
 		return nil, sdk.WithStack(sdk.ErrNotFound)
 	}
 
-	return operation.PushOperation(ctx, db, store, proj, wp, vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
+	var previous exportentities.WorkflowComponents
+	previous.Workflow, err = exportentities.NewWorkflow(ctx, old, v2.WorkflowSkipIfOnlyOneRepoWebhook)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to export workflow")
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("chore: Update workflow %s [@%s]", wf.Name, u.GetUsername())
+	}
+	return operation.PushOperation(ctx, db, store, proj, wp, previous, vcsServerName, repoFullname, branch, message, vcsStrategy, true, u)
 }
 
 // MigrateAsCode does a workflow pull and start an operation to push cds files into the git repository
@@ -61,5 +70,5 @@ func MigrateAsCode(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 	if branch == "" {
 		branch = fmt.Sprintf("cdsAsCode-%d", time.Now().Unix())
 	}
-	return operation.PushOperation(ctx, db, store, proj, pull, vcsServerName, repoFullname, branch, message, vcsStrategy, false, u)
+	return operation.PushOperation(ctx, db, store, proj, pull, exportentities.WorkflowComponents{}, vcsServerName, repoFullname, branch, message, vcsStrategy, false, u)
 }
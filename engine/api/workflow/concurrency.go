@@ -0,0 +1,184 @@
+package workflow
+
+import (
+	"context"
+	"math"
+	"unicode/utf8"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// concurrencyGroupMaxLength is the size of the workflow_run.concurrency_group column.
+const concurrencyGroupMaxLength = 256
+
+// lockWorkflowConcurrency serializes concurrency checks for all the runs of a workflow.
+func lockWorkflowConcurrency(db gorp.SqlExecutor, workflowID int64) error {
+	if _, err := db.Exec("SELECT id FROM workflow WHERE id = $1 FOR UPDATE", workflowID); err != nil {
+		return sdk.WrapError(err, "unable to lock workflow %d", workflowID)
+	}
+	return nil
+}
+
+// loadRunNumbersInProgressByConcurrencyGroup returns numbers of runs from the same concurrency
+// group that are not over and were created before given run id.
+func loadRunNumbersInProgressByConcurrencyGroup(db gorp.SqlExecutor, workflowID int64, group string, beforeID int64) ([]int64, error) {
+	var nums []int64
+	query := `
+		SELECT num FROM workflow_run
+		WHERE workflow_id = $1 AND concurrency_group = $2 AND id < $3 AND status IN ($4, $5)
+		ORDER BY num`
+	if _, err := db.Select(&nums, query, workflowID, group, beforeID, sdk.StatusWaiting, sdk.StatusBuilding); err != nil {
+		return nil, sdk.WrapError(err, "unable to load runs for concurrency group %s", group)
+	}
+	return nums, nil
+}
+
+// LoadRunsToCancelByConcurrencyGroup returns the runs from the same concurrency group than given run
+// that are not over, except the most recent one that should keep running. It also returns the number of this run.
+// Workflow concurrency is locked so the selection can't interleave with a run joining the group, it's released
+// when given transaction ends and the returned runs should be stopped after that.
+func LoadRunsToCancelByConcurrencyGroup(tx gorp.SqlExecutor, wr sdk.WorkflowRun) ([]sdk.WorkflowRun, int64, error) {
+	if wr.ConcurrencyGroup == "" {
+		return nil, 0, nil
+	}
+	if err := lockWorkflowConcurrency(tx, wr.WorkflowID); err != nil {
+		return nil, 0, err
+	}
+	nums, err := loadRunNumbersInProgressByConcurrencyGroup(tx, wr.WorkflowID, wr.ConcurrencyGroup, math.MaxInt64)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(nums) == 0 {
+		return nil, 0, nil
+	}
+	lastNum := nums[len(nums)-1]
+	runs := make([]sdk.WorkflowRun, 0, len(nums)-1)
+	for _, num := range nums[:len(nums)-1] {
+		query := `
+			SELECT ` + wfRunfields + `
+			FROM workflow_run
+			WHERE workflow_run.workflow_id = $1 AND workflow_run.num = $2`
+		r, err := loadRun(tx, LoadRunOptions{}, query, wr.WorkflowID, num)
+		if err != nil {
+			return nil, 0, sdk.WrapError(err, "unable to load workflow run %d", num)
+		}
+		runs = append(runs, *r)
+	}
+	return runs, lastNum, nil
+}
+
+// truncateConcurrencyGroup truncates given group to the size of the concurrency_group column without breaking a rune.
+func truncateConcurrencyGroup(group string) string {
+	if utf8.RuneCountInString(group) <= concurrencyGroupMaxLength {
+		return group
+	}
+	return string([]rune(group)[:concurrencyGroupMaxLength])
+}
+
+// checkConcurrencyGroup sets the concurrency group on the workflow run when its root node run is created.
+// It returns true if the root node run has to wait for older runs of the same group.
+func checkConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) (bool, error) {
+	c := wr.Workflow.Concurrency
+	if c == nil || nr.SubNumber > 0 || nr.WorkflowNodeID != wr.Workflow.WorkflowData.Node.ID || wr.ConcurrencyGroup != "" {
+		return false, nil
+	}
+
+	group, err := interpolate.Do(c.Group, sdk.ParametersToMap(nr.BuildParameters))
+	if err != nil {
+		return false, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to interpolate concurrency group %s: %v", c.Group, err)
+	}
+	group = truncateConcurrencyGroup(group)
+	wr.ConcurrencyGroup = group
+
+	if err := lockWorkflowConcurrency(db, wr.WorkflowID); err != nil {
+		return false, err
+	}
+
+	// Older runs will be stopped by the caller
+	if c.CancelInProgress {
+		return false, UpdateWorkflowRun(ctx, db, wr)
+	}
+
+	nums, err := loadRunNumbersInProgressByConcurrencyGroup(db, wr.WorkflowID, group, wr.ID)
+	if err != nil {
+		return false, err
+	}
+	if len(nums) == 0 {
+		return false, UpdateWorkflowRun(ctx, db, wr)
+	}
+
+	log.Debug("Noderun %s processed but not executed because of concurrency group %s", nr.WorkflowNodeName, group)
+	AddWorkflowRunInfo(wr, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowConcurrencyQueued.ID,
+		Args: []interface{}{nums[len(nums)-1], group},
+		Type: sdk.MsgWorkflowConcurrencyQueued.Type,
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return false, sdk.WrapError(err, "unable to update workflow run")
+	}
+	return true, nil
+}
+
+// ReleaseConcurrencyGroup executes the next queued run of the concurrency group once given run is over.
+func ReleaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wr *sdk.WorkflowRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+	if wr.ConcurrencyGroup == "" || !sdk.StatusIsTerminated(wr.Status) {
+		return report, nil
+	}
+
+	if err := lockWorkflowConcurrency(db, wr.WorkflowID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id FROM workflow_run
+		WHERE workflow_id = $1 AND concurrency_group = $2 AND status IN ($3, $4)
+		ORDER BY id
+		LIMIT 1`
+	nextID, err := db.SelectNullInt(query, wr.WorkflowID, wr.ConcurrencyGroup, sdk.StatusWaiting, sdk.StatusBuilding)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load next run for concurrency group %s", wr.ConcurrencyGroup)
+	}
+	if !nextID.Valid {
+		return report, nil
+	}
+
+	next, err := LoadRunByID(db, nextID.Int64, LoadRunOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the root node run of a queued run was created, if it's not waiting the run was already started
+	rootNodeRuns := next.WorkflowNodeRuns[next.Workflow.WorkflowData.Node.ID]
+	if len(next.WorkflowNodeRuns) != 1 || len(rootNodeRuns) != 1 || rootNodeRuns[0].Status != sdk.StatusWaiting {
+		return report, nil
+	}
+	nr := rootNodeRuns[0]
+
+	// The root node run could be blocked by a freeze window
+	if _, err := freezewindow.LoadPendingBlockByNodeRunID(ctx, db, nr.ID); err == nil {
+		return report, nil
+	} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	frozen, err := checkFreezeWindows(ctx, db, next, &next.Workflow.WorkflowData.Node, &nr)
+	if err != nil {
+		return nil, err
+	}
+	if frozen {
+		return report, nil
+	}
+
+	log.Debug("workflow.ReleaseConcurrencyGroup> process the node run %d because run %d is over", nr.ID, wr.Number)
+	return executeWaitingNodeRun(ctx, db, store, proj, next, &nr, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowConcurrencyRelease.ID,
+		Args: []interface{}{wr.Number, wr.ConcurrencyGroup, nr.WorkflowNodeName},
+		Type: sdk.MsgWorkflowConcurrencyRelease.Type,
+	})
+}
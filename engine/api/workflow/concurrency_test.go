@@ -0,0 +1,191 @@
+package workflow_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/go-gorp/gorp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func insertConcurrencyWorkflow(t *testing.T, db *gorp.DbMap, store cache.Store, concurrency sdk.WorkflowConcurrency) (*sdk.Project, *sdk.Workflow) {
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, store, key, key)
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+	}
+	require.NoError(t, pipeline.InsertPipeline(db, &pip))
+	s := sdk.NewStage("stage 1")
+	s.Enabled = true
+	s.PipelineID = pip.ID
+	require.NoError(t, pipeline.InsertStage(db, s))
+	j := &sdk.Job{
+		Enabled: true,
+		Action: sdk.Action{
+			Enabled: true,
+		},
+	}
+	require.NoError(t, pipeline.InsertJob(db, j, s.ID, &pip))
+
+	proj, err := project.LoadByID(db, proj.ID, project.LoadOptions.WithPipelines, project.LoadOptions.WithGroups)
+	require.NoError(t, err)
+
+	w := sdk.Workflow{
+		Name:        "test_concurrency",
+		ProjectID:   proj.ID,
+		ProjectKey:  proj.Key,
+		Concurrency: &concurrency,
+		WorkflowData: sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "node1",
+				Ref:  "node1",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+				},
+			},
+		},
+	}
+	require.NoError(t, workflow.Insert(context.TODO(), db, store, *proj, &w))
+
+	w1, err := workflow.Load(context.TODO(), db, store, *proj, w.Name, workflow.LoadOptions{DeepPipeline: true})
+	require.NoError(t, err)
+	return proj, w1
+}
+
+func startConcurrencyRun(t *testing.T, db *gorp.DbMap, store cache.Store, proj *sdk.Project, w *sdk.Workflow, u *sdk.AuthentifiedUser, branch string) *sdk.WorkflowRun {
+	consumer, err := authentication.LoadConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	require.NoError(t, err)
+
+	wr, err := workflow.CreateRun(db, w, nil, u)
+	require.NoError(t, err)
+	wr.Workflow = *w
+	_, err = workflow.StartWorkflowRun(context.TODO(), db, store, *proj, wr, &sdk.WorkflowRunPostHandlerOption{
+		Manual: &sdk.WorkflowNodeRunManual{
+			Username: u.Username,
+			Payload: map[string]string{
+				"git.branch": branch,
+			},
+		},
+	}, consumer, nil)
+	require.NoError(t, err)
+
+	wr, err = workflow.LoadRunByID(db, wr.ID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	return wr
+}
+
+func hasRunInfo(wr *sdk.WorkflowRun, msgID string) bool {
+	for _, info := range wr.Infos {
+		if info.Message.ID == msgID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConcurrencyGroupQueueAndRelease(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	u, _ := assets.InsertAdminUser(t, db)
+
+	proj, w := insertConcurrencyWorkflow(t, db, cache, sdk.WorkflowConcurrency{Group: "{{.cds.workflow}}-{{.git.branch}}"})
+
+	wr1 := startConcurrencyRun(t, db, cache, proj, w, u, "master")
+	assert.Equal(t, "test_concurrency-master", wr1.ConcurrencyGroup)
+	assert.Equal(t, sdk.StatusBuilding, wr1.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+
+	// A run on another branch is not in the same group
+	wrOther := startConcurrencyRun(t, db, cache, proj, w, u, "feat/other")
+	assert.Equal(t, "test_concurrency-feat/other", wrOther.ConcurrencyGroup)
+	assert.Equal(t, sdk.StatusBuilding, wrOther.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+
+	// A second run on the same branch is queued
+	wr2 := startConcurrencyRun(t, db, cache, proj, w, u, "master")
+	assert.Equal(t, "test_concurrency-master", wr2.ConcurrencyGroup)
+	assert.Equal(t, sdk.StatusWaiting, wr2.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+	assert.True(t, hasRunInfo(wr2, sdk.MsgWorkflowConcurrencyQueued.ID))
+
+	// Nothing is released while the first run is in progress
+	_, err := workflow.ReleaseConcurrencyGroup(context.TODO(), db, cache, *proj, wr1)
+	require.NoError(t, err)
+	wr2, err = workflow.LoadRunByID(db, wr2.ID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusWaiting, wr2.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+
+	// The queued run starts once the first one is over
+	wr1.Status = sdk.StatusStopped
+	require.NoError(t, workflow.UpdateWorkflowRun(context.TODO(), db, wr1))
+	_, err = workflow.ReleaseConcurrencyGroup(context.TODO(), db, cache, *proj, wr1)
+	require.NoError(t, err)
+	wr2, err = workflow.LoadRunByID(db, wr2.ID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusBuilding, wr2.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+	assert.True(t, hasRunInfo(wr2, sdk.MsgWorkflowConcurrencyRelease.ID))
+}
+
+func TestConcurrencyGroupCancelInProgress(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	u, _ := assets.InsertAdminUser(t, db)
+
+	proj, w := insertConcurrencyWorkflow(t, db, cache, sdk.WorkflowConcurrency{Group: "{{.cds.workflow}}", CancelInProgress: true})
+
+	wr1 := startConcurrencyRun(t, db, cache, proj, w, u, "master")
+	wr2 := startConcurrencyRun(t, db, cache, proj, w, u, "master")
+	wr3 := startConcurrencyRun(t, db, cache, proj, w, u, "master")
+
+	// New runs are never queued
+	for _, wr := range []*sdk.WorkflowRun{wr1, wr2, wr3} {
+		assert.Equal(t, "test_concurrency", wr.ConcurrencyGroup)
+		assert.Equal(t, sdk.StatusBuilding, wr.WorkflowNodeRuns[w.WorkflowData.Node.ID][0].Status)
+	}
+
+	// Whatever the run that checks the group, only the most recent one is kept
+	for _, wr := range []*sdk.WorkflowRun{wr1, wr3} {
+		runs, lastNum, err := workflow.LoadRunsToCancelByConcurrencyGroup(db, *wr)
+		require.NoError(t, err)
+		assert.Equal(t, wr3.Number, lastNum)
+		require.Len(t, runs, 2)
+		assert.Equal(t, wr1.ID, runs[0].ID)
+		assert.Equal(t, wr2.ID, runs[1].ID)
+	}
+
+	// Runs that are over are ignored
+	wr1.Status = sdk.StatusStopped
+	require.NoError(t, workflow.UpdateWorkflowRun(context.TODO(), db, wr1))
+	runs, lastNum, err := workflow.LoadRunsToCancelByConcurrencyGroup(db, *wr3)
+	require.NoError(t, err)
+	assert.Equal(t, wr3.Number, lastNum)
+	require.Len(t, runs, 1)
+	assert.Equal(t, wr2.ID, runs[0].ID)
+}
+
+func TestConcurrencyGroupTruncate(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	u, _ := assets.InsertAdminUser(t, db)
+
+	proj, w := insertConcurrencyWorkflow(t, db, cache, sdk.WorkflowConcurrency{Group: "é-{{.git.branch}}"})
+
+	wr := startConcurrencyRun(t, db, cache, proj, w, u, strings.Repeat("é", 300))
+	assert.True(t, utf8.ValidString(wr.ConcurrencyGroup))
+	assert.Equal(t, 256, utf8.RuneCountInString(wr.ConcurrencyGroup))
+	assert.Equal(t, "é-"+strings.Repeat("é", 254), wr.ConcurrencyGroup)
+}
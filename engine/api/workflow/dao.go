@@ -164,9 +164,10 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 		Metadata     sql.NullString `db:"metadata"`
 		PurgeTags    sql.NullString `db:"purge_tags"`
 		WorkflowData sql.NullString `db:"workflow_data"`
+		Concurrency  sql.NullString `db:"concurrency"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data, concurrency FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -182,6 +183,14 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.Concurrency.Valid {
+		var concurrency sdk.WorkflowConcurrency
+		if err := gorpmapping.JSONNullString(res.Concurrency, &concurrency); err != nil {
+			return sdk.WrapError(err, "Unable to unmarshall workflow concurrency")
+		}
+		w.Concurrency = &concurrency
+	}
+
 	data := sdk.WorkflowData{}
 	if err := gorpmapping.JSONNullString(res.WorkflowData, &data); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshall workflow data")
@@ -231,7 +240,15 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 	if errD != nil {
 		return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow data")
 	}
-	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3 where id = $2", pt, w.ID, data); err != nil {
+	var concurrency sql.NullString
+	if w.Concurrency != nil {
+		var errC error
+		concurrency, errC = gorpmapping.JSONToNullString(w.Concurrency)
+		if errC != nil {
+			return sdk.WrapError(errC, "Workflow.PostUpdate> Unable to marshall workflow concurrency")
+		}
+	}
+	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3, concurrency = $4 where id = $2", pt, w.ID, data, concurrency); err != nil {
 		return err
 	}
 
@@ -1062,6 +1079,12 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	if w.Concurrency != nil {
+		if err := w.Concurrency.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	//Check refs
 	for _, j := range w.WorkflowData.Joins {
 		if len(j.JoinContext) == 0 {
@@ -30,7 +30,8 @@ workflow_run.last_modified,
 workflow_run.status,
 workflow_run.last_sub_num,
 workflow_run.last_execution,
-workflow_run.to_delete
+workflow_run.to_delete,
+workflow_run.concurrency_group
 `
 
 // LoadRunOptions are options for loading a run (node or workflow)
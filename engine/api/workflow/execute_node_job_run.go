@@ -413,7 +413,7 @@ func LoadNodeJobRunKeys(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Proj
 func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNodeRun, w *sdk.WorkflowRun, pv []sdk.Variable) ([]sdk.Variable, error) {
 	var secrets []sdk.Variable
 
-	pv = sdk.VariablesFilter(pv, sdk.SecretVariable, sdk.KeyVariable)
+	pv = sdk.VariablesFilter(pv, sdk.SecretVariable, sdk.KeyVariable, sdk.VaultVariable)
 	pv = sdk.VariablesPrefix(pv, "cds.proj.")
 	secrets = append(secrets, pv...)
 
@@ -456,7 +456,7 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			if err != nil {
 				return nil, sdk.WrapError(err, "LoadSecrets> Cannot load application variables")
 			}
-			av = sdk.VariablesFilter(appVariables, sdk.SecretVariable)
+			av = sdk.VariablesFilter(appVariables, sdk.SecretVariable, sdk.VaultVariable)
 			av = sdk.VariablesPrefix(av, "cds.app.")
 
 			av = append(av, sdk.Variable{
@@ -474,7 +474,7 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			if errE != nil {
 				return nil, sdk.WrapError(errE, "LoadSecrets> Cannot load environment variables")
 			}
-			ev = sdk.VariablesFilter(envv, sdk.SecretVariable, sdk.KeyVariable)
+			ev = sdk.VariablesFilter(envv, sdk.SecretVariable, sdk.KeyVariable, sdk.VaultVariable)
 			ev = sdk.VariablesPrefix(ev, "cds.env.")
 		}
 		secrets = append(secrets, ev...)
@@ -526,6 +526,13 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 	//Decrypt secrets
 	for i := range secrets {
 		s := &secrets[i]
+		// Secrets stored in vault are only read for the job, they are never stored in CDS
+		if sdk.IsExternalSecret(s.Type) {
+			if err := secret.ResolveVaultVariable(w.Workflow.ProjectKey, s); err != nil {
+				return nil, sdk.WrapError(err, "unable to resolve vault variable %s", s.Name)
+			}
+			continue
+		}
 		if err := secret.DecryptVariable(s); err != nil {
 			return nil, sdk.WrapError(err, "Unable to decrypt variables")
 		}
@@ -295,6 +295,13 @@ func executeNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			report.Merge(ctx, r1)
 		}
 
+		//Start the next run of the concurrency group if the workflow run is over
+		r2, err := ReleaseConcurrencyGroup(ctx, db, store, proj, updatedWorkflowRun)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to release concurrency group")
+		}
+		report.Merge(ctx, r2)
+
 		//Delete the line in workflow_node_run_job
 		if err := DeleteNodeJobRuns(db, nr.ID); err != nil {
 			return nil, sdk.WrapError(err, "unable to delete node %d job runs", nr.ID)
@@ -321,6 +328,7 @@ func executeNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			where workflow.id = $1
 			and workflow_node_run.workflow_node_name = $2
 			and workflow_node_run.status = $3
+			and workflow_node_run.id not in (select workflow_node_run_id from freeze_window_block where released is null)
 			order by workflow_node_run.start asc
 			limit 1`
 			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, nodeName, string(sdk.StatusWaiting))
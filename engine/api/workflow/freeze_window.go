@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// isDeploymentNode returns true if the node is bound to an environment or to a deployment integration.
+func isDeploymentNode(wr *sdk.WorkflowRun, n *sdk.Node) bool {
+	if n.Context == nil {
+		return false
+	}
+	if n.Context.EnvironmentID != 0 {
+		return true
+	}
+	if n.Context.ProjectIntegrationID != 0 {
+		integ, ok := wr.Workflow.ProjectIntegrations[n.Context.ProjectIntegrationID]
+		return !ok || integ.Model.Deployment
+	}
+	return false
+}
+
+// checkFreezeWindows blocks the node run if a freeze window is active for its environment.
+// It returns true if the node run was blocked.
+func checkFreezeWindows(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, n *sdk.Node, nr *sdk.WorkflowNodeRun) (bool, error) {
+	if !isDeploymentNode(wr, n) {
+		return false, nil
+	}
+
+	windows, err := freezewindow.LoadAllByProjectID(ctx, db, wr.ProjectID)
+	if err != nil {
+		return false, err
+	}
+	fw, until := windows.Active(time.Now(), n.Context.EnvironmentID)
+	if fw == nil {
+		return false, nil
+	}
+
+	log.Debug("Noderun %s processed but not executed because of freeze window %s", n.Name, fw.Name)
+	block := sdk.FreezeWindowBlock{
+		ProjectID:         wr.ProjectID,
+		FreezeWindowID:    &fw.ID,
+		FreezeWindowName:  fw.Name,
+		WorkflowRunID:     wr.ID,
+		WorkflowNodeRunID: nr.ID,
+		WorkflowName:      wr.Workflow.Name,
+		WorkflowNodeName:  nr.WorkflowNodeName,
+		Number:            wr.Number,
+	}
+	if err := freezewindow.InsertBlock(db, &block); err != nil {
+		return false, err
+	}
+
+	AddWorkflowRunInfo(wr, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeFrozen.ID,
+		Args: []interface{}{n.Name, fw.String(), until.Format(time.RFC3339)},
+		Type: sdk.MsgWorkflowNodeFrozen.Type,
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return false, sdk.WrapError(err, "unable to update workflow run")
+	}
+
+	return true, nil
+}
+
+// ReleaseFrozenNodeRun executes a workflow node run that was blocked by a freeze window.
+func ReleaseFrozenNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun, msg sdk.SpawnMsg) (*ProcessorReport, error) {
+	return executeWaitingNodeRun(ctx, db, store, proj, wr, nr, msg)
+}
+
+// executeWaitingNodeRun executes a workflow node run that was kept waiting, unless its mutex is locked.
+func executeWaitingNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun, msg sdk.SpawnMsg) (*ProcessorReport, error) {
+	AddWorkflowRunInfo(wr, msg)
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+	}
+
+	n := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+	if n != nil && n.Context != nil && n.Context.Mutex {
+		locked, err := isMutexLocked(db, wr.WorkflowID, nr.ID, n.Name)
+		if err != nil {
+			return nil, err
+		}
+		// The node run will be executed when the mutex will be released
+		if locked {
+			return new(ProcessorReport), nil
+		}
+	}
+
+	return executeNodeRun(ctx, db, store, proj, nr)
+}
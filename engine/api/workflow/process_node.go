@@ -255,6 +255,13 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		setValuesGitInBuildParameters(nr, *vcsInf)
 	}
 
+	// A push hook triggers the update of the git mirror of the repository
+	if isRoot && vcsInf != nil && app.RepositoryFullname != "" && isRepositoryHookEvent(wr.Workflow, hookEvent) {
+		if err := postMirrorUpdateOperation(ctx, db, proj, app, *vcsInf); err != nil {
+			log.Warning(ctx, "processNode> unable to update git mirror of %s: %v", app.RepositoryFullname, err)
+		}
+	}
+
 	// CONDITION
 	if !checkCondition(ctx, wr, n.Context.Conditions, nr.BuildParameters) {
 		log.Debug("Condition failed on processNode %d/%d %+v", wr.ID, n.ID, nr.BuildParameters)
@@ -342,30 +349,33 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to update workflow run")
 	}
 
+	//Check if an older run of the same concurrency group is in progress
+	queued, err := checkConcurrencyGroup(ctx, db, wr, nr)
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "unable to check concurrency group")
+	}
+	if queued {
+		// Node run will be executed when older runs of the concurrency group are over
+		return report, true, nil
+	}
+
+	//Check if a freeze window is blocking deployments
+	frozen, err := checkFreezeWindows(ctx, db, wr, n, nr)
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "unable to check freeze windows")
+	}
+	if frozen {
+		// Node run will be executed when the freeze window ends or is overridden
+		return report, true, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
-		//Check if there are previous waiting or builing workflownoderun
-		// with the same workflow_node_name for the same workflow
-
-		// in this sql, we use 'and workflow_node_run.id < $2' and not and workflow_node_run.id <> $2
-		// we check if there is a previous build in waiting status
-		// and or if there is another build (never or not) with building status
-		mutexQuery := `select count(1)
-		from workflow_node_run
-		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
-		join workflow on workflow.id = workflow_run.workflow_id
-		where workflow.id = $1
-		and workflow_node_run.workflow_node_name = $3
-		and (
-			(workflow_node_run.id < $2 and workflow_node_run.status = $4)
-			or
-			(workflow_node_run.id <> $2 and workflow_node_run.status = $5)
-		)`
-		nbMutex, err := db.SelectInt(mutexQuery, n.WorkflowID, nr.ID, n.Name, sdk.StatusWaiting, sdk.StatusBuilding)
+		locked, err := isMutexLocked(db, n.WorkflowID, nr.ID, n.Name)
 		if err != nil {
-			return nil, false, sdk.WrapError(err, "unable to check mutexes")
+			return nil, false, err
 		}
-		if nbMutex > 0 {
+		if locked {
 			log.Debug("Noderun %s processed but not executed because of mutex", n.Name)
 			AddWorkflowRunInfo(wr, sdk.SpawnMsg{
 				ID:   sdk.MsgWorkflowNodeMutex.ID,
@@ -392,6 +402,32 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	return report, true, nil
 }
 
+// isMutexLocked checks if there are previous waiting or builing workflownoderun
+// with the same workflow_node_name for the same workflow.
+func isMutexLocked(db gorp.SqlExecutor, workflowID, nodeRunID int64, nodeName string) (bool, error) {
+	// in this sql, we use 'and workflow_node_run.id < $2' and not and workflow_node_run.id <> $2
+	// we check if there is a previous build in waiting status
+	// and or if there is another build (never or not) with building status
+	// waiting runs blocked by a freeze window are ignored
+	mutexQuery := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	join workflow on workflow.id = workflow_run.workflow_id
+	where workflow.id = $1
+	and workflow_node_run.workflow_node_name = $3
+	and (
+		(workflow_node_run.id < $2 and workflow_node_run.status = $4
+			and workflow_node_run.id not in (select workflow_node_run_id from freeze_window_block where released is null))
+		or
+		(workflow_node_run.id <> $2 and workflow_node_run.status = $5)
+	)`
+	nbMutex, err := db.SelectInt(mutexQuery, workflowID, nodeRunID, nodeName, sdk.StatusWaiting, sdk.StatusBuilding)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to check mutexes")
+	}
+	return nbMutex > 0, nil
+}
+
 func getParentsStatus(wr *sdk.WorkflowRun, parents []*sdk.WorkflowNodeRun) string {
 	for _, p := range parents {
 		for _, v := range wr.WorkflowNodeRuns {
@@ -38,22 +38,29 @@ type PushOption struct {
 }
 
 // CreateFromRepository a workflow from a repository.
+// Files are read from the branch or the tag given in the run options, the revision used is returned.
 func CreateFromRepository(ctx context.Context, db *gorp.DbMap, store cache.Store, p *sdk.Project, wf *sdk.Workflow,
-	opts sdk.WorkflowRunPostHandlerOption, u sdk.AuthConsumer, decryptFunc keys.DecryptFunc) ([]sdk.Message, error) {
+	opts sdk.WorkflowRunPostHandlerOption, u sdk.AuthConsumer, decryptFunc keys.DecryptFunc, branchOpts AsCodeBranchOptions) ([]sdk.Message, *AsCodeRevision, error) {
 	ctx, end := observability.Span(ctx, "workflow.CreateFromRepository")
 	defer end()
 
 	ope, err := createOperationRequest(*wf, opts)
 	if err != nil {
-		return nil, sdk.WrapError(err, "unable to create operation request")
+		return nil, nil, sdk.WrapError(err, "unable to create operation request")
 	}
 
-	if err := operation.PostRepositoryOperation(ctx, db, *p, &ope, nil); err != nil {
-		return nil, sdk.WrapError(err, "unable to post repository operation")
-	}
+	if loadAsCodeFilesFromCache(ctx, store, &ope) {
+		log.Debug("workflow.CreateFromRepository> files of %s read from cache for commit %s", wf.Name, ope.Setup.Checkout.Commit)
+	} else {
+		if err := operation.PostRepositoryOperation(ctx, db, *p, &ope, nil); err != nil {
+			return nil, nil, sdk.WrapError(err, "unable to post repository operation")
+		}
+
+		if err := pollRepositoryOperation(ctx, db, store, &ope); err != nil {
+			return nil, nil, sdk.WrapError(err, "cannot analyse repository")
+		}
 
-	if err := pollRepositoryOperation(ctx, db, store, &ope); err != nil {
-		return nil, sdk.WrapError(err, "cannot analyse repository")
+		storeAsCodeFilesInCache(ctx, store, ope, branchOpts.CacheTTL)
 	}
 
 	var uuid string
@@ -68,11 +75,18 @@ func CreateFromRepository(ctx context.Context, db *gorp.DbMap, store cache.Store
 			}
 		}
 	}
-	return extractWorkflow(ctx, db, store, p, wf, ope, u, decryptFunc, uuid)
+
+	rev := newAsCodeRevision(ope)
+	msgs, err := extractWorkflow(ctx, db, store, p, wf, ope, u, decryptFunc, uuid, rev.IsTrusted(branchOpts.TrustedBranches))
+	if err != nil {
+		return msgs, nil, err
+	}
+	msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeRevision, rev.Ref(), rev.Commit))
+	return msgs, &rev, nil
 }
 
 func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *sdk.Project, wf *sdk.Workflow,
-	ope sdk.Operation, consumer sdk.AuthConsumer, decryptFunc keys.DecryptFunc, hookUUID string) ([]sdk.Message, error) {
+	ope sdk.Operation, consumer sdk.AuthConsumer, decryptFunc keys.DecryptFunc, hookUUID string, trusted bool) ([]sdk.Message, error) {
 	ctx, end := observability.Span(ctx, "workflow.extractWorkflow")
 	defer end()
 	var allMsgs []sdk.Message
@@ -83,13 +97,14 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 		return allMsgs, sdk.WrapError(err, "unable to read cds files")
 	}
 	ope.RepositoryStrategy.SSHKeyContent = ""
+	rev := newAsCodeRevision(ope)
 	opt := &PushOption{
 		VCSServer:          ope.VCSServer,
 		RepositoryName:     ope.RepoFullName,
 		RepositoryStrategy: ope.RepositoryStrategy,
 		Branch:             ope.Setup.Checkout.Branch,
 		FromRepository:     ope.RepositoryInfo.FetchURL,
-		IsDefaultBranch:    ope.Setup.Checkout.Tag == "" && ope.Setup.Checkout.Branch == ope.RepositoryInfo.DefaultBranch,
+		IsDefaultBranch:    rev.IsDefaultBranch,
 		HookUUID:           hookUUID,
 		OldWorkflow:        *wf,
 	}
@@ -120,11 +135,16 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 	if err := workflowtemplate.UpdateTemplateInstanceWithWorkflow(ctx, db, *workflowPushed, consumer, wti); err != nil {
 		return allMsgs, err
 	}
-	*wf = *workflowPushed
+
+	// Only trusted branches are allowed to change how the workflow is triggered and who can run it
+	if !trusted {
+		allMsgs = append(allMsgs, restrictUntrustedChanges(*wf, workflowPushed, rev)...)
+	}
 
 	if wf.Name != workflowPushed.Name {
 		log.Debug("workflow.extractWorkflow> Workflow has been renamed from %s to %s", wf.Name, workflowPushed.Name)
 	}
+	*wf = *workflowPushed
 
 	return allMsgs, nil
 }
@@ -190,6 +210,50 @@ func pollRepositoryOperation(c context.Context, db gorp.SqlExecutor, store cache
 	}
 }
 
+// isRepositoryHookEvent returns true if the hook event comes from a repository webhook or a git poller,
+// other hooks (scheduler, webhook, workflow...) are not triggered by a change on the repository
+func isRepositoryHookEvent(w sdk.Workflow, hookEvent *sdk.WorkflowNodeRunHookEvent) bool {
+	if hookEvent == nil {
+		return false
+	}
+	var h *sdk.NodeHook
+	if sdk.IsValidUUID(hookEvent.WorkflowNodeHookUUID) {
+		h = w.WorkflowData.GetHooks()[hookEvent.WorkflowNodeHookUUID]
+	} else if ho, ok := w.WorkflowData.GetHooksMapRef()[hookEvent.WorkflowNodeHookUUID]; ok {
+		h = &ho
+	}
+	if h == nil {
+		return false
+	}
+	modelName := h.HookModelName
+	if modelName == "" {
+		modelName = w.HookModels[h.HookModelID].Name
+	}
+	return modelName == sdk.RepositoryWebHookModelName || modelName == sdk.GitPollerModelName
+}
+
+// postMirrorUpdateOperation asks the repositories service to update the git mirror of an application repository
+func postMirrorUpdateOperation(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, app sdk.Application, vcsInf vcsInfos) error {
+	ope := sdk.Operation{
+		VCSServer:          app.VCSServer,
+		RepoFullName:       app.RepositoryFullname,
+		URL:                vcsInf.HTTPUrl,
+		RepositoryStrategy: app.RepositoryStrategy,
+		Setup: sdk.OperationSetup{
+			Mirror: sdk.OperationMirror{
+				Update: true,
+			},
+		},
+	}
+	if app.RepositoryStrategy.ConnectionType == "ssh" {
+		ope.URL = vcsInf.URL
+	}
+	if ope.URL == "" {
+		return nil
+	}
+	return operation.PostRepositoryOperation(ctx, db, proj, &ope, nil)
+}
+
 func createOperationRequest(w sdk.Workflow, opts sdk.WorkflowRunPostHandlerOption) (sdk.Operation, error) {
 	ope := sdk.Operation{}
 	if w.WorkflowData.Node.Context.ApplicationID == 0 {
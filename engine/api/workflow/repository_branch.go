@@ -0,0 +1,184 @@
+package workflow
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	tagAsCodeBranch   = "ascode.branch"
+	tagAsCodeRevision = "ascode.revision"
+)
+
+// AsCodeBranchOptions configures how as code workflows are read from the branch being built
+type AsCodeBranchOptions struct {
+	// TrustedBranches are the branches, in addition to the default one, allowed to change hooks and permissions
+	TrustedBranches []string
+	// CacheTTL is the duration files read from a given commit are kept in cache, zero disables the cache
+	CacheTTL time.Duration
+}
+
+// AsCodeRevision is the revision of the repository an as code workflow has been read from
+type AsCodeRevision struct {
+	Branch          string
+	Tag             string
+	Commit          string
+	IsDefaultBranch bool
+}
+
+// Ref returns the branch or the tag of the revision
+func (r AsCodeRevision) Ref() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return r.Branch
+}
+
+// TagRun records the revision on the workflow run
+func (r AsCodeRevision) TagRun(wr *sdk.WorkflowRun) {
+	wr.Tag(tagAsCodeBranch, r.Ref())
+	if r.Commit != "" {
+		wr.Tag(tagAsCodeRevision, r.Commit)
+	}
+}
+
+// IsTrusted returns true if the revision is allowed to change hooks and permissions of the workflow
+func (r AsCodeRevision) IsTrusted(trustedBranches []string) bool {
+	if r.IsDefaultBranch {
+		return true
+	}
+	if r.Tag != "" {
+		return false
+	}
+	for _, pattern := range trustedBranches {
+		if ok, _ := path.Match(pattern, r.Branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func newAsCodeRevision(ope sdk.Operation) AsCodeRevision {
+	r := AsCodeRevision{
+		Branch: ope.Setup.Checkout.Branch,
+		Tag:    ope.Setup.Checkout.Tag,
+		Commit: ope.Setup.Checkout.Commit,
+	}
+	if ope.RepositoryInfo != nil {
+		r.IsDefaultBranch = r.Tag == "" && r.Branch == ope.RepositoryInfo.DefaultBranch
+	}
+	return r
+}
+
+// asCodeFiles are the files read from a commit, kept in cache to avoid analysing again the repository
+type asCodeFiles struct {
+	URL            string                       `json:"url"`
+	Checkout       sdk.OperationCheckout        `json:"checkout"`
+	RepositoryInfo *sdk.OperationRepositoryInfo `json:"repository_info"`
+	Files          map[string][]byte            `json:"files"`
+}
+
+func asCodeFilesCacheKey(url string, checkout sdk.OperationCheckout) string {
+	return cache.Key("api:workflow:ascode:files", url, checkout.Branch, checkout.Tag, checkout.Commit)
+}
+
+// loadAsCodeFilesFromCache fills the operation with files previously read from the same commit.
+// It returns false when the operation does not target a commit or when nothing valid was found.
+func loadAsCodeFilesFromCache(ctx context.Context, store cache.Store, ope *sdk.Operation) bool {
+	if ope.Setup.Checkout.Commit == "" {
+		return false
+	}
+	var f asCodeFiles
+	find, err := store.Get(asCodeFilesCacheKey(ope.URL, ope.Setup.Checkout), &f)
+	if err != nil {
+		log.Warning(ctx, "unable to get as code files from cache: %v", err)
+		return false
+	}
+	// Files are only reused if they were read from exactly the same revision
+	if !find || f.URL != ope.URL || f.Checkout != ope.Setup.Checkout || f.RepositoryInfo == nil || len(f.Files) == 0 {
+		return false
+	}
+	ope.RepositoryInfo = f.RepositoryInfo
+	ope.LoadFiles.Results = f.Files
+	ope.Status = sdk.OperationStatusDone
+	return true
+}
+
+func storeAsCodeFilesInCache(ctx context.Context, store cache.Store, ope sdk.Operation, ttl time.Duration) {
+	if ttl <= 0 || ope.Setup.Checkout.Commit == "" || ope.RepositoryInfo == nil {
+		return
+	}
+	f := asCodeFiles{
+		URL:            ope.URL,
+		Checkout:       ope.Setup.Checkout,
+		RepositoryInfo: ope.RepositoryInfo,
+		Files:          ope.LoadFiles.Results,
+	}
+	if err := store.SetWithDuration(asCodeFilesCacheKey(ope.URL, ope.Setup.Checkout), f, ttl); err != nil {
+		log.Warning(ctx, "unable to store as code files in cache: %v", err)
+	}
+}
+
+// restrictUntrustedChanges restores hooks, workflow and nodes permissions of the stored workflow on a workflow read from an untrusted branch.
+func restrictUntrustedChanges(oldWf sdk.Workflow, wf *sdk.Workflow, rev AsCodeRevision) []sdk.Message {
+	var msgs []sdk.Message
+
+	if hooksRef(oldWf.WorkflowData.Node.Hooks) != hooksRef(wf.WorkflowData.Node.Hooks) {
+		hooks := make([]sdk.NodeHook, len(oldWf.WorkflowData.Node.Hooks))
+		for i, h := range oldWf.WorkflowData.Node.Hooks {
+			h.NodeID = wf.WorkflowData.Node.ID
+			hooks[i] = h
+		}
+		wf.WorkflowData.Node.Hooks = hooks
+		msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeBranchRestricted, "hooks", rev.Ref()))
+	}
+
+	if groupsRef(oldWf.Groups) != groupsRef(wf.Groups) {
+		wf.Groups = oldWf.Groups
+		msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeBranchRestricted, "permissions", rev.Ref()))
+	}
+
+	// Permissions of the nodes are restored by node name, a node that is not in the stored workflow has no permission
+	var nodeGroupsChanged bool
+	for _, n := range wf.WorkflowData.Array() {
+		var oldGroups []sdk.GroupPermission
+		if oldNode := oldWf.WorkflowData.NodeByName(n.Name); oldNode != nil {
+			oldGroups = oldNode.Groups
+		}
+		if groupsRef(oldGroups) != groupsRef(n.Groups) {
+			n.Groups = oldGroups
+			nodeGroupsChanged = true
+		}
+	}
+	if nodeGroupsChanged {
+		msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeBranchRestricted, "nodes permissions", rev.Ref()))
+	}
+
+	return msgs
+}
+
+func hooksRef(hooks []sdk.NodeHook) string {
+	refs := make([]string, len(hooks))
+	for i := range hooks {
+		refs[i] = hooks[i].Ref()
+	}
+	sort.Strings(refs)
+	return strings.Join(refs, ",")
+}
+
+func groupsRef(groups []sdk.GroupPermission) string {
+	refs := make([]string, len(groups))
+	for i := range groups {
+		refs[i] = groups[i].Group.Name + ":" + strconv.Itoa(groups[i].Permission)
+	}
+	sort.Strings(refs)
+	return strings.Join(refs, ",")
+}
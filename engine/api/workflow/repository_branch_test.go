@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAsCodeRevisionIsTrusted(t *testing.T) {
+	trusted := []string{"release/*", "develop"}
+
+	assert.True(t, AsCodeRevision{Branch: "master", IsDefaultBranch: true}.IsTrusted(nil))
+	assert.True(t, AsCodeRevision{Branch: "release/1.0"}.IsTrusted(trusted))
+	assert.True(t, AsCodeRevision{Branch: "develop"}.IsTrusted(trusted))
+	assert.False(t, AsCodeRevision{Branch: "feat/ascode"}.IsTrusted(trusted))
+	assert.False(t, AsCodeRevision{Branch: "release/1.0/fix"}.IsTrusted(trusted))
+	assert.False(t, AsCodeRevision{Tag: "v1.0.0"}.IsTrusted([]string{"*"}))
+}
+
+func TestRestrictUntrustedChanges(t *testing.T) {
+	hook := sdk.NodeHook{
+		UUID:          "hook-uuid",
+		HookModelName: sdk.RepositoryWebHookModelName,
+		Config:        sdk.WorkflowNodeHookConfig{},
+	}
+	scheduler := sdk.NodeHook{
+		HookModelName: sdk.SchedulerModelName,
+		Config: sdk.WorkflowNodeHookConfig{
+			"cron": {Value: "* * * * *", Configurable: true},
+		},
+	}
+	groups := []sdk.GroupPermission{{Group: sdk.Group{Name: "devs"}, Permission: sdk.PermissionReadWriteExecute}}
+
+	oldWf := sdk.Workflow{Groups: groups}
+	oldWf.WorkflowData.Node.ID = 1
+	oldWf.WorkflowData.Node.Hooks = []sdk.NodeHook{hook}
+
+	// Same hooks and permissions read from another revision are kept
+	wf := sdk.Workflow{Groups: []sdk.GroupPermission{groups[0]}}
+	wf.WorkflowData.Node.ID = 2
+	wf.WorkflowData.Node.Hooks = []sdk.NodeHook{{UUID: "other-uuid", HookModelName: sdk.RepositoryWebHookModelName}}
+	msgs := restrictUntrustedChanges(oldWf, &wf, AsCodeRevision{Branch: "feat"})
+	assert.Empty(t, msgs)
+	assert.Equal(t, "other-uuid", wf.WorkflowData.Node.Hooks[0].UUID)
+
+	// Changed hooks and permissions are restored from the stored workflow
+	wf.WorkflowData.Node.Hooks = append(wf.WorkflowData.Node.Hooks, scheduler)
+	wf.Groups = append(wf.Groups, sdk.GroupPermission{Group: sdk.Group{Name: "others"}, Permission: sdk.PermissionReadWriteExecute})
+	msgs = restrictUntrustedChanges(oldWf, &wf, AsCodeRevision{Branch: "feat"})
+	require.Len(t, msgs, 2)
+	assert.Equal(t, sdk.MsgWorkflowAsCodeBranchRestricted.ID, msgs[0].ID)
+	require.Len(t, wf.WorkflowData.Node.Hooks, 1)
+	assert.Equal(t, "hook-uuid", wf.WorkflowData.Node.Hooks[0].UUID)
+	assert.Equal(t, int64(2), wf.WorkflowData.Node.Hooks[0].NodeID)
+	assert.Equal(t, groups, wf.Groups)
+
+	// Changed nodes permissions are restored from the stored workflow
+	oldWf.WorkflowData.Node.Name = "root"
+	oldWf.WorkflowData.Node.Groups = groups
+	oldWf.WorkflowData.Node.Triggers = []sdk.NodeTrigger{{ChildNode: sdk.Node{Name: "deploy"}}}
+	wf.WorkflowData.Node.Name = "root"
+	wf.WorkflowData.Node.Groups = []sdk.GroupPermission{{Group: sdk.Group{Name: "others"}, Permission: sdk.PermissionReadWriteExecute}}
+	wf.WorkflowData.Node.Triggers = []sdk.NodeTrigger{
+		{ChildNode: sdk.Node{Name: "deploy", Groups: groups}},
+		{ChildNode: sdk.Node{Name: "new", Groups: groups}},
+	}
+	msgs = restrictUntrustedChanges(oldWf, &wf, AsCodeRevision{Branch: "feat"})
+	require.Len(t, msgs, 1)
+	assert.Equal(t, sdk.MsgWorkflowAsCodeBranchRestricted.ID, msgs[0].ID)
+	assert.Equal(t, groups, wf.WorkflowData.Node.Groups)
+	assert.Empty(t, wf.WorkflowData.Node.Triggers[0].ChildNode.Groups)
+	assert.Empty(t, wf.WorkflowData.Node.Triggers[1].ChildNode.Groups)
+}
+
+func TestIsRepositoryHookEvent(t *testing.T) {
+	w := sdk.Workflow{
+		WorkflowData: sdk.WorkflowData{
+			Node: sdk.Node{
+				Hooks: []sdk.NodeHook{
+					{UUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f60", HookModelName: sdk.RepositoryWebHookModelName},
+					{UUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f61", HookModelID: 2},
+					{UUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f62", HookModelName: sdk.SchedulerModelName},
+				},
+			},
+		},
+		HookModels: map[int64]sdk.WorkflowHookModel{
+			2: {ID: 2, Name: sdk.GitPollerModelName},
+		},
+	}
+
+	assert.True(t, isRepositoryHookEvent(w, &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f60"}))
+	assert.True(t, isRepositoryHookEvent(w, &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f61"}))
+	assert.False(t, isRepositoryHookEvent(w, &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f62"}))
+	assert.False(t, isRepositoryHookEvent(w, &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: "6b3b9a6e-7c1a-4f4e-9d7a-1b2c3d4e5f63"}))
+	assert.False(t, isRepositoryHookEvent(w, nil))
+}
@@ -18,6 +18,8 @@ import (
 
 type VCSEventMessenger struct {
 	commitsStatuses map[string][]sdk.VCSCommitStatus
+	pullRequests    map[string][]sdk.VCSPullRequest
+	buildReports    map[int64]*sdk.VCSBuildReport
 	vcsClient       sdk.VCSAuthorizedClient
 }
 
@@ -267,9 +269,16 @@ func (e *VCSEventMessenger) sendVCSEventStatus(ctx context.Context, db gorp.SqlE
 				Revision:   revision,
 				Report:     report,
 				URL:        url,
+				Settings:   app.GerritSettings,
 			}
 		}
 
+	} else if sdk.StatusIsTerminated(nodeRun.Status) {
+		// The commit status is still sent without detailed report
+		eventWNR.Report, err = e.loadVCSBuildReport(ctx, db, app.RepositoryFullname, *nodeRun)
+		if err != nil {
+			log.Warning(ctx, "sendVCSEventStatus> unable to load build report of node run %d: %v", nodeRun.ID, err)
+		}
 	}
 
 	payload, _ := json.Marshal(eventWNR)
@@ -344,6 +353,19 @@ func (e *VCSEventMessenger) sendVCSPullRequestComment(ctx context.Context, db go
 	reqComment := sdk.VCSPullRequestCommentRequest{Message: report}
 	reqComment.Revision = revision
 
+	// Other repository managers get the detailed report in a comment updated on each run
+	if vcsConf.Type != "gerrit" {
+		buildReport, err := e.loadVCSBuildReport(ctx, db, app.RepositoryFullname, *nodeRun)
+		if err != nil {
+			log.Warning(ctx, "sendVCSPullRequestComment> unable to load build report of node run %d: %v", nodeRun.ID, err)
+		} else {
+			reqComment.Message = buildReport.Markdown()
+			reqComment.ReportKey = sdk.VCSCommitStatusDescription(wr.Workflow.ProjectKey, wr.Workflow.Name, sdk.EventRunWorkflowNode{
+				NodeName: nodeRun.WorkflowNodeName,
+			})
+		}
+	}
+
 	// If we are on Gerrit
 	if changeID != "" && vcsConf.Type == "gerrit" {
 		reqComment.ChangeID = changeID
@@ -352,21 +374,98 @@ func (e *VCSEventMessenger) sendVCSPullRequestComment(ctx context.Context, db go
 		}
 	} else if vcsConf.Type != "gerrit" {
 		//Check if this branch and this commit is a pullrequest
-		prs, err := e.vcsClient.PullRequests(ctx, app.RepositoryFullname)
+		pr, err := e.pullRequestByBranch(ctx, app.RepositoryFullname, nodeRun.VCSBranch)
 		if err != nil {
 			return err
 		}
 
 		//Send comment on pull request
-		for _, pr := range prs {
-			if pr.Head.Branch.DisplayID == nodeRun.VCSBranch && pr.Head.Branch.LatestCommit == nodeRun.VCSHash && !pr.Merged && !pr.Closed {
-				reqComment.ID = pr.ID
-				if err := e.vcsClient.PullRequestComment(ctx, app.RepositoryFullname, reqComment); err != nil {
-					return err
-				}
-				break
+		if pr != nil && pr.Head.Branch.LatestCommit == nodeRun.VCSHash {
+			reqComment.ID = pr.ID
+			if err := e.vcsClient.PullRequestComment(ctx, app.RepositoryFullname, reqComment); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
+
+// loadVCSBuildReport loads the tests and the coverage of a node run to compute its detailed report.
+// The coverage is compared to the target branch of the pull request of the node run if any, else to the default branch.
+// The report is computed once by messenger for a node run.
+func (e *VCSEventMessenger) loadVCSBuildReport(ctx context.Context, db gorp.SqlExecutor, repoFullname string, nodeRun sdk.WorkflowNodeRun) (*sdk.VCSBuildReport, error) {
+	if r, ok := e.buildReports[nodeRun.ID]; ok {
+		return r, nil
+	}
+
+	nr, err := LoadNodeRunByID(db, nodeRun.ID, LoadRunOptions{WithTests: true, DisableDetailledNodeRun: true})
+	if err != nil {
+		return nil, err
+	}
+	nodeRun.Tests = nr.Tests
+
+	var r *sdk.VCSBuildReport
+	c, err := LoadCoverageReport(db, nodeRun.ID)
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		r, err = sdk.NewVCSBuildReport(nodeRun, nil, nil)
+	} else if err == nil {
+		r, err = e.newVCSBuildReportWithCoverage(ctx, db, repoFullname, nodeRun, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if e.buildReports == nil {
+		e.buildReports = make(map[int64]*sdk.VCSBuildReport)
+	}
+	e.buildReports[nodeRun.ID] = r
+	return r, nil
+}
+
+func (e *VCSEventMessenger) newVCSBuildReportWithCoverage(ctx context.Context, db gorp.SqlExecutor, repoFullname string, nodeRun sdk.WorkflowNodeRun, c sdk.WorkflowNodeRunCoverage) (*sdk.VCSBuildReport, error) {
+	base := &sdk.WorkflowNodeRunCoverage{Report: c.Trend.DefaultBranch}
+	pr, err := e.pullRequestByBranch(ctx, repoFullname, nodeRun.VCSBranch)
+	if err != nil {
+		log.Warning(ctx, "loadVCSBuildReport> unable to get pull request of branch %s: %v", nodeRun.VCSBranch, err)
+	}
+	if pr != nil {
+		baseBranch := pr.Base.Branch.DisplayID
+		baseCov, err := loadLatestCoverageReport(db, c.WorkflowID, c.Repository, baseBranch, c.ApplicationID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+		// Without coverage on the target branch, the report has no delta
+		baseCov.Branch = baseBranch
+		base = &baseCov
+	}
+
+	return sdk.NewVCSBuildReport(nodeRun, &c, base)
+}
+
+// pullRequestByBranch returns the open pull request of given branch or nil if there is none.
+// Pull requests of a repository are listed once by messenger.
+func (e *VCSEventMessenger) pullRequestByBranch(ctx context.Context, repoFullname, branch string) (*sdk.VCSPullRequest, error) {
+	if branch == "" || repoFullname == "" {
+		return nil, nil
+	}
+
+	prs, ok := e.pullRequests[repoFullname]
+	if !ok {
+		var err error
+		prs, err = e.vcsClient.PullRequests(ctx, repoFullname)
+		if err != nil {
+			return nil, err
+		}
+		if e.pullRequests == nil {
+			e.pullRequests = make(map[string][]sdk.VCSPullRequest)
+		}
+		e.pullRequests[repoFullname] = prs
+	}
+
+	for i := range prs {
+		if prs[i].Head.Branch.DisplayID == branch && !prs[i].Merged && !prs[i].Closed {
+			return &prs[i], nil
+		}
+	}
+	return nil, nil
+}
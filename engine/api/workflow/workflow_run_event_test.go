@@ -576,3 +576,131 @@ func TestResyncCommitStatusCommitCache(t *testing.T) {
 	err := e.SendVCSEvent(ctx, db, cache, *proj, *wr, wr.WorkflowNodeRuns[1][0])
 	assert.NoError(t, err)
 }
+
+// Test TestResyncCommitStatusCommentGithubPR with a terminated node run on a pull request branch.
+// Must: no error returned, pull requests listed once, status and comment sent
+func TestResyncCommitStatusCommentGithubPR(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+
+	ctx := context.TODO()
+
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, pkey, pkey)
+	assert.NoError(t, repositoriesmanager.InsertForProject(db, proj, &sdk.ProjectVCSServer{
+		Name: "github",
+		Data: map[string]string{
+			"token":  "foo",
+			"secret": "bar",
+		},
+	}))
+
+	// Create Application
+	app := sdk.Application{
+		Name:               sdk.RandomString(10),
+		ProjectID:          proj.ID,
+		RepositoryFullname: "foo/myrepo",
+		VCSServer:          "github",
+		RepositoryStrategy: sdk.RepositoryStrategy{
+			ConnectionType: "https",
+		},
+	}
+	assert.NoError(t, application.Insert(db, *proj, &app))
+	assert.NoError(t, repositoriesmanager.InsertForApplication(db, &app, proj.Key))
+
+	fls := false
+	wr := &sdk.WorkflowRun{
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			1: {
+				{
+					ID:             1,
+					ApplicationID:  app.ID,
+					Status:         sdk.StatusFail,
+					WorkflowNodeID: 1,
+					VCSHash:        "6c3efde",
+					VCSBranch:      "feat/foo",
+				},
+			},
+		},
+		Workflow: sdk.Workflow{
+			WorkflowData: sdk.WorkflowData{
+				Node: sdk.Node{
+					ID: 1,
+					Context: &sdk.NodeContext{
+						ApplicationID: app.ID,
+					},
+				},
+			},
+			Applications: map[int64]sdk.Application{
+				app.ID: app,
+			},
+			Notifications: []sdk.WorkflowNotification{
+				{
+					Settings: sdk.UserNotificationSettings{
+						Template: &sdk.UserNotificationTemplate{
+							DisableComment: &fls,
+							DisableStatus:  &fls,
+							Body:           "MyTemplate",
+						},
+					},
+					Type: "vcs",
+				},
+			},
+		},
+	}
+
+	// Setup a mock for all services called by the API
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	servicesClients := mock_services.NewMockClient(ctrl)
+	services.NewClient = func(_ gorp.SqlExecutor, _ []sdk.Service) services.Client {
+		return servicesClients
+	}
+	defer func() {
+		services.NewClient = services.NewDefaultClient
+	}()
+
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/foo/myrepo/commits/6c3efde/statuses",
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, 201, nil).MaxTimes(1)
+
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github",
+			gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, method, path string, in interface{}, out interface{}) (http.Header, int, error) {
+			vcs := sdk.VCSConfiguration{Type: "github"}
+			*(out.(*sdk.VCSConfiguration)) = vcs
+			return nil, 200, nil
+		}).AnyTimes()
+
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "POST", "/vcs/github/status",
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, 201, nil).Times(1)
+
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "GET", "/vcs/github/repos/foo/myrepo/pullrequests",
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+			var pr sdk.VCSPullRequest
+			pr.ID = 42
+			pr.Head.Branch.DisplayID = "feat/foo"
+			pr.Head.Branch.LatestCommit = "6c3efde"
+			pr.Base.Branch.DisplayID = "master"
+			*(out.(*[]sdk.VCSPullRequest)) = []sdk.VCSPullRequest{pr}
+			return nil, 200, nil
+		}).Times(1)
+
+	servicesClients.EXPECT().
+		DoJSONRequest(gomock.Any(), "POST", "/vcs/github/repos/foo/myrepo/pullrequests/comments",
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, method, path string, in interface{}, out interface{}, _ interface{}) (http.Header, int, error) {
+			assert.Equal(t, 42, in.(sdk.VCSPullRequestCommentRequest).ID)
+			return nil, 200, nil
+		}).Times(1)
+
+	err := workflow.ResyncCommitStatus(ctx, db, cache, *proj, wr)
+	assert.NoError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/application"
@@ -49,7 +50,18 @@ func (api *API) postWorkflowAsCodeHandler() service.Handler {
 		branch := FormString(r, "branch")
 		message := FormString(r, "message")
 
-		u := getAPIConsumer(ctx)
+		if !migrate {
+			var wk sdk.Workflow
+			if err := service.UnmarshalBody(r, &wk); err != nil {
+				return err
+			}
+			ope, err := api.updateAsCodeWorkflow(ctx, key, workflowName, wk, branch, message)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, ope, http.StatusOK)
+		}
+
 		p, err := project.Load(api.mustDB(), key,
 			project.LoadOptions.WithApplicationWithDeploymentStrategies,
 			project.LoadOptions.WithPipelines,
@@ -62,67 +74,89 @@ func (api *API) postWorkflowAsCodeHandler() service.Handler {
 		}
 
 		wfDB, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, workflowName, workflow.LoadOptions{
-			DeepPipeline:          migrate,
-			WithAsCodeUpdateEvent: migrate,
+			DeepPipeline:          true,
+			WithAsCodeUpdateEvent: true,
 			WithTemplate:          true,
 		})
 		if err != nil {
 			return err
 		}
 
-		var rootApp *sdk.Application
-		if wfDB.WorkflowData.Node.Context != nil && wfDB.WorkflowData.Node.Context.ApplicationID != 0 {
-			rootApp, err = application.LoadByIDWithClearVCSStrategyPassword(api.mustDB(), wfDB.WorkflowData.Node.Context.ApplicationID)
-			if err != nil {
-				return err
-			}
+		rootApp, err := loadWorkflowRootApplication(api.mustDB(), *wfDB)
+		if err != nil {
+			return err
 		}
-		if rootApp == nil {
-			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow")
+		if rootApp.VCSServer == "" || rootApp.RepositoryFullname == "" {
+			return sdk.NewErrorFrom(sdk.ErrRepoNotFound, "no vcs configuration set on the root application of the given workflow")
 		}
+		return api.migrateWorkflowAsCode(ctx, w, *p, wfDB, *rootApp, branch, message)
+	}
+}
 
-		if migrate {
-			if rootApp.VCSServer == "" || rootApp.RepositoryFullname == "" {
-				return sdk.NewErrorFrom(sdk.ErrRepoNotFound, "no vcs configuration set on the root application of the given workflow")
-			}
-			return api.migrateWorkflowAsCode(ctx, w, *p, wfDB, *rootApp, branch, message)
-		}
+// loadWorkflowRootApplication returns the application of the root node of the workflow with clear vcs strategy password.
+func loadWorkflowRootApplication(db gorp.SqlExecutor, wf sdk.Workflow) (*sdk.Application, error) {
+	if wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow")
+	}
+	return application.LoadByIDWithClearVCSStrategyPassword(db, wf.WorkflowData.Node.Context.ApplicationID)
+}
 
-		if wfDB.FromRepository == "" {
-			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot update a workflow that is not ascode")
-		}
+// updateAsCodeWorkflow pushes the given workflow to the repository of the as code workflow, this will create
+// a pull request or commit on the given branch if it is the default branch of the repository.
+func (api *API) updateAsCodeWorkflow(ctx context.Context, key, workflowName string, wk sdk.Workflow, branch, message string) (*sdk.Operation, error) {
+	u := getAPIConsumer(ctx)
+	p, err := project.Load(api.mustDB(), key,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithClearKeys,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		if wfDB.TemplateInstance != nil {
-			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot update a workflow that was generated by a template")
-		}
+	wfDB, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, workflowName, workflow.LoadOptions{
+		WithTemplate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		var wk sdk.Workflow
-		if err := service.UnmarshalBody(r, &wk); err != nil {
-			return err
-		}
+	rootApp, err := loadWorkflowRootApplication(api.mustDB(), *wfDB)
+	if err != nil {
+		return nil, err
+	}
 
-		ope, err := workflow.UpdateWorkflowAsCode(ctx, api.Cache, api.mustDB(), *p, wk, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, u.AuthentifiedUser)
-		if err != nil {
-			return err
-		}
+	if wfDB.FromRepository == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "cannot update a workflow that is not ascode")
+	}
 
-		sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodeResult-%s", ope.UUID), func(ctx context.Context) {
-			ed := ascode.EntityData{
-				Operation: ope,
-				Name:      wk.Name,
-				ID:        wk.ID,
-				Type:      ascode.AsCodeWorkflow,
-				FromRepo:  wk.FromRepository,
-			}
-			asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *p, *rootApp, ed, u)
-			if asCodeEvent != nil {
-				event.PublishAsCodeEvent(ctx, p.Key, *asCodeEvent, u)
-			}
-			event.PublishWorkflowUpdate(ctx, p.Key, wk, wk, u)
-		}, api.PanicDump())
+	if wfDB.TemplateInstance != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "cannot update a workflow that was generated by a template")
+	}
 
-		return service.WriteJSON(w, ope, http.StatusOK)
+	ope, err := workflow.UpdateWorkflowAsCode(ctx, api.Cache, api.mustDB(), *p, wk, *wfDB, rootApp.VCSServer, rootApp.RepositoryFullname, branch, message, rootApp.RepositoryStrategy, u.AuthentifiedUser)
+	if err != nil {
+		return nil, err
 	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("UpdateAsCodeResult-%s", ope.UUID), func(ctx context.Context) {
+		ed := ascode.EntityData{
+			Operation: ope,
+			Name:      wk.Name,
+			ID:        wk.ID,
+			Type:      ascode.AsCodeWorkflow,
+			FromRepo:  wk.FromRepository,
+		}
+		asCodeEvent := ascode.UpdateAsCodeResult(ctx, api.mustDB(), api.Cache, *p, *rootApp, ed, u)
+		if asCodeEvent != nil {
+			event.PublishAsCodeEvent(ctx, p.Key, *asCodeEvent, u)
+		}
+		event.PublishWorkflowUpdate(ctx, p.Key, wk, wk, u)
+	}, api.PanicDump())
+
+	return ope, nil
 }
 
 func (api *API) migrateWorkflowAsCode(ctx context.Context, w http.ResponseWriter, proj sdk.Project, wf *sdk.Workflow, app sdk.Application, branch, message string) error {
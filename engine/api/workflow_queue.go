@@ -107,6 +107,12 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		if api.Config.CDN.TCP.Addr != "" && api.Config.CDN.TCP.Port > 0 {
 			pbji.GelfServiceAddr = fmt.Sprintf("%s:%d", api.Config.CDN.TCP.Addr, api.Config.CDN.TCP.Port)
 		}
+		if !api.Config.SecretScan.Disabled {
+			pbji.SecretScan, err = api.secretScanConfig(ctx, p)
+			if err != nil {
+				return err
+			}
+		}
 		workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, *p, report)
 		go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, *p, report)
 
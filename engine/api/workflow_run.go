@@ -481,6 +481,12 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 	}
 	report.Add(ctx, *run)
 
+	r1, err := workflow.ReleaseConcurrencyGroup(ctx, tx, store, *p, run)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to release concurrency group")
+	}
+	report.Merge(ctx, r1)
+
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WithStack(err)
 	}
@@ -717,6 +723,12 @@ func (api *API) stopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbM
 
 	report.Merge(ctx, r1)
 
+	r2, err := workflow.ReleaseConcurrencyGroup(ctx, tx, store, *p, wr)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to release concurrency group")
+	}
+	report.Merge(ctx, r2)
+
 	observability.Current(ctx,
 		observability.Tag(observability.TagProjectKey, p.Key),
 		observability.Tag(observability.TagWorkflow, wr.Workflow.Name),
@@ -965,12 +977,16 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 			wf.FromRepository = fromRepo
 		}
 
-		// If the workflow is as code we need to reimport it.
-		// NOTICE: Only repository webhooks and manual run will perform the repository analysis.
-		workflowStartedByRepoWebHook := opts.Hook != nil && wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID) != nil &&
-			wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID).HookModelName == sdk.RepositoryWebHookModelName
+		// If the workflow is as code we need to reimport it from the branch being built.
+		// NOTICE: Only repository webhooks, git pollers and manual run will perform the repository analysis.
+		var workflowStartedByRepoHook bool
+		if opts.Hook != nil {
+			if h := wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID); h != nil {
+				workflowStartedByRepoHook = h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.GitPollerModelName
+			}
+		}
 
-		if wf.FromRepository != "" && (workflowStartedByRepoWebHook || opts.Manual != nil) {
+		if wf.FromRepository != "" && (workflowStartedByRepoHook || opts.Manual != nil) {
 			log.Debug("initWorkflowRun> rebuild workflow %s/%s from as code configuration", p.Key, wf.Name)
 			p1, err := project.Load(api.mustDB(), projKey,
 				project.LoadOptions.WithVariables,
@@ -992,7 +1008,8 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 			// Get workflow from repository
 			log.Debug("workflow.CreateFromRepository> %s", wf.Name)
 			oldWf := *wf
-			asCodeInfosMsg, err := workflow.CreateFromRepository(ctx, api.mustDB(), api.Cache, p1, wf, *opts, *u, project.DecryptWithBuiltinKey)
+			var rev *workflow.AsCodeRevision
+			asCodeInfosMsg, rev, err = workflow.CreateFromRepository(ctx, api.mustDB(), api.Cache, p1, wf, *opts, *u, project.DecryptWithBuiltinKey, api.asCodeBranchOptions())
 			if err != nil {
 				infos := make([]sdk.SpawnMsg, len(asCodeInfosMsg))
 				for i, msg := range asCodeInfosMsg {
@@ -1009,6 +1026,7 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 				return
 			}
 
+			rev.TagRun(wfRun)
 			event.PublishWorkflowUpdate(ctx, p.Key, *wf, oldWf, u)
 		}
 
@@ -1023,6 +1041,15 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 		return
 	}
 
+	// Stop the other runs of the same concurrency group, only the most recent one keeps running
+	if wfRun.ConcurrencyGroup != "" && wfRun.Workflow.Concurrency != nil && wfRun.Workflow.Concurrency.CancelInProgress {
+		r, err := api.cancelConcurrentWorkflowRuns(ctx, p, wfRun, u)
+		if err != nil {
+			log.Error(ctx, "initWorkflowRun> unable to cancel runs of concurrency group %s: %v", wfRun.ConcurrencyGroup, err)
+		}
+		report.Merge(ctx, r)
+	}
+
 	workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, *p, report)
 
 	// Purge workflow run
@@ -1033,6 +1060,43 @@ func (api *API) initWorkflowRun(ctx context.Context, projKey string, wf *sdk.Wor
 	}, api.PanicDump())
 }
 
+// cancelConcurrentWorkflowRuns stops the runs of the same concurrency group than given run, except the most recent one.
+// Given run is stopped too if a more recent run joined the group before it.
+func (api *API) cancelConcurrentWorkflowRuns(ctx context.Context, p *sdk.Project, wfRun *sdk.WorkflowRun, u *sdk.AuthConsumer) (*workflow.ProcessorReport, error) {
+	report := new(workflow.ProcessorReport)
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return report, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	runs, lastNum, err := workflow.LoadRunsToCancelByConcurrencyGroup(tx, *wfRun)
+	if err != nil {
+		return report, err
+	}
+
+	// Stopping a run releases its concurrency group that needs the lock, so it should be done outside the transaction
+	if err := tx.Commit(); err != nil {
+		return report, sdk.WithStack(err)
+	}
+
+	for i := range runs {
+		workflow.AddWorkflowRunInfo(&runs[i], sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowConcurrencyCanceled.ID,
+			Args: []interface{}{lastNum, wfRun.ConcurrencyGroup},
+			Type: sdk.MsgWorkflowConcurrencyCanceled.Type,
+		})
+		r, err := stopWorkflowRun(ctx, api.mustDB, api.Cache, p, &runs[i], u, 0)
+		if err != nil {
+			return report, sdk.WrapError(err, "unable to stop workflow run %d", runs[i].Number)
+		}
+		report.Merge(ctx, r)
+	}
+
+	return report, nil
+}
+
 func failInitWorkflowRun(ctx context.Context, db *gorp.DbMap, wfRun *sdk.WorkflowRun, err error) *workflow.ProcessorReport {
 	report := new(workflow.ProcessorReport)
 
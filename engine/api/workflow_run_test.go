@@ -2851,3 +2851,90 @@ func Test_postWorkflowRunHandlerRestartResync(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(wrrResyncDB.Workflow.Pipelines[pip.ID].Stages[0].Jobs))
 }
+
+func Test_cancelConcurrentWorkflowRuns(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+	u, _ := assets.InsertAdminUser(t, db)
+	consumer, _ := authentication.LoadConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, key, key)
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+	}
+	require.NoError(t, pipeline.InsertPipeline(db, &pip))
+	s := sdk.NewStage("stage 1")
+	s.Enabled = true
+	s.PipelineID = pip.ID
+	require.NoError(t, pipeline.InsertStage(db, s))
+	j := &sdk.Job{
+		Enabled: true,
+		Action: sdk.Action{
+			Enabled: true,
+		},
+	}
+	require.NoError(t, pipeline.InsertJob(db, j, s.ID, &pip))
+
+	w := sdk.Workflow{
+		Name:       "test_1",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Concurrency: &sdk.WorkflowConcurrency{
+			Group:            "{{.cds.workflow}}",
+			CancelInProgress: true,
+		},
+		WorkflowData: sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "root",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+				},
+			},
+		},
+	}
+
+	proj2, err := project.Load(api.mustDB(), proj.Key, project.LoadOptions.WithPipelines, project.LoadOptions.WithGroups)
+	require.NoError(t, err)
+	require.NoError(t, workflow.Insert(context.TODO(), db, api.Cache, *proj2, &w))
+	w1, err := workflow.Load(context.TODO(), db, api.Cache, *proj2, "test_1", workflow.LoadOptions{DeepPipeline: true})
+	require.NoError(t, err)
+
+	var runs []*sdk.WorkflowRun
+	for i := 0; i < 2; i++ {
+		wr, err := workflow.CreateRun(db, w1, nil, u)
+		require.NoError(t, err)
+		wr.Workflow = *w1
+		_, err = workflow.StartWorkflowRun(context.TODO(), db, api.Cache, *proj2, wr, &sdk.WorkflowRunPostHandlerOption{
+			Manual: &sdk.WorkflowNodeRunManual{
+				Username: u.GetUsername(),
+			},
+		}, consumer, nil)
+		require.NoError(t, err)
+		runs = append(runs, wr)
+	}
+
+	// Canceling from the older run still keeps the most recent one
+	_, err = api.cancelConcurrentWorkflowRuns(context.TODO(), proj2, runs[0], consumer)
+	require.NoError(t, err)
+
+	wr1, err := workflow.LoadRunByID(db, runs[0].ID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusStopped, wr1.Status)
+	var canceled bool
+	for _, info := range wr1.Infos {
+		if info.Message.ID == sdk.MsgWorkflowConcurrencyCanceled.ID {
+			canceled = true
+			assert.Equal(t, []interface{}{float64(runs[1].Number), "test_1"}, info.Message.Args)
+		}
+	}
+	assert.True(t, canceled)
+
+	wr2, err := workflow.LoadRunByID(db, runs[1].ID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusBuilding, wr2.Status)
+}
@@ -18,9 +18,10 @@ import (
 )
 
 func prepareParams(wt sdk.WorkflowTemplate, r sdk.WorkflowTemplateRequest) interface{} {
+	values := wt.ParamsWithDefaults(r.Parameters)
 	m := make(map[string]interface{}, len(wt.Parameters))
 	for _, p := range wt.Parameters {
-		v, ok := r.Parameters[p.Key]
+		v, ok := values[p.Key]
 		if ok {
 			switch p.Type {
 			case sdk.ParameterTypeBoolean:
@@ -173,6 +174,10 @@ func Execute(wt sdk.WorkflowTemplate, instance sdk.WorkflowTemplateInstance) (ex
 		Environments: make([]exportentities.Environment, len(wt.Environments)),
 	}
 
+	if err := wt.CheckParamsValues(instance.Request.Parameters); err != nil {
+		return result, err
+	}
+
 	data := map[string]interface{}{
 		"id":     instance.ID,
 		"name":   instance.Request.WorkflowName,
@@ -198,3 +198,62 @@ name: Environment-[[if .id]]`)),
 	}}
 	assert.Equal(t, errs, e.Data)
 }
+
+func TestExecuteTemplateWithInvalidParameters(t *testing.T) {
+	tmpl := sdk.WorkflowTemplate{
+		ID: 42,
+		Parameters: []sdk.WorkflowTemplateParameter{
+			{Key: "withDeploy", Type: sdk.ParameterTypeBoolean, Required: true},
+			{Key: "environment", Type: sdk.ParameterTypeString, Choices: []string{"dev", "prod"}},
+			{Key: "version", Type: sdk.ParameterTypeString, Regex: "^v[0-9]+$", Default: "v1"},
+			{Key: "deployWhen", Type: sdk.ParameterTypeString, Required: true, Condition: &sdk.WorkflowTemplateParameterCondition{Key: "withDeploy", Value: "true"}},
+			{Key: "repo", Type: sdk.ParameterTypeRepository, Required: true},
+		},
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+name: [[.name]]
+version: v1.0
+workflow:
+  Node-1:
+    pipeline: Pipeline-[[.params.version]]`)),
+	}
+
+	_, err := workflowtemplate.Execute(tmpl, sdk.WorkflowTemplateInstance{
+		Request: sdk.WorkflowTemplateRequest{
+			WorkflowName: "my-workflow",
+			Parameters: map[string]string{
+				"withDeploy":  "true",
+				"environment": "staging",
+				"version":     "1.0",
+			},
+		},
+	})
+	require.Error(t, err)
+	e := sdk.ExtractHTTPError(err, "")
+	assert.Equal(t, sdk.ErrInvalidData.ID, e.ID)
+	assert.Equal(t, []sdk.WorkflowTemplateParameterError{
+		{Key: "environment", Message: "value should be one of dev, prod"},
+		{Key: "version", Message: "value doesn't match ^v[0-9]+$"},
+		{Key: "deployWhen", Message: "value is required"},
+		{Key: "repo", Message: "value is required"},
+	}, e.Data)
+
+	// hidden parameters are ignored and default values are used
+	res, err := workflowtemplate.Execute(tmpl, sdk.WorkflowTemplateInstance{
+		Request: sdk.WorkflowTemplateRequest{
+			WorkflowName: "my-workflow",
+			Parameters: map[string]string{
+				"withDeploy": "false",
+				"repo":       "github/ovh/cds",
+			},
+		},
+	})
+	require.NoError(t, err)
+	buf, err := yaml.Marshal(res.Workflow)
+	require.NoError(t, err)
+	assert.Equal(t, `name: my-workflow
+version: v1.0
+workflow:
+  Node-1:
+    pipeline: Pipeline-v1
+`, string(buf))
+}
@@ -132,12 +132,15 @@ func configBootstrap(args []string) Configuration {
 			defaults.SetDefaults(&gitlab)
 			var gerrit vcs.GerritServerConfiguration
 			defaults.SetDefaults(&gerrit)
+			var gitea vcs.GiteaServerConfiguration
+			defaults.SetDefaults(&gitea)
 			conf.VCS.Servers = map[string]vcs.ServerConfiguration{
 				"github":         {URL: "https://github.com", Github: &github},
 				"bitbucket":      {URL: "https://mybitbucket.com", Bitbucket: &bitbucket},
 				"bitbucketcloud": {BitbucketCloud: &bitbucketcloud},
 				"gitlab":         {URL: "https://gitlab.com", Gitlab: &gitlab},
 				"gerrit":         {URL: "http://localhost:8080", Gerrit: &gerrit},
+				"gitea":          {URL: "https://gitea.com", Gitea: &gitea},
 			}
 			conf.VCS.Name = "cds-vcs-" + namesgenerator.GetRandomNameCDS(0)
 		case services.TypeRepositories:
@@ -269,6 +272,16 @@ func configSetStartupData(conf *Configuration) (string, error) {
 
 	if conf.API != nil {
 		conf.API.Auth.RSAPrivateKey = string(apiPrivateKeyPEM)
+
+		jobIDTokenPrivateKey, err := jws.NewRandomRSAKey()
+		if err != nil {
+			return "", err
+		}
+		jobIDTokenPrivateKeyPEM, err := jws.ExportPrivateKey(jobIDTokenPrivateKey)
+		if err != nil {
+			return "", err
+		}
+		conf.API.Auth.JobIDTokenRSAPrivateKey = string(jobIDTokenPrivateKeyPEM)
 		conf.API.Secrets.Key = sdk.RandomString(32)
 
 		key, _ := keyloader.GenerateKey("hmac", gorpmapping.KeySignIdentifier, false, time.Now())
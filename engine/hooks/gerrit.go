@@ -116,6 +116,15 @@ func (s *Service) doGerritExecution(e *sdk.TaskExecution) (*sdk.WorkflowNodeRunH
 		payload["gerrit.change.url"] = gerritEvent.Change.URL
 		payload["gerrit.change.status"] = gerritEvent.Change.Status
 		payload["gerrit.change.branch"] = gerritEvent.Change.Branch
+
+		// A change is handled as a pull request on its destination branch
+		if gerritEvent.Change.Number > 0 {
+			payload[PR_ID] = gerritEvent.Change.Number
+			payload[PR_TITLE] = gerritEvent.Change.Subject
+			payload[PR_STATE] = gerritEvent.Change.Status
+			payload[GIT_BRANCH_DEST] = gerritEvent.Change.Branch
+			payload["gerrit.change.number"] = gerritEvent.Change.Number
+		}
 	}
 
 	// ref-updated
@@ -140,6 +149,7 @@ func (s *Service) doGerritExecution(e *sdk.TaskExecution) (*sdk.WorkflowNodeRunH
 			payload[GIT_HASH_BEFORE] = gerritEvent.PatchSet.Parents[0]
 		}
 		payload["gerrit.change.ref"] = gerritEvent.PatchSet.Ref
+		payload["gerrit.patchset.number"] = gerritEvent.PatchSet.Number
 		if gerritEvent.PatchSet.Author != nil {
 			if gerritEvent.PatchSet.Author.Username != "" {
 				payload[GIT_AUTHOR] = gerritEvent.PatchSet.Author.Username
@@ -287,6 +297,15 @@ func ListenGerritStreamEvent(ctx context.Context, store cache.Store, v sdk.VCSCo
 		if err := session.Run("gerrit stream-events"); err != nil {
 			log.Error(ctx, "ListenGerritStreamEvent> unable to run gerrit stream-events command: %v", err)
 		}
+		// Unblock the reader when the stream ends
+		w.Close() // nolint
+	}()
+
+	go func() {
+		// Stop the stream, the reader can be blocked until the next event
+		<-ctx.Done()
+		session.Close() // nolint
+		conn.Close()    // nolint
 	}()
 
 	lockKey := cache.Key("gerrit", "event", "lock")
@@ -296,10 +315,12 @@ func ListenGerritStreamEvent(ctx context.Context, store cache.Store, v sdk.VCSCo
 		case <-ctx.Done():
 			session.Close()
 			conn.Close()
+			return
 		case <-tick.C:
 			line, errs := stdoutreader.ReadString('\n')
 			if errs == io.EOF {
-				continue
+				log.Warning(ctx, "ListenGerritStreamEvent> gerrit event stream %s closed", v.URL)
+				return
 			}
 			if errs != nil {
 				log.Warning(ctx, "ListenGerritStreamEvent> unable to read string")
@@ -37,3 +37,27 @@ func TestBuildHookMessage(t *testing.T) {
 	assert.Equal(t, hookEvent.Payload["gerrit.change.branch"], "master")
 	assert.Equal(t, hookEvent.Payload["git.branch"], "")
 }
+
+func TestBuildHookMessageWithChangeNumber(t *testing.T) {
+	msg := `
+    {"type":"patchset-created","change":{"project":"CDS/gerrit","branch":"master","id":"Ied67a65d33f13d77c2d98540823a9eb0c887f35a","number":11,"subject":"fix","owner":{"username":"steven.guiheux"},"url":"https://mygerrit/c/CDS/gerrit/+/11","status":"NEW"},"eventCreatedOn":1549015585,"patchSet":{"number":2,"revision":"bb488dea35f140fcac3ffd04d2d01f0f29c75100","parents":["70849c92d899f30f092ad74cd59a651e03a07902"],"ref":"refs/changes/11/11/2","uploader":{"username":"steven.guiheux"}}}
+  `
+
+	s := Service{}
+	te := &sdk.TaskExecution{
+		GerritEvent: &sdk.GerritEventExecution{
+			Message: []byte(msg),
+		},
+		UUID: "123",
+	}
+	hookEvent, err := s.doGerritExecution(te)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "11", hookEvent.Payload["git.pr.id"])
+	assert.Equal(t, "fix", hookEvent.Payload["git.pr.title"])
+	assert.Equal(t, "master", hookEvent.Payload["git.branch.dest"])
+	assert.Equal(t, "11", hookEvent.Payload["gerrit.change.number"])
+	assert.Equal(t, "2", hookEvent.Payload["gerrit.patchset.number"])
+	assert.Equal(t, "refs/changes/11/11/2", hookEvent.Payload["gerrit.change.ref"])
+	assert.Equal(t, "", hookEvent.Payload["git.branch"])
+}
@@ -0,0 +1,114 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const giteaEmptyHash = "0000000000000000000000000000000000000000"
+
+func (s *Service) generatePayloadFromGiteaRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	projectKey := t.Config["project"].Value
+	workflowName := t.Config["workflow"].Value
+
+	var request GiteaWebHookEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+	}
+
+	// Branch deletion is sent as a delete event
+	if event == "delete" || request.After == giteaEmptyHash {
+		if request.RefType == "tag" || strings.HasPrefix(request.Ref, "refs/tags/") {
+			return nil, nil
+		}
+		err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(request.Ref, "refs/heads/"))
+		return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+	}
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+
+	if request.PullRequest != nil {
+		getPayloadFromGiteaPullRequest(payload, request.PullRequest)
+	} else if request.Ref != "" {
+		if request.RefType == "tag" || strings.HasPrefix(request.Ref, "refs/tags/") {
+			payload[GIT_TAG] = strings.TrimPrefix(request.Ref, "refs/tags/")
+		} else {
+			branch := strings.TrimPrefix(request.Ref, "refs/heads/")
+			payload[GIT_BRANCH] = branch
+			if err := s.stopBranchDeletionTask(ctx, branch); err != nil {
+				log.Error(ctx, "cannot stop branch deletion task for branch %s : %v", branch, err)
+			}
+		}
+		if request.Before != "" && request.Before != giteaEmptyHash {
+			payload[GIT_HASH_BEFORE] = request.Before
+		}
+		if request.After != "" {
+			payload[GIT_HASH] = request.After
+			payload[GIT_HASH_SHORT] = giteaShortHash(request.After)
+		}
+		getPayloadFromGiteaRepository(payload, request.Repository)
+	}
+
+	getPayloadFromGiteaUser(payload, request.Sender)
+	if request.HeadCommit != nil {
+		payload[GIT_MESSAGE] = request.HeadCommit.Message
+		payload[GIT_AUTHOR] = request.HeadCommit.Author.Username
+		payload[GIT_AUTHOR_EMAIL] = request.HeadCommit.Author.Email
+	}
+
+	for i := range request.Commits {
+		request.Commits[i].Added = nil
+		request.Commits[i].Removed = nil
+		request.Commits[i].Modified = nil
+	}
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
+func giteaShortHash(hash string) string {
+	if len(hash) >= 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func getPayloadFromGiteaRepository(payload map[string]interface{}, repo *GiteaRepository) {
+	if repo == nil {
+		return
+	}
+	payload[GIT_REPOSITORY] = repo.FullName
+}
+
+func getPayloadFromGiteaUser(payload map[string]interface{}, user *GiteaUser) {
+	if user == nil {
+		return
+	}
+	payload[GIT_AUTHOR] = user.Login
+	payload[GIT_AUTHOR_EMAIL] = user.Email
+	payload[CDS_TRIGGERED_BY_USERNAME] = user.Login
+	payload[CDS_TRIGGERED_BY_FULLNAME] = user.FullName
+	payload[CDS_TRIGGERED_BY_EMAIL] = user.Email
+}
+
+func getPayloadFromGiteaPullRequest(payload map[string]interface{}, pr *GiteaPullRequest) {
+	payload[PR_ID] = pr.Number
+	payload[PR_STATE] = pr.State
+	payload[PR_TITLE] = pr.Title
+	payload[GIT_BRANCH] = pr.Head.Ref
+	payload[GIT_HASH] = pr.Head.Sha
+	payload[GIT_HASH_SHORT] = giteaShortHash(pr.Head.Sha)
+	payload[GIT_BRANCH_DEST] = pr.Base.Ref
+	payload[GIT_HASH_DEST] = pr.Base.Sha
+	if pr.Head.Repo != nil {
+		payload[GIT_REPOSITORY] = pr.Head.Repo.FullName
+	}
+	if pr.Base.Repo != nil {
+		payload[GIT_REPOSITORY_DEST] = pr.Base.Repo.FullName
+	}
+}
@@ -30,6 +30,7 @@ const (
 
 	GithubHeader         = "X-Github-Event"
 	GitlabHeader         = "X-Gitlab-Event"
+	GiteaHeader          = "X-Gitea-Event" // Also sent by Forgejo
 	BitbucketHeader      = "X-Event-Key"
 	BitbucketCloudHeader = "X-Event-Key_Cloud" // Fake header, do not use to fetch header, just to return custom header
 
@@ -45,6 +46,8 @@ var (
 	schedulerQueueKey = cache.Key("hooks", "scheduler", "queue")
 	gerritRepoKey     = cache.Key("hooks", "gerrit", "repo")
 	gerritRepoHooks   = make(map[string]bool)
+
+	gerritStreamReconnectDelay = 10 * time.Second
 )
 
 // runTasks should run as a long-running goroutine
@@ -138,7 +141,14 @@ func (s *Service) initGerritStreamEvent(ctx context.Context, vcsName string, vcs
 	gerritEventChan := make(chan GerritEvent, 20)
 	// Listen to gerrit event stream
 	sdk.GoRoutine(ctx, "gerrit.EventStream."+vcsName, func(ctx context.Context) {
-		ListenGerritStreamEvent(ctx, s.Cache, vcsConfig[vcsName], gerritEventChan)
+		// Reconnect to the stream when it's closed by gerrit
+		for ctx.Err() == nil {
+			ListenGerritStreamEvent(ctx, s.Cache, vcsConfig[vcsName], gerritEventChan)
+			select {
+			case <-ctx.Done():
+			case <-time.After(gerritStreamReconnectDelay):
+			}
+		}
 	})
 	// Listen to gerrit event stream
 	sdk.GoRoutine(ctx, "gerrit.EventStreamCompute."+vcsName, func(ctx context.Context) {
@@ -0,0 +1,150 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_getRepositoryHeaderGitea(t *testing.T) {
+	// Gitea and Forgejo also send the Github header
+	whe := &sdk.WebHookExecution{
+		RequestHeader: map[string][]string{
+			GiteaHeader:  {"push"},
+			GithubHeader: {"push"},
+		},
+	}
+	assert.Equal(t, GiteaHeader, getRepositoryHeader(whe, nil))
+	assert.Equal(t, "", getRepositoryHeader(whe, []string{"pull_request"}))
+
+	whe.RequestHeader[GiteaHeader] = []string{"delete"}
+	assert.Equal(t, GiteaHeader, getRepositoryHeader(whe, nil))
+}
+
+func Test_doWebHookExecutionGitea(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPushEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader: {"push"},
+			},
+			RequestURL: "",
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	assert.Equal(t, 1, len(hs))
+	assert.Equal(t, "feat/gitea", hs[0].Payload["git.branch"])
+	assert.Equal(t, "john", hs[0].Payload["git.author"])
+	assert.Equal(t, "Add gitea support", hs[0].Payload["git.message"])
+	assert.Equal(t, "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2", hs[0].Payload["git.hash"])
+	assert.Equal(t, "cds/my-repo", hs[0].Payload["git.repository"])
+}
+
+func Test_doWebHookExecutionGiteaPullRequest(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigEventFilter: sdk.WorkflowNodeHookConfigValue{Value: "pull_request"},
+		},
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPullRequestEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader: {"pull_request"},
+			},
+			RequestURL: "",
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	assert.Equal(t, 1, len(hs))
+	assert.Equal(t, "3", hs[0].Payload["git.pr.id"])
+	assert.Equal(t, "feat/gitea", hs[0].Payload["git.branch"])
+	assert.Equal(t, "main", hs[0].Payload["git.branch.dest"])
+	assert.Equal(t, "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2", hs[0].Payload["git.hash"])
+	assert.Equal(t, "cds/my-repo", hs[0].Payload["git.repository.dest"])
+}
+
+var giteaPushEvent = `{
+  "ref": "refs/heads/feat/gitea",
+  "before": "4c1d9e4a0f0c7d6b2a5e3f1b8c9d0e1f2a3b4c5d",
+  "after": "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+  "compare_url": "https://gitea.example.com/cds/my-repo/compare/4c1d9e4a0f0c7d6b2a5e3f1b8c9d0e1f2a3b4c5d...9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+  "commits": [
+    {
+      "id": "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+      "message": "Add gitea support",
+      "url": "https://gitea.example.com/cds/my-repo/commit/9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+      "author": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+      "committer": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+      "timestamp": "2020-04-10T10:00:00Z",
+      "added": [],
+      "removed": [],
+      "modified": ["README.md"]
+    }
+  ],
+  "head_commit": {
+    "id": "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+    "message": "Add gitea support",
+    "url": "https://gitea.example.com/cds/my-repo/commit/9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+    "author": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+    "committer": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+    "timestamp": "2020-04-10T10:00:00Z"
+  },
+  "repository": {
+    "id": 1,
+    "owner": {"id": 1, "login": "cds", "full_name": "CDS", "email": "cds@example.com", "username": "cds"},
+    "name": "my-repo",
+    "full_name": "cds/my-repo",
+    "html_url": "https://gitea.example.com/cds/my-repo",
+    "ssh_url": "git@gitea.example.com:cds/my-repo.git",
+    "clone_url": "https://gitea.example.com/cds/my-repo.git",
+    "default_branch": "main"
+  },
+  "pusher": {"id": 2, "login": "john", "full_name": "John Doe", "email": "john@example.com", "username": "john"},
+  "sender": {"id": 2, "login": "john", "full_name": "John Doe", "email": "john@example.com", "username": "john"}
+}`
+
+var giteaPullRequestEvent = `{
+  "action": "opened",
+  "number": 3,
+  "pull_request": {
+    "id": 12,
+    "number": 3,
+    "user": {"id": 2, "login": "john", "full_name": "John Doe", "email": "john@example.com", "username": "john"},
+    "title": "Add gitea support",
+    "state": "open",
+    "html_url": "https://gitea.example.com/cds/my-repo/pulls/3",
+    "merged": false,
+    "head": {
+      "label": "feat/gitea",
+      "ref": "feat/gitea",
+      "sha": "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2",
+      "repo": {"id": 1, "name": "my-repo", "full_name": "cds/my-repo"}
+    },
+    "base": {
+      "label": "main",
+      "ref": "main",
+      "sha": "4c1d9e4a0f0c7d6b2a5e3f1b8c9d0e1f2a3b4c5d",
+      "repo": {"id": 1, "name": "my-repo", "full_name": "cds/my-repo"}
+    }
+  },
+  "repository": {"id": 1, "name": "my-repo", "full_name": "cds/my-repo"},
+  "sender": {"id": 2, "login": "john", "full_name": "John Doe", "email": "john@example.com", "username": "john"}
+}`
@@ -88,6 +88,7 @@ type GerritChange struct {
 	Branch          string               `json:"branch,omitempty"`  // master
 	Topic           string               `json:"topic,omitempty"`
 	ID              string               `json:"id,omitempty"`
+	Number          int64                `json:"number,omitempty"`
 	Subject         string               `json:"subject,omitempty"`
 	Owner           *GerritAccount       `json:"owner,omitempty"`
 	URL             string               `json:"url,omitempty"`
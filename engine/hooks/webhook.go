@@ -30,7 +30,13 @@ func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution)
 }
 
 func getRepositoryHeader(whe *sdk.WebHookExecution, events []string) string {
-	if v, ok := whe.RequestHeader[GithubHeader]; ok && ((len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events)) {
+	// Gitea also sends the Github header, so it has to be checked first
+	if v, ok := whe.RequestHeader[GiteaHeader]; ok {
+		if (len(events) == 0 && (v[0] == "push" || v[0] == "delete")) || sdk.IsInArray(v[0], events) {
+			return GiteaHeader
+		}
+		return ""
+	} else if v, ok := whe.RequestHeader[GithubHeader]; ok && ((len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events)) {
 		return GithubHeader
 	} else if v, ok := whe.RequestHeader[GitlabHeader]; ok && ((len(events) == 0 && (v[0] == string(gitlab.EventTypePush) || v[0] == string(gitlab.EventTypeTagPush))) || sdk.IsInArray(v[0], events)) {
 		return GitlabHeader
@@ -62,6 +68,15 @@ func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecu
 		if payload != nil {
 			payloads = append(payloads, payload)
 		}
+	case GiteaHeader:
+		headerValue := t.WebHook.RequestHeader[GiteaHeader][0]
+		payload, err := s.generatePayloadFromGiteaRequest(ctx, t, headerValue)
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			payloads = append(payloads, payload)
+		}
 	case GitlabHeader:
 		headerValue := t.WebHook.RequestHeader[GitlabHeader][0]
 		payload, err := s.generatePayloadFromGitlabRequest(ctx, t, headerValue)
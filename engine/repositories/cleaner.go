@@ -29,6 +29,9 @@ func (s *Service) vacuumCleaner(ctx context.Context) error {
 			if err := s.vacuumStoreCleanerRun(ctx); err != nil {
 				log.Error(ctx, "vacuumCleaner> Error cleaning the store: %v", err)
 			}
+			if err := s.vacuumMirrorsCleanerRun(ctx); err != nil {
+				log.Error(ctx, "vacuumCleaner> Error cleaning the git mirrors: %v", err)
+			}
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -68,6 +71,10 @@ func (s *Service) vacuumFilesystemCleanerRun(ctx context.Context) error {
 	sort.Strings(names)
 
 	for _, n := range names {
+		// git mirrors are evicted by vacuumMirrorsCleanerRun
+		if s.Cfg.Mirrors.Basedir != "" && filepath.Clean(filepath.Join(s.Cfg.Basedir, n)) == filepath.Clean(s.Cfg.Mirrors.Basedir) {
+			continue
+		}
 		if err := s.vacuumFileSystemCleanerFunc(ctx, n); err != nil {
 			log.Error(context.TODO(), "vacuumFilesystemCleanerRun> %v ", err)
 		}
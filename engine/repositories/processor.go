@@ -91,6 +91,25 @@ func (s *Service) do(ctx context.Context, op sdk.Operation) error {
 			}
 			log.ErrorWithFields(ctx, fields, "%s", err)
 
+			op.Error = sdk.ExtractHTTPError(err, "").Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
+	// Create or update the mirror of the repository
+	case op.Setup.Mirror.Update:
+		if err := s.processMirror(ctx, &op); err != nil {
+			if err == errLockUnavailable {
+				return err
+			}
+			isErrWithStack := sdk.IsErrorWithStack(err)
+			fields := logrus.Fields{}
+			if isErrWithStack {
+				fields["stack_trace"] = fmt.Sprintf("%+v", err)
+			}
+			log.ErrorWithFields(ctx, fields, "%s", err)
+
 			op.Error = sdk.ExtractHTTPError(err, "").Error()
 			op.Status = sdk.OperationStatusError
 		} else {
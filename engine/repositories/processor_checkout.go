@@ -61,6 +61,13 @@ func (s *Service) processCheckout(ctx context.Context, op *sdk.Operation) error
 		}
 	}
 
+	// Report the commit really checked out so the caller knows which revision it reads
+	currentCommit, err := gitRepo.LatestCommit()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	op.Setup.Checkout.Commit = currentCommit.LongHash
+
 	log.Info(ctx, "processCheckout> repository %s ready", op.URL)
 	return nil
 }
@@ -1,12 +1,18 @@
 package repositories
 
 import (
+	"bytes"
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/fsamin/go-repo"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
 func (s *Service) processGitClone(ctx context.Context, op *sdk.Operation) (repo.Repo, string, string, error) {
@@ -31,7 +37,15 @@ func (s *Service) processGitClone(ctx context.Context, op *sdk.Operation) (repo.
 	gitRepo, err := repo.New(r.Basedir, opts...)
 	if err != nil {
 		log.Info(ctx, "processGitClone> cloning %s into %s", r.URL, r.Basedir)
-		gitRepo, err = repo.Clone(r.Basedir, r.URL, opts...)
+		if r.Clone.IsEmpty() && s.Cfg.Mirrors.Basedir == "" {
+			gitRepo, err = repo.Clone(r.Basedir, r.URL, opts...)
+		} else {
+			// go-repo does not handle clone options nor mirrors, the first clone is done with the git sdk
+			err = s.processGitCloneWithOptions(ctx, op.ProjectKey, r)
+			if err == nil {
+				gitRepo, err = repo.New(r.Basedir, opts...)
+			}
+		}
 		if err != nil {
 			return gitRepo, "", "", sdk.NewErrorFrom(err, "cannot clone repository at given url: %s", r.URL)
 		}
@@ -60,3 +74,59 @@ func (s *Service) processGitClone(ctx context.Context, op *sdk.Operation) (repo.
 	}
 	return gitRepo, r.Basedir, currentBranch, nil
 }
+
+// processGitCloneWithOptions clones the repository with LFS, partial clone or sparse checkout,
+// and from the mirror of the repository for the project if any
+func (s *Service) processGitCloneWithOptions(ctx context.Context, projectKey string, r *sdk.OperationRepo) error {
+	opts := &git.CloneOpts{
+		Quiet:          true,
+		LFS:            r.Clone.LFS,
+		Filter:         r.Clone.Filter,
+		SparseCheckout: r.Clone.SparseCheckout,
+		// the repository is kept, it must not depend on a mirror that could be evicted
+		Reference:  s.mirrorReference(ctx, projectKey, r.URL),
+		Dissociate: true,
+	}
+
+	auth, clean, err := gitAuth(r.RepositoryStrategy)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	stdErr := new(bytes.Buffer)
+	if _, err := git.Clone(r.URL, r.Basedir, r.Basedir, auth, opts, &git.OutputOpts{Stderr: stdErr}); err != nil {
+		log.Error(ctx, "processGitCloneWithOptions> unable to clone %s: %s", r.URL, stdErr.String())
+		return err
+	}
+	return nil
+}
+
+// gitAuth returns the git sdk authentication options from a repository strategy,
+// the returned func removes the temporary ssh key
+func gitAuth(strategy sdk.RepositoryStrategy) (*git.AuthOpts, func(), error) {
+	switch {
+	case strategy.ConnectionType == "ssh":
+		keyDir, err := ioutil.TempDir("", "cds-repositories-")
+		if err != nil {
+			return nil, nil, sdk.WithStack(err)
+		}
+		clean := func() { _ = os.RemoveAll(keyDir) }
+
+		key := vcs.SSHKey{
+			Filename: filepath.Join(keyDir, "id_rsa"),
+			Content:  []byte(strategy.SSHKeyContent),
+		}
+		if err := ioutil.WriteFile(key.Filename, key.Content, os.FileMode(0600)); err != nil {
+			clean()
+			return nil, nil, sdk.WithStack(err)
+		}
+		return &git.AuthOpts{PrivateKey: key}, clean, nil
+	case strategy.User != "" && strategy.Password != "":
+		return &git.AuthOpts{
+			Username: strategy.User,
+			Password: strategy.Password,
+		}, func() {}, nil
+	}
+	return nil, func() {}, nil
+}
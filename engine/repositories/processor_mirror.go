@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func (s *Service) initMetrics(ctx context.Context) error {
+	s.Metrics.MirrorHits = stats.Int64("cds/repositories/mirror_hits", "number of clones using a git mirror", stats.UnitDimensionless)
+	s.Metrics.MirrorMisses = stats.Int64("cds/repositories/mirror_misses", "number of clones without git mirror", stats.UnitDimensionless)
+	s.Metrics.MirrorUpdates = stats.Int64("cds/repositories/mirror_updates", "number of git mirror updates", stats.UnitDimensionless)
+	s.Metrics.MirrorEvictions = stats.Int64("cds/repositories/mirror_evictions", "number of idle git mirrors removed", stats.UnitDimensionless)
+
+	tagServiceType := observability.MustNewKey(observability.TagServiceType)
+	tagServiceName := observability.MustNewKey(observability.TagServiceName)
+	tags := []tag.Key{tagServiceType, tagServiceName}
+
+	return observability.RegisterView(
+		observability.NewViewCount("cds/repositories/mirror_hits", s.Metrics.MirrorHits, tags),
+		observability.NewViewCount("cds/repositories/mirror_misses", s.Metrics.MirrorMisses, tags),
+		observability.NewViewCount("cds/repositories/mirror_updates", s.Metrics.MirrorUpdates, tags),
+		observability.NewViewCount("cds/repositories/mirror_evictions", s.Metrics.MirrorEvictions, tags),
+	)
+}
+
+func (s *Service) recordMetric(ctx context.Context, m *stats.Int64Measure) {
+	if m == nil {
+		return
+	}
+	ctx = observability.ContextWithTag(ctx, observability.TagServiceType, s.Type(), observability.TagServiceName, s.Name())
+	observability.Record(ctx, m, 1)
+}
+
+// mirrorDirectory returns the absolute path of the mirror of a repository for a project
+func (s *Service) mirrorDirectory(projectKey, url string) (string, error) {
+	p, err := git.MirrorPath(projectKey, url)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Cfg.Mirrors.Basedir, p), nil
+}
+
+// mirrorReference returns the path of the mirror of a repository for a project if it exists
+func (s *Service) mirrorReference(ctx context.Context, projectKey, url string) string {
+	if s.Cfg.Mirrors.Basedir == "" || projectKey == "" {
+		return ""
+	}
+	path, err := s.mirrorDirectory(projectKey, url)
+	if err != nil {
+		log.Warning(ctx, "mirrorReference> %v", err)
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err != nil {
+		s.recordMetric(ctx, s.Metrics.MirrorMisses)
+		return ""
+	}
+	s.recordMetric(ctx, s.Metrics.MirrorHits)
+	return path
+}
+
+// processMirror creates or updates the bare mirror of the repository
+func (s *Service) processMirror(ctx context.Context, op *sdk.Operation) error {
+	if s.Cfg.Mirrors.Basedir == "" {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "git mirrors are disabled")
+	}
+
+	path, err := s.mirrorDirectory(op.ProjectKey, op.URL)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "%v", err)
+	}
+
+	// the mirror must not be removed by the vacuum cleaner during the update
+	lockKey := mirrorLockKey(path)
+	if err := s.dao.lock(lockKey); err != nil {
+		return err
+	}
+	defer s.dao.deleteLock(ctx, lockKey) // nolint
+
+	auth, clean, err := gitAuth(op.RepositoryStrategy)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	log.Info(ctx, "processMirror> updating mirror of %s into %s", op.URL, path)
+	stdErr := new(bytes.Buffer)
+	if err := git.Mirror(op.URL, path, auth, &git.OutputOpts{Stderr: stdErr}); err != nil {
+		return sdk.NewErrorFrom(err, "cannot update mirror of repository %s: %s", op.URL, strings.TrimSpace(stdErr.String()))
+	}
+	s.recordMetric(ctx, s.Metrics.MirrorUpdates)
+
+	op.Setup.Mirror.Path, _ = git.MirrorPath(op.ProjectKey, op.URL)
+	return nil
+}
+
+// vacuumMirrorsCleanerRun removes the mirrors that were not updated during the retention period
+func (s *Service) vacuumMirrorsCleanerRun(ctx context.Context) error {
+	if s.Cfg.Mirrors.Basedir == "" {
+		return nil
+	}
+	retention := 24 * time.Hour * time.Duration(s.Cfg.Mirrors.Retention)
+
+	var idles []string
+	err := filepath.Walk(s.Cfg.Mirrors.Basedir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || !strings.HasSuffix(path, ".git") {
+			return nil
+		}
+		if time.Since(info.ModTime()) > retention {
+			idles = append(idles, path)
+		}
+		// do not walk into the mirror
+		return filepath.SkipDir
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return sdk.WithStack(err)
+	}
+
+	for _, path := range idles {
+		lockKey := mirrorLockKey(path)
+		if err := s.dao.lock(lockKey); err == errLockUnavailable {
+			log.Debug("vacuumMirrorsCleanerRun> %s is locked. skipping", path)
+			continue
+		}
+		log.Info(ctx, "vacuumMirrorsCleanerRun> removing idle mirror %s", path)
+		if err := os.RemoveAll(path); err != nil {
+			log.Error(ctx, "vacuumMirrorsCleanerRun> unable to remove %s: %v", path, err)
+		} else {
+			s.recordMetric(ctx, s.Metrics.MirrorEvictions)
+		}
+		if err := s.dao.deleteLock(ctx, lockKey); err != nil {
+			log.Error(ctx, "vacuumMirrorsCleanerRun> unable to delete lock %s: %v", lockKey, err)
+		}
+	}
+	return nil
+}
+
+func mirrorLockKey(path string) string {
+	return "mirror-" + path
+}
@@ -3,12 +3,17 @@ package repositories
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/fsamin/go-repo"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -34,6 +39,22 @@ func (s *Service) processPush(ctx context.Context, op *sdk.Operation) error {
 		op.Setup.Push.ToBranch = op.RepositoryInfo.DefaultBranch
 	}
 
+	// Compare the files known by CDS with the current files of the target branch
+	if len(op.Setup.Push.Previous) > 0 {
+		conflicts, err := s.processPushConflicts(ctx, gitRepo, path, op)
+		if err != nil {
+			return err
+		}
+		op.Setup.Push.Conflicts = conflicts
+		if len(conflicts) > 0 && op.Setup.Push.IsDirect() {
+			return sdk.NewErrorFrom(sdk.ErrConflict, "files %s were updated on branch %s since the last synchronization, please use another branch to create a pull request",
+				strings.Join(conflicts, ", "), op.Setup.Push.ToBranch)
+		}
+		if currentBranch, err = gitRepo.CurrentBranch(); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+
 	// Switch to target branch
 	if currentBranch != op.Setup.Push.FromBranch {
 		if err := gitRepo.CheckoutNewBranch(op.Setup.Push.FromBranch); err != nil {
@@ -117,3 +138,91 @@ func (s *Service) processPush(ctx context.Context, op *sdk.Operation) error {
 	log.Debug("processPush> files pushed")
 	return nil
 }
+
+// processPushConflicts returns the files of the target branch that differ from the files known by CDS
+func (s *Service) processPushConflicts(ctx context.Context, gitRepo repo.Repo, path string, op *sdk.Operation) ([]string, error) {
+	if err := gitRepo.FetchRemoteBranch("origin", op.Setup.Push.ToBranch); err != nil {
+		return nil, sdk.WrapError(err, "cannot fetch branch %s", op.Setup.Push.ToBranch)
+	}
+	if err := gitRepo.ResetHard("origin/" + op.Setup.Push.ToBranch); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var conflicts []string
+	for k, previous := range op.Setup.Push.Previous {
+		current, err := ioutil.ReadFile(filepath.Join(path, ".cds", k))
+		if os.IsNotExist(err) {
+			log.Debug("processPushConflicts> file %s not found on branch %s", k, op.Setup.Push.ToBranch)
+			continue
+		}
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		same, err := sameYAML([]byte(previous), current)
+		if err != nil {
+			log.Warning(ctx, "processPushConflicts> unable to compare file %s: %v", k, err)
+		}
+		if !same {
+			conflicts = append(conflicts, k)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts, nil
+}
+
+// sameYAML checks if two yaml contents are equivalents, empty values are ignored because they are
+// omitted by CDS exports but could be written in the repository
+func sameYAML(a, b []byte) (bool, error) {
+	var ia, ib interface{}
+	if err := yaml.Unmarshal(a, &ia); err != nil {
+		return false, sdk.WithStack(err)
+	}
+	if err := yaml.Unmarshal(b, &ib); err != nil {
+		return false, sdk.WithStack(err)
+	}
+	return reflect.DeepEqual(removeEmptyYAMLValues(ia), removeEmptyYAMLValues(ib)), nil
+}
+
+func removeEmptyYAMLValues(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			vv = removeEmptyYAMLValues(vv)
+			if vv == nil {
+				continue
+			}
+			res[fmt.Sprintf("%v", k)] = vv
+		}
+		if len(res) == 0 {
+			return nil
+		}
+		return res
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = removeEmptyYAMLValues(v[i])
+		}
+		return res
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case int:
+		if v == 0 {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	}
+	return i
+}
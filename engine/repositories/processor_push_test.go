@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSameYAML(t *testing.T) {
+	previous := `version: v1.0
+name: build
+stages:
+- Build
+jobs:
+- job: Compile
+  stage: Build
+  steps:
+  - script:
+    - make
+`
+
+	same, err := sameYAML([]byte(previous), []byte(`# written by hand
+name: build
+version: v1.0
+stages: [Build]
+jobs:
+  - job: Compile
+    stage: Build
+    enabled: false
+    requirements: []
+    steps:
+      - script: [make]
+`))
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	same, err = sameYAML([]byte(previous), []byte(`version: v1.0
+name: build
+stages:
+- Build
+jobs:
+- job: Compile
+  stage: Build
+  steps:
+  - script:
+    - make test
+`))
+	require.NoError(t, err)
+	assert.False(t, same)
+
+	_, err = sameYAML([]byte(previous), []byte("name: [build"))
+	assert.Error(t, err)
+}
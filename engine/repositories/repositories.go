@@ -102,6 +102,10 @@ func (s *Service) Serve(c context.Context) error {
 		store: s.Cache,
 	}
 
+	if err := s.initMetrics(ctx); err != nil {
+		log.Error(ctx, "Repositories> unable to init metrics: %v", err)
+	}
+
 	go func() {
 		if err := s.processor(ctx); err != nil {
 			log.Info(ctx, "Repositories> Shutdown processor")
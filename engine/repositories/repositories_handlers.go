@@ -48,6 +48,12 @@ func (s *Service) postOperationHandler() service.Handler {
 			}
 		}
 
+		// nothing to do if git mirrors are disabled
+		if op.Setup.Mirror.Update && s.Cfg.Mirrors.Basedir == "" {
+			op.Status = sdk.OperationStatusDone
+			return service.WriteJSON(w, op, http.StatusAccepted)
+		}
+
 		op.Status = sdk.OperationStatusPending
 		if err := s.dao.saveOperation(op); err != nil {
 			return err
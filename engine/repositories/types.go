@@ -4,6 +4,8 @@ package repositories
 import (
 	"path/filepath"
 
+	"go.opencensus.io/stats"
+
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/service"
@@ -13,10 +15,16 @@ import (
 // Service is the repostories service
 type Service struct {
 	service.Common
-	Cfg    Configuration
-	Router *api.Router
-	Cache  cache.Store
-	dao    dao
+	Cfg     Configuration
+	Router  *api.Router
+	Cache   cache.Store
+	dao     dao
+	Metrics struct {
+		MirrorHits      *stats.Int64Measure
+		MirrorMisses    *stats.Int64Measure
+		MirrorUpdates   *stats.Int64Measure
+		MirrorEvictions *stats.Int64Measure
+	}
 }
 
 // Configuration is the vcs configuration structure
@@ -29,6 +37,10 @@ type Configuration struct {
 		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen address without port, example: 127.0.0.1" json:"addr"`
 		Port int    `toml:"port" default:"8085" json:"port"`
 	} `toml:"http" comment:"######################\n CDS Repositories HTTP Configuration \n######################" json:"http"`
+	Mirrors struct {
+		Basedir   string `toml:"basedir" default:"" commented:"true" comment:"Root directory where the service will store the bare mirrors of the repositories per project, updated on push hooks. Share the directory of a project with its workers (CDS_GIT_MIRRORS_PATH) to use it as reference on git clone, mirrors are readable by any worker with access to the directory.\n Leave empty to disable mirrors" json:"basedir"`
+		Retention int    `toml:"retention" default:"7" comment:"Mirrors not updated since this number of days are removed" json:"retention"`
+	} `toml:"mirrors" comment:"######################\n CDS Repositories Git Mirrors Settings \n######################" json:"mirrors"`
 	URL   string                          `default:"http://localhost:8085" json:"url"`
 	API   service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
 	Cache struct {
@@ -46,5 +58,6 @@ func (s Service) Repo(op sdk.Operation) *sdk.OperationRepo {
 	r.URL = op.URL
 	r.Basedir = filepath.Join(s.Cfg.Basedir, r.ID())
 	r.RepositoryStrategy = op.RepositoryStrategy
+	r.Clone = op.Setup.Clone
 	return r
 }
@@ -51,11 +51,14 @@ type HandlerConfig struct {
 	NeedAuth         bool
 	NeedAdmin        bool
 	MaintenanceAware bool
+	AllowWithoutMFA  bool
+	AllowRestricted  bool
 	EnableTracing    bool
 	AllowProvider    bool
 	AllowedTokens    []string
 	AllowedScopes    []sdk.AuthConsumerScope
 	PermissionLevel  int
+	PermissionAction string
 	CleanURL         string
 }
 
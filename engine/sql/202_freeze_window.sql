@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "freeze_window" (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(256) NOT NULL,
+    project_id BIGINT NOT NULL,
+    environment_id BIGINT,
+    reason TEXT,
+    start_date TIMESTAMP WITH TIME ZONE,
+    end_date TIMESTAMP WITH TIME ZONE,
+    cron VARCHAR(256),
+    duration BIGINT,
+    timezone VARCHAR(256),
+    override_group_id BIGINT,
+    author VARCHAR(256),
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_PROJECT', 'freeze_window', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_ENVIRONMENT', 'freeze_window', 'environment', 'environment_id', 'id');
+ALTER TABLE "freeze_window" ADD CONSTRAINT "FK_FREEZE_WINDOW_GROUP" FOREIGN KEY (override_group_id) REFERENCES "group"(id) ON DELETE SET NULL;
+SELECT create_unique_index('freeze_window', 'IDX_FREEZE_WINDOW_PROJECT_NAME_UNIQ', 'project_id,name');
+
+CREATE TABLE IF NOT EXISTS "freeze_window_block" (
+    id BIGSERIAL PRIMARY KEY,
+    freeze_window_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_name VARCHAR(256),
+    workflow_node_name VARCHAR(256),
+    num BIGINT,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    released TIMESTAMP WITH TIME ZONE,
+    release_type VARCHAR(32),
+    override_username VARCHAR(256),
+    override_reason TEXT
+);
+
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_BLOCK_WINDOW', 'freeze_window_block', 'freeze_window', 'freeze_window_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_BLOCK_WORKFLOW_RUN', 'freeze_window_block', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('freeze_window_block', 'IDX_FREEZE_WINDOW_BLOCK_NODE_RUN', 'workflow_node_run_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "freeze_window_block";
+DROP TABLE IF EXISTS "freeze_window";
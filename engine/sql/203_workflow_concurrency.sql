@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE "workflow" ADD COLUMN IF NOT EXISTS concurrency JSONB;
+ALTER TABLE "workflow_run" ADD COLUMN IF NOT EXISTS concurrency_group VARCHAR(256);
+SELECT create_index('workflow_run', 'IDX_WORKFLOW_RUN_CONCURRENCY_GROUP', 'workflow_id,concurrency_group');
+
+-- +migrate Down
+ALTER TABLE "workflow_run" DROP COLUMN IF EXISTS concurrency_group;
+ALTER TABLE "workflow" DROP COLUMN IF EXISTS concurrency;
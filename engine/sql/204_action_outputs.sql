@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "action" ADD COLUMN IF NOT EXISTS outputs JSONB;
+
+-- +migrate Down
+ALTER TABLE "action" DROP COLUMN IF EXISTS outputs;
@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "group_sync" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    type VARCHAR(64) NOT NULL,
+    value TEXT NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_sync TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_sync_info TEXT NOT NULL DEFAULT ''
+);
+
+SELECT create_foreign_key_idx_cascade('FK_GROUP_SYNC_GROUP', 'group_sync', 'group', 'group_id', 'id');
+SELECT create_unique_index('group_sync', 'IDX_GROUP_SYNC_GROUP_UNIQ', 'group_id');
+SELECT create_index('group_sync', 'IDX_GROUP_SYNC_TYPE', 'type');
+
+CREATE TABLE IF NOT EXISTS "group_audit" (
+    id BIGSERIAL PRIMARY KEY,
+    triggered_by VARCHAR(100),
+    created TIMESTAMP WITH TIME ZONE,
+    data_before TEXT,
+    data_after TEXT,
+    event_type VARCHAR(100),
+    data_type VARCHAR(20),
+    group_id BIGINT
+);
+
+SELECT create_foreign_key_idx_cascade('FK_GROUP_AUDIT_GROUP', 'group_audit', 'group', 'group_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "group_audit";
+DROP TABLE IF EXISTS "group_sync";
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "auth_local_totp" (
+    id VARCHAR(36) PRIMARY KEY,
+    authentified_user_id VARCHAR(36) NOT NULL,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_counter BIGINT NOT NULL DEFAULT 0,
+    recovery_codes JSONB,
+    cipher_secret BYTEA,
+    sig BYTEA,
+    signer TEXT
+);
+
+SELECT create_foreign_key_idx_cascade('FK_AUTH_LOCAL_TOTP_AUTHENTIFIED_USER', 'auth_local_totp', 'authentified_user', 'authentified_user_id', 'id');
+SELECT create_unique_index('auth_local_totp', 'IDX_AUTH_LOCAL_TOTP_AUTHENTIFIED_USER_UNIQ', 'authentified_user_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "auth_local_totp";
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "auth_consumer" ADD COLUMN IF NOT EXISTS expire_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "auth_consumer" ADD COLUMN IF NOT EXISTS restrictions JSONB;
+
+-- +migrate Down
+ALTER TABLE "auth_consumer" DROP COLUMN IF EXISTS expire_at;
+ALTER TABLE "auth_consumer" DROP COLUMN IF EXISTS restrictions;
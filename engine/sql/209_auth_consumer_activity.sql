@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "auth_consumer_activity" (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id VARCHAR(36) NOT NULL,
+    ip VARCHAR(64) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    first_seen TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_seen TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    count BIGINT NOT NULL DEFAULT 0
+);
+
+SELECT create_foreign_key_idx_cascade('FK_AUTH_CONSUMER_ACTIVITY_CONSUMER', 'auth_consumer_activity', 'auth_consumer', 'consumer_id', 'id');
+SELECT create_unique_index('auth_consumer_activity', 'IDX_AUTH_CONSUMER_ACTIVITY_UNIQ', 'consumer_id,ip,user_agent');
+SELECT create_index('auth_consumer_activity', 'IDX_AUTH_CONSUMER_ACTIVITY_LAST_SEEN', 'last_seen');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "auth_consumer_activity";
@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "permission_role" (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(256) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    actions JSONB NOT NULL DEFAULT '[]',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('permission_role', 'IDX_PERMISSION_ROLE_NAME', 'name');
+
+CREATE TABLE IF NOT EXISTS "permission_role_link" (
+    id BIGSERIAL PRIMARY KEY,
+    role_id BIGINT NOT NULL,
+    group_id BIGINT NOT NULL,
+    project_id BIGINT NOT NULL,
+    workflow_id BIGINT,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PERMISSION_ROLE_LINK_ROLE', 'permission_role_link', 'permission_role', 'role_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_PERMISSION_ROLE_LINK_GROUP', 'permission_role_link', 'group', 'group_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_PERMISSION_ROLE_LINK_PROJECT', 'permission_role_link', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_PERMISSION_ROLE_LINK_WORKFLOW', 'permission_role_link', 'workflow', 'workflow_id', 'id');
+CREATE UNIQUE INDEX IF NOT EXISTS "IDX_PERMISSION_ROLE_LINK_UNIQ" ON "permission_role_link" (role_id, group_id, project_id, COALESCE(workflow_id, 0));
+
+-- +migrate Down
+DROP TABLE IF EXISTS "permission_role_link";
+DROP TABLE IF EXISTS "permission_role";
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_secret_scan_allowlist" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    pattern TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_SECRET_SCAN_ALLOWLIST_PROJECT', 'project_secret_scan_allowlist', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_secret_scan_allowlist', 'IDX_PROJECT_SECRET_SCAN_ALLOWLIST_UNIQ', 'project_id,pattern');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_secret_scan_allowlist";
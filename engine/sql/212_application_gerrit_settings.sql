@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE application ADD COLUMN IF NOT EXISTS gerrit_settings JSONB;
+
+-- +migrate Down
+ALTER TABLE application DROP COLUMN IF EXISTS gerrit_settings;
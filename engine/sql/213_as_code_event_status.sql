@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE as_code_events ADD COLUMN IF NOT EXISTS status VARCHAR(64) DEFAULT 'opened';
+
+-- +migrate Down
+ALTER TABLE as_code_events DROP COLUMN IF EXISTS status;
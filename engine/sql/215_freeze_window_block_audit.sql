@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE "freeze_window_block" ADD COLUMN IF NOT EXISTS project_id BIGINT;
+ALTER TABLE "freeze_window_block" ADD COLUMN IF NOT EXISTS freeze_window_name VARCHAR(256);
+UPDATE "freeze_window_block" SET project_id = freeze_window.project_id, freeze_window_name = freeze_window.name
+FROM "freeze_window" WHERE freeze_window.id = freeze_window_block.freeze_window_id;
+ALTER TABLE "freeze_window_block" ALTER COLUMN project_id SET NOT NULL;
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_BLOCK_PROJECT', 'freeze_window_block', 'project', 'project_id', 'id');
+
+-- Blocks are kept as audit of the overrides when the freeze window is deleted
+ALTER TABLE "freeze_window_block" ALTER COLUMN freeze_window_id DROP NOT NULL;
+ALTER TABLE "freeze_window_block" DROP CONSTRAINT IF EXISTS "fk_freeze_window_block_window";
+ALTER TABLE "freeze_window_block" ADD CONSTRAINT "FK_FREEZE_WINDOW_BLOCK_WINDOW" FOREIGN KEY (freeze_window_id) REFERENCES "freeze_window"(id) ON DELETE SET NULL;
+
+-- +migrate Down
+DELETE FROM "freeze_window_block" WHERE freeze_window_id IS NULL;
+ALTER TABLE "freeze_window_block" DROP CONSTRAINT IF EXISTS "FK_FREEZE_WINDOW_BLOCK_WINDOW";
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_BLOCK_WINDOW', 'freeze_window_block', 'freeze_window', 'freeze_window_id', 'id');
+ALTER TABLE "freeze_window_block" ALTER COLUMN freeze_window_id SET NOT NULL;
+ALTER TABLE "freeze_window_block" DROP COLUMN IF EXISTS freeze_window_name;
+ALTER TABLE "freeze_window_block" DROP COLUMN IF EXISTS project_id;
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/url"
+	"strings"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -79,11 +80,27 @@ func (client *bitbucketcloudClient) PullRequestComment(ctx context.Context, repo
 		return nil
 	}
 
-	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, prRequest.ID)
-	payload := map[string]string{
-		"body": prRequest.Message,
+	path := fmt.Sprintf("/repositories/%s/pullrequests/%d/comments", repo, prRequest.ID)
+	var comment PullRequestComment
+	comment.Content.Raw = prRequest.Message
+
+	// Update the previous report instead of adding a new comment
+	if prRequest.ReportKey != "" {
+		comment.Content.Raw = sdk.WithVCSReportMarker(prRequest.Message, prRequest.ReportKey)
+		previous, err := client.pullRequestReportComment(ctx, path, sdk.VCSReportMarker(prRequest.ReportKey))
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			values, _ := json.Marshal(comment)
+			if err := client.do(ctx, "PUT", "core", fmt.Sprintf("%s/%d", path, previous.ID), nil, values, &PullRequestComment{}); err != nil {
+				return sdk.WrapError(err, "unable to update comment %d", previous.ID)
+			}
+			return nil
+		}
 	}
-	values, _ := json.Marshal(payload)
+
+	values, _ := json.Marshal(comment)
 	res, err := client.post(path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
 	if err != nil {
 		return sdk.WrapError(err, "Unable to post status")
@@ -105,6 +122,38 @@ func (client *bitbucketcloudClient) PullRequestComment(ctx context.Context, repo
 	return nil
 }
 
+// pullRequestReportComment returns the comment containing the given report marker, or nil if not found
+func (client *bitbucketcloudClient) pullRequestReportComment(ctx context.Context, path string, marker string) (*PullRequestComment, error) {
+	params := url.Values{}
+	params.Set("pagelen", "100")
+	nextPage := 1
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if nextPage != 1 {
+			params.Set("page", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response PullRequestComments
+		if err := client.do(ctx, "GET", "core", path, params, nil, &response); err != nil {
+			return nil, sdk.WrapError(err, "unable to get pull request comments")
+		}
+		for i := range response.Values {
+			if strings.Contains(response.Values[i].Content.Raw, marker) {
+				return &response.Values[i], nil
+			}
+		}
+
+		if response.Next == "" {
+			break
+		}
+		nextPage++
+	}
+	return nil, nil
+}
+
 func (client *bitbucketcloudClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/pulls", repo)
 	payload := map[string]string{
@@ -177,6 +177,22 @@ type PullRequests struct {
 	Previous string        `json:"previous,omitempty"`
 }
 
+// PullRequestComment represents a comment on a pull request
+type PullRequestComment struct {
+	ID      int64 `json:"id,omitempty"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+}
+
+type PullRequestComments struct {
+	Pagelen int                  `json:"pagelen"`
+	Page    int                  `json:"page"`
+	Size    int64                `json:"size"`
+	Values  []PullRequestComment `json:"values"`
+	Next    string               `json:"next"`
+}
+
 type AccessToken struct {
 	AccessToken  string `json:"access_token"`
 	Scopes       string `json:"scopes"`
@@ -85,13 +85,6 @@ func (b *bitbucketClient) PullRequestComment(ctx context.Context, repo string, p
 	if err != nil {
 		return sdk.WithStack(err)
 	}
-	payload := map[string]string{
-		"text": prRequest.Message,
-	}
-	values, err := json.Marshal(payload)
-	if err != nil {
-		return sdk.WithStack(err)
-	}
 
 	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/comments", project, slug, prRequest.ID)
 
@@ -104,7 +97,62 @@ func (b *bitbucketClient) PullRequestComment(ctx context.Context, repo string, p
 			return err
 		}
 	}
-	return b.do(ctx, "POST", "core", path, nil, values, nil, &options{asUser: true})
+
+	comment := PullRequestComment{Text: prRequest.Message}
+	method := "POST"
+	// Update the previous report instead of adding a new comment
+	if prRequest.ReportKey != "" {
+		comment.Text = sdk.WithVCSReportMarker(prRequest.Message, prRequest.ReportKey)
+		previous, err := b.pullRequestReportComment(ctx, project, slug, prRequest.ID, sdk.VCSReportMarker(prRequest.ReportKey))
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			method = "PUT"
+			path = fmt.Sprintf("%s/%d", path, previous.ID)
+			comment.Version = previous.Version
+		}
+	}
+
+	values, err := json.Marshal(comment)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return b.do(ctx, method, "core", path, nil, values, nil, &options{asUser: true})
+}
+
+// pullRequestReportComment returns the comment containing the given report marker, or nil if not found
+func (b *bitbucketClient) pullRequestReportComment(ctx context.Context, project, slug string, id int, marker string) (*PullRequestComment, error) {
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/activities", project, slug, id)
+	params := url.Values{}
+
+	nextPage := 0
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if nextPage != 0 {
+			params.Set("start", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response PullRequestActivitiesResponse
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, &options{asUser: true, noCache: true}); err != nil {
+			return nil, sdk.WrapError(err, "unable to get pull request activities")
+		}
+
+		for _, a := range response.Values {
+			if a.Action == "COMMENTED" && a.Comment != nil && strings.Contains(a.Comment.Text, marker) {
+				return a.Comment, nil
+			}
+		}
+
+		if response.IsLastPage {
+			break
+		}
+		nextPage = response.NextPageStart
+	}
+	return nil, nil
 }
 
 func (b *bitbucketClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
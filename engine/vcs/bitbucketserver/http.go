@@ -66,7 +66,8 @@ func (c *bitbucketClient) getFullAPIURL(api string) string {
 }
 
 type options struct {
-	asUser bool
+	asUser  bool
+	noCache bool
 }
 
 func (c *bitbucketClient) do(ctx context.Context, method, api, path string, params url.Values, values []byte, v interface{}, opts *options) error {
@@ -123,7 +124,8 @@ func (c *bitbucketClient) do(ctx context.Context, method, api, path string, para
 	}
 
 	cacheKey := cache.Key("vcs", "bitbucket", "request", req.URL.String(), token.Token())
-	if v != nil && method == "GET" {
+	useCache := opts == nil || !opts.noCache
+	if v != nil && method == "GET" && useCache {
 		find, err := c.consumer.cache.Get(cacheKey, v)
 		if err != nil {
 			log.Error(ctx, "cannot get from cache %s: %v", cacheKey, err)
@@ -181,7 +183,7 @@ func (c *bitbucketClient) do(ctx context.Context, method, api, path string, para
 				return err
 			}
 		}
-		if method == "GET" {
+		if method == "GET" && useCache {
 			if err := c.consumer.cache.Set(cacheKey, v); err != nil {
 				log.Error(ctx, "unable to cache set %v: %v", cacheKey, err)
 			}
@@ -260,3 +260,22 @@ type UserPermission struct {
 	User       sdk.BitbucketServerActor `json:"user"`
 	Permission string                   `json:"permission"`
 }
+
+// PullRequestComment is a comment on a pull request
+type PullRequestComment struct {
+	ID      int64  `json:"id,omitempty"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+type PullRequestActivity struct {
+	Action  string              `json:"action"`
+	Comment *PullRequestComment `json:"comment,omitempty"`
+}
+
+type PullRequestActivitiesResponse struct {
+	Values        []PullRequestActivity `json:"values"`
+	Size          int                   `json:"size"`
+	NextPageStart int                   `json:"nextPageStart"`
+	IsLastPage    bool                  `json:"isLastPage"`
+}
@@ -2,19 +2,55 @@ package gerrit
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/andygrunwald/go-gerrit"
 
 	"github.com/ovh/cds/sdk"
 )
 
+// Gerrit changes are the pull requests, the change number is used as pull request id
+// and the current patchset ref as head branch
+var changeOptions = gerrit.ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_ACCOUNTS"}}
+
 func (c *gerritClient) PullRequest(ctx context.Context, repo string, id int) (sdk.VCSPullRequest, error) {
-	return sdk.VCSPullRequest{}, nil
+	change, _, err := c.client.Changes.GetChange(strconv.Itoa(id), &changeOptions)
+	if err != nil {
+		return sdk.VCSPullRequest{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound,
+			"cannot found a change for repo %s with number %d", repo, id))
+	}
+	return c.toVCSPullRequest(*change), nil
 }
 
-// PullRequests fetch all the pull request for a repository
-func (c *gerritClient) PullRequests(context.Context, string) ([]sdk.VCSPullRequest, error) {
-	return []sdk.VCSPullRequest{}, nil
+// PullRequests fetch all the open changes for a repository
+func (c *gerritClient) PullRequests(ctx context.Context, repo string) ([]sdk.VCSPullRequest, error) {
+	opt := &gerrit.QueryChangeOptions{
+		QueryOptions:  gerrit.QueryOptions{Query: []string{fmt.Sprintf("status:open project:%s", repo)}},
+		ChangeOptions: changeOptions,
+	}
+
+	prs := []sdk.VCSPullRequest{}
+	for {
+		changes, _, err := c.client.Changes.QueryChanges(opt)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list open changes on %s", repo)
+		}
+		if changes == nil || len(*changes) == 0 {
+			break
+		}
+		for _, change := range *changes {
+			prs = append(prs, c.toVCSPullRequest(change))
+		}
+
+		// The last change of the page tells if there are more results
+		if !(*changes)[len(*changes)-1].MoreChanges {
+			break
+		}
+		opt.Start += len(*changes)
+	}
+
+	return prs, nil
 }
 
 // PullRequestComment push a new comment on a pull request
@@ -36,10 +72,58 @@ func (c *gerritClient) PullRequestComment(ctx context.Context, repo string, prRe
 	}
 
 	return nil
-	return nil
 }
 
 // PullRequestCreate create a new pullrequest
 func (c *gerritClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, nil
 }
+
+func (c *gerritClient) toVCSPullRequest(change gerrit.ChangeInfo) sdk.VCSPullRequest {
+	pr := sdk.VCSPullRequest{
+		ID:       change.Number,
+		ChangeID: change.ID,
+		Revision: change.CurrentRevision,
+		Title:    change.Subject,
+		URL:      fmt.Sprintf("%s/c/%s/+/%d", c.url, change.Project, change.Number),
+		User: sdk.VCSAuthor{
+			Name:        change.Owner.Username,
+			DisplayName: change.Owner.Name,
+			Email:       change.Owner.Email,
+		},
+		Base: sdk.VCSPushEvent{
+			Repo: change.Project,
+			Branch: sdk.VCSBranch{
+				ID:        change.Branch,
+				DisplayID: change.Branch,
+			},
+		},
+		Head: sdk.VCSPushEvent{
+			Repo: change.Project,
+		},
+		Closed: change.Status == "ABANDONED",
+		Merged: change.Status == "MERGED",
+	}
+
+	if revision, has := change.Revisions[change.CurrentRevision]; has {
+		pr.Head.Branch = sdk.VCSBranch{
+			ID:           revision.Ref,
+			DisplayID:    revision.Ref,
+			LatestCommit: change.CurrentRevision,
+		}
+		pr.Head.Commit = sdk.VCSCommit{
+			Hash:    change.CurrentRevision,
+			Message: revision.Commit.Message,
+			Author: sdk.VCSAuthor{
+				Name:        revision.Uploader.Username,
+				DisplayName: revision.Uploader.Name,
+				Email:       revision.Uploader.Email,
+			},
+		}
+		if len(revision.Commit.Parents) > 0 {
+			pr.Base.Branch.LatestCommit = revision.Commit.Parents[0].Commit
+		}
+	}
+
+	return pr
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/andygrunwald/go-gerrit"
@@ -12,8 +13,13 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
-//SetStatus set build status on Gitlab
+// SetStatus set build status on Gerrit: a review with a vote on the application label and a build summary
 func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.disableStatus {
+		log.Warning(ctx, "gerrit.SetStatus>  ⚠ Gerrit statuses are disabled")
+		return nil
+	}
+
 	var eventNR sdk.EventRunWorkflowNode
 	if err := json.Unmarshal(event.Payload, &eventNR); err != nil {
 		return sdk.WrapError(err, "cannot unmarshal payload")
@@ -24,37 +30,43 @@ func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event) error {
 		return nil
 	}
 
-	// Use reviewer account to post the review
-	c.client.Authentication.SetBasicAuth(c.reviewerName, c.reviewerToken)
-
+	settings := eventNR.GerritChange.Settings
 	// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#review-input
 	ri := gerrit.ReviewInput{
-		Message: c.buildMessage(eventNR),
-		Tag:     "CDS",
-		Labels:  c.buildLabel(eventNR),
-		Notify:  "OWNER", // Send notification to the owner
+		Tag:    "CDS",
+		Notify: settings.Notify,
+	}
+	if ri.Notify == "" {
+		ri.Notify = sdk.GerritDefaultNotify
 	}
+	if !settings.DisableComment {
+		ri.Message = c.buildMessage(event, eventNR)
+	}
+	if !settings.DisableVote {
+		ri.Labels = c.buildLabel(eventNR)
+	}
+	if ri.Message == "" && ri.Labels == nil {
+		return nil
+	}
+
+	// Use reviewer account to post the review
+	c.client.Authentication.SetBasicAuth(c.reviewerName, c.reviewerToken)
 
 	// Check if we already send the message
-	changeDetail, _, err := c.client.Changes.GetChangeDetail(eventNR.GerritChange.ID, nil)
-	if err != nil {
-		return sdk.WrapError(err, "error while getting change detail")
-	}
-	found := false
-	for _, m := range changeDetail.Messages {
-		if m.Tag != "CDS" {
-			continue
+	if ri.Message != "" {
+		changeDetail, _, err := c.client.Changes.GetChangeDetail(eventNR.GerritChange.ID, nil)
+		if err != nil {
+			return sdk.WrapError(err, "error while getting change detail")
 		}
-		if strings.Contains(m.Message, ri.Message) {
-			found = true
-			break
+		for _, m := range changeDetail.Messages {
+			if m.Tag == "CDS" && strings.Contains(m.Message, ri.Message) {
+				return nil
+			}
 		}
 	}
 
-	if !found {
-		if _, _, err := c.client.Changes.SetReview(eventNR.GerritChange.ID, eventNR.GerritChange.Revision, &ri); err != nil {
-			return sdk.WrapError(err, "unable to set gerrit review")
-		}
+	if _, _, err := c.client.Changes.SetReview(eventNR.GerritChange.ID, eventNR.GerritChange.Revision, &ri); err != nil {
+		return sdk.WrapError(err, "unable to set gerrit review")
 	}
 
 	return nil
@@ -64,30 +76,67 @@ func (c *gerritClient) ListStatuses(ctx context.Context, repo string, ref string
 	return nil, nil
 }
 
-func (c *gerritClient) buildMessage(eventNR sdk.EventRunWorkflowNode) string {
+func (c *gerritClient) buildMessage(event sdk.Event, eventNR sdk.EventRunWorkflowNode) string {
 	var message string
 	switch eventNR.Status {
 	case sdk.StatusSuccess:
-		message += fmt.Sprintf("Build Success on %s\n%s", eventNR.NodeName, eventNR.GerritChange.URL)
+		message = fmt.Sprintf("Build Success on %s", eventNR.NodeName)
 	case sdk.StatusSkipped:
-		message += fmt.Sprintf("Build Skipped on %s\n%s", eventNR.NodeName, eventNR.GerritChange.URL)
+		message = fmt.Sprintf("Build Skipped on %s", eventNR.NodeName)
 	case sdk.StatusFail, sdk.StatusStopped:
-		message += fmt.Sprintf("Build Failed on %s\n%s \n%s", eventNR.NodeName, eventNR.GerritChange.URL, eventNR.GerritChange.Report)
+		message = fmt.Sprintf("Build Failed on %s", eventNR.NodeName)
 	case sdk.StatusWaiting, sdk.StatusDisabled:
-		message += fmt.Sprintf("CDS starts working on %s\n%s", eventNR.NodeName, eventNR.GerritChange.URL)
+		message = fmt.Sprintf("CDS starts working on %s", eventNR.NodeName)
+	default:
+		return ""
+	}
+	if event.WorkflowName != "" {
+		message += fmt.Sprintf(" (%s/%s #%d.%d)", event.ProjectKey, event.WorkflowName, eventNR.Number, eventNR.SubNumber)
+	}
+
+	// Build summary once the node run is finished
+	if sdk.StatusIsTerminated(eventNR.Status) {
+		for _, s := range eventNR.StagesSummary {
+			if s.Status == "" {
+				continue
+			}
+			if s.Name != "" {
+				message += fmt.Sprintf("\n* %s: %s", s.Name, s.Status)
+			} else {
+				message += fmt.Sprintf("\n* Stage %d: %s", s.BuildOrder, s.Status)
+			}
+		}
+	}
+
+	if !c.disableStatusDetail && eventNR.GerritChange.URL != "" {
+		message += "\n" + eventNR.GerritChange.URL
+	}
+	if (eventNR.Status == sdk.StatusFail || eventNR.Status == sdk.StatusStopped) && eventNR.GerritChange.Report != "" {
+		message += "\n" + eventNR.GerritChange.Report
 	}
 	return message
 }
 
 func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]string {
-	labels := make(map[string]string)
+	settings := eventNR.GerritChange.Settings
+	label := settings.Label
+	if label == "" {
+		label = sdk.GerritDefaultLabel
+	}
+
+	vote := sdk.GerritDefaultVoteSuccess
 	switch eventNR.Status {
 	case sdk.StatusSuccess:
-		labels["Verified"] = "1"
+		if settings.VoteSuccess != nil {
+			vote = *settings.VoteSuccess
+		}
 	case sdk.StatusFail, sdk.StatusStopped:
-		labels["Verified"] = "-1"
+		vote = sdk.GerritDefaultVoteFailure
+		if settings.VoteFailure != nil {
+			vote = *settings.VoteFailure
+		}
 	default:
 		return nil
 	}
-	return labels
+	return map[string]string{label: strconv.Itoa(vote)}
 }
@@ -0,0 +1,199 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g "github.com/andygrunwald/go-gerrit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const testChange = `{"id": "CDS~master~Iabc", "project": "CDS", "branch": "master", "subject": "my feature", "status": "NEW", "_number": %d,
+  "owner": {"username": "john", "name": "John Doe", "email": "john@doe.com"},
+  "current_revision": "bbbbbb",
+  "revisions": {"bbbbbb": {"ref": "refs/changes/11/11/2", "commit": {"parents": [{"commit": "aaaaaa"}], "message": "my feature"}}}%s}`
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *gerritClient {
+	log.SetLogger(t)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := g.NewClient(srv.URL, nil)
+	require.NoError(t, err)
+	return &gerritClient{client: client, url: srv.URL, reviewerName: "cds", reviewerToken: "token"}
+}
+
+func TestGerritPullRequests(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/changes/":
+			assert.Equal(t, "status:open project:CDS", r.URL.Query().Get("q"))
+			switch r.URL.Query().Get("start") {
+			case "":
+				_, _ = w.Write([]byte(")]}'\n[" + sprintfChange(11, `, "_more_changes": true`) + "]"))
+			case "1":
+				_, _ = w.Write([]byte(")]}'\n[" + sprintfChange(12, "") + "]"))
+			default:
+				t.Errorf("unexpected start %s", r.URL.Query().Get("start"))
+			}
+		case "/changes/11":
+			_, _ = w.Write([]byte(")]}'\n" + sprintfChange(11, "")))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	prs, err := c.PullRequests(ctx, "CDS")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 11, prs[0].ID)
+	assert.Equal(t, 12, prs[1].ID)
+
+	pr, err := c.PullRequest(ctx, "CDS", 11)
+	require.NoError(t, err)
+	assert.Equal(t, "CDS~master~Iabc", pr.ChangeID)
+	assert.Equal(t, "my feature", pr.Title)
+	assert.Equal(t, c.url+"/c/CDS/+/11", pr.URL)
+	assert.Equal(t, "john", pr.User.Name)
+	assert.Equal(t, "master", pr.Base.Branch.DisplayID)
+	assert.Equal(t, "aaaaaa", pr.Base.Branch.LatestCommit)
+	assert.Equal(t, "refs/changes/11/11/2", pr.Head.Branch.DisplayID)
+	assert.Equal(t, "bbbbbb", pr.Head.Commit.Hash)
+	assert.False(t, pr.Merged)
+	assert.False(t, pr.Closed)
+}
+
+func sprintfChange(number int, extra string) string {
+	return fmt.Sprintf(testChange, number, extra)
+}
+
+func TestGerritSetStatus(t *testing.T) {
+	voteSuccess := 2
+	tests := []struct {
+		name     string
+		status   string
+		settings sdk.ApplicationGerritSettings
+		review   *g.ReviewInput
+	}{
+		{
+			name:   "default settings on success",
+			status: sdk.StatusSuccess,
+			review: &g.ReviewInput{
+				Message: "Build Success on build (PROJ/wf #1.0)\n* Stage 1: Success\nhttp://cds/run/1",
+				Tag:     "CDS",
+				Labels:  map[string]string{"Verified": "1"},
+				Notify:  "OWNER",
+			},
+		},
+		{
+			name:     "custom label on failure",
+			status:   sdk.StatusFail,
+			settings: sdk.ApplicationGerritSettings{Label: "CI", Notify: "ALL", VoteSuccess: &voteSuccess},
+			review: &g.ReviewInput{
+				Message: "Build Failed on build (PROJ/wf #1.0)\n* Stage 1: Fail\nhttp://cds/run/1\nreport",
+				Tag:     "CDS",
+				Labels:  map[string]string{"CI": "-1"},
+				Notify:  "ALL",
+			},
+		},
+		{
+			name:     "custom vote without comment",
+			status:   sdk.StatusSuccess,
+			settings: sdk.ApplicationGerritSettings{DisableComment: true, VoteSuccess: &voteSuccess},
+			review: &g.ReviewInput{
+				Tag:    "CDS",
+				Labels: map[string]string{"Verified": "2"},
+				Notify: "OWNER",
+			},
+		},
+		{
+			name:     "comment without vote on building",
+			status:   sdk.StatusWaiting,
+			settings: sdk.ApplicationGerritSettings{DisableVote: true},
+			review: &g.ReviewInput{
+				Message: "CDS starts working on build (PROJ/wf #1.0)\nhttp://cds/run/1",
+				Tag:     "CDS",
+				Notify:  "OWNER",
+			},
+		},
+		{
+			name:     "nothing to send",
+			status:   sdk.StatusSuccess,
+			settings: sdk.ApplicationGerritSettings{DisableComment: true, DisableVote: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var review *g.ReviewInput
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/a/changes/Iabc/detail":
+					_, _ = w.Write([]byte(`{"messages": [{"tag": "CDS", "message": "Patch Set 1: Build Success on other"}]}`))
+				case "/a/changes/Iabc/revisions/bbbbbb/review":
+					body, _ := ioutil.ReadAll(r.Body)
+					review = new(g.ReviewInput)
+					require.NoError(t, json.Unmarshal(body, review))
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			payload, _ := json.Marshal(sdk.EventRunWorkflowNode{
+				Number:        1,
+				Status:        tt.status,
+				NodeName:      "build",
+				StagesSummary: []sdk.StageSummary{{BuildOrder: 1, Status: tt.status}},
+				GerritChange: &sdk.GerritChangeEvent{
+					ID:       "Iabc",
+					Revision: "bbbbbb",
+					URL:      "http://cds/run/1",
+					Report:   "report",
+					Settings: tt.settings,
+				},
+			})
+			event := sdk.Event{ProjectKey: "PROJ", WorkflowName: "wf", Payload: payload}
+
+			require.NoError(t, c.SetStatus(context.Background(), event))
+			assert.Equal(t, tt.review, review)
+		})
+	}
+}
+
+func TestGerritSetStatusAlreadySent(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/a/changes/Iabc/detail":
+			_, _ = w.Write([]byte(`{"messages": [{"tag": "CDS", "message": "Patch Set 1: CDS starts working on build (PROJ/wf #1.0)"}]}`))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c.disableStatusDetail = true
+
+	payload, _ := json.Marshal(sdk.EventRunWorkflowNode{
+		Number:       1,
+		Status:       sdk.StatusWaiting,
+		NodeName:     "build",
+		GerritChange: &sdk.GerritChangeEvent{ID: "Iabc", Revision: "bbbbbb"},
+	})
+	event := sdk.Event{ProjectKey: "PROJ", WorkflowName: "wf", Payload: payload}
+	require.NoError(t, c.SetStatus(context.Background(), event))
+}
@@ -0,0 +1,64 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns list of branches for a repo
+func (client *giteaClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	repo, err := client.repoByFullname(ctx, fullname)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get repo by fullname")
+	}
+
+	var branches []Branch
+	path := fmt.Sprintf("/repos/%s/branches", fullname)
+	if err := client.getAll(ctx, path, nil, func(raw json.RawMessage) (int, error) {
+		var page []Branch
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		branches = append(branches, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get branches")
+	}
+
+	branchesResult := make([]sdk.VCSBranch, 0, len(branches))
+	for _, b := range branches {
+		branchesResult = append(branchesResult, sdk.VCSBranch{
+			DisplayID:    b.Name,
+			ID:           b.Name,
+			LatestCommit: b.Commit.ID,
+			Default:      b.Name == repo.DefaultBranch,
+		})
+	}
+	return branchesResult, nil
+}
+
+// Branch returns only detail of a branch
+func (client *giteaClient) Branch(ctx context.Context, fullname, theBranch string) (*sdk.VCSBranch, error) {
+	repo, err := client.repoByFullname(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch Branch
+	path := fmt.Sprintf("/repos/%s/branches/%s", fullname, url.PathEscape(theBranch))
+	if _, err := client.do(ctx, http.MethodGet, path, nil, nil, &branch); err != nil {
+		return nil, sdk.WrapError(err, "unable to get branch %s", theBranch)
+	}
+
+	return &sdk.VCSBranch{
+		DisplayID:    branch.Name,
+		ID:           branch.Name,
+		LatestCommit: branch.Commit.ID,
+		Default:      branch.Name == repo.DefaultBranch,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Commits returns the commits list on a branch between a commit SHA (since) until another commit SHA (until)
+func (client *giteaClient) Commits(ctx context.Context, repo, theBranch, since, until string) ([]sdk.VCSCommit, error) {
+	if until == "" {
+		until = theBranch
+	}
+	if since == "" {
+		var commits []Commit
+		params := url.Values{}
+		params.Set("sha", until)
+		path := fmt.Sprintf("/repos/%s/commits", repo)
+		if err := client.getAll(ctx, path, params, func(raw json.RawMessage) (int, error) {
+			var page []Commit
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return 0, err
+			}
+			commits = append(commits, page...)
+			return len(page), nil
+		}); err != nil {
+			return nil, sdk.WrapError(err, "unable to get commits on %s", until)
+		}
+		return toVCSCommits(commits), nil
+	}
+	return client.CommitsBetweenRefs(ctx, repo, since, until)
+}
+
+// Commit Get a single commit
+func (client *giteaClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	var c Commit
+	path := fmt.Sprintf("/repos/%s/git/commits/%s", repo, hash)
+	if _, err := client.do(ctx, http.MethodGet, path, nil, nil, &c); err != nil {
+		return sdk.VCSCommit{}, sdk.WrapError(err, "unable to get commit %s", hash)
+	}
+	return c.ToVCSCommit(), nil
+}
+
+// CommitsBetweenRefs returns the commits reachable from head and not from base
+func (client *giteaClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	var compare Compare
+	path := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, url.PathEscape(base), url.PathEscape(head))
+	if _, err := client.do(ctx, http.MethodGet, path, nil, nil, &compare); err != nil {
+		return nil, sdk.WrapError(err, "unable to compare %s and %s", base, head)
+	}
+	return toVCSCommits(compare.Commits), nil
+}
+
+func toVCSCommits(commits []Commit) []sdk.VCSCommit {
+	res := make([]sdk.VCSCommit, 0, len(commits))
+	for _, c := range commits {
+		res = append(res, c.ToVCSCommit())
+	}
+	return res
+}
+
+// ToVCSCommit converts a gitea commit to sdk.VCSCommit
+func (c Commit) ToVCSCommit() sdk.VCSCommit {
+	commit := sdk.VCSCommit{
+		Timestamp: c.Commit.Author.Date.Unix() * 1000,
+		Message:   c.Commit.Message,
+		Hash:      c.SHA,
+		URL:       c.HTMLURL,
+		Author: sdk.VCSAuthor{
+			Name:        c.Commit.Author.Name,
+			DisplayName: c.Commit.Author.Name,
+			Email:       c.Commit.Author.Email,
+		},
+	}
+	if c.Author != nil {
+		commit.Author.Name = c.Author.Login
+		commit.Author.Avatar = c.Author.AvatarURL
+		if c.Author.FullName != "" {
+			commit.Author.DisplayName = c.Author.FullName
+		}
+	}
+	return commit
+}
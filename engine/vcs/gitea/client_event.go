@@ -0,0 +1,33 @@
+package gitea
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// GetEvents is not supported, gitea repositories are only triggered by webhooks
+func (client *giteaClient) GetEvents(ctx context.Context, fullname string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// PushEvents returns push events as commits
+func (client *giteaClient) PushEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// CreateEvents checks create events from a event list
+func (client *giteaClient) CreateEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// DeleteEvents checks delete events from a event list
+func (client *giteaClient) DeleteEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// PullRequestEvents checks pull request events from a event list
+func (client *giteaClient) PullRequestEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -0,0 +1,31 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns the forks of a repository
+func (client *giteaClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	var repos []Repository
+	path := fmt.Sprintf("/repos/%s/forks", repo)
+	if err := client.getAll(ctx, path, nil, func(raw json.RawMessage) (int, error) {
+		var page []Repository
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		repos = append(repos, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get forks")
+	}
+
+	responseRepos := make([]sdk.VCSRepo, 0, len(repos))
+	for _, r := range repos {
+		responseRepos = append(responseRepos, r.ToVCSRepo())
+	}
+	return responseRepos, nil
+}
@@ -0,0 +1,124 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// defaultHookEvents are used when no event is given on a repository webhook,
+// branch deletions are only sent as delete events
+var defaultHookEvents = []string{"push", "delete"}
+
+func (client *giteaClient) hookURL(u string) string {
+	if client.proxyURL == "" {
+		return u
+	}
+	lastIndexSlash := strings.LastIndex(u, "/")
+	if client.proxyURL[len(client.proxyURL)-1] == '/' {
+		lastIndexSlash++
+	}
+	return client.proxyURL + u[lastIndexSlash:]
+}
+
+// CreateHook adds a JSON webhook on the repository
+func (client *giteaClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	hook.URL = client.hookURL(hook.URL)
+	if len(hook.Events) == 0 {
+		hook.Events = defaultHookEvents
+	}
+
+	r := CreateHook{
+		Type: "gitea",
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+		Events: hook.Events,
+		Active: true,
+	}
+	var res Hook
+	path := fmt.Sprintf("/repos/%s/hooks", repo)
+	if _, err := client.do(ctx, http.MethodPost, path, nil, r, &res); err != nil {
+		return sdk.WrapError(err, "unable to create webhook on %s", repo)
+	}
+	hook.ID = strconv.FormatInt(res.ID, 10)
+	return nil
+}
+
+func (client *giteaClient) getHooks(ctx context.Context, fullname string) ([]Hook, error) {
+	var hooks []Hook
+	path := fmt.Sprintf("/repos/%s/hooks", fullname)
+	if err := client.getAll(ctx, path, nil, func(raw json.RawMessage) (int, error) {
+		var page []Hook
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		hooks = append(hooks, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get hooks")
+	}
+	return hooks, nil
+}
+
+// GetHook returns the webhook of the repository matching given url
+func (client *giteaClient) GetHook(ctx context.Context, fullname, webhookURL string) (sdk.VCSHook, error) {
+	hooks, err := client.getHooks(ctx, fullname)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+
+	for _, h := range hooks {
+		if h.Config["url"] == webhookURL {
+			return sdk.VCSHook{
+				ID:          strconv.FormatInt(h.ID, 10),
+				Events:      h.Events,
+				URL:         h.Config["url"],
+				ContentType: h.Config["content_type"],
+				Disable:     !h.Active,
+			}, nil
+		}
+	}
+
+	return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotFound)
+}
+
+// UpdateHook updates url and events of a webhook
+func (client *giteaClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	hook.URL = client.hookURL(hook.URL)
+	if len(hook.Events) == 0 {
+		hook.Events = defaultHookEvents
+	}
+
+	r := EditHook{
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+		Events: hook.Events,
+		Active: true,
+	}
+	path := fmt.Sprintf("/repos/%s/hooks/%s", repo, hook.ID)
+	if _, err := client.do(ctx, http.MethodPatch, path, nil, r, nil); err != nil {
+		return sdk.WrapError(err, "unable to update webhook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
+
+// DeleteHook removes a webhook from the repository
+func (client *giteaClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	path := fmt.Sprintf("/repos/%s/hooks/%s", repo, hook.ID)
+	if _, err := client.do(ctx, http.MethodDelete, path, nil, nil, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return sdk.WrapError(err, "unable to delete webhook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
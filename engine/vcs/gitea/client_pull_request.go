@@ -0,0 +1,116 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// PullRequest returns a pull request by its number
+func (client *giteaClient) PullRequest(ctx context.Context, fullname string, id int) (sdk.VCSPullRequest, error) {
+	var pr PullRequest
+	path := fmt.Sprintf("/repos/%s/pulls/%d", fullname, id)
+	if _, err := client.do(ctx, http.MethodGet, path, nil, nil, &pr); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to get pull request %d", id)
+	}
+	return pr.ToVCSPullRequest(), nil
+}
+
+// PullRequests fetch all the opened pull request for a repository
+func (client *giteaClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	var prs []PullRequest
+	params := url.Values{}
+	params.Set("state", "open")
+	path := fmt.Sprintf("/repos/%s/pulls", fullname)
+	if err := client.getAll(ctx, path, params, func(raw json.RawMessage) (int, error) {
+		var page []PullRequest
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		prs = append(prs, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get pull requests")
+	}
+
+	res := make([]sdk.VCSPullRequest, 0, len(prs))
+	for _, pr := range prs {
+		res = append(res, pr.ToVCSPullRequest())
+	}
+	return res, nil
+}
+
+// PullRequestComment push a new comment on a pull request
+func (client *giteaClient) PullRequestComment(ctx context.Context, repo string, prRequest sdk.VCSPullRequestCommentRequest) error {
+	if client.DisableStatus {
+		log.Warning(ctx, "gitea.PullRequestComment>  ⚠ gitea statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, prRequest.ID)
+	if _, err := client.do(ctx, http.MethodPost, path, nil, CreateComment{Body: prRequest.Message}, nil); err != nil {
+		return sdk.WrapError(err, "unable to comment pull request %d", prRequest.ID)
+	}
+	return nil
+}
+
+// PullRequestCreate opens a pull request from head branch to base branch
+func (client *giteaClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	path := fmt.Sprintf("/repos/%s/pulls", repo)
+	req := CreatePullRequest{
+		Title: pr.Title,
+		Head:  pr.Head.Branch.DisplayID,
+		Base:  pr.Base.Branch.DisplayID,
+	}
+	var res PullRequest
+	if _, err := client.do(ctx, http.MethodPost, path, nil, req, &res); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to create pull request")
+	}
+	return res.ToVCSPullRequest(), nil
+}
+
+// ToVCSPullRequest converts a gitea pull request to sdk.VCSPullRequest
+func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
+	return sdk.VCSPullRequest{
+		ID:    pullr.Number,
+		URL:   pullr.HTMLURL,
+		Title: pullr.Title,
+		Base: sdk.VCSPushEvent{
+			Repo:     pullr.Base.Repo.FullName,
+			CloneURL: pullr.Base.Repo.CloneURL,
+			Branch: sdk.VCSBranch{
+				ID:           pullr.Base.Ref,
+				DisplayID:    pullr.Base.Ref,
+				LatestCommit: pullr.Base.Sha,
+			},
+			Commit: sdk.VCSCommit{
+				Hash: pullr.Base.Sha,
+			},
+		},
+		Head: sdk.VCSPushEvent{
+			Repo:     pullr.Head.Repo.FullName,
+			CloneURL: pullr.Head.Repo.CloneURL,
+			Branch: sdk.VCSBranch{
+				ID:           pullr.Head.Ref,
+				DisplayID:    pullr.Head.Ref,
+				LatestCommit: pullr.Head.Sha,
+			},
+			Commit: sdk.VCSCommit{
+				Hash: pullr.Head.Sha,
+			},
+		},
+		User: sdk.VCSAuthor{
+			Avatar:      pullr.User.AvatarURL,
+			DisplayName: pullr.User.FullName,
+			Email:       pullr.User.Email,
+			Name:        pullr.User.Login,
+		},
+		Closed: pullr.State == "closed" && !pullr.Merged,
+		Merged: pullr.Merged,
+	}
+}
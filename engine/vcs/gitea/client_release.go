@@ -0,0 +1,68 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release Create a release
+func (client *giteaClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
+	req := CreateRelease{
+		TagName: tagName,
+		Title:   title,
+		Note:    releaseNote,
+	}
+	var res Release
+	path := fmt.Sprintf("/repos/%s/releases", fullname)
+	if _, err := client.do(ctx, http.MethodPost, path, nil, req, &res); err != nil {
+		return nil, sdk.WrapError(err, "unable to create release %s on %s", tagName, fullname)
+	}
+
+	return &sdk.VCSRelease{
+		ID:        res.ID,
+		UploadURL: res.UploadURL,
+	}, nil
+}
+
+// UploadReleaseFile Attach a file into the release, releaseName is the id of the release
+func (client *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	defer r.Close() // nolint
+
+	// Stream the file as a multipart form to avoid loading it in memory
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("attachment", artifactName)
+		if err != nil {
+			pw.CloseWithError(err) // nolint
+			return
+		}
+		if _, err := io.Copy(part, r); err != nil {
+			pw.CloseWithError(err) // nolint
+			return
+		}
+		pw.CloseWithError(mw.Close()) // nolint
+	}()
+
+	params := url.Values{}
+	params.Set("name", artifactName)
+	path := fmt.Sprintf("/repos/%s/releases/%s/assets", repo, releaseName)
+	req, err := client.newRequest(ctx, http.MethodPost, path, params, pr)
+	if err != nil {
+		pr.Close() // nolint
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	if _, err := client.send(ctx, req, nil); err != nil {
+		pr.Close() // nolint
+		return sdk.WrapError(err, "unable to upload %s on release %s", artifactName, releaseName)
+	}
+	return nil
+}
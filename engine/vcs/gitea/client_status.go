@@ -0,0 +1,154 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type statusData struct {
+	pipName      string
+	desc         string
+	status       string
+	repoFullName string
+	hash         string
+	urlPipeline  string
+	context      string
+}
+
+// SetStatus Users with push access can create commit statuses for a given ref:
+func (client *giteaClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if client.DisableStatus {
+		log.Warning(ctx, "gitea.SetStatus>  ⚠ gitea statuses are disabled")
+		return nil
+	}
+
+	var data statusData
+	var err error
+	switch event.EventType {
+	case fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}):
+		data, err = processEventWorkflowNodeRun(event, client.uiURL, client.DisableStatusDetail)
+	default:
+		log.Error(ctx, "gitea.SetStatus> Unknown event %v", event)
+		return nil
+	}
+	if err != nil {
+		return sdk.WrapError(err, "Cannot process Event")
+	}
+
+	if data.status == "" {
+		log.Debug("gitea.SetStatus> Do not process event for current status: %v", event)
+		return nil
+	}
+
+	s := CreateStatus{
+		State:       data.status,
+		TargetURL:   data.urlPipeline,
+		Description: data.desc,
+		Context:     data.context,
+	}
+	path := fmt.Sprintf("/repos/%s/statuses/%s", data.repoFullName, data.hash)
+	var res Status
+	if _, err := client.do(ctx, http.MethodPost, path, nil, s, &res); err != nil {
+		return sdk.WrapError(err, "unable to create status on %s for %s", data.repoFullName, data.hash)
+	}
+
+	log.Debug("gitea.SetStatus> Status %d %s created at %v", res.ID, res.Context, res.CreatedAt)
+	return nil
+}
+
+func (client *giteaClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	var ss []Status
+	path := fmt.Sprintf("/repos/%s/commits/%s/statuses", repo, ref)
+	if err := client.getAll(ctx, path, nil, func(raw json.RawMessage) (int, error) {
+		var page []Status
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		ss = append(ss, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get statuses for %s", ref)
+	}
+
+	vcsStatuses := []sdk.VCSCommitStatus{}
+	for _, s := range ss {
+		if !strings.HasPrefix(s.Context, "CDS/") {
+			continue
+		}
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.CreatedAt,
+			Decription: s.Context,
+			Ref:        ref,
+			State:      processGiteaState(s),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processGiteaState(s Status) string {
+	switch s.State {
+	case "success":
+		return sdk.StatusSuccess
+	case "error", "failure":
+		return sdk.StatusFail
+	case "warning":
+		return sdk.StatusStopped
+	case "pending":
+		return sdk.StatusBuilding
+	default:
+		return sdk.StatusDisabled
+	}
+}
+
+func processEventWorkflowNodeRun(event sdk.Event, cdsUIURL string, disabledStatusDetail bool) (statusData, error) {
+	data := statusData{}
+	var eventNR sdk.EventRunWorkflowNode
+	if err := json.Unmarshal(event.Payload, &eventNR); err != nil {
+		return data, sdk.WrapError(err, "cannot unmarshal payload")
+	}
+	//We only manage status Success, Failure and Stopped
+	if eventNR.Status == sdk.StatusChecking ||
+		eventNR.Status == sdk.StatusDisabled ||
+		eventNR.Status == sdk.StatusNeverBuilt ||
+		eventNR.Status == sdk.StatusSkipped ||
+		eventNR.Status == sdk.StatusUnknown ||
+		eventNR.Status == sdk.StatusWaiting {
+		return data, nil
+	}
+
+	switch eventNR.Status {
+	case sdk.StatusFail:
+		data.status = "failure"
+	case sdk.StatusSuccess:
+		data.status = "success"
+	case sdk.StatusStopped:
+		data.status = "warning"
+	default:
+		data.status = "pending"
+	}
+	data.hash = eventNR.Hash
+	data.repoFullName = eventNR.RepositoryFullName
+	data.pipName = eventNR.NodeName
+
+	data.urlPipeline = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d",
+		cdsUIURL,
+		event.ProjectKey,
+		event.WorkflowName,
+		eventNR.Number,
+	)
+
+	//CDS can avoid sending gitea target url in status, if it's disable
+	if disabledStatusDetail {
+		data.urlPipeline = ""
+	}
+
+	data.context = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
+	data.desc = eventNR.NodeName + ": " + eventNR.Status
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns list of tags for a repo
+func (client *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	var tags []Tag
+	path := fmt.Sprintf("/repos/%s/tags", fullname)
+	if err := client.getAll(ctx, path, nil, func(raw json.RawMessage) (int, error) {
+		var page []Tag
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		tags = append(tags, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to get tags")
+	}
+
+	responseTags := make([]sdk.VCSTag, 0, len(tags))
+	for _, tag := range tags {
+		responseTags = append(responseTags, sdk.VCSTag{
+			Tag:     tag.Name,
+			Sha:     tag.ID,
+			Message: tag.Message,
+			Hash:    tag.Commit.SHA,
+		})
+	}
+	return responseTags, nil
+}
@@ -0,0 +1,27 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Error wraps gitea error format
+type Error struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("(gitea) %s", e.Message)
+}
+
+func (e Error) String() string {
+	return e.Error()
+}
+
+// errorAPI creates a new error
+func errorAPI(body []byte) error {
+	var res Error
+	_ = json.Unmarshal(body, &res)
+	return res
+}
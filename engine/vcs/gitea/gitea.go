@@ -0,0 +1,62 @@
+package gitea
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &giteaClient{}
+	_ sdk.VCSServer           = &giteaConsumer{}
+)
+
+// giteaClient is a Gitea (or Forgejo) wrapper for CDS vcs. interface
+type giteaClient struct {
+	ClientID            string
+	OAuthToken          string
+	RefreshToken        string
+	DisableStatus       bool
+	DisableStatusDetail bool
+	Cache               cache.Store
+	apiURL              string
+	uiURL               string
+	proxyURL            string
+}
+
+// giteaConsumer implements vcs.Server and it's used to instantiate a giteaClient
+type giteaConsumer struct {
+	ClientID            string `json:"client-id"`
+	ClientSecret        string `json:"-"`
+	URL                 string `json:"url"`
+	CallbackURL         string `json:"callback-url"`
+	Cache               cache.Store
+	uiURL               string
+	apiURL              string
+	proxyURL            string
+	disableStatus       bool
+	disableStatusDetail bool
+}
+
+// New creates a new gitea consumer, URL is the root URL of the Gitea or Forgejo server
+func New(ClientID, ClientSecret, URL, callbackURL, uiURL, proxyURL string, store cache.Store, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+	URL = strings.TrimSuffix(URL, "/")
+	return &giteaConsumer{
+		ClientID:            ClientID,
+		ClientSecret:        ClientSecret,
+		URL:                 URL,
+		CallbackURL:         callbackURL,
+		Cache:               store,
+		apiURL:              URL + "/api/v1",
+		uiURL:               uiURL,
+		proxyURL:            proxyURL,
+		disableStatus:       disableStatus,
+		disableStatusDetail: disableStatusDetail,
+	}
+}
+
+func (c *giteaClient) GetAccessToken(_ context.Context) string {
+	return c.OAuthToken
+}
@@ -0,0 +1,293 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	query  string
+	auth   string
+	body   []byte
+	header http.Header
+}
+
+// newFixtureServer serves recorded gitea responses from testdata, routes are indexed by "METHOD path"
+func newFixtureServer(t *testing.T, routes map[string]string) (*httptest.Server, *[]recordedRequest) {
+	var reqs []recordedRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		reqs = append(reqs, recordedRequest{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			query:  r.URL.RawQuery,
+			auth:   r.Header.Get("Authorization"),
+			body:   body,
+			header: r.Header,
+		})
+
+		fixture, ok := routes[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`)) // nolint
+			return
+		}
+		if fixture == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		b, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write(b) // nolint
+	}))
+	return ts, &reqs
+}
+
+func newTestClient(ts *httptest.Server) *giteaClient {
+	consumer := New("client-id", "client-secret", ts.URL, "http://cds.example.com/callback", "https://cds.example.com", "", nil, false, false).(*giteaConsumer)
+	return consumer.newClient("my-token", "my-refresh-token")
+}
+
+func TestRepos(t *testing.T) {
+	ts, reqs := newFixtureServer(t, map[string]string{
+		"GET /api/v1/user/repos":        "user_repos.json",
+		"GET /api/v1/repos/cds/my-repo": "repo.json",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	repos, err := client.Repos(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "cds/my-repo", repos[0].Fullname)
+	assert.Equal(t, "https://gitea.example.com/cds/my-repo.git", repos[0].HTTPCloneURL)
+	assert.Equal(t, "git@gitea.example.com:cds/my-repo.git", repos[0].SSHCloneURL)
+	assert.Equal(t, "token my-token", (*reqs)[0].auth)
+	assert.Contains(t, (*reqs)[0].query, "limit=50")
+	assert.Contains(t, (*reqs)[0].query, "page=1")
+
+	repo, err := client.RepoByFullname(context.TODO(), "cds/my-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "1", repo.ID)
+
+	_, err = client.RepoByFullname(context.TODO(), "cds/unknown")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrRepoNotFound))
+}
+
+func TestPagination(t *testing.T) {
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("X-Total-Count", "51")
+		var tags []Tag
+		n := 1
+		if page == "1" {
+			n = pageLimit
+		}
+		for i := 0; i < n; i++ {
+			tags = append(tags, Tag{Name: "v" + page})
+		}
+		json.NewEncoder(w).Encode(tags) // nolint
+	}))
+	defer ts.Close()
+
+	tags, err := newTestClient(ts).Tags(context.TODO(), "cds/my-repo")
+	require.NoError(t, err)
+	assert.Len(t, tags, pageLimit+1)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+func TestBranchesAndTags(t *testing.T) {
+	ts, _ := newFixtureServer(t, map[string]string{
+		"GET /api/v1/repos/cds/my-repo":                       "repo.json",
+		"GET /api/v1/repos/cds/my-repo/branches":              "branches.json",
+		"GET /api/v1/repos/cds/my-repo/branches/feat%2Fgitea": "branches_feat.json",
+		"GET /api/v1/repos/cds/my-repo/tags":                  "tags.json",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	branches, err := client.Branches(context.TODO(), "cds/my-repo")
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, "main", sdk.GetDefaultBranch(branches).DisplayID)
+	assert.Equal(t, "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2", branches[0].LatestCommit)
+
+	branch, err := client.Branch(context.TODO(), "cds/my-repo", "feat/gitea")
+	require.NoError(t, err)
+	assert.Equal(t, "feat/gitea", branch.DisplayID)
+	assert.False(t, branch.Default)
+
+	tags, err := client.Tags(context.TODO(), "cds/my-repo")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1.0.0", tags[0].Tag)
+	assert.Equal(t, "4c1d9e4a0f0c7d6b2a5e3f1b8c9d0e1f2a3b4c5d", tags[0].Hash)
+}
+
+func TestCommit(t *testing.T) {
+	ts, _ := newFixtureServer(t, map[string]string{
+		"GET /api/v1/repos/cds/my-repo/git/commits/9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2": "commit.json",
+	})
+	defer ts.Close()
+
+	c, err := newTestClient(ts).Commit(context.TODO(), "cds/my-repo", "9d2ec5a2a4bd3d8b1d4c8bd0e3cf5bd4a1b0e7f2")
+	require.NoError(t, err)
+	assert.Equal(t, "john", c.Author.Name)
+	assert.Equal(t, "John Doe", c.Author.DisplayName)
+	assert.Equal(t, "john@example.com", c.Author.Email)
+	assert.Equal(t, time.Date(2020, 4, 10, 10, 0, 0, 0, time.UTC).Unix()*1000, c.Timestamp)
+}
+
+func TestPullRequest(t *testing.T) {
+	ts, reqs := newFixtureServer(t, map[string]string{
+		"GET /api/v1/repos/cds/my-repo/pulls/3":            "pull.json",
+		"POST /api/v1/repos/cds/my-repo/issues/3/comments": "",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	pr, err := client.PullRequest(context.TODO(), "cds/my-repo", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, pr.ID)
+	assert.Equal(t, "feat/gitea", pr.Head.Branch.DisplayID)
+	assert.Equal(t, "main", pr.Base.Branch.DisplayID)
+	assert.False(t, pr.Closed)
+	assert.False(t, pr.Merged)
+
+	require.NoError(t, client.PullRequestComment(context.TODO(), "cds/my-repo", sdk.VCSPullRequestCommentRequest{
+		VCSPullRequest: sdk.VCSPullRequest{ID: 3},
+		Message:        "Build failed",
+	}))
+	last := (*reqs)[len(*reqs)-1]
+	assert.JSONEq(t, `{"body":"Build failed"}`, string(last.body))
+}
+
+func TestHooks(t *testing.T) {
+	ts, reqs := newFixtureServer(t, map[string]string{
+		"GET /api/v1/repos/cds/my-repo/hooks":      "hooks.json",
+		"POST /api/v1/repos/cds/my-repo/hooks":     "hooks_created.json",
+		"DELETE /api/v1/repos/cds/my-repo/hooks/7": "",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	h, err := client.GetHook(context.TODO(), "cds/my-repo", "https://cds.example.com/webhook/uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "7", h.ID)
+
+	_, err = client.GetHook(context.TODO(), "cds/my-repo", "https://cds.example.com/webhook/other")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	hook := sdk.VCSHook{URL: "https://cds.example.com/webhook/uuid"}
+	require.NoError(t, client.CreateHook(context.TODO(), "cds/my-repo", &hook))
+	assert.Equal(t, "7", hook.ID)
+	var created CreateHook
+	require.NoError(t, json.Unmarshal((*reqs)[len(*reqs)-1].body, &created))
+	assert.Equal(t, "gitea", created.Type)
+	assert.Equal(t, "json", created.Config["content_type"])
+	assert.Equal(t, []string{"push", "delete"}, created.Events)
+
+	require.NoError(t, client.DeleteHook(context.TODO(), "cds/my-repo", hook))
+}
+
+func TestStatuses(t *testing.T) {
+	ts, reqs := newFixtureServer(t, map[string]string{
+		"GET /api/v1/repos/cds/my-repo/commits/9d2ec5a/statuses": "statuses.json",
+		"POST /api/v1/repos/cds/my-repo/statuses/9d2ec5a":        "status_created.json",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	ss, err := client.ListStatuses(context.TODO(), "cds/my-repo", "9d2ec5a")
+	require.NoError(t, err)
+	require.Len(t, ss, 1)
+	assert.Equal(t, sdk.StatusSuccess, ss[0].State)
+
+	payload, _ := json.Marshal(sdk.EventRunWorkflowNode{
+		Number:             1,
+		NodeName:           "build",
+		Status:             sdk.StatusFail,
+		Hash:               "9d2ec5a",
+		RepositoryFullName: "cds/my-repo",
+	})
+	require.NoError(t, client.SetStatus(context.TODO(), sdk.Event{
+		EventType:    "sdk.EventRunWorkflowNode",
+		ProjectKey:   "PROJ",
+		WorkflowName: "wf",
+		Payload:      payload,
+	}))
+	var status CreateStatus
+	require.NoError(t, json.Unmarshal((*reqs)[len(*reqs)-1].body, &status))
+	assert.Equal(t, "failure", status.State)
+	assert.Equal(t, "CDS/PROJ-wf-build", status.Context)
+	assert.Equal(t, "https://cds.example.com/project/PROJ/workflow/wf/run/1", status.TargetURL)
+}
+
+func TestRelease(t *testing.T) {
+	ts, reqs := newFixtureServer(t, map[string]string{
+		"POST /api/v1/repos/cds/my-repo/releases":          "release.json",
+		"POST /api/v1/repos/cds/my-repo/releases/5/assets": "release_asset.json",
+	})
+	defer ts.Close()
+	client := newTestClient(ts)
+
+	r, err := client.Release(context.TODO(), "cds/my-repo", "v1.0.0", "v1.0.0", "First release")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), r.ID)
+
+	require.NoError(t, client.UploadReleaseFile(context.TODO(), "cds/my-repo", "5", r.UploadURL, "app.tar.gz",
+		ioutil.NopCloser(strings.NewReader("content"))))
+	last := (*reqs)[len(*reqs)-1]
+	assert.Equal(t, "name=app.tar.gz", last.query)
+	mr := multipart.NewReader(strings.NewReader(string(last.body)), strings.TrimPrefix(last.header.Get("Content-Type"), "multipart/form-data; boundary="))
+	part, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "attachment", part.FormName())
+	content, _ := ioutil.ReadAll(part)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestGetAuthorizedClientRefresh(t *testing.T) {
+	var form string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/login/oauth/access_token", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		form = string(b)
+		f, _ := ioutil.ReadFile(filepath.Join("testdata", "access_token.json"))
+		w.Write(f) // nolint
+	}))
+	defer ts.Close()
+
+	consumer := New("client-id", "client-secret", ts.URL+"/", "http://cds.example.com/callback", "", "", nil, false, false)
+
+	_, u, err := consumer.AuthorizeRedirect(context.TODO())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(u, ts.URL+"/login/oauth/authorize?"))
+
+	c, err := consumer.GetAuthorizedClient(context.TODO(), "old-token", "old-refresh-token", time.Now().Add(-2*time.Hour).Unix())
+	require.NoError(t, err)
+	assert.Equal(t, "new-access-token", c.GetAccessToken(context.TODO()))
+	assert.Contains(t, form, "grant_type=refresh_token")
+	assert.Contains(t, form, "refresh_token=old-refresh-token")
+}
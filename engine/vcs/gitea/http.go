@@ -0,0 +1,161 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pageLimit is the number of items asked per page, Gitea default max is 50
+const pageLimit = 50
+
+// Gitea http var
+var (
+	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+)
+
+func (consumer *giteaConsumer) postForm(url string, data url.Values, headers map[string][]string) (int, []byte, error) {
+	body := strings.NewReader(data.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for k, h := range headers {
+		for i := range h {
+			req.Header.Add(k, h[i])
+		}
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, err
+	}
+
+	return res.StatusCode, resBody, nil
+}
+
+func (client *giteaClient) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader) (*http.Request, error) {
+	uri, err := url.Parse(client.apiURL + path)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if len(params) > 0 {
+		uri.RawQuery = params.Encode()
+	}
+
+	req, err := http.NewRequest(method, uri.String(), body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", fmt.Sprintf("token %s", client.OAuthToken))
+
+	log.Debug("Gitea API>> Request %s %s", method, req.URL.String())
+	return req, nil
+}
+
+// do sends a JSON request to the Gitea API and unmarshal the response in v if not nil
+func (client *giteaClient) do(ctx context.Context, method, path string, params url.Values, in interface{}, v interface{}) (http.Header, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot marshal body %+v", in)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := client.newRequest(ctx, method, path, params, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return client.send(ctx, req, v)
+}
+
+func (client *giteaClient) send(ctx context.Context, req *http.Request, v interface{}) (http.Header, error) {
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, sdk.WrapError(err, "HTTP Error")
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	case res.StatusCode == http.StatusForbidden:
+		return nil, sdk.WithStack(sdk.ErrForbidden)
+	case res.StatusCode == http.StatusUnauthorized:
+		return nil, sdk.WithStack(sdk.ErrUnauthorized)
+	case res.StatusCode >= 400:
+		log.Warning(ctx, "giteaClient.do> %s %s: (%d) %s", req.Method, req.URL.String(), res.StatusCode, string(body))
+		return nil, sdk.NewError(sdk.ErrWrongRequest, errorAPI(body))
+	}
+
+	if v == nil || len(body) == 0 {
+		return res.Header, nil
+	}
+	return res.Header, sdk.WithStack(json.Unmarshal(body, v))
+}
+
+// getAll calls the given path page after page, appendPage should decode the
+// page and return the number of items in it
+func (client *giteaClient) getAll(ctx context.Context, path string, params url.Values, appendPage func(json.RawMessage) (int, error)) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("limit", strconv.Itoa(pageLimit))
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return sdk.WithStack(ctx.Err())
+		}
+		params.Set("page", strconv.Itoa(page))
+
+		var raw json.RawMessage
+		header, err := client.do(ctx, http.MethodGet, path, params, nil, &raw)
+		if err != nil {
+			return err
+		}
+		n, err := appendPage(raw)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+
+		// Stop when the last page is reached, using the total count header when available
+		if n < pageLimit {
+			return nil
+		}
+		if total, err := strconv.Atoi(header.Get("X-Total-Count")); err == nil && page*pageLimit >= total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Gitea access tokens are valid for one hour by default
+const accessTokenTTL = time.Hour
+
+// AuthorizeRedirect returns the request token, the Authorize Gitea URL
+func (consumer *giteaConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	requestToken, err := sdk.GenerateHash()
+	if err != nil {
+		return "", "", err
+	}
+
+	val := url.Values{}
+	val.Add("client_id", consumer.ClientID)
+	val.Add("redirect_uri", consumer.CallbackURL)
+	val.Add("response_type", "code")
+	val.Add("state", requestToken)
+
+	authorizeURL := fmt.Sprintf("%s/login/oauth/authorize?%s", consumer.URL, val.Encode())
+
+	return requestToken, authorizeURL, nil
+}
+
+// AuthorizeToken returns the authorized token (and its refresh_token)
+// from the request token and the verifier got on authorize url
+func (consumer *giteaConsumer) AuthorizeToken(ctx context.Context, _, code string) (string, string, error) {
+	log.Debug("AuthorizeToken> Gitea send code %s", code)
+
+	params := url.Values{}
+	params.Add("code", code)
+	params.Add("grant_type", "authorization_code")
+	params.Add("redirect_uri", consumer.CallbackURL)
+
+	return consumer.accessToken(params)
+}
+
+// RefreshToken returns the refreshed authorized token
+func (consumer *giteaConsumer) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+	params := url.Values{}
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", "refresh_token")
+
+	return consumer.accessToken(params)
+}
+
+func (consumer *giteaConsumer) accessToken(params url.Values) (string, string, error) {
+	params.Add("client_id", consumer.ClientID)
+	params.Add("client_secret", consumer.ClientSecret)
+
+	headers := map[string][]string{}
+	headers["Accept"] = []string{"application/json"}
+
+	status, res, err := consumer.postForm(consumer.URL+"/login/oauth/access_token", params, headers)
+	if err != nil {
+		return "", "", err
+	}
+
+	if status < 200 || status >= 400 {
+		return "", "", fmt.Errorf("Gitea error (%d) %s ", status, string(res))
+	}
+
+	var resp AccessToken
+	if err := json.Unmarshal(res, &resp); err != nil {
+		return "", "", fmt.Errorf("Unable to parse gitea response (%d) %s ", status, string(res))
+	}
+
+	return resp.AccessToken, resp.RefreshToken, nil
+}
+
+// keep client in memory
+var instancesAuthorizedClient = map[string]*giteaClient{}
+
+// GetAuthorizedClient returns an authorized client
+func (consumer *giteaConsumer) GetAuthorizedClient(ctx context.Context, accessToken, refreshToken string, created int64) (sdk.VCSAuthorizedClient, error) {
+	createdTime := time.Unix(created, 0)
+
+	c, ok := instancesAuthorizedClient[accessToken]
+	if createdTime.Add(accessTokenTTL).Before(time.Now()) {
+		if ok {
+			delete(instancesAuthorizedClient, accessToken)
+		}
+		newAccessToken, newRefreshToken, err := consumer.RefreshToken(ctx, refreshToken)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot refresh token")
+		}
+		if newRefreshToken == "" {
+			newRefreshToken = refreshToken
+		}
+		c = consumer.newClient(newAccessToken, newRefreshToken)
+		instancesAuthorizedClient[newAccessToken] = c
+	} else if !ok {
+		c = consumer.newClient(accessToken, refreshToken)
+		instancesAuthorizedClient[accessToken] = c
+	}
+
+	return c, nil
+}
+
+func (consumer *giteaConsumer) newClient(accessToken, refreshToken string) *giteaClient {
+	return &giteaClient{
+		ClientID:            consumer.ClientID,
+		OAuthToken:          accessToken,
+		RefreshToken:        refreshToken,
+		Cache:               consumer.Cache,
+		apiURL:              consumer.apiURL,
+		uiURL:               consumer.uiURL,
+		DisableStatus:       consumer.disableStatus,
+		DisableStatusDetail: consumer.disableStatusDetail,
+		proxyURL:            consumer.proxyURL,
+	}
+}
@@ -0,0 +1,194 @@
+package gitea
+
+import "time"
+
+// AccessToken represents a gitea oauth2 access token
+type AccessToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// User represents a gitea user or organization
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Repository represents a gitea repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         User   `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Fork          bool   `json:"fork"`
+	HTMLURL       string `json:"html_url"`
+	SSHURL        string `json:"ssh_url"`
+	CloneURL      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// PayloadUser represents the author or committer of a commit
+type PayloadUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
+}
+
+// PayloadCommit represents the commit of a branch
+type PayloadCommit struct {
+	ID        string      `json:"id"`
+	Message   string      `json:"message"`
+	URL       string      `json:"url"`
+	Author    PayloadUser `json:"author"`
+	Committer PayloadUser `json:"committer"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// Branch represents a gitea branch
+type Branch struct {
+	Name   string        `json:"name"`
+	Commit PayloadCommit `json:"commit"`
+}
+
+// CommitMeta contains the sha of a commit
+type CommitMeta struct {
+	URL     string    `json:"url"`
+	SHA     string    `json:"sha"`
+	Created time.Time `json:"created"`
+}
+
+// Tag represents a gitea tag
+type Tag struct {
+	Name    string     `json:"name"`
+	Message string     `json:"message"`
+	ID      string     `json:"id"`
+	Commit  CommitMeta `json:"commit"`
+}
+
+// CommitUser represents the git author or committer of a commit
+type CommitUser struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// RepoCommit contains the git information of a commit
+type RepoCommit struct {
+	Message   string     `json:"message"`
+	Author    CommitUser `json:"author"`
+	Committer CommitUser `json:"committer"`
+}
+
+// Commit represents a gitea commit
+type Commit struct {
+	SHA     string       `json:"sha"`
+	HTMLURL string       `json:"html_url"`
+	Commit  RepoCommit   `json:"commit"`
+	Author  *User        `json:"author"`
+	Parents []CommitMeta `json:"parents"`
+}
+
+// Compare represents the commits between two refs
+type Compare struct {
+	TotalCommits int      `json:"total_commits"`
+	Commits      []Commit `json:"commits"`
+}
+
+// PRBranchInfo represents the base or the head of a pull request
+type PRBranchInfo struct {
+	Name   string     `json:"label"`
+	Ref    string     `json:"ref"`
+	Sha    string     `json:"sha"`
+	RepoID int64      `json:"repo_id"`
+	Repo   Repository `json:"repo"`
+}
+
+// PullRequest represents a gitea pull request
+type PullRequest struct {
+	ID      int64        `json:"id"`
+	Number  int          `json:"number"`
+	HTMLURL string       `json:"html_url"`
+	User    User         `json:"user"`
+	Title   string       `json:"title"`
+	Body    string       `json:"body"`
+	State   string       `json:"state"`
+	Merged  bool         `json:"merged"`
+	Head    PRBranchInfo `json:"head"`
+	Base    PRBranchInfo `json:"base"`
+}
+
+// CreatePullRequest is the body used to create a pull request
+type CreatePullRequest struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// CreateComment is the body used to comment an issue or a pull request
+type CreateComment struct {
+	Body string `json:"body"`
+}
+
+// Hook represents a gitea repository webhook
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// CreateHook is the body used to create a webhook
+type CreateHook struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// EditHook is the body used to update a webhook
+type EditHook struct {
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// Status represents a gitea commit status
+type Status struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"status"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateStatus is the body used to create a commit status
+type CreateStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// CreateRelease is the body used to create a release
+type CreateRelease struct {
+	TagName string `json:"tag_name"`
+	Title   string `json:"name"`
+	Note    string `json:"body"`
+}
+
+// Release represents a gitea release
+type Release struct {
+	ID        int64  `json:"id"`
+	TagName   string `json:"tag_name"`
+	Title     string `json:"name"`
+	HTMLURL   string `json:"html_url"`
+	UploadURL string `json:"upload_url"`
+}
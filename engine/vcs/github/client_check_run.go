@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// The Checks API is still in preview on Github Enterprise
+const checksMediaType = "application/vnd.github.antiope-preview+json"
+
+// Github refuses check run summaries bigger than 65535 characters
+const checkRunMaxSummary = 65535
+
+// Github refuses commit status descriptions bigger than 140 characters
+const statusMaxDescription = 140
+
+// isChecksAPIUnavailable returns true if the response status means that the Checks API can't be used
+// with the token of the client. Only Github Apps can write check runs, OAuth tokens get a 403.
+func isChecksAPIUnavailable(status int) bool {
+	return status == http.StatusForbidden || status == http.StatusNotFound
+}
+
+// setCheckRun creates or updates the check run of a node with its detailed report
+// https://developer.github.com/v3/checks/runs/#create-a-check-run
+func (g *githubClient) setCheckRun(ctx context.Context, data statusData) error {
+	now := time.Now()
+	checkRun := CheckRun{
+		Name:        data.context,
+		HeadSHA:     data.hash,
+		DetailsURL:  data.urlPipeline,
+		Status:      "completed",
+		Conclusion:  checkRunConclusion(data.nodeStatus),
+		CompletedAt: &now,
+		Output: &CheckRunOutput{
+			Title:   data.report.Title,
+			Summary: data.report.Summary,
+		},
+	}
+	if len(checkRun.Output.Summary) > checkRunMaxSummary {
+		checkRun.Output.Summary = checkRun.Output.Summary[:checkRunMaxSummary]
+	}
+	for _, a := range data.report.Annotations {
+		checkRun.Output.Annotations = append(checkRun.Output.Annotations, CheckRunAnnotation{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: a.Level,
+			Title:           a.Title,
+			Message:         a.Message,
+		})
+	}
+
+	// Update the existing check run to keep only one check per node on the commit
+	existing, err := g.checkRunByName(ctx, data.repoFullName, data.hash, data.context)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(checkRun)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal github check run")
+	}
+
+	var res *http.Response
+	var expectedStatus int
+	if existing == nil {
+		path := fmt.Sprintf("/repos/%s/check-runs", data.repoFullName)
+		res, err = g.post(path, "application/json", bytes.NewReader(b), &postOptions{accept: checksMediaType})
+		expectedStatus = http.StatusCreated
+	} else {
+		path := fmt.Sprintf("/repos/%s/check-runs/%d", data.repoFullName, existing.ID)
+		res, err = g.patch(path, "application/json", bytes.NewReader(b), &postOptions{accept: checksMediaType})
+		expectedStatus = http.StatusOK
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to post check run")
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+	if isChecksAPIUnavailable(res.StatusCode) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "checks API is not available: %s", errorAPI(body))
+	}
+	if res.StatusCode != expectedStatus {
+		return sdk.WithStack(fmt.Errorf("unable to set check run on github. Status code : %d - Body: %s", res.StatusCode, body))
+	}
+
+	return nil
+}
+
+// checkRunByName returns the check run with the given name on a commit, or nil if not found
+// https://developer.github.com/v3/checks/runs/#list-check-runs-for-a-specific-ref
+func (g *githubClient) checkRunByName(ctx context.Context, repo, ref, name string) (*CheckRun, error) {
+	path := fmt.Sprintf("/repos/%s/commits/%s/check-runs?check_name=%s", repo, ref, url.QueryEscape(name))
+	status, body, _, err := g.get(ctx, path, withoutETag, withAccept(checksMediaType))
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list check runs")
+	}
+	if isChecksAPIUnavailable(status) {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "checks API is not available: %s", errorAPI(body))
+	}
+	if status >= 400 {
+		return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+	}
+
+	var runs CheckRuns
+	if err := json.Unmarshal(body, &runs); err != nil {
+		return nil, sdk.WrapError(err, "unable to parse github check runs")
+	}
+	for i := range runs.CheckRuns {
+		if runs.CheckRuns[i].Name == name {
+			return &runs.CheckRuns[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func checkRunConclusion(status string) string {
+	switch status {
+	case sdk.StatusSuccess:
+		return "success"
+	case sdk.StatusStopped:
+		return "cancelled"
+	case sdk.StatusFail:
+		return "failure"
+	default:
+		return "neutral"
+	}
+}
+
+// statusDescriptionWithReport returns the title of the report to be used as commit status description
+// when the report can't be sent as a check run
+func statusDescriptionWithReport(report sdk.VCSBuildReport) string {
+	if len(report.Title) > statusMaxDescription {
+		return report.Title[:statusMaxDescription-3] + "..."
+	}
+	return report.Title
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func TestSetStatusWithCheckRun(t *testing.T) {
+	log.SetLogger(t)
+
+	var existingCheckRun, checksForbidden bool
+	var checkRun CheckRun
+	var status CreateStatus
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/foo/bar/statuses/abcdef":
+			body, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &status))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/foo/bar/commits/abcdef/check-runs":
+			assert.Equal(t, "CDS/PROJ-wf-build", r.URL.Query().Get("check_name"))
+			assert.Equal(t, checksMediaType, r.Header.Get("Accept"))
+			if checksForbidden {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+			} else if existingCheckRun {
+				_, _ = w.Write([]byte(`{"total_count": 1, "check_runs": [{"id": 42, "name": "CDS/PROJ-wf-build"}]}`))
+			} else {
+				_, _ = w.Write([]byte(`{"total_count": 0, "check_runs": []}`))
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/foo/bar/check-runs",
+			r.Method == http.MethodPatch && r.URL.Path == "/repos/foo/bar/check-runs/42":
+			assert.Equal(t, checksMediaType, r.Header.Get("Accept"))
+			body, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &checkRun))
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			_, _ = w.Write([]byte(`{"id": 42}`))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &githubClient{GitHubAPIURL: srv.URL, uiURL: "http://cds"}
+
+	payload, _ := json.Marshal(sdk.EventRunWorkflowNode{
+		Number:             1,
+		Status:             sdk.StatusFail,
+		NodeName:           "build",
+		Hash:               "abcdef",
+		RepositoryFullName: "foo/bar",
+		Report: &sdk.VCSBuildReport{
+			Title:   "build: Fail - 2 tests, 1 failed, 0 skipped",
+			Summary: "CDS Report build#1.0",
+			Annotations: []sdk.VCSBuildReportAnnotation{
+				{Path: "main_test.go", StartLine: 12, EndLine: 12, Level: sdk.VCSBuildReportAnnotationFailure, Title: "TestKO", Message: "expected 1"},
+			},
+		},
+	})
+	event := sdk.Event{EventType: "sdk.EventRunWorkflowNode", ProjectKey: "PROJ", WorkflowName: "wf", Payload: payload}
+
+	require.NoError(t, c.SetStatus(context.Background(), event))
+	assert.Equal(t, []string{
+		"GET /repos/foo/bar/commits/abcdef/check-runs",
+		"POST /repos/foo/bar/check-runs",
+		"POST /repos/foo/bar/statuses/abcdef",
+	}, calls)
+	assert.Equal(t, "build: Fail", status.Description)
+	assert.Equal(t, "CDS/PROJ-wf-build", checkRun.Name)
+	assert.Equal(t, "abcdef", checkRun.HeadSHA)
+	assert.Equal(t, "completed", checkRun.Status)
+	assert.Equal(t, "failure", checkRun.Conclusion)
+	assert.Equal(t, "http://cds/project/PROJ/workflow/wf/run/1", checkRun.DetailsURL)
+	require.NotNil(t, checkRun.Output)
+	assert.Equal(t, "build: Fail - 2 tests, 1 failed, 0 skipped", checkRun.Output.Title)
+	require.Len(t, checkRun.Output.Annotations, 1)
+	assert.Equal(t, "main_test.go", checkRun.Output.Annotations[0].Path)
+	assert.Equal(t, "failure", checkRun.Output.Annotations[0].AnnotationLevel)
+
+	// The existing check run is updated
+	existingCheckRun = true
+	calls = nil
+	require.NoError(t, c.SetStatus(context.Background(), event))
+	assert.Equal(t, []string{
+		"GET /repos/foo/bar/commits/abcdef/check-runs",
+		"PATCH /repos/foo/bar/check-runs/42",
+		"POST /repos/foo/bar/statuses/abcdef",
+	}, calls)
+
+	// Without access to the Checks API, the report title is sent as the status description
+	checksForbidden = true
+	calls = nil
+	require.NoError(t, c.SetStatus(context.Background(), event))
+	assert.Equal(t, []string{
+		"GET /repos/foo/bar/commits/abcdef/check-runs",
+		"POST /repos/foo/bar/statuses/abcdef",
+	}, calls)
+	assert.Equal(t, "build: Fail - 2 tests, 1 failed, 0 skipped", status.Description)
+}
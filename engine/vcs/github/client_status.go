@@ -22,6 +22,8 @@ type statusData struct {
 	hash         string
 	urlPipeline  string
 	context      string
+	nodeStatus   string
+	report       *sdk.VCSBuildReport
 }
 
 //SetStatus Users with push access can create commit statuses for a given ref:
@@ -57,6 +59,17 @@ func (g *githubClient) SetStatus(ctx context.Context, event sdk.Event) error {
 		Context:     data.context,
 	}
 
+	// The detailed report is sent as a check run. The Checks API is only available to Github Apps,
+	// with other tokens the title of the report is sent as the commit status description.
+	if data.report != nil {
+		if err := g.setCheckRun(ctx, data); err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrForbidden) {
+				log.Warning(ctx, "github.SetStatus> unable to set check run: %v", err)
+			}
+			ghStatus.Description = statusDescriptionWithReport(*data.report)
+		}
+	}
+
 	path := fmt.Sprintf("/repos/%s/statuses/%s", data.repoFullName, data.hash)
 
 	b, err := json.Marshal(ghStatus)
@@ -189,5 +202,7 @@ func processEventWorkflowNodeRun(event sdk.Event, cdsUIURL string, disabledStatu
 
 	data.context = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
 	data.desc = eventNR.NodeName + ": " + eventNR.Status
+	data.nodeStatus = eventNR.Status
+	data.report = eventNR.Report
 	return data, nil
 }
@@ -120,9 +120,16 @@ func withETag(ctx context.Context, c *githubClient, req *http.Request, path stri
 }
 func withoutETag(ctx context.Context, c *githubClient, req *http.Request, path string) {}
 
+func withAccept(accept string) getArgFunc {
+	return func(ctx context.Context, c *githubClient, req *http.Request, path string) {
+		req.Header.Set("Accept", accept)
+	}
+}
+
 type postOptions struct {
 	skipDefaultBaseURL bool
 	asUser             bool
+	accept             string
 }
 
 func (c *githubClient) post(path string, bodyType string, body io.Reader, opts *postOptions) (*http.Response, error) {
@@ -140,7 +147,11 @@ func (c *githubClient) post(path string, bodyType string, body io.Reader, opts *
 
 	req.Header.Set("Content-Type", bodyType)
 	req.Header.Set("User-Agent", "CDS-gh_client_id="+c.ClientID)
-	req.Header.Add("Accept", "application/json")
+	if opts.accept != "" {
+		req.Header.Add("Accept", opts.accept)
+	} else {
+		req.Header.Add("Accept", "application/json")
+	}
 	if opts.asUser && c.token != "" {
 		req.SetBasicAuth(c.username, c.token)
 	} else {
@@ -169,7 +180,11 @@ func (c *githubClient) patch(path string, bodyType string, body io.Reader, opts
 		req.Header.Set("Content-Type", bodyType)
 	}
 	req.Header.Set("User-Agent", "CDS-gh_client_id="+c.ClientID)
-	req.Header.Add("Accept", "application/json")
+	if opts.accept != "" {
+		req.Header.Add("Accept", opts.accept)
+	} else {
+		req.Header.Add("Accept", "application/json")
+	}
 	if opts.asUser && c.token != "" {
 		req.SetBasicAuth(c.username, c.token)
 	} else {
@@ -437,6 +437,42 @@ type Status struct {
 	} `json:"creator"`
 }
 
+// CheckRun represents a check run on a commit
+// https://developer.github.com/v3/checks/runs/
+type CheckRun struct {
+	ID          int64           `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	HeadSHA     string          `json:"head_sha,omitempty"`
+	DetailsURL  string          `json:"details_url,omitempty"`
+	Status      string          `json:"status"`
+	Conclusion  string          `json:"conclusion,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Output      *CheckRunOutput `json:"output,omitempty"`
+}
+
+// CheckRunOutput represents the output of a check run
+type CheckRunOutput struct {
+	Title       string               `json:"title"`
+	Summary     string               `json:"summary"`
+	Annotations []CheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// CheckRunAnnotation represents an annotation on a file of a check run
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+// CheckRuns represents a list of check runs
+type CheckRuns struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
 //RateLimit represents Rate Limit API
 type RateLimit struct {
 	Resources struct {
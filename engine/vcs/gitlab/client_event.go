@@ -2,33 +2,276 @@ package gitlab
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"time"
 
+	"github.com/xanzy/go-gitlab"
+
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
-//GetEvents is not implemented
+const eventsPerPage = 100
+
+// GetEvents calls Gitlab project events API and returns gitlab.ContributionEvent as []interface{}
 func (c *gitlabClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
-	return nil, 0.0, fmt.Errorf("Not implemented on Gitlab")
+	log.Debug("gitlabClient.GetEvents> loading events for %s after %v", repo, dateRef)
+	interval := 60 * time.Second
+
+	if isRateLimitReached() {
+		return nil, rateLimitResetDelay(interval), ErrorRateLimit
+	}
+
+	// The 'after' filter only accepts a date and excludes it, so we take the day before
+	// and then filter on the creation time of each event
+	after := gitlab.ISOTime(dateRef.AddDate(0, 0, -1))
+	opt := &gitlab.ListContributionEventsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: eventsPerPage},
+		After:       &after,
+	}
+
+	events := []interface{}{}
+	for {
+		page, resp, err := c.client.Events.ListProjectVisibleEvents(repo, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			if isRateLimitReached() {
+				return nil, rateLimitResetDelay(interval), ErrorRateLimit
+			}
+			return nil, interval, sdk.WrapError(err, "unable to get events on %s", repo)
+		}
+
+		var olderEventFound bool
+		for _, e := range page {
+			if e.CreatedAt == nil || !e.CreatedAt.After(dateRef) {
+				olderEventFound = true
+				continue
+			}
+			if e.TargetType == "MergeRequest" && e.ActionName != "opened" && e.ActionName != "reopened" {
+				continue
+			}
+			events = append(events, *e)
+		}
+
+		// Events are sorted from the newest to the oldest
+		if olderEventFound || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	log.Debug("gitlabClient.GetEvents> Found %d events...", len(events))
+	return events, interval, nil
 }
 
-//PushEvents is not implemented
-func (c *gitlabClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
-	return nil, fmt.Errorf("Not implemented on Gitlab")
+// decodeEvents casts events received from GetEvents, they could have been serialized in between
+func decodeEvents(iEvents []interface{}) ([]gitlab.ContributionEvent, error) {
+	btes, err := json.Marshal(iEvents)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var events []gitlab.ContributionEvent
+	if err := json.Unmarshal(btes, &events); err != nil {
+		return nil, sdk.WrapError(err, "unable to decode gitlab events")
+	}
+	return events, nil
 }
 
-//CreateEvents is not implemented
-func (c *gitlabClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
-	return nil, fmt.Errorf("Not implemented on Gitlab")
+func isBranchEvent(e gitlab.ContributionEvent, action string) bool {
+	return e.PushData.RefType == "branch" && e.PushData.Action == action
 }
 
-//DeleteEvents is not implemented
-func (c *gitlabClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
-	return nil, fmt.Errorf("Not implemented on Gitlab")
+// PushEvents returns push events as commits
+func (c *gitlabClient) PushEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSPushEvent, error) {
+	events, err := decodeEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	lastCommitPerBranch := map[string]sdk.VCSCommit{}
+	for _, e := range events {
+		if !isBranchEvent(e, "pushed") && !isBranchEvent(e, "created") {
+			continue
+		}
+		if e.PushData.CommitTo == "" || e.CreatedAt == nil {
+			continue
+		}
+		commit := sdk.VCSCommit{
+			Hash:      e.PushData.CommitTo,
+			Message:   e.PushData.CommitTitle,
+			Timestamp: e.CreatedAt.Unix() * 1000,
+			Author: sdk.VCSAuthor{
+				DisplayName: e.Author.Name,
+				Name:        e.Author.Username,
+				Avatar:      e.Author.AvatarURL,
+			},
+		}
+		l, b := lastCommitPerBranch[e.PushData.Ref]
+		if !b || l.Timestamp < commit.Timestamp {
+			lastCommitPerBranch[e.PushData.Ref] = commit
+		}
+	}
+
+	res := []sdk.VCSPushEvent{}
+	for b, commit := range lastCommitPerBranch {
+		branch, err := c.Branch(ctx, repo, b)
+		if err != nil || branch == nil {
+			log.Debug("gitlabClient.PushEvents> Unable to find branch %s in %s : %v", b, repo, err)
+			continue
+		}
+		res = append(res, sdk.VCSPushEvent{
+			Branch: *branch,
+			Commit: commit,
+			Repo:   repo,
+		})
+	}
+
+	return res, nil
+}
+
+// CreateEvents checks create events from a event list
+func (c *gitlabClient) CreateEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSCreateEvent, error) {
+	events, err := decodeEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSCreateEvent{}
+	for _, e := range events {
+		if !isBranchEvent(e, "created") {
+			continue
+		}
+		branch, err := c.Branch(ctx, repo, e.PushData.Ref)
+		if err != nil || branch == nil {
+			log.Debug("gitlabClient.CreateEvents> Unable to find branch %s in %s : %v", e.PushData.Ref, repo, err)
+			continue
+		}
+		commit, err := c.Commit(ctx, repo, branch.LatestCommit)
+		if err != nil {
+			log.Warning(ctx, "gitlabClient.CreateEvents> Unable to find commit %s in %s : %v", branch.LatestCommit, repo, err)
+			continue
+		}
+		res = append(res, sdk.VCSCreateEvent{
+			Branch: *branch,
+			Commit: commit,
+		})
+	}
+
+	log.Debug("gitlabClient.CreateEvents> found %d create events : %#v", len(res), res)
+	return res, nil
+}
+
+// DeleteEvents checks delete events from a event list
+func (c *gitlabClient) DeleteEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	events, err := decodeEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSDeleteEvent{}
+	for _, e := range events {
+		if !isBranchEvent(e, "removed") {
+			continue
+		}
+		res = append(res, sdk.VCSDeleteEvent{
+			Branch: sdk.VCSBranch{
+				DisplayID: e.PushData.Ref,
+			},
+		})
+	}
+
+	log.Debug("gitlabClient.DeleteEvents> found %d delete events : %#v", len(res), res)
+	return res, nil
+}
+
+// PullRequestEvents checks merge request events from a event list
+func (c *gitlabClient) PullRequestEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	events, err := decodeEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSPullRequestEvent{}
+	projects := map[int]*gitlab.Project{}
+	seen := map[int]bool{}
+	for _, e := range events {
+		if e.TargetType != "MergeRequest" || seen[e.TargetIID] {
+			continue
+		}
+		seen[e.TargetIID] = true
+
+		mr, _, err := c.client.MergeRequests.GetMergeRequest(repo, e.TargetIID, nil, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Warning(ctx, "gitlabClient.PullRequestEvents> Unable to get merge request %d in %s : %v", e.TargetIID, repo, err)
+			continue
+		}
+		if mr.State != "opened" {
+			continue
+		}
+
+		source, err := c.project(ctx, projects, mr.SourceProjectID)
+		if err != nil {
+			log.Warning(ctx, "gitlabClient.PullRequestEvents> Unable to get source project %d : %v", mr.SourceProjectID, err)
+			continue
+		}
+		target, err := c.project(ctx, projects, mr.TargetProjectID)
+		if err != nil {
+			log.Warning(ctx, "gitlabClient.PullRequestEvents> Unable to get target project %d : %v", mr.TargetProjectID, err)
+			continue
+		}
+
+		author := sdk.VCSAuthor{
+			Name:        mr.Author.Username,
+			DisplayName: mr.Author.Name,
+		}
+		baseSha := mr.DiffRefs.BaseSha
+
+		res = append(res, sdk.VCSPullRequestEvent{
+			Action: e.ActionName,
+			Repo:   source.PathWithNamespace,
+			Head: sdk.VCSPushEvent{
+				Branch: sdk.VCSBranch{
+					ID:           mr.SourceBranch,
+					DisplayID:    mr.SourceBranch,
+					LatestCommit: mr.SHA,
+				},
+				Commit: sdk.VCSCommit{
+					Author:  author,
+					Hash:    mr.SHA,
+					Message: mr.Title,
+				},
+				CloneURL: source.HTTPURLToRepo,
+				Repo:     source.PathWithNamespace,
+			},
+			Base: sdk.VCSPushEvent{
+				Branch: sdk.VCSBranch{
+					ID:           mr.TargetBranch,
+					DisplayID:    mr.TargetBranch,
+					LatestCommit: baseSha,
+				},
+				Commit: sdk.VCSCommit{
+					Author:  author,
+					Hash:    baseSha,
+					Message: mr.Title,
+				},
+				CloneURL: target.HTTPURLToRepo,
+				Repo:     target.PathWithNamespace,
+			},
+		})
+	}
+
+	log.Debug("gitlabClient.PullRequestEvents> found %d merge request events : %#v", len(res), res)
+	return res, nil
 }
 
-//PullRequestEvents is not implemented
-func (c *gitlabClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
-	return nil, fmt.Errorf("Not implemented on Gitlab")
+// project loads a project by its id, source and target projects differ for merge requests from forks
+func (c *gitlabClient) project(ctx context.Context, cache map[int]*gitlab.Project, id int) (*gitlab.Project, error) {
+	if p, ok := cache[id]; ok {
+		return p, nil
+	}
+	p, _, err := c.client.Projects.GetProject(id, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	cache[id] = p
+	return p, nil
 }
@@ -0,0 +1,187 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const testEvents = `[
+  {"project_id": 1, "action_name": "opened", "target_iid": 2, "target_type": "MergeRequest", "created_at": "2020-01-10T10:00:00Z",
+   "author": {"name": "John Doe", "username": "john"}},
+  {"project_id": 1, "action_name": "pushed to", "created_at": "2020-01-10T09:00:00Z",
+   "push_data": {"action": "pushed", "ref_type": "branch", "ref": "master", "commit_to": "bbbbbb", "commit_title": "second"},
+   "author": {"name": "John Doe", "username": "john"}},
+  {"project_id": 1, "action_name": "pushed new", "created_at": "2020-01-10T08:00:00Z",
+   "push_data": {"action": "created", "ref_type": "branch", "ref": "feat", "commit_to": "cccccc", "commit_title": "feat"},
+   "author": {"name": "John Doe", "username": "john"}},
+  {"project_id": 1, "action_name": "deleted", "created_at": "2020-01-10T07:00:00Z",
+   "push_data": {"action": "removed", "ref_type": "branch", "ref": "old"},
+   "author": {"name": "John Doe", "username": "john"}},
+  {"project_id": 1, "action_name": "pushed to", "created_at": "2020-01-01T09:00:00Z",
+   "push_data": {"action": "pushed", "ref_type": "branch", "ref": "master", "commit_to": "aaaaaa", "commit_title": "first"},
+   "author": {"name": "John Doe", "username": "john"}}
+]`
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *gitlabClient {
+	log.SetLogger(t)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	httpClient := &http.Client{Transport: &rateLimitTransport{transport: http.DefaultTransport}}
+	c := &gitlabClient{client: gitlab.NewOAuthClient(httpClient, "token")}
+	require.NoError(t, c.client.SetBaseURL(srv.URL+"/api/v4"))
+	return c
+}
+
+func testHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "500")
+		w.Header().Set("RateLimit-Reset", "1893456000")
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/foo%2Fbar/events":
+			assert.Equal(t, "2020-01-04", r.URL.Query().Get("after"))
+			_, _ = w.Write([]byte(testEvents))
+		case "/api/v4/projects/foo%2Fbar/repository/branches/master":
+			_, _ = w.Write([]byte(`{"name": "master", "commit": {"id": "bbbbbb"}}`))
+		case "/api/v4/projects/foo%2Fbar/repository/branches/feat":
+			_, _ = w.Write([]byte(`{"name": "feat", "commit": {"id": "cccccc"}}`))
+		case "/api/v4/projects/foo%2Fbar/repository/commits/cccccc":
+			_, _ = w.Write([]byte(`{"id": "cccccc", "message": "feat", "author_name": "John Doe", "author_email": "john@doe.com", "authored_date": "2020-01-10T08:00:00Z"}`))
+		case "/api/v4/projects/foo%2Fbar/merge_requests/2":
+			_, _ = w.Write([]byte(`{"iid": 2, "title": "my feature", "state": "opened", "source_branch": "feat", "target_branch": "master",
+				"source_project_id": 1, "target_project_id": 1, "sha": "cccccc", "diff_refs": {"base_sha": "bbbbbb"},
+				"author": {"name": "John Doe", "username": "john"}}`))
+		case "/api/v4/projects/1":
+			_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "foo/bar", "http_url_to_repo": "https://gitlab.local/foo/bar.git"}`))
+		case "/api/v4/projects/foo%2Fbar":
+			_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "foo/bar", "web_url": "https://gitlab.local/foo/bar"}`))
+		case "/api/v4/projects/foo%2Fbar/releases":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "Release v1.0.0", "tag_name": "v1.0.0", "description": "notes"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "Release v1.0.0", "tag_name": "v1.0.0"}`))
+		case "/api/v4/projects/foo%2Fbar/uploads":
+			require.NoError(t, r.ParseMultipartForm(1024))
+			f, h, err := r.FormFile("file")
+			require.NoError(t, err)
+			content, _ := ioutil.ReadAll(f)
+			assert.Equal(t, "my.zip", h.Filename)
+			assert.Equal(t, "content", string(content))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"alt": "my.zip", "url": "/uploads/abcdef/my.zip"}`))
+		case "/api/v4/projects/foo%2Fbar/releases/v1.0.0/assets/links":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "my.zip", "url": "https://gitlab.local/foo/bar/uploads/abcdef/my.zip"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestGitlabEvents(t *testing.T) {
+	c := newTestClient(t, testHandler(t))
+	ctx := context.Background()
+
+	dateRef := time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)
+	events, interval, err := c.GetEvents(ctx, "foo/bar", dateRef)
+	require.NoError(t, err)
+	assert.Equal(t, 60*time.Second, interval)
+	require.Len(t, events, 4)
+	assert.Equal(t, 600, RateLimitLimit)
+	assert.Equal(t, 500, RateLimitRemaining)
+
+	// Events are serialized between the hooks, api and vcs services
+	btes, err := json.Marshal(events)
+	require.NoError(t, err)
+	var iEvents []interface{}
+	require.NoError(t, json.Unmarshal(btes, &iEvents))
+
+	pushEvents, err := c.PushEvents(ctx, "foo/bar", iEvents)
+	require.NoError(t, err)
+	require.Len(t, pushEvents, 2)
+	for _, e := range pushEvents {
+		switch e.Branch.DisplayID {
+		case "master":
+			assert.Equal(t, "bbbbbb", e.Commit.Hash)
+			assert.Equal(t, "second", e.Commit.Message)
+		case "feat":
+			assert.Equal(t, "cccccc", e.Commit.Hash)
+		default:
+			t.Errorf("unexpected push event on %s", e.Branch.DisplayID)
+		}
+	}
+
+	createEvents, err := c.CreateEvents(ctx, "foo/bar", iEvents)
+	require.NoError(t, err)
+	require.Len(t, createEvents, 1)
+	assert.Equal(t, "feat", createEvents[0].Branch.DisplayID)
+	assert.Equal(t, "cccccc", createEvents[0].Commit.Hash)
+
+	deleteEvents, err := c.DeleteEvents(ctx, "foo/bar", iEvents)
+	require.NoError(t, err)
+	require.Len(t, deleteEvents, 1)
+	assert.Equal(t, "old", deleteEvents[0].Branch.DisplayID)
+
+	prEvents, err := c.PullRequestEvents(ctx, "foo/bar", iEvents)
+	require.NoError(t, err)
+	require.Len(t, prEvents, 1)
+	assert.Equal(t, "opened", prEvents[0].Action)
+	assert.Equal(t, "feat", prEvents[0].Head.Branch.DisplayID)
+	assert.Equal(t, "cccccc", prEvents[0].Head.Commit.Hash)
+	assert.Equal(t, "master", prEvents[0].Base.Branch.DisplayID)
+	assert.Equal(t, "bbbbbb", prEvents[0].Base.Commit.Hash)
+	assert.Equal(t, "https://gitlab.local/foo/bar.git", prEvents[0].Head.CloneURL)
+}
+
+func TestGitlabRelease(t *testing.T) {
+	c := newTestClient(t, testHandler(t))
+	ctx := context.Background()
+
+	release, err := c.Release(ctx, "foo/bar", "v1.0.0", "Release v1.0.0", "notes")
+	require.NoError(t, err)
+
+	err = c.UploadReleaseFile(ctx, "foo/bar", "0", release.UploadURL, "my.zip", ioutil.NopCloser(strings.NewReader("content")))
+	require.NoError(t, err)
+}
+
+func TestGitlabRateLimit(t *testing.T) {
+	var calls int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer func() { RateLimitLimit, RateLimitRemaining, RateLimitReset = 0, 0, 0 }()
+
+	_, interval, err := c.GetEvents(context.Background(), "foo/bar", time.Now())
+	assert.Equal(t, ErrorRateLimit, err)
+	assert.True(t, interval > 50*time.Second)
+	assert.True(t, isRateLimitReached())
+
+	// The API is not called while the rate limit is reached
+	_, err = c.Branch(context.Background(), "foo/bar", "master")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	lines := GetStatus()
+	require.Len(t, lines, 3)
+	assert.Equal(t, sdk.MonitoringStatusAlert, lines[0].Status)
+}
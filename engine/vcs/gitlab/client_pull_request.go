@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"context"
+	"strings"
 
 	"github.com/xanzy/go-gitlab"
 
@@ -14,40 +15,78 @@ func (c *gitlabClient) PullRequest(ctx context.Context, repo string, id int) (sd
 		return sdk.VCSPullRequest{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound,
 			"cannot found a merge request for repo %s with id %d", repo, id))
 	}
-	return sdk.VCSPullRequest{
-		ID: mr.IID,
-		Base: sdk.VCSPushEvent{
-			Repo: repo,
-			Branch: sdk.VCSBranch{
-				LatestCommit: mr.DiffRefs.BaseSha,
-			},
-		},
-		Head: sdk.VCSPushEvent{
-			Repo: repo,
-			Branch: sdk.VCSBranch{
-				LatestCommit: mr.DiffRefs.HeadSha,
-			},
-		},
-		URL: mr.WebURL,
-		User: sdk.VCSAuthor{
-			DisplayName: mr.Author.Username,
-			Name:        mr.Author.Name,
-		},
-		Closed: mr.State == "closed",
-		Merged: mr.State == "merged",
-	}, nil
+	return toVCSPullRequest(repo, mr), nil
 }
 
-// PullRequests fetch all the pull request for a repository
-func (c *gitlabClient) PullRequests(context.Context, string) ([]sdk.VCSPullRequest, error) {
-	return []sdk.VCSPullRequest{}, nil
+// PullRequests fetch all the opened merge requests for a repository
+func (c *gitlabClient) PullRequests(ctx context.Context, repo string) ([]sdk.VCSPullRequest, error) {
+	state := "opened"
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+		State:       &state,
+	}
+
+	prs := []sdk.VCSPullRequest{}
+	for {
+		mrs, resp, err := c.client.MergeRequests.ListProjectMergeRequests(repo, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list merge requests on %s", repo)
+		}
+		for _, mr := range mrs {
+			prs = append(prs, toVCSPullRequest(repo, mr))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return prs, nil
 }
 
-// PullRequestComment push a new comment on a pull request
-func (c *gitlabClient) PullRequestComment(context.Context, string, sdk.VCSPullRequestCommentRequest) error {
+// PullRequestComment push a new note on a merge request, or updates the previous report
+func (c *gitlabClient) PullRequestComment(ctx context.Context, repo string, prRequest sdk.VCSPullRequestCommentRequest) error {
+	body := prRequest.Message
+	if prRequest.ReportKey != "" {
+		body = sdk.WithVCSReportMarker(prRequest.Message, prRequest.ReportKey)
+		note, err := c.mergeRequestReportNote(ctx, repo, prRequest.ID, sdk.VCSReportMarker(prRequest.ReportKey))
+		if err != nil {
+			return err
+		}
+		if note != nil {
+			if _, _, err := c.client.Notes.UpdateMergeRequestNote(repo, prRequest.ID, note.ID, &gitlab.UpdateMergeRequestNoteOptions{Body: &body}, gitlab.WithContext(ctx)); err != nil {
+				return sdk.WrapError(err, "unable to update note %d on merge request %d", note.ID, prRequest.ID)
+			}
+			return nil
+		}
+	}
+
+	if _, _, err := c.client.Notes.CreateMergeRequestNote(repo, prRequest.ID, &gitlab.CreateMergeRequestNoteOptions{Body: &body}, gitlab.WithContext(ctx)); err != nil {
+		return sdk.WrapError(err, "unable to comment merge request %d", prRequest.ID)
+	}
 	return nil
 }
 
+// mergeRequestReportNote returns the note containing the given report marker, or nil if not found
+func (c *gitlabClient) mergeRequestReportNote(ctx context.Context, repo string, id int, marker string) (*gitlab.Note, error) {
+	opt := &gitlab.ListMergeRequestNotesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for {
+		notes, resp, err := c.client.Notes.ListMergeRequestNotes(repo, id, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list notes on merge request %d", id)
+		}
+		for _, n := range notes {
+			if !n.System && strings.Contains(n.Body, marker) {
+				return n, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
 // PullRequestCreate create a new pullrequest
 func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	mr, _, err := c.client.MergeRequests.CreateMergeRequest(repo, &gitlab.CreateMergeRequestOptions{
@@ -86,3 +125,37 @@ func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sd
 		Merged: mr.State == "merged",
 	}, nil
 }
+
+func toVCSPullRequest(repo string, mr *gitlab.MergeRequest) sdk.VCSPullRequest {
+	headSha := mr.DiffRefs.HeadSha
+	if headSha == "" {
+		headSha = mr.SHA
+	}
+	return sdk.VCSPullRequest{
+		ID:    mr.IID,
+		Title: mr.Title,
+		Base: sdk.VCSPushEvent{
+			Repo: repo,
+			Branch: sdk.VCSBranch{
+				ID:           mr.TargetBranch,
+				DisplayID:    mr.TargetBranch,
+				LatestCommit: mr.DiffRefs.BaseSha,
+			},
+		},
+		Head: sdk.VCSPushEvent{
+			Repo: repo,
+			Branch: sdk.VCSBranch{
+				ID:           mr.SourceBranch,
+				DisplayID:    mr.SourceBranch,
+				LatestCommit: headSha,
+			},
+		},
+		URL: mr.WebURL,
+		User: sdk.VCSAuthor{
+			DisplayName: mr.Author.Username,
+			Name:        mr.Author.Name,
+		},
+		Closed: mr.State == "closed",
+		Merged: mr.State == "merged",
+	}
+}
@@ -0,0 +1,76 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestGitlabPullRequestComment(t *testing.T) {
+	var notes []string
+	var calls []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/projects/foo%2Fbar/merge_requests":
+			assert.Equal(t, "opened", r.URL.Query().Get("state"))
+			_, _ = w.Write([]byte(`[{"iid": 2, "title": "my feature", "state": "opened", "source_branch": "feat", "target_branch": "master", "sha": "cccccc"}]`))
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/projects/foo%2Fbar/merge_requests/2/notes":
+			var res []map[string]interface{}
+			for i, n := range notes {
+				res = append(res, map[string]interface{}{"id": i + 1, "body": n})
+			}
+			btes, _ := json.Marshal(res)
+			_, _ = w.Write(btes)
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/v4/projects/foo%2Fbar/merge_requests/2/notes":
+			var note map[string]string
+			body, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &note))
+			notes = append(notes, note["body"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v4/projects/foo%2Fbar/merge_requests/2/notes/1":
+			var note map[string]string
+			body, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &note))
+			notes[0] = note["body"]
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	prs, err := c.PullRequests(ctx, "foo/bar")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 2, prs[0].ID)
+	assert.Equal(t, "feat", prs[0].Head.Branch.DisplayID)
+	assert.Equal(t, "cccccc", prs[0].Head.Branch.LatestCommit)
+
+	req := sdk.VCSPullRequestCommentRequest{Message: "first report", ReportKey: "CDS/PROJ-wf-build"}
+	req.ID = 2
+	require.NoError(t, c.PullRequestComment(ctx, "foo/bar", req))
+
+	// The second report replaces the first one
+	req.Message = "second report"
+	require.NoError(t, c.PullRequestComment(ctx, "foo/bar", req))
+	require.Len(t, notes, 1)
+	assert.Equal(t, "second report\n\n[//]: # (CDS/PROJ-wf-build)", notes[0])
+
+	// Comments without report key are always added
+	req = sdk.VCSPullRequestCommentRequest{Message: "hello"}
+	req.ID = 2
+	require.NoError(t, c.PullRequestComment(ctx, "foo/bar", req))
+	require.Len(t, notes, 2)
+	assert.Equal(t, "PUT /api/v4/projects/foo%2Fbar/merge_requests/2/notes/1", calls[4])
+}
@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Gitlab rate limit values, updated from the RateLimit-* headers of each API response.
+// Rate limiting is optional on self-hosted instances, RateLimitLimit stays to 0 when it's disabled.
+var (
+	RateLimitLimit     = 0
+	RateLimitRemaining = 0
+	RateLimitReset     = 0
+)
+
+// ErrorRateLimit is returned when the Gitlab rate limit is nearly exceeded
+var ErrorRateLimit = fmt.Errorf("Gitlab rate limit reached")
+
+func isRateLimitReached() bool {
+	if RateLimitLimit <= 0 {
+		return false
+	}
+	if RateLimitReset > 0 && RateLimitReset < int(time.Now().Unix()) {
+		log.Debug("RateLimitReset reached, it's ok to call gitlab")
+		return false
+	}
+	// Keep 10% of the requests for the other calls (webhooks, statuses...)
+	return RateLimitRemaining <= RateLimitLimit/10
+}
+
+// rateLimitResetDelay returns the delay before the rate limit reset, or the given default delay
+func rateLimitResetDelay(defaultDelay time.Duration) time.Duration {
+	d := time.Until(time.Unix(int64(RateLimitReset), 0))
+	if d <= 0 {
+		return defaultDelay
+	}
+	return d
+}
+
+// rateLimitTransport keeps track of the Gitlab rate limit and refuses to
+// call the API when it's nearly exceeded
+type rateLimitTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isRateLimitReached() {
+		return nil, ErrorRateLimit
+	}
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	updateRateLimit(res)
+	return res, nil
+}
+
+func updateRateLimit(res *http.Response) {
+	if limit, err := strconv.Atoi(res.Header.Get("RateLimit-Limit")); err == nil {
+		RateLimitLimit = limit
+		RateLimitRemaining, _ = strconv.Atoi(res.Header.Get("RateLimit-Remaining"))
+		RateLimitReset, _ = strconv.Atoi(res.Header.Get("RateLimit-Reset"))
+	}
+
+	if res.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	// The limit is exceeded, wait until the reset time or the given retry delay
+	RateLimitRemaining = 0
+	if RateLimitLimit <= 0 {
+		RateLimitLimit = 1
+	}
+	if retryAfter, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		RateLimitReset = int(time.Now().Add(time.Duration(retryAfter) * time.Second).Unix())
+	} else if RateLimitReset < int(time.Now().Unix()) {
+		RateLimitReset = int(time.Now().Add(time.Minute).Unix())
+	}
+	log.Warning(context.Background(), "Gitlab Rate Limit exceeded, reset at %d", RateLimitReset)
+}
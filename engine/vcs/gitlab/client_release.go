@@ -4,17 +4,111 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/url"
+
+	"github.com/xanzy/go-gitlab"
 
 	"github.com/ovh/cds/sdk"
 )
 
-// Release on gitlab
-// TODO: https://docs.gitlab.com/ee/api/tags.html#create-a-new-release
+type createReleaseOptions struct {
+	Name        string `url:"name" json:"name"`
+	TagName     string `url:"tag_name" json:"tag_name"`
+	Description string `url:"description" json:"description"`
+}
+
+type createReleaseLinkOptions struct {
+	Name string `url:"name" json:"name"`
+	URL  string `url:"url" json:"url"`
+}
+
+type release struct {
+	Name    string `json:"name"`
+	TagName string `json:"tag_name"`
+}
+
+// Release creates a release on an existing tag
+// https://docs.gitlab.com/ee/api/releases/#create-a-release
 func (c *gitlabClient) Release(ctx context.Context, repo string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
-	return nil, fmt.Errorf("not implemented")
+	opt := &createReleaseOptions{
+		Name:        title,
+		TagName:     tagName,
+		Description: releaseNote,
+	}
+	path := fmt.Sprintf("projects/%s/releases", url.QueryEscape(repo))
+	req, err := c.client.NewRequest("POST", path, opt, []gitlab.OptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var r release
+	if _, err := c.client.Do(req, &r); err != nil {
+		return nil, sdk.WrapError(err, "unable to create release %s on %s", tagName, repo)
+	}
+
+	// Gitlab releases have no id, files are attached with links on the release tag
+	return &sdk.VCSRelease{
+		UploadURL: fmt.Sprintf("projects/%s/releases/%s/assets/links", url.QueryEscape(repo), url.QueryEscape(r.TagName)),
+	}, nil
 }
 
-// UploadReleaseFile upload a release file project
+// UploadReleaseFile uploads a file on the project and links it to the release
+// https://docs.gitlab.com/ee/api/projects.html#upload-a-file
+// https://docs.gitlab.com/ee/api/releases/links.html#create-a-link
 func (c *gitlabClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
-	return fmt.Errorf("not implemented")
+	defer r.Close() // nolint
+
+	// Stream the file as a multipart form to avoid loading it in memory
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", artifactName)
+		if err != nil {
+			pw.CloseWithError(err) // nolint
+			return
+		}
+		if _, err := io.Copy(part, r); err != nil {
+			pw.CloseWithError(err) // nolint
+			return
+		}
+		pw.CloseWithError(mw.Close()) // nolint
+	}()
+
+	path := fmt.Sprintf("projects/%s/uploads", url.QueryEscape(repo))
+	req, err := c.client.NewRequest("POST", path, nil, []gitlab.OptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		pr.Close() // nolint
+		return sdk.WithStack(err)
+	}
+	req.Body = ioutil.NopCloser(pr)
+	req.GetBody = nil
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var file gitlab.ProjectFile
+	if _, err := c.client.Do(req, &file); err != nil {
+		pr.Close() // nolint
+		return sdk.WrapError(err, "unable to upload %s on %s", artifactName, repo)
+	}
+
+	// Uploaded file URL is relative to the project
+	project, _, err := c.client.Projects.GetProject(repo, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return sdk.WrapError(err, "unable to get project %s", repo)
+	}
+
+	link := &createReleaseLinkOptions{
+		Name: artifactName,
+		URL:  project.WebURL + file.URL,
+	}
+	req, err = c.client.NewRequest("POST", uploadURL, link, []gitlab.OptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := c.client.Do(req, nil); err != nil {
+		return sdk.WrapError(err, "unable to link %s to the release on %s", artifactName, repo)
+	}
+	return nil
 }
@@ -122,7 +122,8 @@ var instancesAuthorizedClient = map[string]*gitlabClient{}
 func (g *gitlabConsumer) GetAuthorizedClient(ctx context.Context, accessToken, accessTokenSecret string, _created int64) (sdk.VCSAuthorizedClient, error) {
 	c, ok := instancesAuthorizedClient[accessToken]
 	httpClient := &http.Client{
-		Timeout: 60 * time.Second,
+		Timeout:   60 * time.Second,
+		Transport: &rateLimitTransport{transport: http.DefaultTransport},
 	}
 	if !ok {
 		c = &gitlabClient{
@@ -0,0 +1,38 @@
+package gitlab
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// GetStatus returns gitlab status
+func GetStatus() []sdk.MonitoringStatusLine {
+	// Rate limiting is not enabled or no call has been done yet
+	if RateLimitLimit <= 0 {
+		return []sdk.MonitoringStatusLine{{Component: "Gitlab-RateLimit", Value: "disabled", Status: sdk.MonitoringStatusOK}}
+	}
+
+	var statusRemaining string
+	switch {
+	case isRateLimitReached():
+		statusRemaining = sdk.MonitoringStatusAlert
+	case RateLimitRemaining < RateLimitLimit/4:
+		statusRemaining = sdk.MonitoringStatusWarn
+	default:
+		statusRemaining = sdk.MonitoringStatusOK
+	}
+	a := sdk.MonitoringStatusLine{Component: "Gitlab-RateLimitRemaining", Value: fmt.Sprintf("%d", RateLimitRemaining), Status: statusRemaining}
+
+	var resetTime string
+	if RateLimitReset > 0 {
+		tm := time.Unix(int64(RateLimitReset), 0)
+		resetTime = fmt.Sprintf("%dh%dm%ds", tm.Hour(), tm.Minute(), tm.Second())
+	}
+	b := sdk.MonitoringStatusLine{Component: "Gitlab-RateLimitReset", Value: resetTime, Status: sdk.MonitoringStatusOK}
+
+	c := sdk.MonitoringStatusLine{Component: "Gitlab-RateLimit", Value: fmt.Sprintf("%d", RateLimitLimit), Status: sdk.MonitoringStatusOK}
+
+	return []sdk.MonitoringStatusLine{a, b, c}
+}
@@ -50,6 +50,7 @@ type ServerConfiguration struct {
 	Bitbucket      *BitbucketServerConfiguration `toml:"bitbucket" json:"bitbucket,omitempty"`
 	BitbucketCloud *BitbucketCloudConfiguration  `toml:"bitbucketcloud" json:"bitbucketcloud,omitempty"`
 	Gerrit         *GerritServerConfiguration    `toml:"gerrit" json:"gerrit,omitempty"`
+	Gitea          *GiteaServerConfiguration     `toml:"gitea" json:"gitea,omitempty"`
 }
 
 // GithubServerConfiguration represents the github configuration
@@ -147,6 +148,29 @@ func (s BitbucketCloudConfiguration) check() error {
 	return nil
 }
 
+// GiteaServerConfiguration represents the gitea or forgejo configuration
+type GiteaServerConfiguration struct {
+	ClientID     string `toml:"clientId" json:"-" default:"xxxxx" comment:"#######\n CDS <-> Gitea / Forgejo. Documentation on https://ovh.github.io/cds/docs/integrations/gitea/ \n#######\n Gitea OAuth2 Application Client ID"`
+	ClientSecret string `toml:"clientSecret" json:"-" default:"xxxxx" comment:"Gitea OAuth2 Application Client Secret"`
+	CallbackURL  string `toml:"callbackUrl" json:"callbackUrl" default:"http://localhost:8081/repositories_manager/oauth2/callback" comment:"OAuth2 Application Redirect URI"`
+	Status       struct {
+		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
+		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+	}
+	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+	ProxyWebhook    string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+}
+
+func (s GiteaServerConfiguration) check() error {
+	if s.ClientID == "" || s.ClientSecret == "" {
+		return fmt.Errorf("Gitea configuration Error")
+	}
+	if s.ProxyWebhook != "" && !strings.Contains(s.ProxyWebhook, "://") {
+		return fmt.Errorf("Gitea proxy webhook must have the HTTP scheme")
+	}
+	return nil
+}
+
 func (s *Service) addServerConfiguration(name string, c ServerConfiguration) error {
 	if name == "" {
 		return fmt.Errorf("Invalid VCS server name")
@@ -195,6 +219,12 @@ func (s ServerConfiguration) check() error {
 		}
 	}
 
+	if s.Gitea != nil {
+		if err := s.Gitea.check(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/ovh/cds/engine/vcs/bitbucketcloud"
 	"github.com/ovh/cds/engine/vcs/bitbucketserver"
 	"github.com/ovh/cds/engine/vcs/gerrit"
+	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
 	"github.com/ovh/cds/sdk"
@@ -149,6 +150,18 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 			serverCfg.Gerrit.Reviewer.User,
 			serverCfg.Gerrit.Reviewer.Token), nil
 	}
+	if serverCfg.Gitea != nil {
+		return gitea.New(serverCfg.Gitea.ClientID,
+			serverCfg.Gitea.ClientSecret,
+			serverCfg.URL,
+			serverCfg.Gitea.CallbackURL,
+			s.Cfg.UI.HTTP.URL,
+			serverCfg.Gitea.ProxyWebhook,
+			s.Cache,
+			serverCfg.Gitea.Status.Disable,
+			!serverCfg.Gitea.Status.ShowDetail,
+		), nil
+	}
 	return nil, sdk.WithStack(sdk.ErrNotFound)
 }
 
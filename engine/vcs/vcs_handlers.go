@@ -13,6 +13,7 @@ import (
 
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/engine/vcs/github"
+	gitlabvcs "github.com/ovh/cds/engine/vcs/gitlab"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -39,6 +40,8 @@ func (s *Service) getAllVCSServersHandler() service.Handler {
 				vcsType = "github"
 			} else if v.Gitlab != nil {
 				vcsType = "gitlab"
+			} else if v.Gitea != nil {
+				vcsType = "gitea"
 			}
 
 			servers[k] = sdk.VCSConfiguration{
@@ -71,6 +74,8 @@ func (s *Service) getVCSServersHandler() service.Handler {
 			s.Type = "github"
 		} else if cfg.Gitlab != nil {
 			s.Type = "gitlab"
+		} else if cfg.Gitea != nil {
+			s.Type = "gitea"
 		}
 		return service.WriteJSON(w, s, http.StatusOK)
 	}
@@ -208,6 +213,33 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 				string(gitlab.EventTypePipeline),
 				"Job Hook", // TODO update gitlab sdk
 			}
+		case cfg.Gitea != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.Gitea.DisableWebHooks
+			res.WebhooksIcon = sdk.GiteaIcon
+			// https://docs.gitea.com/usage/webhooks
+			res.Events = []string{
+				"push",
+				"create",
+				"delete",
+				"fork",
+				"issues",
+				"issue_assign",
+				"issue_label",
+				"issue_milestone",
+				"issue_comment",
+				"pull_request",
+				"pull_request_assign",
+				"pull_request_label",
+				"pull_request_milestone",
+				"pull_request_comment",
+				"pull_request_review_approved",
+				"pull_request_review_rejected",
+				"pull_request_review_comment",
+				"pull_request_sync",
+				"repository",
+				"release",
+			}
 		case cfg.Gerrit != nil:
 			res.WebhooksSupported = false
 			res.GerritHookDisabled = cfg.Gerrit.DisableGerritEvent
@@ -261,8 +293,10 @@ func (s *Service) getVCSServersPollingHandler() service.Handler {
 			res.PollingSupported = true
 			res.PollingDisabled = cfg.Github.DisablePolling
 		case cfg.Gitlab != nil:
-			res.PollingSupported = false
+			res.PollingSupported = true
 			res.PollingDisabled = cfg.Gitlab.DisablePolling
+		case cfg.Gitea != nil:
+			res.PollingSupported = false
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -1215,6 +1249,13 @@ func (s *Service) Status(ctx context.Context) sdk.MonitoringStatus {
 		m.Lines = append(m.Lines, github.GetStatus()...)
 	}
 
+	for _, cfg := range s.Cfg.Servers {
+		if cfg.Gitlab != nil {
+			m.Lines = append(m.Lines, gitlabvcs.GetStatus()...)
+			break
+		}
+	}
+
 	return m
 }
 
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+var cmdIDTokenAudience string
+
+func cmdIDToken() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "idtoken",
+		Short: "worker idtoken [--audience <audience>]",
+		Long: `
+Inside a job, you can get a short-lived identity token signed by CDS for the current job:
+
+	# worker idtoken --audience https://vault.mydomain.net
+	worker idtoken --audience https://vault.mydomain.net
+
+The token is a JWT with claims about the current job: project_key, workflow, node, branch, environment, run_number and job_id.
+Its subject is formatted as project:<key>:workflow:<name>:node:<name>.
+
+External systems like Vault JWT auth method or cloud IAM can verify it with the keys published by the CDS API at /auth/jwks,
+the OpenID configuration is available at /.well-known/openid-configuration. So a job can be trusted without storing static credentials in CDS.
+		`,
+		Run: idTokenCmd(),
+	}
+	c.Flags().StringVar(&cmdIDTokenAudience, "audience", "", "Audience of the token, it should be the system that will verify it. Optional, default: CDS API url")
+	return c
+}
+
+func idTokenCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		uri := fmt.Sprintf("http://127.0.0.1:%d/idtoken", port)
+		if cmdIDTokenAudience != "" {
+			uri += "?audience=" + url.QueryEscape(cmdIDTokenAudience)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 30 * time.Second
+
+		resp, errDo := client.Get(uri)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("idtoken failed: unable to read body %v\n", err)
+		}
+
+		if resp.StatusCode >= 300 {
+			cdsError := sdk.DecodeError(body)
+			sdk.Exit("idtoken failed: %v\n", cdsError)
+		}
+
+		var token sdk.JobIDToken
+		if err := json.Unmarshal(body, &token); err != nil {
+			sdk.Exit("idtoken failed: unable to unmarshal body %v\n", err)
+		}
+		fmt.Println(token.Token)
+	}
+}
@@ -39,6 +39,7 @@ func RunCheckoutApplication(ctx context.Context, wk workerruntime.Runtime, a sdk
 	} else {
 		opts.SingleBranch = true
 	}
+	setCloneAdvancedOptions(a, opts)
 
 	// if there is no branch, check if there a defaultBranch
 	if (opts.Branch == "" || opts.Branch == "{{.git.branch}}") && defaultBranch != "" && tag == "" {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -131,6 +132,7 @@ func RunGitClone(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, se
 	if submodules != nil && submodules.Value == "false" {
 		opts.Recursive = false
 	}
+	setCloneAdvancedOptions(a, opts)
 
 	// if there is no branch, check if there a defaultBranch
 	if (opts.Branch == "" || opts.Branch == "{{.git.branch}}") && defaultBranch != "" && tag == "" {
@@ -162,34 +164,68 @@ func RunGitClone(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, se
 	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, opts)
 }
 
+// envGitMirrorsPath is the directory of the git mirrors shared with the repositories service
+const envGitMirrorsPath = "CDS_GIT_MIRRORS_PATH"
+
+// gitMirrorReference returns the path of the git mirror of a repository for a project if it exists
+func gitMirrorReference(mirrorsPath, projectKey, url string) string {
+	if mirrorsPath == "" {
+		return ""
+	}
+	p, err := git.MirrorPath(projectKey, url)
+	if err != nil {
+		return ""
+	}
+	p = filepath.Join(mirrorsPath, p)
+	if _, err := os.Stat(filepath.Join(p, "HEAD")); err != nil {
+		return ""
+	}
+	return p
+}
+
 func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Parameter, url, basedir, dir string, auth *git.AuthOpts, clone *git.CloneOpts) (sdk.Result, error) {
-	//Prepare all options - logs
-	stdErr := new(bytes.Buffer)
-	stdOut := new(bytes.Buffer)
+	// LFS objects are stored in a local directory synchronized with the project cache
+	var lfs *lfsCache
+	if clone.LFS {
+		var err error
+		lfs, err = pullLFSCache(ctx, w, params, url)
+		if err != nil {
+			w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("git LFS cache disabled: %v", err))
+		} else {
+			defer lfs.clean()
+			clone.LFSStorage = lfs.dir
+		}
+	}
 
-	output := &git.OutputOpts{
-		Stderr: stdErr,
-		Stdout: stdOut,
+	// Objects found in the shared git mirror are not downloaded from the repository
+	if ref := gitMirrorReference(os.Getenv(envGitMirrorsPath), sdk.ParameterValue(params, "cds.project"), url); ref != "" {
+		clone.Reference = ref
+		clone.Dissociate = true
+		w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Using git mirror %s", ref))
 	}
 
 	git.LogFunc = log.InfoWithoutCtx
 	//Perform the git clone
-	userLogCommand, err := git.Clone(url, basedir, dir, auth, clone, output)
-
-	w.SendLog(ctx, workerruntime.LevelInfo, userLogCommand)
+	err := runGitCloneCommand(ctx, w, url, basedir, dir, auth, clone)
 
-	//Send the logs
-	if len(stdOut.Bytes()) > 0 {
-		w.SendLog(ctx, workerruntime.LevelInfo, stdOut.String())
-	}
-	if len(stdErr.Bytes()) > 0 {
-		w.SendLog(ctx, workerruntime.LevelWarn, stdErr.String())
+	// The mirror could be corrupted or being updated, all objects are then downloaded from the repository
+	if err != nil && clone.Reference != "" {
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Unable to git clone using mirror %s, retrying without it", clone.Reference))
+		clone.Reference = ""
+		clone.Dissociate = false
+		err = runGitCloneCommand(ctx, w, url, basedir, dir, auth, clone)
 	}
 
 	if err != nil {
 		return sdk.Result{}, fmt.Errorf("Unable to git clone: %s", err)
 	}
 
+	if lfs != nil {
+		if err := lfs.push(ctx, w); err != nil {
+			w.SendLog(ctx, workerruntime.LevelWarn, err.Error())
+		}
+	}
+
 	// extract info only if we git clone the same repo as current application linked to the pipeline
 	gitURLSSH := sdk.ParameterValue(params, "git.url")
 	gitURLHTTP := sdk.ParameterValue(params, "git.http_url")
@@ -222,6 +258,31 @@ func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Paramet
 	return sdk.Result{Status: sdk.StatusSuccess, NewVariables: vars}, nil
 }
 
+// runGitCloneCommand performs the git clone and sends its logs
+func runGitCloneCommand(ctx context.Context, w workerruntime.Runtime, url, basedir, dir string, auth *git.AuthOpts, clone *git.CloneOpts) error {
+	stdErr := new(bytes.Buffer)
+	stdOut := new(bytes.Buffer)
+
+	output := &git.OutputOpts{
+		Stderr: stdErr,
+		Stdout: stdOut,
+	}
+
+	userLogCommand, err := git.Clone(url, basedir, dir, auth, clone, output)
+
+	w.SendLog(ctx, workerruntime.LevelInfo, userLogCommand)
+
+	//Send the logs
+	if len(stdOut.Bytes()) > 0 {
+		w.SendLog(ctx, workerruntime.LevelInfo, stdOut.String())
+	}
+	if len(stdErr.Bytes()) > 0 {
+		w.SendLog(ctx, workerruntime.LevelWarn, stdErr.String())
+	}
+
+	return err
+}
+
 func extractInfo(ctx context.Context, w workerruntime.Runtime, basedir, dir string, params []sdk.Parameter, tag, branch, commit string, opts *git.CloneOpts) ([]sdk.Variable, error) {
 	var res []sdk.Variable
 	author := sdk.ParameterValue(params, "git.author")
@@ -0,0 +1,128 @@
+package action
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+// lfsCache is a local git LFS storage directory synchronized with the project cache,
+// so LFS objects are downloaded from the repository only once
+type lfsCache struct {
+	projectKey string
+	tag        string
+	dir        string
+	nbObjects  int
+}
+
+// setCloneAdvancedOptions reads the LFS, partial clone and sparse checkout parameters of an action
+func setCloneAdvancedOptions(a sdk.Action, opts *git.CloneOpts) {
+	opts.LFS = sdk.ParameterValue(a.Parameters, "lfs") == "true"
+	opts.Filter = strings.TrimSpace(sdk.ParameterValue(a.Parameters, "filter"))
+	opts.SparseCheckout = nil
+	for _, p := range strings.Split(sdk.ParameterValue(a.Parameters, "sparseCheckout"), ",") {
+		p = strings.Trim(strings.TrimSpace(p), "/")
+		if p != "" {
+			opts.SparseCheckout = append(opts.SparseCheckout, p)
+		}
+	}
+}
+
+// lfsCacheTag returns the cache tag of the LFS objects of a repository
+func lfsCacheTag(url string) string {
+	return fmt.Sprintf("git-lfs-%x", sha1.Sum([]byte(url)))
+}
+
+// pullLFSCache creates a local LFS storage directory filled with the LFS objects found in the project cache
+func pullLFSCache(ctx context.Context, w workerruntime.Runtime, params []sdk.Parameter, url string) (*lfsCache, error) {
+	projectKey := sdk.ParameterValue(params, "cds.project")
+	if projectKey == "" {
+		return nil, fmt.Errorf("cannot find project")
+	}
+
+	dir, err := ioutil.TempDir("", "cds-git-lfs-")
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	c := &lfsCache{
+		projectKey: projectKey,
+		tag:        lfsCacheTag(url),
+		dir:        dir,
+	}
+
+	r, err := w.Client().WorkflowCachePull(projectKey, sdk.DefaultIfEmptyStorage(""), c.tag)
+	if err != nil {
+		w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git LFS cache not found: %v", err))
+		return c, nil
+	}
+	if rc, ok := r.(io.Closer); ok {
+		defer rc.Close() // nolint
+	}
+	if err := sdk.Untar(afero.NewOsFs(), dir, r); err != nil {
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("unable to read git LFS cache: %v", err))
+		return c, nil
+	}
+
+	c.nbObjects = c.countObjects()
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git LFS cache restored with %d objects", c.nbObjects))
+	return c, nil
+}
+
+// push uploads the LFS storage directory to the project cache if new objects were fetched
+func (c *lfsCache) push(ctx context.Context, w workerruntime.Runtime) error {
+	nbObjects := c.countObjects()
+	if nbObjects == 0 || nbObjects == c.nbObjects {
+		return nil
+	}
+
+	tarF, err := ioutil.TempFile("", "cds-git-lfs-*.tar")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer os.RemoveAll(tarF.Name()) // nolint
+	defer tarF.Close()              // nolint
+
+	if err := sdk.CreateTarFromPaths(afero.NewOsFs(), c.dir, []string{"objects"}, tarF, nil); err != nil {
+		return sdk.WrapError(err, "unable to tar git LFS objects")
+	}
+	tarInfo, err := tarF.Stat()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := tarF.Seek(0, 0); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	if err := w.Client().WorkflowCachePush(c.projectKey, sdk.DefaultIfEmptyStorage(""), c.tag, tarF, int(tarInfo.Size())); err != nil {
+		return sdk.WrapError(err, "unable to push git LFS cache")
+	}
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git LFS cache updated with %d objects", nbObjects))
+	return nil
+}
+
+func (c *lfsCache) countObjects() int {
+	var n int
+	_ = filepath.Walk(filepath.Join(c.dir, "objects"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
+func (c *lfsCache) clean() {
+	_ = os.RemoveAll(c.dir)
+}
@@ -1,12 +1,17 @@
 package action
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunGitCloneInSSHWithoutVCSStrategyShouldRaiseError(t *testing.T) {
@@ -286,3 +291,93 @@ func TestRunGitCloneWithSecret(t *testing.T) {
 
 	assert.DirExists(t, filepath.Join(wk.workingDirectory.File.Name(), ".git"))
 }
+
+func TestSetCloneAdvancedOptions(t *testing.T) {
+	opts := &git.CloneOpts{}
+	setCloneAdvancedOptions(sdk.Action{
+		Parameters: []sdk.Parameter{
+			{Name: "lfs", Value: "true"},
+			{Name: "filter", Value: "blob:none"},
+			{Name: "sparseCheckout", Value: "engine/api/, sdk,,"},
+		},
+	}, opts)
+	assert.True(t, opts.LFS)
+	assert.Equal(t, "blob:none", opts.Filter)
+	assert.Equal(t, []string{"engine/api", "sdk"}, opts.SparseCheckout)
+
+	opts = &git.CloneOpts{}
+	setCloneAdvancedOptions(sdk.Action{}, opts)
+	assert.False(t, opts.LFS)
+	assert.Empty(t, opts.Filter)
+	assert.Empty(t, opts.SparseCheckout)
+}
+
+func TestGitMirrorReference(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cds-git-mirrors-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	assert.Empty(t, gitMirrorReference("", "PROJ", "https://github.com/ovh/cds.git"))
+	assert.Empty(t, gitMirrorReference(dir, "PROJ", "https://github.com/ovh/cds.git"))
+
+	mirror := filepath.Join(dir, "PROJ", "github.com", "ovh", "cds.git")
+	require.NoError(t, os.MkdirAll(mirror, os.FileMode(0755)))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(mirror, "HEAD"), []byte("ref: refs/heads/master"), os.FileMode(0644)))
+	assert.Equal(t, mirror, gitMirrorReference(dir, "PROJ", "https://github.com/ovh/cds.git"))
+	assert.Equal(t, mirror, gitMirrorReference(dir, "PROJ", "git@github.com:ovh/cds.git"))
+
+	// Mirrors are not shared between projects
+	assert.Empty(t, gitMirrorReference(dir, "OTHER", "https://github.com/ovh/cds.git"))
+	assert.Empty(t, gitMirrorReference(dir, "", "https://github.com/ovh/cds.git"))
+}
+
+func TestGitCloneWithBrokenMirror(t *testing.T) {
+	wk, ctx := SetupTest(t)
+
+	dir, err := ioutil.TempDir("", "cds-git-clone-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	// Local repository with one commit
+	repo := filepath.Join(dir, "repo")
+	for _, args := range [][]string{
+		{"init", "-q", repo},
+		{"-C", repo, "-c", "user.name=cds", "-c", "user.email=cds@localhost", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	url := "file://localhost" + filepath.ToSlash(repo)
+
+	// A mirror with corrupted objects
+	mirrors := filepath.Join(dir, "mirrors")
+	p, err := git.MirrorPath("PROJ", url)
+	require.NoError(t, err)
+	mirror := filepath.Join(mirrors, p)
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", mirror},
+		{"-C", mirror, "-c", "fetch.unpackLimit=100", "fetch", "-q", url, "refs/heads/*:refs/heads/*"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	objects, err := filepath.Glob(filepath.Join(mirror, "objects", "??", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, objects)
+	for _, o := range objects {
+		require.NoError(t, os.Chmod(o, os.FileMode(0644)))
+		require.NoError(t, ioutil.WriteFile(o, []byte("corrupted"), os.FileMode(0644)))
+	}
+	require.NoError(t, os.Setenv(envGitMirrorsPath, mirrors))
+	defer os.Unsetenv(envGitMirrorsPath) // nolint
+
+	auth := &git.AuthOpts{}
+	auth.PrivateKey.Filename = filepath.Join(dir, "key")
+	clone := &git.CloneOpts{}
+	res, err := gitClone(ctx, wk, []sdk.Parameter{{Name: "cds.project", Value: "PROJ"}}, url, dir, "clone", auth, clone)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.Empty(t, clone.Reference)
+	assert.False(t, clone.Dissociate)
+	assert.DirExists(t, filepath.Join(dir, "clone", ".git"))
+}
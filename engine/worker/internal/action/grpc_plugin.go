@@ -15,6 +15,8 @@ import (
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/spf13/afero"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/grpcplugin"
@@ -78,6 +80,22 @@ func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Paramete
 	//For the moment we consider that plugin name = action name
 	pluginName := actionName
 
+	// An API that does not know the plugin as remote answers with an error, the plugin then runs locally
+	remote, err := w.Client().PluginGetRemote(pluginName)
+	if err != nil {
+		log.Warning(ctx, "unable to get remote configuration of grpc plugin %s, running it locally: %v", pluginName, err)
+	} else if remote != nil && remote.Address != "" {
+		// Secrets from the job are not sent outside of the worker, only parameters of the step are sent as they are
+		query, err := newActionQuery(ctx, remotePluginParameters(params), action)
+		if err != nil {
+			close(done)
+			pluginFail(ctx, w, chanRes, fmt.Sprintf("Unable to retrieve job ID... Aborting (%v)", err))
+			return
+		}
+		runRemoteGRPCPlugin(ctx, pluginName, action.StepName, *remote, query, w, chanRes, done)
+		return
+	}
+
 	var envs []string
 	//set up environment variables from job parameters
 	for _, p := range params {
@@ -94,7 +112,11 @@ func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Paramete
 		envs = append(envs, fmt.Sprintf("%s=%s", envName, p.Value))
 	}
 
-	pluginSocket, err := startGRPCPlugin(ctx, pluginName, w, nil, startGRPCPluginOptions{
+	// The plugin process outlives the cancellation of the action for a while, to let the plugin handle it
+	pluginCtx, stopPlugin := withCancelGracePeriod(ctx, PluginCancelGracePeriod)
+	defer stopPlugin()
+
+	pluginSocket, err := startGRPCPlugin(pluginCtx, pluginName, w, nil, startGRPCPluginOptions{
 		envs: envs,
 	})
 	if err != nil {
@@ -125,7 +147,7 @@ func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Paramete
 		return
 	}
 
-	logCtx, stopLogs := context.WithCancel(ctx)
+	logCtx, stopLogs := context.WithCancel(pluginCtx)
 	go enablePluginLogger(logCtx, done, pluginSocket, w)
 
 	manifest, err := actionPluginClient.Manifest(ctx, &empty.Empty{})
@@ -138,33 +160,63 @@ func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Paramete
 
 	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plugin %s version %s is ready", manifest.Name, manifest.Version))
 
-	jobID, err := workerruntime.JobID(ctx)
+	query, err := newActionQuery(ctx, params, action)
 	if err != nil {
 		pluginFail(ctx, w, chanRes, fmt.Sprintf("Unable to retrieve job ID... Aborting (%v)", err))
 		actionPluginClientStop(ctx, actionPluginClient, stopLogs)
 		return
 	}
-	query := actionplugin.ActionQuery{
-		Options: sdk.ParametersMapMerge(sdk.ParametersToMap(params), sdk.ParametersToMap(action.Parameters), sdk.MapMergeOptions.ExcludeGitParams),
-		JobID:   jobID,
-	}
 
-	result, err := actionPluginClient.Run(ctx, &query)
 	pluginDetails := fmt.Sprintf("plugin %s v%s", manifest.Name, manifest.Version)
+	res, err := runActionPlugin(ctx, w, actionPluginClient, action.StepName, query)
 	if err != nil {
 		t := fmt.Sprintf("failure %s err: %v", pluginDetails, err)
-		actionPluginClientStop(ctx, actionPluginClient, stopLogs)
+		actionPluginClientStop(pluginCtx, actionPluginClient, stopLogs)
 		log.Error(ctx, t)
 		pluginFail(ctx, w, chanRes, fmt.Sprintf("Error running action: %v", err))
 		return
 	}
 
-	actionPluginClientStop(ctx, actionPluginClient, stopLogs)
+	actionPluginClientStop(pluginCtx, actionPluginClient, stopLogs)
+
+	chanRes <- res
+}
 
-	chanRes <- sdk.Result{
+// runActionPlugin runs the action with RunStream, plugins that do not implement it are called with Run.
+// Cancelling ctx cancels the action on the plugin side.
+func runActionPlugin(ctx context.Context, w workerruntime.Runtime, c actionplugin.ActionPluginClient, stepName string, query actionplugin.ActionQuery) (sdk.Result, error) {
+	stream, err := c.RunStream(ctx, &query)
+	if err == nil {
+		var res sdk.Result
+		res, err = receiveActionRunFrames(ctx, w, stepName, stream)
+		if err == nil {
+			return res, nil
+		}
+	}
+	if status.Code(err) != codes.Unimplemented {
+		return sdk.Result{}, err
+	}
+
+	log.Debug("plugin does not implement RunStream, fallback on Run")
+	result, err := c.Run(ctx, &query)
+	if err != nil {
+		return sdk.Result{}, err
+	}
+	return sdk.Result{
 		Status: result.GetStatus(),
 		Reason: result.GetDetails(),
+	}, nil
+}
+
+func newActionQuery(ctx context.Context, params []sdk.Parameter, action sdk.Action) (actionplugin.ActionQuery, error) {
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return actionplugin.ActionQuery{}, err
 	}
+	return actionplugin.ActionQuery{
+		Options: sdk.ParametersMapMerge(sdk.ParametersToMap(params), sdk.ParametersToMap(action.Parameters), sdk.MapMergeOptions.ExcludeGitParams),
+		JobID:   jobID,
+	}, nil
 }
 
 func startGRPCPlugin(ctx context.Context, pluginName string, w workerruntime.Runtime, p *sdk.GRPCPluginBinary, opts startGRPCPluginOptions) (*pluginClientSocket, error) {
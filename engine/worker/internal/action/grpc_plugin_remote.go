@@ -0,0 +1,118 @@
+package action
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/actionplugin"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Client certificate and key used by the worker to call remote plugins
+const (
+	envRemotePluginsCert = "CDS_REMOTE_PLUGINS_CERT"
+	envRemotePluginsKey  = "CDS_REMOTE_PLUGINS_KEY"
+)
+
+// HasRemotePluginsCertificate returns true if the worker has a client certificate to call remote plugins
+func HasRemotePluginsCertificate() bool {
+	return os.Getenv(envRemotePluginsCert) != "" && os.Getenv(envRemotePluginsKey) != ""
+}
+
+// remotePluginParameters returns the job parameters that can be sent to a remote plugin, without secrets
+func remotePluginParameters(params []sdk.Parameter) []sdk.Parameter {
+	res := make([]sdk.Parameter, 0, len(params))
+	for _, p := range params {
+		switch {
+		case strings.HasSuffix(p.Name, ".pub"):
+		case sdk.NeedPlaceholder(p.Type), sdk.IsExternalSecret(p.Type), p.Type == sdk.KeySSHParameter, p.Type == sdk.KeyPGPParameter:
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// remotePluginTLSConfig returns the mutual TLS configuration used to call a remote plugin
+func remotePluginTLSConfig(remote sdk.GRPCPluginRemote, certFile, keyFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("a client certificate is mandatory to call remote plugins, set %s and %s", envRemotePluginsCert, envRemotePluginsKey)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load client certificate")
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   remote.ServerName,
+	}
+	if remote.CA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(remote.CA)) {
+			return nil, fmt.Errorf("invalid CA certificate for remote plugin %s", remote.Address)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+func runRemoteGRPCPlugin(ctx context.Context, pluginName, stepName string, remote sdk.GRPCPluginRemote, query actionplugin.ActionQuery, w workerruntime.Runtime, chanRes chan sdk.Result, done chan struct{}) {
+	defer close(done)
+
+	tlsConfig, err := remotePluginTLSConfig(remote, os.Getenv(envRemotePluginsCert), os.Getenv(envRemotePluginsKey))
+	if err != nil {
+		pluginFail(ctx, w, chanRes, fmt.Sprintf("Unable to call remote grpc plugin %s... Aborting (%v)", pluginName, err))
+		return
+	}
+
+	c, conn, err := actionplugin.RemoteClient(ctx, remote.Address, tlsConfig)
+	if err != nil {
+		pluginFail(ctx, w, chanRes, fmt.Sprintf("Unable to call remote grpc plugin %s... Aborting (%v)", pluginName, err))
+		return
+	}
+	defer conn.Close() // nolint
+
+	manifest, err := c.Manifest(ctx, &empty.Empty{})
+	if err != nil {
+		pluginFail(ctx, w, chanRes, fmt.Sprintf("Unable to retrieve plugin manifest... Aborting (%v)", err))
+		return
+	}
+	log.Debug("remote plugin successfully initialized: %#v", manifest)
+
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plugin %s version %s is ready on %s", manifest.Name, manifest.Version, remote.Address))
+
+	// The stream is bound to the job context, so cancelling the job cancels the action on the plugin side
+	stream, err := c.Run(ctx, &query)
+	if err != nil {
+		pluginFail(ctx, w, chanRes, fmt.Sprintf("Error running action: %v", err))
+		return
+	}
+
+	res, err := receiveActionRunFrames(ctx, w, stepName, stream)
+	if err != nil {
+		log.Error(ctx, "failure plugin %s v%s err: %v", manifest.Name, manifest.Version, err)
+		pluginFail(ctx, w, chanRes, fmt.Sprintf("Error running action: %v", err))
+		return
+	}
+	chanRes <- res
+}
+
+func remotePluginLogLevel(level string) workerruntime.Level {
+	switch level {
+	case actionplugin.LogLevelWarn:
+		return workerruntime.LevelWarn
+	case actionplugin.LogLevelError:
+		return workerruntime.LevelError
+	default:
+		return workerruntime.LevelInfo
+	}
+}
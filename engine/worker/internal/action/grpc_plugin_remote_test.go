@@ -0,0 +1,148 @@
+package action
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/actionplugin"
+)
+
+type testRemotePlugin struct {
+	actionplugin.UnimplementedRemoteActionPluginServer
+}
+
+func (testRemotePlugin) Manifest(context.Context, *empty.Empty) (*actionplugin.ActionPluginManifest, error) {
+	return &actionplugin.ActionPluginManifest{Name: "remote-plugin", Version: "1.0"}, nil
+}
+
+func (testRemotePlugin) Run(q *actionplugin.ActionQuery, stream actionplugin.RemoteActionPlugin_RunServer) error {
+	if err := actionplugin.SendLog(stream, actionplugin.LogLevelInfo, "scanning %s", q.GetOptions()["path"]); err != nil {
+		return err
+	}
+	return actionplugin.SendResult(stream, sdk.StatusSuccess, "")
+}
+
+func testCertificate(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{cn},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestRunRemoteGRPCPlugin(t *testing.T) {
+	wk, ctx := SetupTest(t)
+
+	ca, caKey, caPEM, _ := testCertificate(t, "ca.test", nil, nil)
+	_, _, serverPEM, serverKeyPEM := testCertificate(t, "plugin.test", ca, caKey)
+	_, _, clientPEM, clientKeyPEM := testCertificate(t, "worker.test", ca, caKey)
+
+	serverCert, err := tls.X509KeyPair(serverPEM, serverKeyPEM)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	srvCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = actionplugin.StartRemote(srvCtx, address, &tls.Config{Certificates: []tls.Certificate{serverCert}, ClientCAs: pool}, testRemotePlugin{})
+	}()
+
+	dir, err := ioutil.TempDir("", "remote-plugin")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	require.NoError(t, ioutil.WriteFile(certFile, clientPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, clientKeyPEM, 0600))
+
+	remote := sdk.GRPCPluginRemote{Address: address, ServerName: "plugin.test", CA: string(caPEM)}
+
+	// A worker without client certificate can not call the plugin
+	_, err = remotePluginTLSConfig(remote, "", "")
+	require.Error(t, err)
+
+	require.NoError(t, os.Setenv(envRemotePluginsCert, certFile))
+	require.NoError(t, os.Setenv(envRemotePluginsKey, keyFile))
+	defer os.Unsetenv(envRemotePluginsCert) // nolint
+	defer os.Unsetenv(envRemotePluginsKey)  // nolint
+	assert.True(t, HasRemotePluginsCertificate())
+
+	query := actionplugin.ActionQuery{Options: map[string]string{"path": "src"}, JobID: 666}
+	chanRes := make(chan sdk.Result, 1)
+	done := make(chan struct{})
+	runCtx, runCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer runCancel()
+	runRemoteGRPCPlugin(runCtx, "remote-plugin", "scan", remote, query, wk, chanRes, done)
+
+	<-done
+	res := <-chanRes
+	assert.Equal(t, sdk.StatusSuccess, res.Status, res.Reason)
+}
+
+func TestRemotePluginParameters(t *testing.T) {
+	params := []sdk.Parameter{
+		{Name: "cds.project", Type: sdk.StringParameter, Value: "PROJ"},
+		{Name: "cds.proj.password", Type: sdk.SecretVariable, Value: "secret"},
+		{Name: "cds.key.proj-ssh.priv", Type: sdk.KeySSHParameter, Value: "private"},
+		{Name: "cds.key.proj-ssh.pub", Type: sdk.KeySSHParameter, Value: "public"},
+		{Name: "cds.key.proj-pgp.priv", Type: sdk.KeyPGPParameter, Value: "private"},
+		{Name: "cds.app.key", Type: sdk.KeyParameter, Value: "private"},
+	}
+
+	res := remotePluginParameters(params)
+	require.Len(t, res, 2)
+	assert.Equal(t, "cds.project", res[0].Name)
+	assert.Equal(t, "cds.key.proj-ssh.pub", res[1].Name)
+
+	// Step parameters are sent as they are
+	q, err := newActionQuery(workerruntime.SetJobID(context.TODO(), 1), res, sdk.Action{Parameters: []sdk.Parameter{{Name: "token", Type: sdk.SecretVariable, Value: "given"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "given", q.Options["token"])
+	assert.Equal(t, "PROJ", q.Options["cds.project"])
+	_, has := q.Options["cds.proj.password"]
+	assert.False(t, has)
+}
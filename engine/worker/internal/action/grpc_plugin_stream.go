@@ -0,0 +1,108 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/actionplugin"
+)
+
+// PluginCancelGracePeriod is the time given to a plugin to handle the cancellation of its action before it is killed
+const PluginCancelGracePeriod = 10 * time.Second
+
+type actionRunFrameReceiver interface {
+	Recv() (*actionplugin.ActionRunFrame, error)
+}
+
+// receiveActionRunFrames forwards the logs and progress streamed by a plugin until it sends the result of the action.
+// Outputs sent by the plugin are added to the result as new variables.
+func receiveActionRunFrames(ctx context.Context, w workerruntime.Runtime, stepName string, stream actionRunFrameReceiver) (sdk.Result, error) {
+	var newVariables []sdk.Variable
+	for {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			return sdk.Result{}, fmt.Errorf("stream ended without result")
+		}
+		if err != nil {
+			return sdk.Result{}, err
+		}
+
+		switch f := frame.Frame.(type) {
+		case *actionplugin.ActionRunFrame_Log:
+			w.SendLog(ctx, remotePluginLogLevel(f.Log.GetLevel()), withNewLine(f.Log.GetMessage()))
+		case *actionplugin.ActionRunFrame_Progress:
+			w.SendLog(ctx, workerruntime.LevelInfo, withNewLine(fmt.Sprintf("[%3d%%] %s", f.Progress.GetPercent(), f.Progress.GetMessage())))
+		case *actionplugin.ActionRunFrame_Output:
+			name := f.Output.GetName()
+			if !sdk.NamePatternRegex.MatchString(name) {
+				return sdk.Result{}, fmt.Errorf("invalid output name %q, it should match %s", name, sdk.NamePattern)
+			}
+			newVariables = append(newVariables, sdk.Variable{
+				Name:  pluginOutputVariableName(stepName, name),
+				Type:  sdk.StringVariable,
+				Value: f.Output.GetValue(),
+			})
+		case *actionplugin.ActionRunFrame_Result:
+			res := sdk.Result{
+				Status: f.Result.GetStatus(),
+				Reason: f.Result.GetDetails(),
+			}
+			// Outputs are only exported by successful actions, like the ones declared on steps
+			if res.Status == sdk.StatusSuccess {
+				res.NewVariables = newVariables
+			}
+			return res, nil
+		}
+	}
+}
+
+// pluginOutputVariableName returns steps.<step>.outputs.<output> for named steps, else the output
+// is exported as a build variable like with the worker export command.
+func pluginOutputVariableName(stepName, name string) string {
+	if stepName == "" {
+		return "cds.build." + name
+	}
+	return sdk.ActionOutputVariableName(stepName, name)
+}
+
+func withNewLine(msg string) string {
+	if !strings.HasSuffix(msg, "\n") {
+		return msg + "\n"
+	}
+	return msg
+}
+
+// detachedContext keeps the values of its parent but not its cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// withCancelGracePeriod returns a context cancelled after the given grace period once ctx is done.
+// It is used for the plugin process, so the plugin is able to handle the cancellation of the action before being killed.
+func withCancelGracePeriod(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	graceCtx, cancel := context.WithCancel(detachedContext{ctx})
+	go func() {
+		select {
+		case <-graceCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+		t := time.NewTimer(grace)
+		defer t.Stop()
+		select {
+		case <-graceCtx.Done():
+		case <-t.C:
+			cancel()
+		}
+	}()
+	return graceCtx, cancel
+}
@@ -0,0 +1,141 @@
+package action
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/actionplugin"
+)
+
+type testStreamPlugin struct {
+	actionplugin.Common
+	cancelled chan struct{}
+}
+
+func (testStreamPlugin) Manifest(context.Context, *empty.Empty) (*actionplugin.ActionPluginManifest, error) {
+	return &actionplugin.ActionPluginManifest{Name: "stream-plugin", Version: "1.0"}, nil
+}
+
+func (testStreamPlugin) Run(context.Context, *actionplugin.ActionQuery) (*actionplugin.ActionResult, error) {
+	return &actionplugin.ActionResult{Status: sdk.StatusSuccess, Details: "unary"}, nil
+}
+
+func (p testStreamPlugin) RunStream(q *actionplugin.ActionQuery, stream actionplugin.ActionPlugin_RunStreamServer) error {
+	if q.GetOptions()["wait"] == "true" {
+		<-stream.Context().Done()
+		close(p.cancelled)
+		return stream.Context().Err()
+	}
+	if err := actionplugin.SendLog(stream, actionplugin.LogLevelInfo, "building"); err != nil {
+		return err
+	}
+	if err := actionplugin.SendProgress(stream, 50, "half done"); err != nil {
+		return err
+	}
+	if err := actionplugin.SendOutput(stream, "version", "1.2.0"); err != nil {
+		return err
+	}
+	return actionplugin.SendResult(stream, sdk.StatusSuccess, "")
+}
+
+// testUnaryPlugin only implements Run, as plugins written before RunStream
+type testUnaryPlugin struct {
+	testStreamPlugin
+}
+
+func (p testUnaryPlugin) RunStream(q *actionplugin.ActionQuery, stream actionplugin.ActionPlugin_RunStreamServer) error {
+	return p.Common.RunStream(q, stream)
+}
+
+func startTestActionPlugin(t *testing.T, ctx context.Context, srv actionplugin.ActionPluginServer) actionplugin.ActionPluginClient {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	actionplugin.RegisterActionPluginServer(s, srv)
+	go s.Serve(l) // nolint
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+
+	c, err := actionplugin.Client(ctx, l.Addr().String())
+	require.NoError(t, err)
+	return c
+}
+
+func TestRunActionPlugin(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Logs, progress and outputs are streamed by the plugin
+	c := startTestActionPlugin(t, ctx, &testStreamPlugin{})
+	res, err := runActionPlugin(ctx, wk, c, "compile", actionplugin.ActionQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	require.Len(t, res.NewVariables, 1)
+	assert.Equal(t, "steps.compile.outputs.version", res.NewVariables[0].Name)
+	assert.Equal(t, "1.2.0", res.NewVariables[0].Value)
+
+	res, err = runActionPlugin(ctx, wk, c, "", actionplugin.ActionQuery{})
+	require.NoError(t, err)
+	require.Len(t, res.NewVariables, 1)
+	assert.Equal(t, "cds.build.version", res.NewVariables[0].Name)
+
+	// Plugins without RunStream are called with Run
+	c = startTestActionPlugin(t, ctx, &testUnaryPlugin{})
+	res, err = runActionPlugin(ctx, wk, c, "compile", actionplugin.ActionQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.Equal(t, "unary", res.Reason)
+	assert.Empty(t, res.NewVariables)
+}
+
+func TestRunActionPluginCancel(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	srv := testStreamPlugin{cancelled: make(chan struct{})}
+	c := startTestActionPlugin(t, ctx, &srv)
+
+	stepCtx, cancelStep := context.WithCancel(ctx)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := runActionPlugin(stepCtx, wk, c, "wait", actionplugin.ActionQuery{Options: map[string]string{"wait": "true"}})
+		errs <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancelStep()
+
+	select {
+	case <-srv.cancelled:
+	case <-ctx.Done():
+		t.Fatal("the plugin has not been cancelled")
+	}
+	assert.Error(t, <-errs)
+}
+
+func TestWithCancelGracePeriod(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "key", "value")) // nolint
+	graceCtx, stop := withCancelGracePeriod(ctx, 50*time.Millisecond)
+	defer stop()
+	assert.Equal(t, "value", graceCtx.Value("key"))
+
+	cancel()
+	assert.NoError(t, graceCtx.Err())
+	select {
+	case <-graceCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context has not been cancelled after the grace period")
+	}
+}
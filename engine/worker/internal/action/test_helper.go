@@ -41,6 +41,11 @@ func (w TestWorker) Blur(i interface{}) error {
 	return nil
 }
 
+func (w TestWorker) ScanSecrets(ctx context.Context, path string) error {
+	w.t.Log("ScanSecrets")
+	return nil
+}
+
 func (w TestWorker) Parameters() []sdk.Parameter {
 	return w.Params
 }
@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ovh/cds/engine/worker/internal/action"
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -51,6 +52,10 @@ func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []
 }
 
 func (w *CurrentWorker) runGRPCPlugin(ctx context.Context, a sdk.Action) sdk.Result {
+	// The plugin call is bound to the step, it is cancelled with the job or when the step ends
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	chanRes := make(chan sdk.Result, 1)
 	done := make(chan struct{})
 	sdk.GoRoutine(ctx, "runGRPCPlugin", func(ctx context.Context) {
@@ -60,6 +65,11 @@ func (w *CurrentWorker) runGRPCPlugin(ctx context.Context, a sdk.Action) sdk.Res
 	select {
 	case <-ctx.Done():
 		log.Error(ctx, "CDS Worker execution cancelled: %v", ctx.Err())
+		// Wait for the plugin to handle the cancellation and to send its last logs
+		select {
+		case <-done:
+		case <-time.After(action.PluginCancelGracePeriod):
+		}
 		return sdk.Result{
 			Status: sdk.StatusFail,
 			Reason: "CDS Worker execution cancelled",
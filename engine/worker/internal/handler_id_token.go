@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+func idTokenHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		token, err := wk.client.QueueJobIDToken(ctx, wk.currentJob.wJob.ID, r.URL.Query().Get("audience"))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, token, http.StatusOK)
+	}
+}
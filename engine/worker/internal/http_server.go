@@ -51,6 +51,7 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/cache/push", LogMiddleware(cachePushHandler(c, w)))
 	r.HandleFunc("/download", LogMiddleware(downloadHandler(c, w)))
 	r.HandleFunc("/exit", LogMiddleware(exitHandler(c, w)))
+	r.HandleFunc("/idtoken", LogMiddleware(idTokenHandler(c, w)))
 	r.HandleFunc("/key/{key}/install", LogMiddleware(keyInstallHandler(c, w)))
 	r.HandleFunc("/services/{type}", LogMiddleware(serviceHandler(c, w)))
 	r.HandleFunc("/tag", LogMiddleware(tagHandler(c, w)))
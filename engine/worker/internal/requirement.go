@@ -12,6 +12,7 @@ import (
 
 	"github.com/shirou/gopsutil/mem"
 
+	"github.com/ovh/cds/engine/worker/internal/action"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -62,6 +63,15 @@ func checkPluginRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	var currentOS = strings.ToLower(sdk.GOOS)
 	var currentARCH = strings.ToLower(sdk.GOARCH)
 
+	// Remote plugins are not downloaded, the worker only needs a client certificate to call them
+	remote, err := w.client.PluginGetRemote(r.Name)
+	if err != nil {
+		return false, err
+	}
+	if remote.Address != "" {
+		return action.HasRemotePluginsCertificate(), nil
+	}
+
 	binary, err := w.client.PluginGetBinaryInfos(r.Name, currentOS, currentARCH)
 	if err != nil {
 		return false, err
@@ -160,6 +160,7 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 				}
 			}
 		}
+		w.sendSecretScanFindings(ctx, jobID, jobStepIndex)
 		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, stepResult.Status); err != nil {
 			jobResult.Status = sdk.StatusFail
 			jobResult.Reason = fmt.Sprintf("Cannot update step (%d) status (%s): %v", jobStepIndex, sdk.StatusBuilding, err)
@@ -232,10 +233,10 @@ func (w *CurrentWorker) runAction(ctx context.Context, a sdk.Action, jobID int64
 
 	// There is is no children actions (action is empty) to do, success !
 	if len(a.Actions) == 0 {
-		return sdk.Result{
+		return w.processActionOutputs(ctx, a, sdk.Result{
 			Status:  sdk.StatusSuccess,
 			BuildID: jobID,
-		}
+		})
 	}
 
 	//Run children actions
@@ -245,6 +246,34 @@ func (w *CurrentWorker) runAction(ctx context.Context, a sdk.Action, jobID int64
 		r.Status = sdk.StatusDisabled
 	}
 
+	return w.processActionOutputs(ctx, a, r)
+}
+
+// processActionOutputs computes the outputs declared by a named step once it succeeded,
+// they are added to the step result as new variables named steps.<step>.outputs.<output>.
+func (w *CurrentWorker) processActionOutputs(ctx context.Context, a sdk.Action, r sdk.Result) sdk.Result {
+	if a.StepName == "" || len(a.Outputs) == 0 || r.Status != sdk.StatusSuccess {
+		return r
+	}
+
+	params := sdk.ParametersMapMerge(sdk.ParametersToMap(w.currentJob.params), sdk.ParametersToMap(a.Parameters))
+	for _, o := range a.Outputs {
+		value, err := interpolate.Do(o.Value, params)
+		if err == nil {
+			err = o.CheckValue(value)
+		}
+		if err != nil {
+			w.SendLog(ctx, workerruntime.LevelError, fmt.Sprintf("Unable to compute output %s of step %s: %v", o.Name, a.StepName, err))
+			r.Status = sdk.StatusFail
+			r.Reason = err.Error()
+			return r
+		}
+		r.NewVariables = append(r.NewVariables, sdk.Variable{
+			Name:  sdk.ActionOutputVariableName(a.StepName, o.Name),
+			Type:  sdk.StringVariable,
+			Value: value,
+		})
+	}
 	return r
 }
 
@@ -255,6 +284,7 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 	}()
 	var criticalStepFailed bool
 	var nbDisabledChildren int
+	var newVariables []sdk.Variable
 
 	r := sdk.Result{
 		Status:  sdk.StatusFail,
@@ -295,8 +325,11 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 			// append the new variable from a chile to the following children
 			w.currentJob.params = append(w.currentJob.params, newVariable.ToParameter(""))
 		}
+		// Propagate new variables from all children to the parent result
+		newVariables = append(newVariables, r.NewVariables...)
 	}
 
+	r.NewVariables = newVariables
 	if criticalStepFailed {
 		r.Status = sdk.StatusFail
 	} else {
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -31,3 +32,40 @@ func Test_processVariablesAndParameters(t *testing.T) {
 	assert.Equal(t, expectedJobParameters, string(actualJobParameters))
 
 }
+
+func Test_processActionOutputs(t *testing.T) {
+	var w = new(CurrentWorker)
+	w.SetContext(context.Background())
+	w.currentJob.params = []sdk.Parameter{
+		{Name: "cds.build.version", Type: sdk.StringParameter, Value: "1.2.0"},
+	}
+
+	a := sdk.Action{
+		Name:     "build",
+		StepName: "compile",
+		Parameters: []sdk.Parameter{
+			{Name: "retries", Type: sdk.NumberParameter, Value: "3"},
+		},
+		Outputs: sdk.ActionOutputs{
+			{Name: "version", Type: sdk.ActionOutputString, Value: "{{.cds.build.version}}"},
+			{Name: "retries", Type: sdk.ActionOutputNumber, Value: "{{.retries}}"},
+		},
+	}
+
+	r := w.processActionOutputs(context.Background(), a, sdk.Result{Status: sdk.StatusSuccess})
+	require.Equal(t, sdk.StatusSuccess, r.Status)
+	require.Len(t, r.NewVariables, 2)
+	assert.Equal(t, "steps.compile.outputs.version", r.NewVariables[0].Name)
+	assert.Equal(t, "1.2.0", r.NewVariables[0].Value)
+	assert.Equal(t, "steps.compile.outputs.retries", r.NewVariables[1].Name)
+	assert.Equal(t, "3", r.NewVariables[1].Value)
+
+	// Outputs are not computed for failed steps
+	r = w.processActionOutputs(context.Background(), a, sdk.Result{Status: sdk.StatusFail})
+	assert.Len(t, r.NewVariables, 0)
+
+	// A value that doesn't match the output type fails the step
+	a.Outputs = sdk.ActionOutputs{{Name: "ok", Type: sdk.ActionOutputBoolean, Value: "{{.cds.build.version}}"}}
+	r = w.processActionOutputs(context.Background(), a, sdk.Result{Status: sdk.StatusSuccess})
+	assert.Equal(t, sdk.StatusFail, r.Status)
+}
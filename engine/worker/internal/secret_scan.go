@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maxSecretScanLineSize is the max size of a line scanned in an artifact, longer lines stop the scan of the file.
+const maxSecretScanLineSize = 1024 * 1024
+
+func (wk *CurrentWorker) initSecretScanner(ctx context.Context, cfg *sdk.SecretScanConfig) {
+	wk.currentJob.secretScan.Lock()
+	defer wk.currentJob.secretScan.Unlock()
+	wk.currentJob.secretScan.scanner = nil
+	wk.currentJob.secretScan.findings = nil
+	if cfg == nil {
+		return
+	}
+	scanner, err := sdk.NewSecretScanner(*cfg)
+	if err != nil {
+		log.Error(ctx, "unable to init secret scanner: %v", err)
+		return
+	}
+	wk.currentJob.secretScan.scanner = scanner
+}
+
+func (wk *CurrentWorker) addSecretScanFindings(ctx context.Context, source, filename string, rules []string) {
+	stepOrder, err := workerruntime.StepOrder(ctx)
+	if err != nil {
+		// Findings without step will be given to the current step when it ends
+		stepOrder = -1
+	}
+	wk.currentJob.secretScan.Lock()
+	for _, r := range rules {
+		wk.currentJob.secretScan.findings.Add(sdk.SecretScanFinding{Rule: r, Source: source, StepOrder: stepOrder, Filename: filename, Count: 1})
+	}
+	wk.currentJob.secretScan.Unlock()
+}
+
+// redactSecrets replaces secrets detected by the scanner in given log.
+func (wk *CurrentWorker) redactSecrets(ctx context.Context, s string) string {
+	wk.currentJob.secretScan.Lock()
+	scanner := wk.currentJob.secretScan.scanner
+	wk.currentJob.secretScan.Unlock()
+	if scanner == nil {
+		return s
+	}
+
+	s, rules := scanner.Redact(s)
+	if len(rules) > 0 {
+		wk.addSecretScanFindings(ctx, sdk.SecretScanSourceLog, "", rules)
+	}
+	return s
+}
+
+// ScanSecrets looks for secrets in given file, only text files are scanned and the file is never modified.
+func (wk *CurrentWorker) ScanSecrets(ctx context.Context, path string) error {
+	wk.currentJob.secretScan.Lock()
+	scanner := wk.currentJob.secretScan.scanner
+	wk.currentJob.secretScan.Unlock()
+	if scanner == nil {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer f.Close() // nolint
+
+	r := bufio.NewReader(f)
+	head, err := r.Peek(8000)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return sdk.WithStack(err)
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		log.Debug("worker.ScanSecrets> skipping binary file %s", path)
+		return nil
+	}
+
+	var rules []string
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 64*1024), maxSecretScanLineSize)
+	for lines.Scan() {
+		_, rs := scanner.Redact(lines.Text())
+		rules = append(rules, rs...)
+	}
+	if err := lines.Err(); err != nil {
+		log.Warning(ctx, "worker.ScanSecrets> unable to scan all lines of file %s: %v", path, err)
+	}
+
+	if len(rules) > 0 {
+		wk.addSecretScanFindings(ctx, sdk.SecretScanSourceArtifact, filepath.Base(path), rules)
+	}
+	return nil
+}
+
+// sendSecretScanFindings sends to the API secrets detected during given step and displays a warning in step logs.
+func (wk *CurrentWorker) sendSecretScanFindings(ctx context.Context, jobID int64, stepOrder int) {
+	wk.currentJob.secretScan.Lock()
+	var findings, others sdk.SecretScanFindings
+	for _, f := range wk.currentJob.secretScan.findings {
+		if f.StepOrder >= 0 && f.StepOrder != stepOrder {
+			others = append(others, f)
+			continue
+		}
+		f.StepOrder = stepOrder
+		findings.Add(f)
+	}
+	wk.currentJob.secretScan.findings = others
+	wk.currentJob.secretScan.Unlock()
+
+	if len(findings) == 0 {
+		return
+	}
+
+	for _, f := range findings {
+		msg := fmt.Sprintf("%d secret(s) matching rule %s detected in %s", f.Count, f.Rule, f.Source)
+		if f.Filename != "" {
+			msg += " " + f.Filename + ", please remove it from the artifact"
+		} else {
+			msg += ", it has been redacted"
+		}
+		wk.SendLog(ctx, workerruntime.LevelWarn, msg)
+	}
+
+	if err := wk.Client().QueueJobSendSecretScanFindings(ctx, jobID, findings); err != nil {
+		log.Error(ctx, "unable to send secret scan findings for job %d: %v", jobID, err)
+	}
+}
@@ -32,6 +32,7 @@ func (w *CurrentWorker) Take(ctx context.Context, job sdk.WorkflowNodeJobRun) er
 	w.currentJob.secrets = info.Secrets
 	// Reset build variables
 	w.currentJob.newVariables = nil
+	w.initSecretScanner(ctx, info.SecretScan)
 
 	if info.SigningKey != "" {
 		secretKey := make([]byte, 32)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -51,6 +52,11 @@ type CurrentWorker struct {
 		secrets      []sdk.Variable
 		context      context.Context
 		signer       jose.Signer
+		secretScan   struct {
+			sync.Mutex
+			scanner  *sdk.SecretScanner
+			findings sdk.SecretScanFindings
+		}
 	}
 	status struct {
 		Name   string `json:"name"`
@@ -93,6 +99,7 @@ func (wk *CurrentWorker) SendLog(ctx context.Context, level workerruntime.Level,
 		log.Error(wk.GetContext(), "unable to blur log: %v", err)
 		return
 	}
+	s = wk.redactSecrets(ctx, s)
 
 	jobID, _ := workerruntime.JobID(ctx)
 	stepOrder, err := workerruntime.StepOrder(ctx)
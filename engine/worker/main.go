@@ -9,6 +9,7 @@ func main() {
 	cmd.AddCommand(cmdTmpl())
 	cmd.AddCommand(cmdCheckSecret())
 	cmd.AddCommand(cmdTag())
+	cmd.AddCommand(cmdIDToken())
 	cmd.AddCommand(cmdRun())
 	cmd.AddCommand(cmdExit())
 	cmd.AddCommand(cmdVersion)
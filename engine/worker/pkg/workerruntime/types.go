@@ -72,6 +72,7 @@ type Runtime interface {
 	BaseDir() afero.Fs
 	Environ() []string
 	Blur(interface{}) error
+	ScanSecrets(ctx context.Context, path string) error
 	HTTPPort() int32
 	Parameters() []sdk.Parameter
 }
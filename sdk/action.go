@@ -50,9 +50,12 @@ type Action struct {
 	StepName       string `json:"step_name,omitempty" yaml:"step_name,omitempty" db:"-"`
 	Optional       bool   `json:"optional" yaml:"-" db:"-"`
 	AlwaysExecuted bool   `json:"always_executed" yaml:"-" db:"-"`
+	// version of the action used by the step, empty to use the current action
+	Version string `json:"version,omitempty" yaml:"version,omitempty" db:"-"`
 	// aggregates
 	Requirements RequirementList `json:"requirements" db:"-"`
 	Parameters   []Parameter     `json:"parameters" db:"-"`
+	Outputs      ActionOutputs   `json:"outputs,omitempty" yaml:"outputs,omitempty" db:"outputs"`
 	Actions      []Action        `json:"actions,omitempty" yaml:"actions,omitempty" db:"-"`
 	Group        *Group          `json:"group,omitempty" db:"-"`
 	FirstAudit   *AuditAction    `json:"first_audit,omitempty" db:"-"`
@@ -68,6 +71,7 @@ type UsageAction struct {
 	ParentActionName string `json:"parent_action_name"`
 	ActionID         int64  `json:"action_id"`
 	ActionName       string `json:"action_name"`
+	ActionVersion    string `json:"action_version,omitempty"`
 	Warning          bool   `json:"warning"`
 }
 
@@ -79,18 +83,19 @@ type ActionUsages struct {
 
 // UsagePipeline represent a pipeline using an action.
 type UsagePipeline struct {
-	ProjectID    int64  `json:"project_id"`
-	ProjectKey   string `json:"project_key"`
-	ProjectName  string `json:"project_name"`
-	PipelineID   int64  `json:"pipeline_id"`
-	PipelineName string `json:"pipeline_name"`
-	StageID      int64  `json:"stage_id"`
-	StageName    string `json:"stage_name"`
-	JobID        int64  `json:"job_id"`
-	JobName      string `json:"job_name"`
-	ActionID     int64  `json:"action_id"`
-	ActionName   string `json:"action_name"`
-	Warning      bool   `json:"warning"`
+	ProjectID     int64  `json:"project_id"`
+	ProjectKey    string `json:"project_key"`
+	ProjectName   string `json:"project_name"`
+	PipelineID    int64  `json:"pipeline_id"`
+	PipelineName  string `json:"pipeline_name"`
+	StageID       int64  `json:"stage_id"`
+	StageName     string `json:"stage_name"`
+	JobID         int64  `json:"job_id"`
+	JobName       string `json:"job_name"`
+	ActionID      int64  `json:"action_id"`
+	ActionName    string `json:"action_name"`
+	ActionVersion string `json:"action_version,omitempty"`
+	Warning       bool   `json:"warning"`
 }
 
 // Value returns driver.Value from action.
@@ -136,10 +141,19 @@ func (a Action) IsValid() error {
 		return err
 	}
 
+	if err := a.Outputs.IsValid(); err != nil {
+		return err
+	}
+
 	for i := range a.Actions {
 		if a.Actions[i].ID == 0 {
 			return NewErrorFrom(ErrWrongRequest, "invalid action id for child")
 		}
+		if v := a.Actions[i].Version; v != "" && v != ActionVersionLatest {
+			if err := (ActionVersion{Version: v}).IsValid(); err != nil {
+				return err
+			}
+		}
 		for j := range a.Actions[i].Parameters {
 			if err := a.Actions[i].Parameters[j].IsValid(); err != nil {
 				return err
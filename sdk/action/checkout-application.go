@@ -16,7 +16,8 @@ Checkout a repository into a new directory.
 
 This action use the configuration from application vcs strategy to git clone the repository.
 The clone will be done with a depth of 50 and with submodules.
-If you want to modify theses options, you have to use gitClone action.
+Git LFS, partial clone and sparse checkout can be enabled with the advanced parameters.
+If you want to modify other options, you have to use gitClone action.
 `,
 		Parameters: []sdk.Parameter{
 			{
@@ -25,6 +26,27 @@ If you want to modify theses options, you have to use gitClone action.
 				Value:       "{{.cds.workspace}}",
 				Type:        sdk.StringParameter,
 			},
+			{
+				Name:        "lfs",
+				Description: "(optional) Fetch git LFS objects after the clone, 'false' by default. LFS objects are kept in the project cache to speed up the next clones. The git-lfs binary is required.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "filter",
+				Description: "(optional) Partial clone filter, empty by default. Use 'blob:none' to download files contents only when they are checked out.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "sparseCheckout",
+				Description: "(optional) Comma separated list of directories to checkout, empty by default to checkout the whole repository.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
 		},
 		Requirements: []sdk.Requirement{
 			{
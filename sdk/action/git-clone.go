@@ -75,6 +75,27 @@ The public key have to be granted on your repository.`,
 				Type:        sdk.StringParameter,
 				Advanced:    true,
 			},
+			{
+				Name:        "lfs",
+				Description: "(optional) Fetch git LFS objects after the clone, 'false' by default. LFS objects are kept in the project cache to speed up the next clones. The git-lfs binary is required.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "filter",
+				Description: "(optional) Partial clone filter, empty by default. Use 'blob:none' to download files contents only when they are checked out.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "sparseCheckout",
+				Description: "(optional) Comma separated list of directories to checkout, empty by default to checkout the whole repository.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
 		},
 		Requirements: []sdk.Requirement{
 			sdk.Requirement{
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Action output types
+const (
+	ActionOutputString  = "string"
+	ActionOutputNumber  = "number"
+	ActionOutputBoolean = "boolean"
+	ActionOutputJSON    = "json"
+)
+
+// AvailableActionOutputType list all existing action output types.
+var AvailableActionOutputType = []string{
+	ActionOutputString,
+	ActionOutputNumber,
+	ActionOutputBoolean,
+	ActionOutputJSON,
+}
+
+// ActionOutput is a typed value computed at the end of an action. Value is interpolated
+// with action parameters and job variables (including exported ones).
+type ActionOutput struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value"`
+}
+
+// IsValid returns an error if the output is not valid.
+func (o ActionOutput) IsValid() error {
+	if !NamePatternRegex.MatchString(o.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid action output name %q, it should match %s", o.Name, NamePattern)
+	}
+	for _, t := range AvailableActionOutputType {
+		if t == o.Type {
+			return nil
+		}
+	}
+	return NewErrorFrom(ErrWrongRequest, "invalid type %q for action output %s", o.Type, o.Name)
+}
+
+// CheckValue returns an error if given value doesn't match the output type.
+func (o ActionOutput) CheckValue(value string) error {
+	var err error
+	switch o.Type {
+	case ActionOutputNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case ActionOutputBoolean:
+		_, err = strconv.ParseBool(value)
+	case ActionOutputJSON:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("invalid json")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for output %s of type %s", value, o.Name, o.Type)
+	}
+	return nil
+}
+
+// ActionOutputVariableName returns the name of the variable that contains the value
+// of an output for given step, usable as {{.steps.<step>.outputs.<output>}}.
+func ActionOutputVariableName(stepName, outputName string) string {
+	return "steps." + stepName + ".outputs." + outputName
+}
+
+// ActionOutputs type used for database json storage.
+type ActionOutputs []ActionOutput
+
+// IsValid returns an error if an output is not valid or if names are duplicated.
+func (os ActionOutputs) IsValid() error {
+	names := make(map[string]struct{}, len(os))
+	for i := range os {
+		if err := os[i].IsValid(); err != nil {
+			return err
+		}
+		if _, ok := names[os[i].Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "duplicated action output %s", os[i].Name)
+		}
+		names[os[i].Name] = struct{}{}
+	}
+	return nil
+}
+
+// Value returns driver.Value from action outputs.
+func (os ActionOutputs) Value() (driver.Value, error) {
+	j, err := json.Marshal(os)
+	return j, WrapError(err, "cannot marshal ActionOutputs")
+}
+
+// Scan action outputs.
+func (os *ActionOutputs) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, os), "cannot unmarshal ActionOutputs")
+}
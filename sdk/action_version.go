@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// ActionVersionLatest can be used in a step to use the latest published version of an action.
+const ActionVersionLatest = "latest"
+
+// ActionVersion is an immutable published version of an action.
+type ActionVersion struct {
+	ID         int64     `json:"id" db:"id" cli:"-"`
+	ActionID   int64     `json:"action_id" db:"action_id" cli:"-"`
+	Version    string    `json:"version" db:"version" cli:"version,key"`
+	Changelog  string    `json:"changelog" db:"changelog" cli:"changelog"`
+	Created    time.Time `json:"created" db:"created" cli:"created"`
+	Author     string    `json:"author" db:"author" cli:"author"`
+	Deprecated bool      `json:"deprecated" db:"deprecated" cli:"deprecated"`
+	// Action contains a snapshot of the action at the time it was published
+	Action Action `json:"action" db:"action" cli:"-"`
+}
+
+// IsValid returns an error if the version is not a valid semantic version.
+func (v ActionVersion) IsValid() error {
+	if _, err := semver.Parse(strings.TrimPrefix(v.Version, "v")); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid action version %q, it should be a semantic version (ie. 1.2.0)", v.Version)
+	}
+	return nil
+}
+
+// SemVer returns the parsed semantic version.
+func (v ActionVersion) SemVer() semver.Version {
+	s, _ := semver.Parse(strings.TrimPrefix(v.Version, "v"))
+	return s
+}
+
+// ActionVersions is a list of action versions.
+type ActionVersions []ActionVersion
+
+// Sort versions from the most recent to the oldest.
+func (vs ActionVersions) Sort() {
+	sort.Slice(vs, func(i, j int) bool { return vs[i].SemVer().GT(vs[j].SemVer()) })
+}
+
+// Latest returns the most recent version that is not deprecated.
+func (vs ActionVersions) Latest() *ActionVersion {
+	var latest *ActionVersion
+	for i := range vs {
+		if vs[i].Deprecated {
+			continue
+		}
+		if latest == nil || vs[i].SemVer().GT(latest.SemVer()) {
+			latest = &vs[i]
+		}
+	}
+	return latest
+}
+
+// Find returns the version for given reference, reference can be a version or "latest".
+func (vs ActionVersions) Find(ref string) *ActionVersion {
+	if ref == ActionVersionLatest {
+		return vs.Latest()
+	}
+	s, err := semver.Parse(strings.TrimPrefix(ref, "v"))
+	if err != nil {
+		return nil
+	}
+	for i := range vs {
+		if vs[i].SemVer().EQ(s) {
+			return &vs[i]
+		}
+	}
+	return nil
+}
+
+// ParseActionReference splits a step action reference like "group/name@1.0.0"
+// into the action path and its version.
+func ParseActionReference(ref string) (string, string) {
+	if i := strings.LastIndex(ref, "@"); i > 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionVersions(t *testing.T) {
+	assert.NoError(t, ActionVersion{Version: "1.2.0"}.IsValid())
+	assert.NoError(t, ActionVersion{Version: "v1.2.0-beta.1"}.IsValid())
+	assert.Error(t, ActionVersion{Version: "1.2"}.IsValid())
+	assert.Error(t, ActionVersion{Version: "latest"}.IsValid())
+
+	vs := ActionVersions{
+		{Version: "1.2.0"},
+		{Version: "1.10.0", Deprecated: true},
+		{Version: "1.9.1"},
+	}
+
+	vs.Sort()
+	assert.Equal(t, "1.10.0", vs[0].Version)
+	assert.Equal(t, "1.2.0", vs[2].Version)
+
+	latest := vs.Find(ActionVersionLatest)
+	require.NotNil(t, latest)
+	assert.Equal(t, "1.9.1", latest.Version)
+
+	v := vs.Find("v1.10.0")
+	require.NotNil(t, v)
+	assert.True(t, v.Deprecated)
+	assert.Nil(t, vs.Find("2.0.0"))
+}
+
+func TestParseActionReference(t *testing.T) {
+	path, version := ParseActionReference("my-group/build@1.2.0")
+	assert.Equal(t, "my-group/build", path)
+	assert.Equal(t, "1.2.0", version)
+
+	path, version = ParseActionReference("build")
+	assert.Equal(t, "build", path)
+	assert.Equal(t, "", version)
+}
@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -32,6 +34,7 @@ type Application struct {
 	DeploymentStrategies map[string]IntegrationConfig `json:"deployment_strategies,omitempty" db:"-" cli:"-"`
 	Vulnerabilities      []Vulnerability              `json:"vulnerabilities,omitempty" db:"-" cli:"-"`
 	FromRepository       string                       `json:"from_repository,omitempty" db:"from_repository" cli:"-"`
+	GerritSettings       ApplicationGerritSettings    `json:"gerrit_settings" db:"gerrit_settings" cli:"-"`
 }
 
 // IsValid returns error if the application is not valid.
@@ -40,6 +43,10 @@ func (app Application) IsValid() error {
 		return NewError(ErrInvalidName, fmt.Errorf("Invalid project key. It should match %s", NamePattern))
 	}
 
+	if err := app.GerritSettings.IsValid(); err != nil {
+		return err
+	}
+
 	if app.Icon != "" {
 		if !strings.HasPrefix(app.Icon, IconFormat) {
 			return ErrIconBadFormat
@@ -86,6 +93,60 @@ type RepositoryStrategy struct {
 	PGPKey         string `json:"pgp_key"`
 }
 
+// Default values for the review posted by CDS on gerrit changes
+const (
+	GerritDefaultLabel       = "Verified"
+	GerritDefaultVoteSuccess = 1
+	GerritDefaultVoteFailure = -1
+	GerritDefaultNotify      = "OWNER"
+)
+
+// ApplicationGerritSettings configures the review posted by CDS on the gerrit changes of an application
+type ApplicationGerritSettings struct {
+	DisableVote    bool   `json:"disable_vote,omitempty" yaml:"disable_vote,omitempty"`
+	DisableComment bool   `json:"disable_comment,omitempty" yaml:"disable_comment,omitempty"`
+	Label          string `json:"label,omitempty" yaml:"label,omitempty"`               // default is "Verified"
+	VoteSuccess    *int   `json:"vote_success,omitempty" yaml:"vote_success,omitempty"` // default is +1
+	VoteFailure    *int   `json:"vote_failure,omitempty" yaml:"vote_failure,omitempty"` // default is -1
+	Notify         string `json:"notify,omitempty" yaml:"notify,omitempty"`             // default is "OWNER"
+}
+
+// IsDefault returns true if no gerrit settings are defined
+func (s ApplicationGerritSettings) IsDefault() bool {
+	return s == ApplicationGerritSettings{}
+}
+
+// IsValid returns an error if the gerrit settings are not valid
+func (s ApplicationGerritSettings) IsValid() error {
+	switch s.Notify {
+	case "", "NONE", "OWNER", "OWNER_REVIEWERS", "ALL":
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid gerrit notify value %q, should be one of NONE, OWNER, OWNER_REVIEWERS or ALL", s.Notify)
+	}
+	if s.VoteSuccess != nil && s.VoteFailure != nil && *s.VoteSuccess <= *s.VoteFailure {
+		return NewErrorFrom(ErrWrongRequest, "gerrit success vote should be greater than failure vote")
+	}
+	return nil
+}
+
+// Value returns driver.Value from ApplicationGerritSettings.
+func (s ApplicationGerritSettings) Value() (driver.Value, error) {
+	j, err := json.Marshal(s)
+	return j, WrapError(err, "cannot marshal ApplicationGerritSettings")
+}
+
+// Scan ApplicationGerritSettings.
+func (s *ApplicationGerritSettings) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, s), "cannot unmarshal ApplicationGerritSettings")
+}
+
 // ApplicationVariableAudit represents an audit on an application variable
 type ApplicationVariableAudit struct {
 	ID             int64     `json:"id" yaml:"-" db:"id"`
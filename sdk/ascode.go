@@ -7,6 +7,13 @@ import (
 	"time"
 )
 
+// AsCodeEvent status
+const (
+	AsCodeEventStatusOpened    = "opened"
+	AsCodeEventStatusConflict  = "conflict"
+	AsCodeEventStatusCommitted = "committed"
+)
+
 type AsCodeEvent struct {
 	ID             int64           `json:"id" db:"id"`
 	PullRequestID  int64           `json:"pullrequest_id" db:"pullrequest_id"`
@@ -15,6 +22,7 @@ type AsCodeEvent struct {
 	CreateDate     time.Time       `json:"creation_date" db:"creation_date"`
 	FromRepo       string          `json:"from_repository" db:"from_repository"`
 	Migrate        bool            `json:"migrate" db:"migrate"`
+	Status         string          `json:"status" db:"status"`
 	Data           AsCodeEventData `json:"data" db:"data"`
 }
 
@@ -23,6 +31,8 @@ type AsCodeEventData struct {
 	Pipelines    AsCodeEventDataValue `json:"pipelines"`
 	Applications AsCodeEventDataValue `json:"applications"`
 	Environments AsCodeEventDataValue `json:"environments"`
+	// Conflicts contains the files updated in the repository since the last synchronization
+	Conflicts []string `json:"conflicts,omitempty"`
 }
 
 type AsCodeEventDataValue map[int64]string
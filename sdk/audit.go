@@ -55,6 +55,15 @@ type AuditWorkflowTemplateInstance struct {
 	DataAfter                  string `json:"data_after" db:"data_after"`
 }
 
+// AuditGroup represents an audit data on a group.
+type AuditGroup struct {
+	AuditCommon
+	GroupID    int64  `json:"group_id" db:"group_id"`
+	DataType   string `json:"data_type" db:"data_type"`
+	DataBefore string `json:"data_before" db:"data_before"`
+	DataAfter  string `json:"data_after" db:"data_after"`
+}
+
 // AuditAction represents an audit data on a action.
 type AuditAction struct {
 	AuditCommon
@@ -69,6 +69,7 @@ type StepStatus struct {
 	Status    string    `json:"status" db:"-"`
 	Start     time.Time `json:"start" db:"-"`
 	Done      time.Time `json:"done" db:"-"`
+	Warnings  []string  `json:"warnings,omitempty" db:"-"`
 }
 
 // StepStatusSummary Represent a step and his status for CDS event
@@ -44,6 +44,27 @@ func (c *client) ActionUsage(groupName, name string, mods ...RequestModifier) (*
 	return &a, nil
 }
 
+func (c *client) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	var vs []sdk.ActionVersion
+	path := fmt.Sprintf("/action/%s/%s/version", groupName, name)
+	if _, err := c.GetJSON(context.Background(), path, &vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+func (c *client) ActionVersionPublish(groupName, name string, v *sdk.ActionVersion) error {
+	path := fmt.Sprintf("/action/%s/%s/version", groupName, name)
+	_, err := c.PostJSON(context.Background(), path, v, v)
+	return err
+}
+
+func (c *client) ActionVersionDeprecate(groupName, name, version string) error {
+	path := fmt.Sprintf("/action/%s/%s/version/%s/deprecate", groupName, name, version)
+	_, err := c.PostJSON(context.Background(), path, nil, nil)
+	return err
+}
+
 func (c *client) ActionList() ([]sdk.Action, error) {
 	actions := []sdk.Action{}
 	if _, err := c.GetJSON(context.Background(), "/action", &actions); err != nil {
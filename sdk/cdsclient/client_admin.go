@@ -184,3 +184,19 @@ func (c *client) AdminDatabaseRollAllEncryptedEntities() error {
 	}
 	return nil
 }
+
+func (c *client) AdminDatabaseRotateSecrets() (*sdk.Migration, error) {
+	var mig sdk.Migration
+	if _, err := c.PostJSON(context.Background(), "/admin/database/secrets/rotate", nil, &mig); err != nil {
+		return nil, err
+	}
+	return &mig, nil
+}
+
+func (c *client) AdminDatabaseRotateSecretsStatus() (*sdk.Migration, error) {
+	var mig sdk.Migration
+	if _, err := c.GetJSON(context.Background(), "/admin/database/secrets/rotate", &mig); err != nil {
+		return nil, err
+	}
+	return &mig, nil
+}
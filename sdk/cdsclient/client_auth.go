@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -50,6 +51,41 @@ func (c *client) AuthConsumerLocalSignupVerify(token, initToken string) (sdk.Aut
 	return res, nil
 }
 
+func (c *client) AuthConsumerLocalSigninTOTP(request sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error) {
+	var res sdk.AuthConsumerSigninResponse
+	_, _, _, err := c.RequestJSON(context.Background(), "POST", "/auth/consumer/local/signin/totp", request, &res)
+	return res, err
+}
+
+func (c *client) AuthTOTPGet(username string) (sdk.AuthLocalTOTP, error) {
+	var t sdk.AuthLocalTOTP
+	_, err := c.GetJSON(context.Background(), "/user/"+username+"/auth/totp", &t)
+	return t, err
+}
+
+func (c *client) AuthTOTPEnroll(username string) (sdk.AuthLocalTOTPEnrollResponse, error) {
+	var res sdk.AuthLocalTOTPEnrollResponse
+	_, err := c.PostJSON(context.Background(), "/user/"+username+"/auth/totp", nil, &res)
+	return res, err
+}
+
+func (c *client) AuthTOTPVerify(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	var res sdk.AuthLocalTOTPRecoveryCodesResponse
+	_, err := c.PostJSON(context.Background(), "/user/"+username+"/auth/totp/verify", sdk.AuthConsumerSigninRequest{"code": code}, &res)
+	return res, err
+}
+
+func (c *client) AuthTOTPRecoveryCodes(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	var res sdk.AuthLocalTOTPRecoveryCodesResponse
+	_, err := c.PostJSON(context.Background(), "/user/"+username+"/auth/totp/recovery", sdk.AuthConsumerSigninRequest{"code": code}, &res)
+	return res, err
+}
+
+func (c *client) AuthTOTPDelete(username, code string) error {
+	_, err := c.DeleteJSON(context.Background(), "/user/"+username+"/auth/totp?code="+url.QueryEscape(code), nil)
+	return err
+}
+
 func (c *client) AuthConsumerListByUser(username string) (sdk.AuthConsumers, error) {
 	var consumers sdk.AuthConsumers
 	if _, err := c.GetJSON(context.Background(), "/user/"+username+"/auth/consumer", &consumers); err != nil {
@@ -58,6 +94,14 @@ func (c *client) AuthConsumerListByUser(username string) (sdk.AuthConsumers, err
 	return consumers, nil
 }
 
+func (c *client) AuthConsumerActivity(username, id string) ([]sdk.AuthConsumerActivity, error) {
+	var activities []sdk.AuthConsumerActivity
+	if _, err := c.GetJSON(context.Background(), "/user/"+username+"/auth/consumer/"+id+"/activity", &activities); err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
 func (c *client) AuthConsumerDelete(username, id string) error {
 	_, err := c.DeleteJSON(context.Background(), "/user/"+username+"/auth/consumer/"+id, nil)
 	return err
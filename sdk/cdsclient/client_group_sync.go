@@ -0,0 +1,37 @@
+package cdsclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) GroupSyncGet(groupName string) (*sdk.GroupSync, error) {
+	var result sdk.GroupSync
+	if _, err := c.GetJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/sync", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) GroupSyncSet(groupName string, s sdk.GroupSync) (*sdk.GroupSync, error) {
+	var result sdk.GroupSync
+	if _, err := c.PutJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/sync", s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) GroupSyncDelete(groupName string) error {
+	_, err := c.DeleteJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/sync", nil)
+	return err
+}
+
+func (c *client) GroupAuditList(groupName string) ([]sdk.AuditGroup, error) {
+	var result []sdk.AuditGroup
+	if _, err := c.GetJSON(context.Background(), "/group/"+url.QueryEscape(groupName)+"/audit", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
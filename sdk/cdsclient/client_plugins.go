@@ -60,6 +60,15 @@ func (c client) PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBina
 	return &res, err
 }
 
+func (c client) PluginGetRemote(name string) (*sdk.GRPCPluginRemote, error) {
+	path := fmt.Sprintf("/download/plugin/%s/remote", name)
+	var res sdk.GRPCPluginRemote
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c client) PluginGetBinary(name, os, arch string, w io.Writer) error {
 	path := fmt.Sprintf("/download/plugin/%s/binary/%s/%s?accept-redirect=true", name, os, arch)
 	var reader io.ReadCloser
@@ -0,0 +1,44 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
+	var ws []sdk.FreezeWindow
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/freeze", &ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (c *client) ProjectFreezeWindowCreate(projectKey string, fw *sdk.FreezeWindow) error {
+	_, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/freeze", fw, fw)
+	return err
+}
+
+func (c *client) ProjectFreezeWindowDelete(projectKey string, name string) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", "/project/"+projectKey+"/freeze/"+url.QueryEscape(name), nil)
+	return err
+}
+
+func (c *client) ProjectFreezeWindowAudit(projectKey string, name string) ([]sdk.FreezeWindowBlock, error) {
+	var bs []sdk.FreezeWindowBlock
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/freeze/"+url.QueryEscape(name)+"/audit", &bs); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+func (c *client) WorkflowNodeRunFreezeOverride(projectKey string, workflowName string, number int64, nodeRunID int64, reason string) (*sdk.FreezeWindowBlock, error) {
+	var b sdk.FreezeWindowBlock
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/freeze/override", projectKey, workflowName, number, nodeRunID)
+	if _, err := c.PostJSON(context.Background(), path, sdk.FreezeWindowOverride{Reason: reason}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
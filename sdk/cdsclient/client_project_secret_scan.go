@@ -0,0 +1,26 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectSecretScanAllowlist(projectKey string) ([]sdk.SecretScanAllowlistEntry, error) {
+	var es []sdk.SecretScanAllowlistEntry
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/secretscan/allowlist", &es); err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+func (c *client) ProjectSecretScanAllowlistAdd(projectKey string, e *sdk.SecretScanAllowlistEntry) error {
+	_, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/secretscan/allowlist", e, e)
+	return err
+}
+
+func (c *client) ProjectSecretScanAllowlistDelete(projectKey string, id int64) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", fmt.Sprintf("/project/%s/secretscan/allowlist/%d", projectKey, id), nil)
+	return err
+}
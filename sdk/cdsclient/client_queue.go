@@ -510,6 +510,23 @@ func (c *client) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.Workfl
 	return err
 }
 
+func (c *client) QueueJobIDToken(ctx context.Context, jobID int64, audience string) (sdk.JobIDToken, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/idtoken", jobID)
+	if audience != "" {
+		path += "?audience=" + url.QueryEscape(audience)
+	}
+	var token sdk.JobIDToken
+	_, err := c.PostJSON(ctx, path, nil, &token)
+	return token, err
+}
+
+// QueueJobSendSecretScanFindings sends to the API secrets detected in logs or artifacts of a job
+func (c *client) QueueJobSendSecretScanFindings(ctx context.Context, jobID int64, findings []sdk.SecretScanFinding) error {
+	path := fmt.Sprintf("/queue/workflows/%d/secretscan", jobID)
+	_, err := c.PostJSON(ctx, path, findings, nil)
+	return err
+}
+
 func (c *client) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	status, err := c.PostJSON(ctx, "/queue/workflows/log/service", logs, nil)
 	if status >= 400 {
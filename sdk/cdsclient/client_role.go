@@ -0,0 +1,62 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) RoleList() ([]sdk.Role, error) {
+	var rs []sdk.Role
+	if _, err := c.GetJSON(context.Background(), "/role", &rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (c *client) RoleCreate(r *sdk.Role) error {
+	_, err := c.PostJSON(context.Background(), "/role", r, r)
+	return err
+}
+
+func (c *client) RoleUpdate(name string, r *sdk.Role) error {
+	_, err := c.PutJSON(context.Background(), "/role/"+url.QueryEscape(name), r, r)
+	return err
+}
+
+func (c *client) RoleDelete(name string) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", "/role/"+url.QueryEscape(name), nil)
+	return err
+}
+
+func (c *client) ProjectRoleLinkList(projectKey string) ([]sdk.RoleLink, error) {
+	var ls []sdk.RoleLink
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/role", &ls); err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+func (c *client) ProjectRoleLinkAdd(projectKey string, l *sdk.RoleLink) error {
+	_, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/role", l, l)
+	return err
+}
+
+func (c *client) ProjectRoleLinkDelete(projectKey string, id int64) error {
+	_, _, _, err := c.Request(context.Background(), "DELETE", fmt.Sprintf("/project/%s/role/%d", projectKey, id), nil)
+	return err
+}
+
+func (c *client) ProjectActions(projectKey, workflowName string) ([]string, error) {
+	path := "/project/" + projectKey + "/actions"
+	if workflowName != "" {
+		path += "?workflow=" + url.QueryEscape(workflowName)
+	}
+	var as []string
+	if _, err := c.GetJSON(context.Background(), path, &as); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
@@ -44,6 +44,8 @@ type Admin interface {
 	AdminDatabaseListEncryptedEntities() ([]string, error)
 	AdminDatabaseRollEncryptedEntity(e string) error
 	AdminDatabaseRollAllEncryptedEntities() error
+	AdminDatabaseRotateSecrets() (*sdk.Migration, error)
+	AdminDatabaseRotateSecretsStatus() (*sdk.Migration, error)
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
@@ -160,6 +162,9 @@ type ActionClient interface {
 	ActionDelete(groupName, name string) error
 	ActionGet(groupName, name string, mods ...RequestModifier) (*sdk.Action, error)
 	ActionUsage(groupName, name string, mods ...RequestModifier) (*sdk.ActionUsages, error)
+	ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error)
+	ActionVersionPublish(groupName, name string, v *sdk.ActionVersion) error
+	ActionVersionDeprecate(groupName, name, version string) error
 	ActionList() ([]sdk.Action, error)
 	ActionImport(content io.Reader, mods ...RequestModifier) error
 	ActionExport(groupName, name string, mods ...RequestModifier) ([]byte, error)
@@ -177,6 +182,18 @@ type GroupClient interface {
 	GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error)
 	GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error)
 	GroupMemberRemove(groupName, username string) error
+	GroupSyncGet(groupName string) (*sdk.GroupSync, error)
+	GroupSyncSet(groupName string, s sdk.GroupSync) (*sdk.GroupSync, error)
+	GroupSyncDelete(groupName string) error
+	GroupAuditList(groupName string) ([]sdk.AuditGroup, error)
+}
+
+// RoleClient exposes permission roles related functions
+type RoleClient interface {
+	RoleList() ([]sdk.Role, error)
+	RoleCreate(r *sdk.Role) error
+	RoleUpdate(name string, r *sdk.Role) error
+	RoleDelete(name string) error
 }
 
 // BroadcastClient expose all function for CDS Broadcasts
@@ -211,6 +228,9 @@ type ProjectClient interface {
 	ProjectList(withApplications, withWorkflow bool, filters ...Filter) ([]sdk.Project, error)
 	ProjectKeysClient
 	ProjectVariablesClient
+	ProjectFreezeWindowsClient
+	ProjectRolesClient
+	ProjectSecretScanClient
 	ProjectGroupsImport(projectKey string, content io.Reader, mods ...RequestModifier) (sdk.Project, error)
 	ProjectIntegrationImport(projectKey string, content io.Reader, mods ...RequestModifier) (sdk.ProjectIntegration, error)
 	ProjectIntegrationGet(projectKey string, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error)
@@ -227,6 +247,29 @@ type ProjectKeysClient interface {
 	ProjectKeysDelete(projectKey string, keyProjectName string) error
 }
 
+// ProjectRolesClient exposes project roles related functions
+type ProjectRolesClient interface {
+	ProjectRoleLinkList(projectKey string) ([]sdk.RoleLink, error)
+	ProjectRoleLinkAdd(projectKey string, l *sdk.RoleLink) error
+	ProjectRoleLinkDelete(projectKey string, id int64) error
+	ProjectActions(projectKey, workflowName string) ([]string, error)
+}
+
+// ProjectSecretScanClient exposes project secret scan allowlist related functions
+type ProjectSecretScanClient interface {
+	ProjectSecretScanAllowlist(projectKey string) ([]sdk.SecretScanAllowlistEntry, error)
+	ProjectSecretScanAllowlistAdd(projectKey string, e *sdk.SecretScanAllowlistEntry) error
+	ProjectSecretScanAllowlistDelete(projectKey string, id int64) error
+}
+
+// ProjectFreezeWindowsClient exposes project freeze windows related functions
+type ProjectFreezeWindowsClient interface {
+	ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error)
+	ProjectFreezeWindowCreate(projectKey string, fw *sdk.FreezeWindow) error
+	ProjectFreezeWindowDelete(projectKey string, name string) error
+	ProjectFreezeWindowAudit(projectKey string, name string) ([]sdk.FreezeWindowBlock, error)
+}
+
 // ProjectVariablesClient exposes project variables related functions
 type ProjectVariablesClient interface {
 	ProjectVariablesList(key string) ([]sdk.Variable, error)
@@ -256,6 +299,8 @@ type QueueClient interface {
 	QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error)
 	QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error)
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
+	QueueJobIDToken(ctx context.Context, jobID int64, audience string) (sdk.JobIDToken, error)
+	QueueJobSendSecretScanFindings(ctx context.Context, jobID int64, findings []sdk.SecretScanFinding) error
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
 }
 
@@ -314,6 +359,7 @@ type WorkflowClient interface {
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowNodeStop(projectKey string, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error)
+	WorkflowNodeRunFreezeOverride(projectKey string, workflowName string, number int64, nodeRunID int64, reason string) (*sdk.FreezeWindowBlock, error)
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
@@ -357,6 +403,7 @@ type Interface interface {
 	EventsClient
 	ExportImportInterface
 	GroupClient
+	RoleClient
 	GRPCPluginsClient
 	BroadcastClient
 	MaintenanceClient
@@ -417,20 +464,22 @@ type GRPCPluginsClient interface {
 	PluginDeleteBinary(name, os, arch string) error
 	PluginGetBinary(name, os, arch string, w io.Writer) error
 	PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error)
-}
-
-/* ProviderClient exposes allowed methods for providers
- Usage:
-
- 	cfg := ProviderConfig{
-		Host: "https://my-cds-api:8081",
-		Name: "my-provider-name",
-		Token: "my-very-long-secret-token",
-	}
-	client := NewProviderClient(cfg)
-	//Get the writable projects of a user
-	projects, err := client.ProjectsList(FilterByUser("a-username"), FilterByWritablePermission())
-	...
+	PluginGetRemote(name string) (*sdk.GRPCPluginRemote, error)
+}
+
+/*
+	 ProviderClient exposes allowed methods for providers
+	 Usage:
+
+	 	cfg := ProviderConfig{
+			Host: "https://my-cds-api:8081",
+			Name: "my-provider-name",
+			Token: "my-very-long-secret-token",
+		}
+		client := NewProviderClient(cfg)
+		//Get the writable projects of a user
+		projects, err := client.ProjectsList(FilterByUser("a-username"), FilterByWritablePermission())
+		...
 */
 type ProviderClient interface {
 	ApplicationsList(projectKey string, opts ...RequestModifier) ([]sdk.Application, error)
@@ -542,9 +591,16 @@ type AuthClient interface {
 	AuthConsumerLocalSignup(sdk.AuthConsumerSigninRequest) error
 	AuthConsumerLocalSignupVerify(token, initToken string) (sdk.AuthConsumerSigninResponse, error)
 	AuthConsumerSignout() error
+	AuthConsumerLocalSigninTOTP(sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error)
+	AuthTOTPGet(username string) (sdk.AuthLocalTOTP, error)
+	AuthTOTPEnroll(username string) (sdk.AuthLocalTOTPEnrollResponse, error)
+	AuthTOTPVerify(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error)
+	AuthTOTPRecoveryCodes(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error)
+	AuthTOTPDelete(username, code string) error
 	AuthConsumerListByUser(username string) (sdk.AuthConsumers, error)
 	AuthConsumerDelete(username, id string) error
 	AuthConsumerRegen(username, id string) (sdk.AuthConsumerCreateResponse, error)
+	AuthConsumerActivity(username, id string) ([]sdk.AuthConsumerActivity, error)
 	AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error)
 	AuthSessionListByUser(username string) (sdk.AuthSessions, error)
 	AuthSessionDelete(username, id string) error
@@ -343,6 +343,36 @@ func (mr *MockAdminMockRecorder) AdminDatabaseRollAllEncryptedEntities() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollAllEncryptedEntities", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollAllEncryptedEntities))
 }
 
+// AdminDatabaseRotateSecrets mocks base method
+func (m *MockAdmin) AdminDatabaseRotateSecrets() (*sdk.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRotateSecrets")
+	ret0, _ := ret[0].(*sdk.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRotateSecrets indicates an expected call of AdminDatabaseRotateSecrets
+func (mr *MockAdminMockRecorder) AdminDatabaseRotateSecrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRotateSecrets", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRotateSecrets))
+}
+
+// AdminDatabaseRotateSecretsStatus mocks base method
+func (m *MockAdmin) AdminDatabaseRotateSecretsStatus() (*sdk.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRotateSecretsStatus")
+	ret0, _ := ret[0].(*sdk.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRotateSecretsStatus indicates an expected call of AdminDatabaseRotateSecretsStatus
+func (mr *MockAdminMockRecorder) AdminDatabaseRotateSecretsStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRotateSecretsStatus", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRotateSecretsStatus))
+}
+
 // AdminCDSMigrationList mocks base method
 func (m *MockAdmin) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	m.ctrl.T.Helper()
@@ -1877,6 +1907,49 @@ func (mr *MockActionClientMockRecorder) ActionUsage(groupName, name interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionUsage", reflect.TypeOf((*MockActionClient)(nil).ActionUsage), varargs...)
 }
 
+// ActionVersionList mocks base method
+func (m *MockActionClient) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockActionClientMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockActionClient)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionVersionPublish mocks base method
+func (m *MockActionClient) ActionVersionPublish(groupName, name string, v *sdk.ActionVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionPublish", groupName, name, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionVersionPublish indicates an expected call of ActionVersionPublish
+func (mr *MockActionClientMockRecorder) ActionVersionPublish(groupName, name, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionPublish", reflect.TypeOf((*MockActionClient)(nil).ActionVersionPublish), groupName, name, v)
+}
+
+// ActionVersionDeprecate mocks base method
+func (m *MockActionClient) ActionVersionDeprecate(groupName, name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionDeprecate", groupName, name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionVersionDeprecate indicates an expected call of ActionVersionDeprecate
+func (mr *MockActionClientMockRecorder) ActionVersionDeprecate(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionDeprecate", reflect.TypeOf((*MockActionClient)(nil).ActionVersionDeprecate), groupName, name, version)
+}
+
 // ActionList mocks base method
 func (m *MockActionClient) ActionList() ([]sdk.Action, error) {
 	m.ctrl.T.Helper()
@@ -2110,6 +2183,145 @@ func (mr *MockGroupClientMockRecorder) GroupMemberRemove(groupName, username int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockGroupClient)(nil).GroupMemberRemove), groupName, username)
 }
 
+// GroupSyncGet mocks base method
+func (m *MockGroupClient) GroupSyncGet(groupName string) (*sdk.GroupSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupSyncGet", groupName)
+	ret0, _ := ret[0].(*sdk.GroupSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupSyncGet indicates an expected call of GroupSyncGet
+func (mr *MockGroupClientMockRecorder) GroupSyncGet(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncGet", reflect.TypeOf((*MockGroupClient)(nil).GroupSyncGet), groupName)
+}
+
+// GroupSyncSet mocks base method
+func (m *MockGroupClient) GroupSyncSet(groupName string, s sdk.GroupSync) (*sdk.GroupSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupSyncSet", groupName, s)
+	ret0, _ := ret[0].(*sdk.GroupSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupSyncSet indicates an expected call of GroupSyncSet
+func (mr *MockGroupClientMockRecorder) GroupSyncSet(groupName, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncSet", reflect.TypeOf((*MockGroupClient)(nil).GroupSyncSet), groupName, s)
+}
+
+// GroupSyncDelete mocks base method
+func (m *MockGroupClient) GroupSyncDelete(groupName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupSyncDelete", groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupSyncDelete indicates an expected call of GroupSyncDelete
+func (mr *MockGroupClientMockRecorder) GroupSyncDelete(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncDelete", reflect.TypeOf((*MockGroupClient)(nil).GroupSyncDelete), groupName)
+}
+
+// GroupAuditList mocks base method
+func (m *MockGroupClient) GroupAuditList(groupName string) ([]sdk.AuditGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupAuditList", groupName)
+	ret0, _ := ret[0].([]sdk.AuditGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupAuditList indicates an expected call of GroupAuditList
+func (mr *MockGroupClientMockRecorder) GroupAuditList(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupAuditList", reflect.TypeOf((*MockGroupClient)(nil).GroupAuditList), groupName)
+}
+
+// MockRoleClient is a mock of RoleClient interface
+type MockRoleClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleClientMockRecorder
+}
+
+// MockRoleClientMockRecorder is the mock recorder for MockRoleClient
+type MockRoleClientMockRecorder struct {
+	mock *MockRoleClient
+}
+
+// NewMockRoleClient creates a new mock instance
+func NewMockRoleClient(ctrl *gomock.Controller) *MockRoleClient {
+	mock := &MockRoleClient{ctrl: ctrl}
+	mock.recorder = &MockRoleClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRoleClient) EXPECT() *MockRoleClientMockRecorder {
+	return m.recorder
+}
+
+// RoleList mocks base method
+func (m *MockRoleClient) RoleList() ([]sdk.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleList")
+	ret0, _ := ret[0].([]sdk.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoleList indicates an expected call of RoleList
+func (mr *MockRoleClientMockRecorder) RoleList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleList", reflect.TypeOf((*MockRoleClient)(nil).RoleList))
+}
+
+// RoleCreate mocks base method
+func (m *MockRoleClient) RoleCreate(r *sdk.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleCreate", r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleCreate indicates an expected call of RoleCreate
+func (mr *MockRoleClientMockRecorder) RoleCreate(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleCreate", reflect.TypeOf((*MockRoleClient)(nil).RoleCreate), r)
+}
+
+// RoleUpdate mocks base method
+func (m *MockRoleClient) RoleUpdate(name string, r *sdk.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleUpdate", name, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleUpdate indicates an expected call of RoleUpdate
+func (mr *MockRoleClientMockRecorder) RoleUpdate(name, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleUpdate", reflect.TypeOf((*MockRoleClient)(nil).RoleUpdate), name, r)
+}
+
+// RoleDelete mocks base method
+func (m *MockRoleClient) RoleDelete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleDelete indicates an expected call of RoleDelete
+func (mr *MockRoleClientMockRecorder) RoleDelete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleDelete", reflect.TypeOf((*MockRoleClient)(nil).RoleDelete), name)
+}
+
 // MockBroadcastClient is a mock of BroadcastClient interface
 type MockBroadcastClient struct {
 	ctrl     *gomock.Controller
@@ -2577,6 +2789,165 @@ func (mr *MockProjectClientMockRecorder) VariableEncrypt(projectKey, varName, co
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockProjectClient)(nil).VariableEncrypt), projectKey, varName, content)
 }
 
+// ProjectFreezeWindowList mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeWindowList indicates an expected call of ProjectFreezeWindowList
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowList", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowList), projectKey)
+}
+
+// ProjectFreezeWindowCreate mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowCreate(projectKey string, fw *sdk.FreezeWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowCreate", projectKey, fw)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeWindowCreate indicates an expected call of ProjectFreezeWindowCreate
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowCreate(projectKey, fw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowCreate", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowCreate), projectKey, fw)
+}
+
+// ProjectFreezeWindowDelete mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeWindowDelete indicates an expected call of ProjectFreezeWindowDelete
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowDelete), projectKey, name)
+}
+
+// ProjectFreezeWindowAudit mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowAudit(projectKey, name string) ([]sdk.FreezeWindowBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowAudit", projectKey, name)
+	ret0, _ := ret[0].([]sdk.FreezeWindowBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeWindowAudit indicates an expected call of ProjectFreezeWindowAudit
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowAudit(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowAudit", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowAudit), projectKey, name)
+}
+
+// ProjectRoleLinkList mocks base method
+func (m *MockProjectClient) ProjectRoleLinkList(projectKey string) ([]sdk.RoleLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkList", projectKey)
+	ret0, _ := ret[0].([]sdk.RoleLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRoleLinkList indicates an expected call of ProjectRoleLinkList
+func (mr *MockProjectClientMockRecorder) ProjectRoleLinkList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkList", reflect.TypeOf((*MockProjectClient)(nil).ProjectRoleLinkList), projectKey)
+}
+
+// ProjectRoleLinkAdd mocks base method
+func (m *MockProjectClient) ProjectRoleLinkAdd(projectKey string, l *sdk.RoleLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkAdd", projectKey, l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkAdd indicates an expected call of ProjectRoleLinkAdd
+func (mr *MockProjectClientMockRecorder) ProjectRoleLinkAdd(projectKey, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkAdd", reflect.TypeOf((*MockProjectClient)(nil).ProjectRoleLinkAdd), projectKey, l)
+}
+
+// ProjectRoleLinkDelete mocks base method
+func (m *MockProjectClient) ProjectRoleLinkDelete(projectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkDelete", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkDelete indicates an expected call of ProjectRoleLinkDelete
+func (mr *MockProjectClientMockRecorder) ProjectRoleLinkDelete(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRoleLinkDelete), projectKey, id)
+}
+
+// ProjectActions mocks base method
+func (m *MockProjectClient) ProjectActions(projectKey, workflowName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectActions", projectKey, workflowName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectActions indicates an expected call of ProjectActions
+func (mr *MockProjectClientMockRecorder) ProjectActions(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectActions", reflect.TypeOf((*MockProjectClient)(nil).ProjectActions), projectKey, workflowName)
+}
+
+// ProjectSecretScanAllowlist mocks base method
+func (m *MockProjectClient) ProjectSecretScanAllowlist(projectKey string) ([]sdk.SecretScanAllowlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlist", projectKey)
+	ret0, _ := ret[0].([]sdk.SecretScanAllowlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectSecretScanAllowlist indicates an expected call of ProjectSecretScanAllowlist
+func (mr *MockProjectClientMockRecorder) ProjectSecretScanAllowlist(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlist", reflect.TypeOf((*MockProjectClient)(nil).ProjectSecretScanAllowlist), projectKey)
+}
+
+// ProjectSecretScanAllowlistAdd mocks base method
+func (m *MockProjectClient) ProjectSecretScanAllowlistAdd(projectKey string, e *sdk.SecretScanAllowlistEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistAdd", projectKey, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectSecretScanAllowlistAdd indicates an expected call of ProjectSecretScanAllowlistAdd
+func (mr *MockProjectClientMockRecorder) ProjectSecretScanAllowlistAdd(projectKey, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistAdd", reflect.TypeOf((*MockProjectClient)(nil).ProjectSecretScanAllowlistAdd), projectKey, e)
+}
+
+// ProjectSecretScanAllowlistDelete mocks base method
+func (m *MockProjectClient) ProjectSecretScanAllowlistDelete(projectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistDelete", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectSecretScanAllowlistDelete indicates an expected call of ProjectSecretScanAllowlistDelete
+func (mr *MockProjectClientMockRecorder) ProjectSecretScanAllowlistDelete(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectSecretScanAllowlistDelete), projectKey, id)
+}
+
 // ProjectGroupsImport mocks base method
 func (m *MockProjectClient) ProjectGroupsImport(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -2655,105 +3026,333 @@ func (m *MockProjectClient) ProjectIntegrationDelete(projectKey, integrationName
 	return ret0
 }
 
-// ProjectIntegrationDelete indicates an expected call of ProjectIntegrationDelete
-func (mr *MockProjectClientMockRecorder) ProjectIntegrationDelete(projectKey, integrationName interface{}) *gomock.Call {
+// ProjectIntegrationDelete indicates an expected call of ProjectIntegrationDelete
+func (mr *MockProjectClientMockRecorder) ProjectIntegrationDelete(projectKey, integrationName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectIntegrationDelete), projectKey, integrationName)
+}
+
+// ProjectRepositoryManagerList mocks base method
+func (m *MockProjectClient) ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryManagerList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectVCSServer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRepositoryManagerList indicates an expected call of ProjectRepositoryManagerList
+func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerList", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerList), projectKey)
+}
+
+// ProjectRepositoryManagerDelete mocks base method
+func (m *MockProjectClient) ProjectRepositoryManagerDelete(projectKey, repoManagerName string, force bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryManagerDelete", projectKey, repoManagerName, force)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRepositoryManagerDelete indicates an expected call of ProjectRepositoryManagerDelete
+func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerDelete(projectKey, repoManagerName, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
+}
+
+// MockProjectKeysClient is a mock of ProjectKeysClient interface
+type MockProjectKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectKeysClientMockRecorder
+}
+
+// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
+type MockProjectKeysClientMockRecorder struct {
+	mock *MockProjectKeysClient
+}
+
+// NewMockProjectKeysClient creates a new mock instance
+func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
+	mock := &MockProjectKeysClient{ctrl: ctrl}
+	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectKeysList mocks base method
+func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeysList indicates an expected call of ProjectKeysList
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+}
+
+// ProjectKeyCreate mocks base method
+func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+}
+
+// ProjectKeysDelete mocks base method
+func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+}
+
+// MockProjectRolesClient is a mock of ProjectRolesClient interface
+type MockProjectRolesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRolesClientMockRecorder
+}
+
+// MockProjectRolesClientMockRecorder is the mock recorder for MockProjectRolesClient
+type MockProjectRolesClientMockRecorder struct {
+	mock *MockProjectRolesClient
+}
+
+// NewMockProjectRolesClient creates a new mock instance
+func NewMockProjectRolesClient(ctrl *gomock.Controller) *MockProjectRolesClient {
+	mock := &MockProjectRolesClient{ctrl: ctrl}
+	mock.recorder = &MockProjectRolesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectRolesClient) EXPECT() *MockProjectRolesClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectRoleLinkList mocks base method
+func (m *MockProjectRolesClient) ProjectRoleLinkList(projectKey string) ([]sdk.RoleLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkList", projectKey)
+	ret0, _ := ret[0].([]sdk.RoleLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRoleLinkList indicates an expected call of ProjectRoleLinkList
+func (mr *MockProjectRolesClientMockRecorder) ProjectRoleLinkList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkList", reflect.TypeOf((*MockProjectRolesClient)(nil).ProjectRoleLinkList), projectKey)
+}
+
+// ProjectRoleLinkAdd mocks base method
+func (m *MockProjectRolesClient) ProjectRoleLinkAdd(projectKey string, l *sdk.RoleLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkAdd", projectKey, l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkAdd indicates an expected call of ProjectRoleLinkAdd
+func (mr *MockProjectRolesClientMockRecorder) ProjectRoleLinkAdd(projectKey, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkAdd", reflect.TypeOf((*MockProjectRolesClient)(nil).ProjectRoleLinkAdd), projectKey, l)
+}
+
+// ProjectRoleLinkDelete mocks base method
+func (m *MockProjectRolesClient) ProjectRoleLinkDelete(projectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkDelete", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkDelete indicates an expected call of ProjectRoleLinkDelete
+func (mr *MockProjectRolesClientMockRecorder) ProjectRoleLinkDelete(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkDelete", reflect.TypeOf((*MockProjectRolesClient)(nil).ProjectRoleLinkDelete), projectKey, id)
+}
+
+// ProjectActions mocks base method
+func (m *MockProjectRolesClient) ProjectActions(projectKey, workflowName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectActions", projectKey, workflowName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectActions indicates an expected call of ProjectActions
+func (mr *MockProjectRolesClientMockRecorder) ProjectActions(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectActions", reflect.TypeOf((*MockProjectRolesClient)(nil).ProjectActions), projectKey, workflowName)
+}
+
+// MockProjectSecretScanClient is a mock of ProjectSecretScanClient interface
+type MockProjectSecretScanClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectSecretScanClientMockRecorder
+}
+
+// MockProjectSecretScanClientMockRecorder is the mock recorder for MockProjectSecretScanClient
+type MockProjectSecretScanClientMockRecorder struct {
+	mock *MockProjectSecretScanClient
+}
+
+// NewMockProjectSecretScanClient creates a new mock instance
+func NewMockProjectSecretScanClient(ctrl *gomock.Controller) *MockProjectSecretScanClient {
+	mock := &MockProjectSecretScanClient{ctrl: ctrl}
+	mock.recorder = &MockProjectSecretScanClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectSecretScanClient) EXPECT() *MockProjectSecretScanClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectSecretScanAllowlist mocks base method
+func (m *MockProjectSecretScanClient) ProjectSecretScanAllowlist(projectKey string) ([]sdk.SecretScanAllowlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlist", projectKey)
+	ret0, _ := ret[0].([]sdk.SecretScanAllowlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectSecretScanAllowlist indicates an expected call of ProjectSecretScanAllowlist
+func (mr *MockProjectSecretScanClientMockRecorder) ProjectSecretScanAllowlist(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectIntegrationDelete), projectKey, integrationName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlist", reflect.TypeOf((*MockProjectSecretScanClient)(nil).ProjectSecretScanAllowlist), projectKey)
 }
 
-// ProjectRepositoryManagerList mocks base method
-func (m *MockProjectClient) ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error) {
+// ProjectSecretScanAllowlistAdd mocks base method
+func (m *MockProjectSecretScanClient) ProjectSecretScanAllowlistAdd(projectKey string, e *sdk.SecretScanAllowlistEntry) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectRepositoryManagerList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectVCSServer)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistAdd", projectKey, e)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectRepositoryManagerList indicates an expected call of ProjectRepositoryManagerList
-func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerList(projectKey interface{}) *gomock.Call {
+// ProjectSecretScanAllowlistAdd indicates an expected call of ProjectSecretScanAllowlistAdd
+func (mr *MockProjectSecretScanClientMockRecorder) ProjectSecretScanAllowlistAdd(projectKey, e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerList", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistAdd", reflect.TypeOf((*MockProjectSecretScanClient)(nil).ProjectSecretScanAllowlistAdd), projectKey, e)
 }
 
-// ProjectRepositoryManagerDelete mocks base method
-func (m *MockProjectClient) ProjectRepositoryManagerDelete(projectKey, repoManagerName string, force bool) error {
+// ProjectSecretScanAllowlistDelete mocks base method
+func (m *MockProjectSecretScanClient) ProjectSecretScanAllowlistDelete(projectKey string, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectRepositoryManagerDelete", projectKey, repoManagerName, force)
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistDelete", projectKey, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectRepositoryManagerDelete indicates an expected call of ProjectRepositoryManagerDelete
-func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerDelete(projectKey, repoManagerName, force interface{}) *gomock.Call {
+// ProjectSecretScanAllowlistDelete indicates an expected call of ProjectSecretScanAllowlistDelete
+func (mr *MockProjectSecretScanClientMockRecorder) ProjectSecretScanAllowlistDelete(projectKey, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistDelete", reflect.TypeOf((*MockProjectSecretScanClient)(nil).ProjectSecretScanAllowlistDelete), projectKey, id)
 }
 
-// MockProjectKeysClient is a mock of ProjectKeysClient interface
-type MockProjectKeysClient struct {
+// MockProjectFreezeWindowsClient is a mock of ProjectFreezeWindowsClient interface
+type MockProjectFreezeWindowsClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockProjectKeysClientMockRecorder
+	recorder *MockProjectFreezeWindowsClientMockRecorder
 }
 
-// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
-type MockProjectKeysClientMockRecorder struct {
-	mock *MockProjectKeysClient
+// MockProjectFreezeWindowsClientMockRecorder is the mock recorder for MockProjectFreezeWindowsClient
+type MockProjectFreezeWindowsClientMockRecorder struct {
+	mock *MockProjectFreezeWindowsClient
 }
 
-// NewMockProjectKeysClient creates a new mock instance
-func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
-	mock := &MockProjectKeysClient{ctrl: ctrl}
-	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
+// NewMockProjectFreezeWindowsClient creates a new mock instance
+func NewMockProjectFreezeWindowsClient(ctrl *gomock.Controller) *MockProjectFreezeWindowsClient {
+	mock := &MockProjectFreezeWindowsClient{ctrl: ctrl}
+	mock.recorder = &MockProjectFreezeWindowsClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
+func (m *MockProjectFreezeWindowsClient) EXPECT() *MockProjectFreezeWindowsClientMockRecorder {
 	return m.recorder
 }
 
-// ProjectKeysList mocks base method
-func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+// ProjectFreezeWindowList mocks base method
+func (m *MockProjectFreezeWindowsClient) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectKeysList indicates an expected call of ProjectKeysList
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+// ProjectFreezeWindowList indicates an expected call of ProjectFreezeWindowList
+func (mr *MockProjectFreezeWindowsClientMockRecorder) ProjectFreezeWindowList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowList", reflect.TypeOf((*MockProjectFreezeWindowsClient)(nil).ProjectFreezeWindowList), projectKey)
 }
 
-// ProjectKeyCreate mocks base method
-func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+// ProjectFreezeWindowCreate mocks base method
+func (m *MockProjectFreezeWindowsClient) ProjectFreezeWindowCreate(projectKey string, fw *sdk.FreezeWindow) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowCreate", projectKey, fw)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+// ProjectFreezeWindowCreate indicates an expected call of ProjectFreezeWindowCreate
+func (mr *MockProjectFreezeWindowsClientMockRecorder) ProjectFreezeWindowCreate(projectKey, fw interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowCreate", reflect.TypeOf((*MockProjectFreezeWindowsClient)(nil).ProjectFreezeWindowCreate), projectKey, fw)
 }
 
-// ProjectKeysDelete mocks base method
-func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+// ProjectFreezeWindowDelete mocks base method
+func (m *MockProjectFreezeWindowsClient) ProjectFreezeWindowDelete(projectKey, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowDelete", projectKey, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+// ProjectFreezeWindowDelete indicates an expected call of ProjectFreezeWindowDelete
+func (mr *MockProjectFreezeWindowsClientMockRecorder) ProjectFreezeWindowDelete(projectKey, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowDelete", reflect.TypeOf((*MockProjectFreezeWindowsClient)(nil).ProjectFreezeWindowDelete), projectKey, name)
+}
+
+// ProjectFreezeWindowAudit mocks base method
+func (m *MockProjectFreezeWindowsClient) ProjectFreezeWindowAudit(projectKey, name string) ([]sdk.FreezeWindowBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowAudit", projectKey, name)
+	ret0, _ := ret[0].([]sdk.FreezeWindowBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeWindowAudit indicates an expected call of ProjectFreezeWindowAudit
+func (mr *MockProjectFreezeWindowsClientMockRecorder) ProjectFreezeWindowAudit(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowAudit", reflect.TypeOf((*MockProjectFreezeWindowsClient)(nil).ProjectFreezeWindowAudit), projectKey, name)
 }
 
 // MockProjectVariablesClient is a mock of ProjectVariablesClient interface
@@ -3140,6 +3739,35 @@ func (mr *MockQueueClientMockRecorder) QueueJobTag(ctx, jobID, tags interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockQueueClient)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobIDToken mocks base method
+func (m *MockQueueClient) QueueJobIDToken(ctx context.Context, jobID int64, audience string) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, audience)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken
+func (mr *MockQueueClientMockRecorder) QueueJobIDToken(ctx, jobID, audience interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockQueueClient)(nil).QueueJobIDToken), ctx, jobID, audience)
+}
+
+// QueueJobSendSecretScanFindings mocks base method
+func (m *MockQueueClient) QueueJobSendSecretScanFindings(ctx context.Context, jobID int64, findings []sdk.SecretScanFinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSendSecretScanFindings", ctx, jobID, findings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobSendSecretScanFindings indicates an expected call of QueueJobSendSecretScanFindings
+func (mr *MockQueueClientMockRecorder) QueueJobSendSecretScanFindings(ctx, jobID, findings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSendSecretScanFindings", reflect.TypeOf((*MockQueueClient)(nil).QueueJobSendSecretScanFindings), ctx, jobID, findings)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockQueueClient) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -3863,6 +4491,21 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowNodeStop(projectKey, workflowN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeStop", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeStop), projectKey, workflowName, number, fromNodeID)
 }
 
+// WorkflowNodeRunFreezeOverride mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunFreezeOverride(projectKey, workflowName string, number, nodeRunID int64, reason string) (*sdk.FreezeWindowBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunFreezeOverride", projectKey, workflowName, number, nodeRunID, reason)
+	ret0, _ := ret[0].(*sdk.FreezeWindowBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunFreezeOverride indicates an expected call of WorkflowNodeRunFreezeOverride
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunFreezeOverride(projectKey, workflowName, number, nodeRunID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunFreezeOverride", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunFreezeOverride), projectKey, workflowName, number, nodeRunID, reason)
+}
+
 // WorkflowNodeRun mocks base method
 func (m *MockWorkflowClient) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
@@ -4463,6 +5106,95 @@ func (mr *MockInterfaceMockRecorder) AuthConsumerSignout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignout", reflect.TypeOf((*MockInterface)(nil).AuthConsumerSignout))
 }
 
+// AuthConsumerLocalSigninTOTP mocks base method
+func (m *MockInterface) AuthConsumerLocalSigninTOTP(arg0 sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalSigninTOTP", arg0)
+	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerLocalSigninTOTP indicates an expected call of AuthConsumerLocalSigninTOTP
+func (mr *MockInterfaceMockRecorder) AuthConsumerLocalSigninTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSigninTOTP", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalSigninTOTP), arg0)
+}
+
+// AuthTOTPGet mocks base method
+func (m *MockInterface) AuthTOTPGet(username string) (sdk.AuthLocalTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPGet", username)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPGet indicates an expected call of AuthTOTPGet
+func (mr *MockInterfaceMockRecorder) AuthTOTPGet(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPGet", reflect.TypeOf((*MockInterface)(nil).AuthTOTPGet), username)
+}
+
+// AuthTOTPEnroll mocks base method
+func (m *MockInterface) AuthTOTPEnroll(username string) (sdk.AuthLocalTOTPEnrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPEnroll", username)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPEnrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPEnroll indicates an expected call of AuthTOTPEnroll
+func (mr *MockInterfaceMockRecorder) AuthTOTPEnroll(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPEnroll", reflect.TypeOf((*MockInterface)(nil).AuthTOTPEnroll), username)
+}
+
+// AuthTOTPVerify mocks base method
+func (m *MockInterface) AuthTOTPVerify(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPVerify", username, code)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPRecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPVerify indicates an expected call of AuthTOTPVerify
+func (mr *MockInterfaceMockRecorder) AuthTOTPVerify(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPVerify", reflect.TypeOf((*MockInterface)(nil).AuthTOTPVerify), username, code)
+}
+
+// AuthTOTPRecoveryCodes mocks base method
+func (m *MockInterface) AuthTOTPRecoveryCodes(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPRecoveryCodes", username, code)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPRecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPRecoveryCodes indicates an expected call of AuthTOTPRecoveryCodes
+func (mr *MockInterfaceMockRecorder) AuthTOTPRecoveryCodes(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPRecoveryCodes", reflect.TypeOf((*MockInterface)(nil).AuthTOTPRecoveryCodes), username, code)
+}
+
+// AuthTOTPDelete mocks base method
+func (m *MockInterface) AuthTOTPDelete(username, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPDelete", username, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthTOTPDelete indicates an expected call of AuthTOTPDelete
+func (mr *MockInterfaceMockRecorder) AuthTOTPDelete(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPDelete", reflect.TypeOf((*MockInterface)(nil).AuthTOTPDelete), username, code)
+}
+
 // AuthConsumerListByUser mocks base method
 func (m *MockInterface) AuthConsumerListByUser(username string) (sdk.AuthConsumers, error) {
 	m.ctrl.T.Helper()
@@ -4507,6 +5239,21 @@ func (mr *MockInterfaceMockRecorder) AuthConsumerRegen(username, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerRegen", reflect.TypeOf((*MockInterface)(nil).AuthConsumerRegen), username, id)
 }
 
+// AuthConsumerActivity mocks base method
+func (m *MockInterface) AuthConsumerActivity(username, id string) ([]sdk.AuthConsumerActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerActivity", username, id)
+	ret0, _ := ret[0].([]sdk.AuthConsumerActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerActivity indicates an expected call of AuthConsumerActivity
+func (mr *MockInterfaceMockRecorder) AuthConsumerActivity(username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerActivity", reflect.TypeOf((*MockInterface)(nil).AuthConsumerActivity), username, id)
+}
+
 // AuthConsumerCreateForUser mocks base method
 func (m *MockInterface) AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -4620,6 +5367,49 @@ func (mr *MockInterfaceMockRecorder) ActionUsage(groupName, name interface{}, mo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionUsage", reflect.TypeOf((*MockInterface)(nil).ActionUsage), varargs...)
 }
 
+// ActionVersionList mocks base method
+func (m *MockInterface) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockInterfaceMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockInterface)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionVersionPublish mocks base method
+func (m *MockInterface) ActionVersionPublish(groupName, name string, v *sdk.ActionVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionPublish", groupName, name, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionVersionPublish indicates an expected call of ActionVersionPublish
+func (mr *MockInterfaceMockRecorder) ActionVersionPublish(groupName, name, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionPublish", reflect.TypeOf((*MockInterface)(nil).ActionVersionPublish), groupName, name, v)
+}
+
+// ActionVersionDeprecate mocks base method
+func (m *MockInterface) ActionVersionDeprecate(groupName, name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionDeprecate", groupName, name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionVersionDeprecate indicates an expected call of ActionVersionDeprecate
+func (mr *MockInterfaceMockRecorder) ActionVersionDeprecate(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionDeprecate", reflect.TypeOf((*MockInterface)(nil).ActionVersionDeprecate), groupName, name, version)
+}
+
 // ActionList mocks base method
 func (m *MockInterface) ActionList() ([]sdk.Action, error) {
 	m.ctrl.T.Helper()
@@ -4838,6 +5628,36 @@ func (mr *MockInterfaceMockRecorder) AdminDatabaseRollAllEncryptedEntities() *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollAllEncryptedEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollAllEncryptedEntities))
 }
 
+// AdminDatabaseRotateSecrets mocks base method
+func (m *MockInterface) AdminDatabaseRotateSecrets() (*sdk.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRotateSecrets")
+	ret0, _ := ret[0].(*sdk.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRotateSecrets indicates an expected call of AdminDatabaseRotateSecrets
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRotateSecrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRotateSecrets", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRotateSecrets))
+}
+
+// AdminDatabaseRotateSecretsStatus mocks base method
+func (m *MockInterface) AdminDatabaseRotateSecretsStatus() (*sdk.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRotateSecretsStatus")
+	ret0, _ := ret[0].(*sdk.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRotateSecretsStatus indicates an expected call of AdminDatabaseRotateSecretsStatus
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRotateSecretsStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRotateSecretsStatus", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRotateSecretsStatus))
+}
+
 // AdminCDSMigrationList mocks base method
 func (m *MockInterface) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	m.ctrl.T.Helper()
@@ -5838,68 +6658,184 @@ func (m *MockInterface) GroupRename(oldName, newName string) error {
 	return ret0
 }
 
-// GroupRename indicates an expected call of GroupRename
-func (mr *MockInterfaceMockRecorder) GroupRename(oldName, newName interface{}) *gomock.Call {
+// GroupRename indicates an expected call of GroupRename
+func (mr *MockInterfaceMockRecorder) GroupRename(oldName, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRename", reflect.TypeOf((*MockInterface)(nil).GroupRename), oldName, newName)
+}
+
+// GroupDelete mocks base method
+func (m *MockInterface) GroupDelete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupDelete indicates an expected call of GroupDelete
+func (mr *MockInterfaceMockRecorder) GroupDelete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupDelete", reflect.TypeOf((*MockInterface)(nil).GroupDelete), name)
+}
+
+// GroupMemberAdd mocks base method
+func (m *MockInterface) GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupMemberAdd", groupName, member)
+	ret0, _ := ret[0].(sdk.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupMemberAdd indicates an expected call of GroupMemberAdd
+func (mr *MockInterfaceMockRecorder) GroupMemberAdd(groupName, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberAdd", reflect.TypeOf((*MockInterface)(nil).GroupMemberAdd), groupName, member)
+}
+
+// GroupMemberEdit mocks base method
+func (m *MockInterface) GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupMemberEdit", groupName, member)
+	ret0, _ := ret[0].(sdk.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupMemberEdit indicates an expected call of GroupMemberEdit
+func (mr *MockInterfaceMockRecorder) GroupMemberEdit(groupName, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberEdit", reflect.TypeOf((*MockInterface)(nil).GroupMemberEdit), groupName, member)
+}
+
+// GroupMemberRemove mocks base method
+func (m *MockInterface) GroupMemberRemove(groupName, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupMemberRemove", groupName, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GroupMemberRemove indicates an expected call of GroupMemberRemove
+func (mr *MockInterfaceMockRecorder) GroupMemberRemove(groupName, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockInterface)(nil).GroupMemberRemove), groupName, username)
+}
+
+// GroupSyncGet mocks base method
+func (m *MockInterface) GroupSyncGet(groupName string) (*sdk.GroupSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupSyncGet", groupName)
+	ret0, _ := ret[0].(*sdk.GroupSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupSyncGet indicates an expected call of GroupSyncGet
+func (mr *MockInterfaceMockRecorder) GroupSyncGet(groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncGet", reflect.TypeOf((*MockInterface)(nil).GroupSyncGet), groupName)
+}
+
+// GroupSyncSet mocks base method
+func (m *MockInterface) GroupSyncSet(groupName string, s sdk.GroupSync) (*sdk.GroupSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupSyncSet", groupName, s)
+	ret0, _ := ret[0].(*sdk.GroupSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupSyncSet indicates an expected call of GroupSyncSet
+func (mr *MockInterfaceMockRecorder) GroupSyncSet(groupName, s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRename", reflect.TypeOf((*MockInterface)(nil).GroupRename), oldName, newName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncSet", reflect.TypeOf((*MockInterface)(nil).GroupSyncSet), groupName, s)
 }
 
-// GroupDelete mocks base method
-func (m *MockInterface) GroupDelete(name string) error {
+// GroupSyncDelete mocks base method
+func (m *MockInterface) GroupSyncDelete(groupName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupDelete", name)
+	ret := m.ctrl.Call(m, "GroupSyncDelete", groupName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GroupDelete indicates an expected call of GroupDelete
-func (mr *MockInterfaceMockRecorder) GroupDelete(name interface{}) *gomock.Call {
+// GroupSyncDelete indicates an expected call of GroupSyncDelete
+func (mr *MockInterfaceMockRecorder) GroupSyncDelete(groupName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupDelete", reflect.TypeOf((*MockInterface)(nil).GroupDelete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupSyncDelete", reflect.TypeOf((*MockInterface)(nil).GroupSyncDelete), groupName)
 }
 
-// GroupMemberAdd mocks base method
-func (m *MockInterface) GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+// GroupAuditList mocks base method
+func (m *MockInterface) GroupAuditList(groupName string) ([]sdk.AuditGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberAdd", groupName, member)
-	ret0, _ := ret[0].(sdk.Group)
+	ret := m.ctrl.Call(m, "GroupAuditList", groupName)
+	ret0, _ := ret[0].([]sdk.AuditGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GroupMemberAdd indicates an expected call of GroupMemberAdd
-func (mr *MockInterfaceMockRecorder) GroupMemberAdd(groupName, member interface{}) *gomock.Call {
+// GroupAuditList indicates an expected call of GroupAuditList
+func (mr *MockInterfaceMockRecorder) GroupAuditList(groupName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberAdd", reflect.TypeOf((*MockInterface)(nil).GroupMemberAdd), groupName, member)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupAuditList", reflect.TypeOf((*MockInterface)(nil).GroupAuditList), groupName)
 }
 
-// GroupMemberEdit mocks base method
-func (m *MockInterface) GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+// RoleList mocks base method
+func (m *MockInterface) RoleList() ([]sdk.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberEdit", groupName, member)
-	ret0, _ := ret[0].(sdk.Group)
+	ret := m.ctrl.Call(m, "RoleList")
+	ret0, _ := ret[0].([]sdk.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GroupMemberEdit indicates an expected call of GroupMemberEdit
-func (mr *MockInterfaceMockRecorder) GroupMemberEdit(groupName, member interface{}) *gomock.Call {
+// RoleList indicates an expected call of RoleList
+func (mr *MockInterfaceMockRecorder) RoleList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberEdit", reflect.TypeOf((*MockInterface)(nil).GroupMemberEdit), groupName, member)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleList", reflect.TypeOf((*MockInterface)(nil).RoleList))
 }
 
-// GroupMemberRemove mocks base method
-func (m *MockInterface) GroupMemberRemove(groupName, username string) error {
+// RoleCreate mocks base method
+func (m *MockInterface) RoleCreate(r *sdk.Role) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberRemove", groupName, username)
+	ret := m.ctrl.Call(m, "RoleCreate", r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GroupMemberRemove indicates an expected call of GroupMemberRemove
-func (mr *MockInterfaceMockRecorder) GroupMemberRemove(groupName, username interface{}) *gomock.Call {
+// RoleCreate indicates an expected call of RoleCreate
+func (mr *MockInterfaceMockRecorder) RoleCreate(r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockInterface)(nil).GroupMemberRemove), groupName, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleCreate", reflect.TypeOf((*MockInterface)(nil).RoleCreate), r)
+}
+
+// RoleUpdate mocks base method
+func (m *MockInterface) RoleUpdate(name string, r *sdk.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleUpdate", name, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleUpdate indicates an expected call of RoleUpdate
+func (mr *MockInterfaceMockRecorder) RoleUpdate(name, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleUpdate", reflect.TypeOf((*MockInterface)(nil).RoleUpdate), name, r)
+}
+
+// RoleDelete mocks base method
+func (m *MockInterface) RoleDelete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleDelete indicates an expected call of RoleDelete
+func (mr *MockInterfaceMockRecorder) RoleDelete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleDelete", reflect.TypeOf((*MockInterface)(nil).RoleDelete), name)
 }
 
 // PluginsList mocks base method
@@ -6031,6 +6967,21 @@ func (mr *MockInterfaceMockRecorder) PluginGetBinaryInfos(name, os, arch interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockInterface)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
+// PluginGetRemote mocks base method
+func (m *MockInterface) PluginGetRemote(name string) (*sdk.GRPCPluginRemote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginGetRemote", name)
+	ret0, _ := ret[0].(*sdk.GRPCPluginRemote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginGetRemote indicates an expected call of PluginGetRemote
+func (mr *MockInterfaceMockRecorder) PluginGetRemote(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetRemote", reflect.TypeOf((*MockInterface)(nil).PluginGetRemote), name)
+}
+
 // Broadcasts mocks base method
 func (m *MockInterface) Broadcasts() ([]sdk.Broadcast, error) {
 	m.ctrl.T.Helper()
@@ -6478,6 +7429,165 @@ func (mr *MockInterfaceMockRecorder) VariableEncrypt(projectKey, varName, conten
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockInterface)(nil).VariableEncrypt), projectKey, varName, content)
 }
 
+// ProjectFreezeWindowList mocks base method
+func (m *MockInterface) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeWindowList indicates an expected call of ProjectFreezeWindowList
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowList", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowList), projectKey)
+}
+
+// ProjectFreezeWindowCreate mocks base method
+func (m *MockInterface) ProjectFreezeWindowCreate(projectKey string, fw *sdk.FreezeWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowCreate", projectKey, fw)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeWindowCreate indicates an expected call of ProjectFreezeWindowCreate
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowCreate(projectKey, fw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowCreate", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowCreate), projectKey, fw)
+}
+
+// ProjectFreezeWindowDelete mocks base method
+func (m *MockInterface) ProjectFreezeWindowDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeWindowDelete indicates an expected call of ProjectFreezeWindowDelete
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowDelete", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowDelete), projectKey, name)
+}
+
+// ProjectFreezeWindowAudit mocks base method
+func (m *MockInterface) ProjectFreezeWindowAudit(projectKey, name string) ([]sdk.FreezeWindowBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowAudit", projectKey, name)
+	ret0, _ := ret[0].([]sdk.FreezeWindowBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeWindowAudit indicates an expected call of ProjectFreezeWindowAudit
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowAudit(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowAudit", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowAudit), projectKey, name)
+}
+
+// ProjectRoleLinkList mocks base method
+func (m *MockInterface) ProjectRoleLinkList(projectKey string) ([]sdk.RoleLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkList", projectKey)
+	ret0, _ := ret[0].([]sdk.RoleLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRoleLinkList indicates an expected call of ProjectRoleLinkList
+func (mr *MockInterfaceMockRecorder) ProjectRoleLinkList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkList", reflect.TypeOf((*MockInterface)(nil).ProjectRoleLinkList), projectKey)
+}
+
+// ProjectRoleLinkAdd mocks base method
+func (m *MockInterface) ProjectRoleLinkAdd(projectKey string, l *sdk.RoleLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkAdd", projectKey, l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkAdd indicates an expected call of ProjectRoleLinkAdd
+func (mr *MockInterfaceMockRecorder) ProjectRoleLinkAdd(projectKey, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkAdd", reflect.TypeOf((*MockInterface)(nil).ProjectRoleLinkAdd), projectKey, l)
+}
+
+// ProjectRoleLinkDelete mocks base method
+func (m *MockInterface) ProjectRoleLinkDelete(projectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRoleLinkDelete", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRoleLinkDelete indicates an expected call of ProjectRoleLinkDelete
+func (mr *MockInterfaceMockRecorder) ProjectRoleLinkDelete(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRoleLinkDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRoleLinkDelete), projectKey, id)
+}
+
+// ProjectActions mocks base method
+func (m *MockInterface) ProjectActions(projectKey, workflowName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectActions", projectKey, workflowName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectActions indicates an expected call of ProjectActions
+func (mr *MockInterfaceMockRecorder) ProjectActions(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectActions", reflect.TypeOf((*MockInterface)(nil).ProjectActions), projectKey, workflowName)
+}
+
+// ProjectSecretScanAllowlist mocks base method
+func (m *MockInterface) ProjectSecretScanAllowlist(projectKey string) ([]sdk.SecretScanAllowlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlist", projectKey)
+	ret0, _ := ret[0].([]sdk.SecretScanAllowlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectSecretScanAllowlist indicates an expected call of ProjectSecretScanAllowlist
+func (mr *MockInterfaceMockRecorder) ProjectSecretScanAllowlist(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlist", reflect.TypeOf((*MockInterface)(nil).ProjectSecretScanAllowlist), projectKey)
+}
+
+// ProjectSecretScanAllowlistAdd mocks base method
+func (m *MockInterface) ProjectSecretScanAllowlistAdd(projectKey string, e *sdk.SecretScanAllowlistEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistAdd", projectKey, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectSecretScanAllowlistAdd indicates an expected call of ProjectSecretScanAllowlistAdd
+func (mr *MockInterfaceMockRecorder) ProjectSecretScanAllowlistAdd(projectKey, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistAdd", reflect.TypeOf((*MockInterface)(nil).ProjectSecretScanAllowlistAdd), projectKey, e)
+}
+
+// ProjectSecretScanAllowlistDelete mocks base method
+func (m *MockInterface) ProjectSecretScanAllowlistDelete(projectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSecretScanAllowlistDelete", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectSecretScanAllowlistDelete indicates an expected call of ProjectSecretScanAllowlistDelete
+func (mr *MockInterfaceMockRecorder) ProjectSecretScanAllowlistDelete(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSecretScanAllowlistDelete", reflect.TypeOf((*MockInterface)(nil).ProjectSecretScanAllowlistDelete), projectKey, id)
+}
+
 // ProjectGroupsImport mocks base method
 func (m *MockInterface) ProjectGroupsImport(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -6842,6 +7952,35 @@ func (mr *MockInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobIDToken mocks base method
+func (m *MockInterface) QueueJobIDToken(ctx context.Context, jobID int64, audience string) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, audience)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken
+func (mr *MockInterfaceMockRecorder) QueueJobIDToken(ctx, jobID, audience interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockInterface)(nil).QueueJobIDToken), ctx, jobID, audience)
+}
+
+// QueueJobSendSecretScanFindings mocks base method
+func (m *MockInterface) QueueJobSendSecretScanFindings(ctx context.Context, jobID int64, findings []sdk.SecretScanFinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSendSecretScanFindings", ctx, jobID, findings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobSendSecretScanFindings indicates an expected call of QueueJobSendSecretScanFindings
+func (mr *MockInterfaceMockRecorder) QueueJobSendSecretScanFindings(ctx, jobID, findings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSendSecretScanFindings", reflect.TypeOf((*MockInterface)(nil).QueueJobSendSecretScanFindings), ctx, jobID, findings)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -7516,6 +8655,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowNodeStop(projectKey, workflowName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeStop", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeStop), projectKey, workflowName, number, fromNodeID)
 }
 
+// WorkflowNodeRunFreezeOverride mocks base method
+func (m *MockInterface) WorkflowNodeRunFreezeOverride(projectKey, workflowName string, number, nodeRunID int64, reason string) (*sdk.FreezeWindowBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunFreezeOverride", projectKey, workflowName, number, nodeRunID, reason)
+	ret0, _ := ret[0].(*sdk.FreezeWindowBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunFreezeOverride indicates an expected call of WorkflowNodeRunFreezeOverride
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunFreezeOverride(projectKey, workflowName, number, nodeRunID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunFreezeOverride", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunFreezeOverride), projectKey, workflowName, number, nodeRunID, reason)
+}
+
 // WorkflowNodeRun mocks base method
 func (m *MockInterface) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
@@ -8054,6 +9208,21 @@ func (mr *MockWorkerInterfaceMockRecorder) PluginGetBinaryInfos(name, os, arch i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockWorkerInterface)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
+// PluginGetRemote mocks base method
+func (m *MockWorkerInterface) PluginGetRemote(name string) (*sdk.GRPCPluginRemote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginGetRemote", name)
+	ret0, _ := ret[0].(*sdk.GRPCPluginRemote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginGetRemote indicates an expected call of PluginGetRemote
+func (mr *MockWorkerInterfaceMockRecorder) PluginGetRemote(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetRemote", reflect.TypeOf((*MockWorkerInterface)(nil).PluginGetRemote), name)
+}
+
 // ProjectIntegrationGet mocks base method
 func (m *MockWorkerInterface) ProjectIntegrationGet(projectKey, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error) {
 	m.ctrl.T.Helper()
@@ -8320,6 +9489,35 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueJobIDToken mocks base method
+func (m *MockWorkerInterface) QueueJobIDToken(ctx context.Context, jobID int64, audience string) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, audience)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobIDToken(ctx, jobID, audience interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobIDToken), ctx, jobID, audience)
+}
+
+// QueueJobSendSecretScanFindings mocks base method
+func (m *MockWorkerInterface) QueueJobSendSecretScanFindings(ctx context.Context, jobID int64, findings []sdk.SecretScanFinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSendSecretScanFindings", ctx, jobID, findings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobSendSecretScanFindings indicates an expected call of QueueJobSendSecretScanFindings
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobSendSecretScanFindings(ctx, jobID, findings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSendSecretScanFindings", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobSendSecretScanFindings), ctx, jobID, findings)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockWorkerInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -8986,6 +10184,21 @@ func (mr *MockGRPCPluginsClientMockRecorder) PluginGetBinaryInfos(name, os, arch
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
+// PluginGetRemote mocks base method
+func (m *MockGRPCPluginsClient) PluginGetRemote(name string) (*sdk.GRPCPluginRemote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginGetRemote", name)
+	ret0, _ := ret[0].(*sdk.GRPCPluginRemote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginGetRemote indicates an expected call of PluginGetRemote
+func (mr *MockGRPCPluginsClientMockRecorder) PluginGetRemote(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetRemote", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginGetRemote), name)
+}
+
 // MockProviderClient is a mock of ProviderClient interface
 type MockProviderClient struct {
 	ctrl     *gomock.Controller
@@ -9231,6 +10444,95 @@ func (mr *MockAuthClientMockRecorder) AuthConsumerSignout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignout", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerSignout))
 }
 
+// AuthConsumerLocalSigninTOTP mocks base method
+func (m *MockAuthClient) AuthConsumerLocalSigninTOTP(arg0 sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalSigninTOTP", arg0)
+	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerLocalSigninTOTP indicates an expected call of AuthConsumerLocalSigninTOTP
+func (mr *MockAuthClientMockRecorder) AuthConsumerLocalSigninTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSigninTOTP", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerLocalSigninTOTP), arg0)
+}
+
+// AuthTOTPGet mocks base method
+func (m *MockAuthClient) AuthTOTPGet(username string) (sdk.AuthLocalTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPGet", username)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPGet indicates an expected call of AuthTOTPGet
+func (mr *MockAuthClientMockRecorder) AuthTOTPGet(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPGet", reflect.TypeOf((*MockAuthClient)(nil).AuthTOTPGet), username)
+}
+
+// AuthTOTPEnroll mocks base method
+func (m *MockAuthClient) AuthTOTPEnroll(username string) (sdk.AuthLocalTOTPEnrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPEnroll", username)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPEnrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPEnroll indicates an expected call of AuthTOTPEnroll
+func (mr *MockAuthClientMockRecorder) AuthTOTPEnroll(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPEnroll", reflect.TypeOf((*MockAuthClient)(nil).AuthTOTPEnroll), username)
+}
+
+// AuthTOTPVerify mocks base method
+func (m *MockAuthClient) AuthTOTPVerify(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPVerify", username, code)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPRecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPVerify indicates an expected call of AuthTOTPVerify
+func (mr *MockAuthClientMockRecorder) AuthTOTPVerify(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPVerify", reflect.TypeOf((*MockAuthClient)(nil).AuthTOTPVerify), username, code)
+}
+
+// AuthTOTPRecoveryCodes mocks base method
+func (m *MockAuthClient) AuthTOTPRecoveryCodes(username, code string) (sdk.AuthLocalTOTPRecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPRecoveryCodes", username, code)
+	ret0, _ := ret[0].(sdk.AuthLocalTOTPRecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthTOTPRecoveryCodes indicates an expected call of AuthTOTPRecoveryCodes
+func (mr *MockAuthClientMockRecorder) AuthTOTPRecoveryCodes(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPRecoveryCodes", reflect.TypeOf((*MockAuthClient)(nil).AuthTOTPRecoveryCodes), username, code)
+}
+
+// AuthTOTPDelete mocks base method
+func (m *MockAuthClient) AuthTOTPDelete(username, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthTOTPDelete", username, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthTOTPDelete indicates an expected call of AuthTOTPDelete
+func (mr *MockAuthClientMockRecorder) AuthTOTPDelete(username, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthTOTPDelete", reflect.TypeOf((*MockAuthClient)(nil).AuthTOTPDelete), username, code)
+}
+
 // AuthConsumerListByUser mocks base method
 func (m *MockAuthClient) AuthConsumerListByUser(username string) (sdk.AuthConsumers, error) {
 	m.ctrl.T.Helper()
@@ -9275,6 +10577,21 @@ func (mr *MockAuthClientMockRecorder) AuthConsumerRegen(username, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerRegen", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerRegen), username, id)
 }
 
+// AuthConsumerActivity mocks base method
+func (m *MockAuthClient) AuthConsumerActivity(username, id string) ([]sdk.AuthConsumerActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerActivity", username, id)
+	ret0, _ := ret[0].([]sdk.AuthConsumerActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerActivity indicates an expected call of AuthConsumerActivity
+func (mr *MockAuthClientMockRecorder) AuthConsumerActivity(username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerActivity", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerActivity), username, id)
+}
+
 // AuthConsumerCreateForUser mocks base method
 func (m *MockAuthClient) AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +200,8 @@ var (
 	ErrWorkflowAsCodeResync                          = Error{ID: 186, Status: http.StatusForbidden}
 	ErrWorkflowNodeNameDuplicate                     = Error{ID: 187, Status: http.StatusBadRequest}
 	ErrUnsupportedMediaType                          = Error{ID: 188, Status: http.StatusUnsupportedMediaType}
+	ErrGroupSynchronized                             = Error{ID: 189, Status: http.StatusForbidden}
+	ErrMFARequired                                   = Error{ID: 190, Status: http.StatusForbidden}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -382,6 +384,8 @@ var errorsAmericanEnglish = map[int]string{
 	ErrWorkflowAsCodeResync.ID:                          "You cannot resynchronize an as-code workflow",
 	ErrWorkflowNodeNameDuplicate.ID:                     "You cannot have same name for different pipelines in your workflow",
 	ErrUnsupportedMediaType.ID:                          "Request format invalid",
+	ErrGroupSynchronized.ID:                             "Group members are synchronized from an external source and can't be edited",
+	ErrMFARequired.ID:                                   "A second factor authentication is required, please enroll a TOTP device",
 }
 
 var errorsFrench = map[int]string{
@@ -564,6 +568,8 @@ var errorsFrench = map[int]string{
 	ErrWorkflowAsCodeResync.ID:                          "Impossible de resynchroniser un workflow en mode as-code",
 	ErrWorkflowNodeNameDuplicate.ID:                     "Vous ne pouvez pas avoir plusieurs fois le même nom de pipeline dans votre workflow",
 	ErrUnsupportedMediaType.ID:                          "Le format de la requête est invalide",
+	ErrGroupSynchronized.ID:                             "Les membres du groupe sont synchronisés depuis une source externe et ne peuvent pas être modifiés",
+	ErrMFARequired.ID:                                   "Une authentification à deux facteurs est requise, veuillez enregistrer un appareil TOTP",
 }
 
 var errorsLanguages = []map[int]string{
@@ -79,6 +79,7 @@ type EventRunWorkflowNode struct {
 	HookLog               string                    `json:"log,omitempty"`
 	NodeType              string                    `json:"node_type,omitempty"`
 	GerritChange          *GerritChangeEvent        `json:"gerrit_change,omitempty"`
+	Report                *VCSBuildReport           `json:"report,omitempty"`
 	EventIntegrations     []int64                   `json:"event_integrations_id,omitempty"`
 }
 
@@ -90,6 +91,8 @@ type GerritChangeEvent struct {
 	Revision   string `json:"revision,omitempty"`
 	Report     string `json:"report,omitempty"`
 	URL        string `json:"url,omitempty"`
+	// Review settings of the application linked to the node
+	Settings ApplicationGerritSettings `json:"settings"`
 }
 
 // EventRunWorkflowOutgoingHook contains event data for a workflow outgoing hook run
@@ -116,6 +119,13 @@ type EventRunWorkflowJob struct {
 	Parameters   []Parameter   `json:"parameters,omitempty"`
 }
 
+// EventRunWorkflowJobSecretDetected contains event data when secrets are detected in logs or artifacts of a job
+type EventRunWorkflowJobSecretDetected struct {
+	ID         int64               `json:"id"`
+	WorkerName string              `json:"worker_name,omitempty"`
+	Findings   []SecretScanFinding `json:"findings"`
+}
+
 // EventRunWorkflow contains event data for a workflow run
 type EventRunWorkflow struct {
 	ID               int64            `json:"id"`
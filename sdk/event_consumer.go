@@ -0,0 +1,20 @@
+package sdk
+
+import "time"
+
+// EventConsumerNewNetwork represents the event when an auth consumer is used from a network never seen before
+type EventConsumerNewNetwork struct {
+	ConsumerID   string `json:"consumer_id"`
+	ConsumerName string `json:"consumer_name"`
+	ConsumerType string `json:"consumer_type"`
+	IP           string `json:"ip"`
+	Network      string `json:"network"`
+	UserAgent    string `json:"user_agent"`
+}
+
+// EventConsumerIdleDisabled represents the event when an auth consumer is disabled after being idle
+type EventConsumerIdleDisabled struct {
+	ConsumerID   string     `json:"consumer_id"`
+	ConsumerName string     `json:"consumer_name"`
+	LastUsed     *time.Time `json:"last_used,omitempty"`
+}
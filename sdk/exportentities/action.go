@@ -1,19 +1,22 @@
 package exportentities
 
 import (
+	"sort"
+
 	"github.com/ovh/cds/sdk"
 )
 
 // Action represents exported sdk.Action
 type Action struct {
-	Version      string                    `json:"version,omitempty" yaml:"version,omitempty"`
-	Name         string                    `json:"name,omitempty" yaml:"name,omitempty"`
-	Group        string                    `json:"group,omitempty" yaml:"group,omitempty"`
-	Description  string                    `json:"description,omitempty" yaml:"description,omitempty"`
-	Enabled      *bool                     `json:"enabled,omitempty" yaml:"enabled,omitempty"`
-	Parameters   map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	Requirements []Requirement             `json:"requirements,omitempty" yaml:"requirements,omitempty"`
-	Steps        []Step                    `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Version      string                       `json:"version,omitempty" yaml:"version,omitempty"`
+	Name         string                       `json:"name,omitempty" yaml:"name,omitempty"`
+	Group        string                       `json:"group,omitempty" yaml:"group,omitempty"`
+	Description  string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Enabled      *bool                        `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Parameters   map[string]ParameterValue    `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Outputs      map[string]ActionOutputValue `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Requirements []Requirement                `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+	Steps        []Step                       `json:"steps,omitempty" yaml:"steps,omitempty"`
 }
 
 // ActionVersion is a version
@@ -49,6 +52,16 @@ func NewAction(a sdk.Action) Action {
 		}
 		ea.Parameters[v.Name] = param
 	}
+	if len(a.Outputs) > 0 {
+		ea.Outputs = make(map[string]ActionOutputValue, len(a.Outputs))
+		for _, o := range a.Outputs {
+			ea.Outputs[o.Name] = ActionOutputValue{
+				Type:        o.Type,
+				Description: o.Description,
+				Value:       o.Value,
+			}
+		}
+	}
 	ea.Steps = newSteps(a)
 	ea.Requirements = newRequirements(a.Requirements)
 	// enabled is the default value
@@ -99,6 +112,20 @@ func (ea *Action) GetAction() (sdk.Action, error) {
 		i++
 	}
 
+	for name, v := range ea.Outputs {
+		o := sdk.ActionOutput{
+			Name:        name,
+			Type:        v.Type,
+			Description: v.Description,
+			Value:       v.Value,
+		}
+		if v.Type == "" {
+			o.Type = sdk.ActionOutputString
+		}
+		a.Outputs = append(a.Outputs, o)
+	}
+	sort.Slice(a.Outputs, func(i, j int) bool { return a.Outputs[i].Name < a.Outputs[j].Name })
+
 	a.Requirements = computeJobRequirements(ea.Requirements)
 
 	children, err := computeSteps(ea.Steps)
@@ -0,0 +1,77 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestActionOutputs(t *testing.T) {
+	in := `version: v1.0
+name: build
+outputs:
+  artifact:
+    description: Path of the built artifact
+    value: '{{.cds.build.artifact}}'
+  size:
+    type: number
+    value: '{{.cds.build.size}}'
+steps:
+- script:
+  - worker export artifact ./bin/app
+`
+
+	var ea exportentities.Action
+	require.NoError(t, yaml.Unmarshal([]byte(in), &ea))
+
+	a, err := ea.GetAction()
+	require.NoError(t, err)
+	require.NoError(t, a.Outputs.IsValid())
+	assert.Equal(t, sdk.ActionOutputs{
+		{Name: "artifact", Type: sdk.ActionOutputString, Description: "Path of the built artifact", Value: "{{.cds.build.artifact}}"},
+		{Name: "size", Type: sdk.ActionOutputNumber, Value: "{{.cds.build.size}}"},
+	}, a.Outputs)
+
+	out := exportentities.NewAction(a)
+	assert.Equal(t, exportentities.ActionOutputValue{
+		Type:  sdk.ActionOutputNumber,
+		Value: "{{.cds.build.size}}",
+	}, out.Outputs["size"])
+	assert.Equal(t, sdk.ActionOutputString, out.Outputs["artifact"].Type)
+}
+
+func TestActionStepVersion(t *testing.T) {
+	in := `version: v1.0
+name: release
+steps:
+- my-group/build@1.2.0:
+    target: linux
+- my-group/publish@latest: {}
+- notify: {}
+`
+
+	var ea exportentities.Action
+	require.NoError(t, yaml.Unmarshal([]byte(in), &ea))
+
+	a, err := ea.GetAction()
+	require.NoError(t, err)
+	require.Len(t, a.Actions, 3)
+	assert.Equal(t, "build", a.Actions[0].Name)
+	assert.Equal(t, "my-group", a.Actions[0].Group.Name)
+	assert.Equal(t, "1.2.0", a.Actions[0].Version)
+	assert.Equal(t, "publish", a.Actions[1].Name)
+	assert.Equal(t, sdk.ActionVersionLatest, a.Actions[1].Version)
+	assert.Equal(t, "notify", a.Actions[2].Name)
+	assert.Equal(t, "", a.Actions[2].Version)
+
+	out := exportentities.NewAction(a)
+	require.Len(t, out.Steps, 3)
+	assert.Contains(t, out.Steps[0].StepCustom, "my-group/build@1.2.0")
+	assert.Contains(t, out.Steps[1].StepCustom, "my-group/publish@latest")
+	assert.Contains(t, out.Steps[2].StepCustom, "notify")
+}
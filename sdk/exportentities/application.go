@@ -19,6 +19,7 @@ type Application struct {
 	VCSPassword          string                              `json:"vcs_password,omitempty" yaml:"vcs_password,omitempty"`
 	VCSPGPKey            string                              `json:"vcs_pgp_key,omitempty" yaml:"vcs_pgp_key,omitempty" jsonschema_description:"Name of the pgp key, ex: proj-my-pgp-key. Will be used to tag for example."`
 	DeploymentStrategies map[string]map[string]VariableValue `json:"deployments,omitempty" yaml:"deployments,omitempty"`
+	GerritSettings       *sdk.ApplicationGerritSettings      `json:"gerrit,omitempty" yaml:"gerrit,omitempty" jsonschema_description:"Review posted by CDS on the gerrit changes of the repository."`
 }
 
 // ApplicationVersion is a version
@@ -96,5 +97,10 @@ func NewApplication(app sdk.Application, keys []EncryptedKey) (a Application, er
 		a.DeploymentStrategies[name] = vars
 	}
 
+	if !app.GerritSettings.IsDefault() {
+		settings := app.GerritSettings
+		a.GerritSettings = &settings
+	}
+
 	return a, nil
 }
@@ -141,6 +141,18 @@ func newStep(act sdk.Action) Step {
 			if tag != nil && tag.Value != sdk.DefaultGitCloneParameterTagValue {
 				s.GitClone.Tag = tag.Value
 			}
+			lfs := sdk.ParameterFind(act.Parameters, "lfs")
+			if lfs != nil && lfs.Value != "false" {
+				s.GitClone.LFS = lfs.Value
+			}
+			filter := sdk.ParameterFind(act.Parameters, "filter")
+			if filter != nil {
+				s.GitClone.Filter = filter.Value
+			}
+			sparseCheckout := sdk.ParameterFind(act.Parameters, "sparseCheckout")
+			if sparseCheckout != nil {
+				s.GitClone.SparseCheckout = sparseCheckout.Value
+			}
 		case sdk.GitTagAction:
 			s.GitTag = &StepGitTag{}
 			path := sdk.ParameterFind(act.Parameters, "path")
@@ -231,6 +243,9 @@ func newStep(act sdk.Action) Step {
 		if act.Group != nil && act.Group.Name != sdk.SharedInfraGroupName {
 			name = fmt.Sprintf("%s/%s", act.Group.Name, act.Name)
 		}
+		if act.Version != "" {
+			name = fmt.Sprintf("%s@%s", name, act.Version)
+		}
 
 		s.StepCustom = StepCustom{
 			name: args,
@@ -277,16 +292,19 @@ type StepServeStaticFiles struct {
 
 // StepGitClone represents exported git clone step.
 type StepGitClone struct {
-	Branch     string `json:"branch,omitempty" yaml:"branch,omitempty"`
-	Commit     string `json:"commit,omitempty" yaml:"commit,omitempty"`
-	Depth      string `json:"depth,omitempty" yaml:"depth,omitempty"`
-	Directory  string `json:"directory,omitempty" yaml:"directory,omitempty"`
-	Password   string `json:"password,omitempty" yaml:"password,omitempty"`
-	PrivateKey string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
-	SubModules string `json:"submodules,omitempty" yaml:"submodules,omitempty"`
-	Tag        string `json:"tag,omitempty" yaml:"tag,omitempty"`
-	URL        string `json:"url,omitempty" yaml:"url,omitempty" jsonschema:"required"`
-	User       string `json:"user,omitempty" yaml:"user,omitempty"`
+	Branch         string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit         string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Depth          string `json:"depth,omitempty" yaml:"depth,omitempty"`
+	Directory      string `json:"directory,omitempty" yaml:"directory,omitempty"`
+	Filter         string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	LFS            string `json:"lfs,omitempty" yaml:"lfs,omitempty"`
+	Password       string `json:"password,omitempty" yaml:"password,omitempty"`
+	PrivateKey     string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
+	SparseCheckout string `json:"sparseCheckout,omitempty" yaml:"sparseCheckout,omitempty"`
+	SubModules     string `json:"submodules,omitempty" yaml:"submodules,omitempty"`
+	Tag            string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	URL            string `json:"url,omitempty" yaml:"url,omitempty" jsonschema:"required"`
+	User           string `json:"user,omitempty" yaml:"user,omitempty"`
 }
 
 // StepRelease represents exported release step.
@@ -750,12 +768,14 @@ func (s Step) asAction() sdk.Action {
 		break
 	}
 
+	path, version := sdk.ParseActionReference(name)
 	a := sdk.Action{
-		Name:       name,
+		Name:       path,
+		Version:    version,
 		Parameters: []sdk.Parameter{},
 	}
 
-	splitted := strings.Split(name, "/")
+	splitted := strings.Split(path, "/")
 	if len(splitted) == 2 {
 		a.Name = splitted[1]
 		a.Group = &sdk.Group{Name: splitted[0]}
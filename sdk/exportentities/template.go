@@ -30,9 +30,20 @@ type Template struct {
 
 // TemplateParameter is the "as code" representation of a sdk.TemplateParameter.
 type TemplateParameter struct {
-	Key      string `json:"key" yaml:"key"`
-	Type     string `json:"type" yaml:"type"`
-	Required bool   `json:"required" yaml:"required"`
+	Key         string                      `json:"key" yaml:"key"`
+	Type        string                      `json:"type" yaml:"type"`
+	Required    bool                        `json:"required" yaml:"required"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     string                      `json:"default,omitempty" yaml:"default,omitempty"`
+	Choices     []string                    `json:"choices,omitempty" yaml:"choices,omitempty"`
+	Regex       string                      `json:"regex,omitempty" yaml:"regex,omitempty"`
+	Condition   *TemplateParameterCondition `json:"condition,omitempty" yaml:"condition,omitempty"`
+}
+
+// TemplateParameterCondition is the "as code" representation of a sdk.WorkflowTemplateParameterCondition.
+type TemplateParameterCondition struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
 }
 
 // Name pattern for template files.
@@ -61,6 +72,16 @@ func NewTemplate(wt sdk.WorkflowTemplate) (Template, error) {
 		exportedTemplate.Parameters[i].Key = p.Key
 		exportedTemplate.Parameters[i].Type = string(p.Type)
 		exportedTemplate.Parameters[i].Required = p.Required
+		exportedTemplate.Parameters[i].Description = p.Description
+		exportedTemplate.Parameters[i].Default = p.Default
+		exportedTemplate.Parameters[i].Choices = p.Choices
+		exportedTemplate.Parameters[i].Regex = p.Regex
+		if p.Condition != nil {
+			exportedTemplate.Parameters[i].Condition = &TemplateParameterCondition{
+				Key:   p.Condition.Key,
+				Value: p.Condition.Value,
+			}
+		}
 	}
 
 	for i := range wt.Pipelines {
@@ -92,11 +113,22 @@ func (w Template) GetTemplate(wkf []byte, pips, apps, envs [][]byte) sdk.Workflo
 	}
 
 	for _, p := range w.Parameters {
-		wt.Parameters = append(wt.Parameters, sdk.WorkflowTemplateParameter{
-			Key:      p.Key,
-			Type:     sdk.TemplateParameterType(p.Type),
-			Required: p.Required,
-		})
+		param := sdk.WorkflowTemplateParameter{
+			Key:         p.Key,
+			Type:        sdk.TemplateParameterType(p.Type),
+			Required:    p.Required,
+			Description: p.Description,
+			Default:     p.Default,
+			Choices:     p.Choices,
+			Regex:       p.Regex,
+		}
+		if p.Condition != nil {
+			param.Condition = &sdk.WorkflowTemplateParameterCondition{
+				Key:   p.Condition.Key,
+				Value: p.Condition.Value,
+			}
+		}
+		wt.Parameters = append(wt.Parameters, param)
 	}
 
 	for i := range pips {
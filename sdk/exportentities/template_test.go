@@ -20,6 +20,8 @@ func TestNewTemplateAndGetTemplate(t *testing.T) {
 			{Key: "my-boolean", Type: "boolean", Required: true},
 			{Key: "my-string", Type: "string", Required: true},
 			{Key: "my-repository", Type: "repository", Required: true},
+			{Key: "my-env", Type: "string", Description: "Target env", Default: "dev", Choices: []string{"dev", "prod"}},
+			{Key: "my-version", Type: "string", Regex: "^v[0-9]+$", Condition: &exportentities.TemplateParameterCondition{Key: "my-env", Value: "prod"}},
 		},
 		Workflow: "workflow.yml",
 	}
@@ -37,6 +39,8 @@ func TestNewTemplateAndGetTemplate(t *testing.T) {
 			{Key: "my-boolean", Type: "boolean", Required: true},
 			{Key: "my-string", Type: "string", Required: true},
 			{Key: "my-repository", Type: "repository", Required: true},
+			{Key: "my-env", Type: "string", Description: "Target env", Default: "dev", Choices: []string{"dev", "prod"}},
+			{Key: "my-version", Type: "string", Regex: "^v[0-9]+$", Condition: &sdk.WorkflowTemplateParameterCondition{Key: "my-env", Value: "prod"}},
 		},
 	}
 	sdkTemplateYaml, err := yaml.Marshal(sdkTemplate)
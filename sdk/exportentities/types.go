@@ -24,6 +24,13 @@ type (
 		Description  string `json:"description,omitempty" yaml:"description,omitempty"`
 		Advanced     *bool  `json:"advanced,omitempty" yaml:"advanced,omitempty"`
 	}
+
+	// ActionOutputValue is a struct to export a typed output of an action
+	ActionOutputValue struct {
+		Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		Value       string `json:"value,omitempty" yaml:"value,omitempty"`
+	}
 )
 
 //All the consts
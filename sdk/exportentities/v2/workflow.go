@@ -22,11 +22,12 @@ type Workflow struct {
 	Hooks    map[string][]HookEntry `json:"hooks,omitempty" yaml:"hooks,omitempty" jsonschema_description:"Workflow hooks list."`
 
 	// extra workflow data
-	Permissions   map[string]int      `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
-	Metadata      map[string]string   `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	PurgeTags     []string            `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
-	Notifications []NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have only one pipeline
-	HistoryLength *int64              `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	Permissions   map[string]int           `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
+	Metadata      map[string]string        `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	PurgeTags     []string                 `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
+	Notifications []NotificationEntry      `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have only one pipeline
+	HistoryLength *int64                   `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	Concurrency   *sdk.WorkflowConcurrency `json:"concurrency,omitempty" yaml:"concurrency,omitempty" jsonschema_description:"Only one run at a time for runs with the same concurrency group (ex: {{.cds.workflow}}-{{.git.branch}}), older runs are queued or canceled."`
 }
 
 // NodeEntry represents a node as code
@@ -91,6 +92,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, version string, opts ...Ex
 	}
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
+	exportedWorkflow.Concurrency = w.Concurrency
 
 	nodes := w.WorkflowData.Array()
 
@@ -347,6 +349,7 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, sdk.WrapError(err, "Unable to check dependencies")
 	}
 	wf.PurgeTags = w.PurgeTags
+	wf.Concurrency = w.Concurrency
 	if len(w.Metadata) > 0 {
 		wf.Metadata = make(map[string]string, len(w.Metadata))
 		for k, v := range w.Metadata {
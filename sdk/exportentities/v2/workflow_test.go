@@ -727,6 +727,17 @@ workflow:
     when:
     - success
     pipeline: test
+`,
+		}, {
+			name: "test with concurrency group",
+			yaml: `name: test1
+version: v2.0
+workflow:
+  build:
+    pipeline: build
+concurrency:
+  group: '{{.cds.workflow}}-{{.git.branch}}'
+  cancel_in_progress: true
 `,
 		}, {
 			name: "test with outgoing hooks",
@@ -72,6 +72,46 @@ type WorkflowComponentsRaw struct {
 	Environments []string `json:"environments,omitempty"`
 }
 
+// Files returns the content of all files for a workflow indexed by file name.
+func (w WorkflowComponents) Files() (map[string][]byte, error) {
+	res := make(map[string][]byte)
+	add := func(name string, i interface{}) error {
+		bs, err := yaml.Marshal(i)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		res[name] = bs
+		return nil
+	}
+
+	if w.Template.Name != "" {
+		if err := add(fmt.Sprintf(PullWorkflowName, w.Template.Name), w.Template); err != nil {
+			return nil, err
+		}
+	}
+	if w.Workflow != nil {
+		if err := add(fmt.Sprintf(PullWorkflowName, w.Workflow.GetName()), w.Workflow); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range w.Applications {
+		if err := add(fmt.Sprintf(PullApplicationName, a.Name), a); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range w.Environments {
+		if err := add(fmt.Sprintf(PullEnvironmentName, e.Name), e); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range w.Pipelines {
+		if err := add(fmt.Sprintf(PullPipelineName, p.Name), p); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 // TarWorkflowComponents returns a tar containing all files for a workflow.
 func TarWorkflowComponents(ctx context.Context, w WorkflowComponents, writer io.Writer) error {
 	tw := tar.NewWriter(writer)
@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// FreezeWindow represents a period during which deployments are blocked for a
+// whole project or for a given environment.
+// A window is either absolute (Start and End are set) or recurring (Cron and
+// Duration are set, Start and End are then optional bounds).
+type FreezeWindow struct {
+	ID                int64      `json:"id" db:"id" cli:"-"`
+	Name              string     `json:"name" db:"name" cli:"name,key"`
+	ProjectID         int64      `json:"project_id" db:"project_id" cli:"-"`
+	EnvironmentID     *int64     `json:"environment_id,omitempty" db:"environment_id" cli:"-"`
+	EnvironmentName   string     `json:"environment_name,omitempty" db:"-" cli:"environment"`
+	Reason            string     `json:"reason" db:"reason" cli:"reason"`
+	Start             *time.Time `json:"start,omitempty" db:"start_date" cli:"start"`
+	End               *time.Time `json:"end,omitempty" db:"end_date" cli:"end"`
+	Cron              string     `json:"cron,omitempty" db:"cron" cli:"cron"`
+	Duration          int64      `json:"duration,omitempty" db:"duration" cli:"duration"` // in seconds
+	Timezone          string     `json:"timezone,omitempty" db:"timezone" cli:"-"`
+	OverrideGroupID   *int64     `json:"override_group_id,omitempty" db:"override_group_id" cli:"-"`
+	OverrideGroupName string     `json:"override_group_name,omitempty" db:"-" cli:"override_group"`
+	Author            string     `json:"author" db:"author" cli:"author"`
+	Created           time.Time  `json:"created" db:"created" cli:"-"`
+}
+
+// IsValid returns an error if the freeze window is not valid.
+func (f FreezeWindow) IsValid() error {
+	if !NamePatternRegex.MatchString(f.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid given freeze window name, should match %s", NamePattern)
+	}
+
+	if f.Cron == "" {
+		if f.Start == nil || f.End == nil {
+			return NewErrorFrom(ErrWrongRequest, "start and end dates are mandatory for a freeze window without cron expression")
+		}
+		if !f.End.After(*f.Start) {
+			return NewErrorFrom(ErrWrongRequest, "freeze window end date should be after start date")
+		}
+		return nil
+	}
+
+	if _, err := cronexpr.Parse(f.Cron); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid cron expression %q: %v", f.Cron, err)
+	}
+	if f.Duration <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "duration is mandatory for a freeze window with cron expression")
+	}
+	if _, err := f.location(); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid timezone %q", f.Timezone)
+	}
+	if f.Start != nil && f.End != nil && !f.End.After(*f.Start) {
+		return NewErrorFrom(ErrWrongRequest, "freeze window end date should be after start date")
+	}
+	return nil
+}
+
+func (f FreezeWindow) location() (*time.Location, error) {
+	if f.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(f.Timezone)
+}
+
+// ActiveUntil returns the end of the current freeze period for given time and
+// false if the window is not active at this time.
+func (f FreezeWindow) ActiveUntil(t time.Time) (time.Time, bool) {
+	if f.Start != nil && t.Before(*f.Start) {
+		return time.Time{}, false
+	}
+	if f.End != nil && !t.Before(*f.End) {
+		return time.Time{}, false
+	}
+
+	// Absolute window
+	if f.Cron == "" {
+		if f.Start == nil || f.End == nil {
+			return time.Time{}, false
+		}
+		return *f.End, true
+	}
+
+	// Recurring window, check if a period started during the last duration
+	expr, err := cronexpr.Parse(f.Cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+	loc, err := f.location()
+	if err != nil {
+		return time.Time{}, false
+	}
+	d := time.Duration(f.Duration) * time.Second
+	begin := expr.Next(t.In(loc).Add(-d))
+	if begin.IsZero() || begin.After(t) {
+		return time.Time{}, false
+	}
+	end := begin.Add(d)
+	if f.End != nil && end.After(*f.End) {
+		end = *f.End
+	}
+	return end, true
+}
+
+// IsActive returns true if the freeze window is active at given time.
+func (f FreezeWindow) IsActive(t time.Time) bool {
+	_, active := f.ActiveUntil(t)
+	return active
+}
+
+// AppliesTo returns true if the freeze window should block a node on given environment.
+func (f FreezeWindow) AppliesTo(environmentID int64) bool {
+	return f.EnvironmentID == nil || *f.EnvironmentID == environmentID
+}
+
+func (f FreezeWindow) String() string {
+	if f.Reason == "" {
+		return f.Name
+	}
+	return fmt.Sprintf("%s (%s)", f.Name, f.Reason)
+}
+
+// FreezeWindows type.
+type FreezeWindows []FreezeWindow
+
+// Active returns the first freeze window active at given time that applies to given environment.
+func (f FreezeWindows) Active(t time.Time, environmentID int64) (*FreezeWindow, time.Time) {
+	for i := range f {
+		if !f[i].AppliesTo(environmentID) {
+			continue
+		}
+		if until, active := f[i].ActiveUntil(t); active {
+			return &f[i], until
+		}
+	}
+	return nil, time.Time{}
+}
+
+// Freeze window block release types.
+const (
+	FreezeWindowReleaseExpired  = "expired"
+	FreezeWindowReleaseOverride = "override"
+	FreezeWindowReleaseStopped  = "stopped"
+	FreezeWindowReleaseDeleted  = "deleted"
+)
+
+// FreezeWindowBlock keeps track of a workflow node run blocked by a freeze window,
+// it's also used as audit for emergency overrides. Blocks are kept when the freeze window
+// is deleted, then FreezeWindowID is nil.
+type FreezeWindowBlock struct {
+	ID                int64      `json:"id" db:"id" cli:"-"`
+	ProjectID         int64      `json:"project_id" db:"project_id" cli:"-"`
+	FreezeWindowID    *int64     `json:"freeze_window_id,omitempty" db:"freeze_window_id" cli:"-"`
+	FreezeWindowName  string     `json:"freeze_window_name" db:"freeze_window_name" cli:"freeze_window"`
+	WorkflowRunID     int64      `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64      `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"node_run_id"`
+	WorkflowName      string     `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	WorkflowNodeName  string     `json:"workflow_node_name" db:"workflow_node_name" cli:"pipeline"`
+	Number            int64      `json:"number" db:"num" cli:"number"`
+	Created           time.Time  `json:"created" db:"created" cli:"created"`
+	Released          *time.Time `json:"released,omitempty" db:"released" cli:"released"`
+	ReleaseType       string     `json:"release_type,omitempty" db:"release_type" cli:"release_type"`
+	OverrideUsername  string     `json:"override_username,omitempty" db:"override_username" cli:"override_by"`
+	OverrideReason    string     `json:"override_reason,omitempty" db:"override_reason" cli:"override_reason"`
+}
+
+// FreezeWindowOverride is the request body to bypass a freeze window.
+type FreezeWindowOverride struct {
+	Reason string `json:"reason"`
+}
+
+// IsValid returns an error if the override request is not valid.
+func (f FreezeWindowOverride) IsValid() error {
+	if f.Reason == "" {
+		return NewErrorFrom(ErrWrongRequest, "a reason is mandatory to override a freeze window")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeWindowIsValid(t *testing.T) {
+	start := time.Date(2019, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	assert.NoError(t, FreezeWindow{Name: "christmas", Start: &start, End: &end}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "christmas", Start: &end, End: &start}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "christmas", Start: &start}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "not valid", Start: &start, End: &end}.IsValid())
+
+	assert.NoError(t, FreezeWindow{Name: "weekend", Cron: "0 18 * * 5", Duration: 3600}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "weekend", Cron: "0 18 * * 5"}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "weekend", Cron: "invalid", Duration: 3600}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "weekend", Cron: "0 18 * * 5", Duration: 3600, Timezone: "Mars/Olympus"}.IsValid())
+}
+
+func TestFreezeWindowActiveUntil(t *testing.T) {
+	start := time.Date(2019, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	fw := FreezeWindow{Name: "christmas", Start: &start, End: &end}
+
+	assert.False(t, fw.IsActive(start.Add(-time.Minute)))
+	until, active := fw.ActiveUntil(start.Add(time.Hour))
+	require.True(t, active)
+	assert.Equal(t, end, until)
+	assert.False(t, fw.IsActive(end))
+
+	// Every friday at 18:00 for 62 hours
+	weekend := FreezeWindow{Name: "weekend", Cron: "0 18 * * 5", Duration: 62 * 3600}
+	friday := time.Date(2019, 12, 20, 18, 0, 0, 0, time.UTC)
+	assert.False(t, weekend.IsActive(friday.Add(-time.Minute)))
+	until, active = weekend.ActiveUntil(friday.Add(24 * time.Hour))
+	require.True(t, active)
+	assert.Equal(t, friday.Add(62*time.Hour), until)
+	assert.False(t, weekend.IsActive(friday.Add(62*time.Hour)))
+
+	// Recurring window bounded by an end date
+	weekend.End = &end
+	until, active = weekend.ActiveUntil(friday.Add(time.Hour))
+	require.True(t, active)
+	assert.Equal(t, end, until)
+	assert.False(t, weekend.IsActive(friday.Add(24*time.Hour)))
+
+	// Recurring window evaluated in given timezone
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	night := FreezeWindow{Name: "night", Cron: "0 22 * * *", Duration: 3600, Timezone: "Europe/Paris"}
+	assert.True(t, night.IsActive(time.Date(2019, 12, 20, 22, 30, 0, 0, paris)))
+	assert.False(t, night.IsActive(time.Date(2019, 12, 20, 22, 30, 0, 0, time.UTC)))
+}
+
+func TestFreezeWindowsActive(t *testing.T) {
+	start := time.Date(2019, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	envID := int64(1)
+	ws := FreezeWindows{
+		{Name: "production", EnvironmentID: &envID, Start: &start, End: &end},
+	}
+
+	fw, _ := ws.Active(start.Add(time.Hour), 2)
+	assert.Nil(t, fw)
+	fw, until := ws.Active(start.Add(time.Hour), 1)
+	require.NotNil(t, fw)
+	assert.Equal(t, "production", fw.Name)
+	assert.Equal(t, end, until)
+
+	ws = append(ws, FreezeWindow{Name: "project", Start: &start, End: &end})
+	fw, _ = ws.Active(start.Add(time.Hour), 2)
+	require.NotNil(t, fw)
+	assert.Equal(t, "project", fw.Name)
+}
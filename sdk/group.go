@@ -1,5 +1,7 @@
 package sdk
 
+import "time"
+
 // SharedInfraGroupName is the name of the builtin group used to share infrastructure between projects
 const SharedInfraGroupName = "shared.infra"
 
@@ -28,6 +30,32 @@ type Group struct {
 	// aggregate
 	Members GroupMembers `json:"members,omitempty" yaml:"members,omitempty" db:"-"`
 	Admin   bool         `json:"admin,omitempty" yaml:"admin,omitempty" db:"-"`
+	Sync    *GroupSync   `json:"sync,omitempty" yaml:"-" db:"-"`
+}
+
+// GroupSync binds a group to an external source, group members are synchronized from this source.
+type GroupSync struct {
+	ID      int64            `json:"id" db:"id" cli:"-"`
+	GroupID int64            `json:"group_id" db:"group_id" cli:"-"`
+	Type    AuthConsumerType `json:"type" db:"type" cli:"type"`
+	// Value is a LDAP group DN or an OpenID Connect groups claim value
+	Value        string    `json:"value" db:"value" cli:"value"`
+	Created      time.Time `json:"created" db:"created" cli:"created"`
+	LastSync     time.Time `json:"last_sync" db:"last_sync" cli:"last_sync"`
+	LastSyncInfo string    `json:"last_sync_info" db:"last_sync_info" cli:"last_sync_info"`
+}
+
+// IsValid returns an error if given group sync is not valid.
+func (s GroupSync) IsValid() error {
+	switch s.Type {
+	case ConsumerLDAP, ConsumerOIDC:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid given type %q for group sync, should be %s or %s", s.Type, ConsumerLDAP, ConsumerOIDC)
+	}
+	if s.Value == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given value for group sync")
+	}
+	return nil
 }
 
 // IsValid returns an error if given group is not valid.
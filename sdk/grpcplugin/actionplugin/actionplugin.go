@@ -11,6 +11,8 @@ import (
 	"github.com/ovh/cds/sdk/grpcplugin"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Common is the common struct of actionplugin
@@ -59,6 +61,12 @@ func (c *Common) WorkerHTTPPort(ctx context.Context, q *WorkerHTTPPortQuery) (*e
 	return &empty.Empty{}, nil
 }
 
+// RunStream is not implemented by default, the worker falls back on Run.
+// Plugins override it to stream logs, progress and outputs while running the action.
+func (c *Common) RunStream(q *ActionQuery, stream ActionPlugin_RunStreamServer) error {
+	return status.Error(codes.Unimplemented, "method RunStream not implemented")
+}
+
 func Fail(format string, args ...interface{}) (*ActionResult, error) {
 	msg := fmt.Sprintf(format, args...)
 	fmt.Println(msg)
@@ -4,11 +4,13 @@
 package actionplugin
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
-	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -21,7 +23,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type ActionPluginManifest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -219,45 +221,312 @@ func (m *WorkerHTTPPortQuery) GetPort() int32 {
 	return 0
 }
 
+type ActionLog struct {
+	Level                string   `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionLog) Reset()         { *m = ActionLog{} }
+func (m *ActionLog) String() string { return proto.CompactTextString(m) }
+func (*ActionLog) ProtoMessage()    {}
+func (*ActionLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8761e3c72e0ffc53, []int{4}
+}
+
+func (m *ActionLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionLog.Unmarshal(m, b)
+}
+func (m *ActionLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionLog.Marshal(b, m, deterministic)
+}
+func (m *ActionLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionLog.Merge(m, src)
+}
+func (m *ActionLog) XXX_Size() int {
+	return xxx_messageInfo_ActionLog.Size(m)
+}
+func (m *ActionLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionLog proto.InternalMessageInfo
+
+func (m *ActionLog) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *ActionLog) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ActionProgress struct {
+	Percent              int32    `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionProgress) Reset()         { *m = ActionProgress{} }
+func (m *ActionProgress) String() string { return proto.CompactTextString(m) }
+func (*ActionProgress) ProtoMessage()    {}
+func (*ActionProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8761e3c72e0ffc53, []int{5}
+}
+
+func (m *ActionProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionProgress.Unmarshal(m, b)
+}
+func (m *ActionProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionProgress.Marshal(b, m, deterministic)
+}
+func (m *ActionProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionProgress.Merge(m, src)
+}
+func (m *ActionProgress) XXX_Size() int {
+	return xxx_messageInfo_ActionProgress.Size(m)
+}
+func (m *ActionProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionProgress proto.InternalMessageInfo
+
+func (m *ActionProgress) GetPercent() int32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+func (m *ActionProgress) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ActionOutput struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionOutput) Reset()         { *m = ActionOutput{} }
+func (m *ActionOutput) String() string { return proto.CompactTextString(m) }
+func (*ActionOutput) ProtoMessage()    {}
+func (*ActionOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8761e3c72e0ffc53, []int{6}
+}
+
+func (m *ActionOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionOutput.Unmarshal(m, b)
+}
+func (m *ActionOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionOutput.Marshal(b, m, deterministic)
+}
+func (m *ActionOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionOutput.Merge(m, src)
+}
+func (m *ActionOutput) XXX_Size() int {
+	return xxx_messageInfo_ActionOutput.Size(m)
+}
+func (m *ActionOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionOutput proto.InternalMessageInfo
+
+func (m *ActionOutput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ActionOutput) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ActionRunFrame struct {
+	// Types that are valid to be assigned to Frame:
+	//	*ActionRunFrame_Log
+	//	*ActionRunFrame_Result
+	//	*ActionRunFrame_Progress
+	//	*ActionRunFrame_Output
+	Frame                isActionRunFrame_Frame `protobuf_oneof:"frame"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ActionRunFrame) Reset()         { *m = ActionRunFrame{} }
+func (m *ActionRunFrame) String() string { return proto.CompactTextString(m) }
+func (*ActionRunFrame) ProtoMessage()    {}
+func (*ActionRunFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8761e3c72e0ffc53, []int{7}
+}
+
+func (m *ActionRunFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionRunFrame.Unmarshal(m, b)
+}
+func (m *ActionRunFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionRunFrame.Marshal(b, m, deterministic)
+}
+func (m *ActionRunFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionRunFrame.Merge(m, src)
+}
+func (m *ActionRunFrame) XXX_Size() int {
+	return xxx_messageInfo_ActionRunFrame.Size(m)
+}
+func (m *ActionRunFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionRunFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionRunFrame proto.InternalMessageInfo
+
+type isActionRunFrame_Frame interface {
+	isActionRunFrame_Frame()
+}
+
+type ActionRunFrame_Log struct {
+	Log *ActionLog `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type ActionRunFrame_Result struct {
+	Result *ActionResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type ActionRunFrame_Progress struct {
+	Progress *ActionProgress `protobuf:"bytes,3,opt,name=progress,proto3,oneof"`
+}
+
+type ActionRunFrame_Output struct {
+	Output *ActionOutput `protobuf:"bytes,4,opt,name=output,proto3,oneof"`
+}
+
+func (*ActionRunFrame_Log) isActionRunFrame_Frame() {}
+
+func (*ActionRunFrame_Result) isActionRunFrame_Frame() {}
+
+func (*ActionRunFrame_Progress) isActionRunFrame_Frame() {}
+
+func (*ActionRunFrame_Output) isActionRunFrame_Frame() {}
+
+func (m *ActionRunFrame) GetFrame() isActionRunFrame_Frame {
+	if m != nil {
+		return m.Frame
+	}
+	return nil
+}
+
+func (m *ActionRunFrame) GetLog() *ActionLog {
+	if x, ok := m.GetFrame().(*ActionRunFrame_Log); ok {
+		return x.Log
+	}
+	return nil
+}
+
+func (m *ActionRunFrame) GetResult() *ActionResult {
+	if x, ok := m.GetFrame().(*ActionRunFrame_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+func (m *ActionRunFrame) GetProgress() *ActionProgress {
+	if x, ok := m.GetFrame().(*ActionRunFrame_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (m *ActionRunFrame) GetOutput() *ActionOutput {
+	if x, ok := m.GetFrame().(*ActionRunFrame_Output); ok {
+		return x.Output
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ActionRunFrame) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ActionRunFrame_Log)(nil),
+		(*ActionRunFrame_Result)(nil),
+		(*ActionRunFrame_Progress)(nil),
+		(*ActionRunFrame_Output)(nil),
+	}
+}
+
 func init() {
 	proto.RegisterType((*ActionPluginManifest)(nil), "actionplugin.ActionPluginManifest")
 	proto.RegisterType((*ActionQuery)(nil), "actionplugin.ActionQuery")
 	proto.RegisterMapType((map[string]string)(nil), "actionplugin.ActionQuery.OptionsEntry")
 	proto.RegisterType((*ActionResult)(nil), "actionplugin.ActionResult")
 	proto.RegisterType((*WorkerHTTPPortQuery)(nil), "actionplugin.WorkerHTTPPortQuery")
+	proto.RegisterType((*ActionLog)(nil), "actionplugin.ActionLog")
+	proto.RegisterType((*ActionProgress)(nil), "actionplugin.ActionProgress")
+	proto.RegisterType((*ActionOutput)(nil), "actionplugin.ActionOutput")
+	proto.RegisterType((*ActionRunFrame)(nil), "actionplugin.ActionRunFrame")
 }
 
 func init() { proto.RegisterFile("actionplugin.proto", fileDescriptor_8761e3c72e0ffc53) }
 
 var fileDescriptor_8761e3c72e0ffc53 = []byte{
-	// 425 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0x63, 0xb7, 0x85, 0x49, 0x84, 0x60, 0xa8, 0x2a, 0x63, 0x2e, 0x61, 0x0f, 0x50, 0x2e,
-	0x5b, 0xa9, 0x5c, 0xaa, 0x1e, 0x50, 0xa9, 0xa8, 0x54, 0x24, 0x2a, 0x8c, 0xa9, 0x84, 0xc4, 0xcd,
-	0xb1, 0x37, 0x8e, 0x89, 0xe3, 0xb5, 0xf6, 0x23, 0x92, 0x2f, 0xfc, 0x97, 0xfc, 0x53, 0xe4, 0x5d,
-	0xbb, 0xb2, 0xa5, 0xf8, 0x36, 0x6f, 0xe6, 0xcd, 0xec, 0xbc, 0x79, 0x5a, 0xc0, 0x38, 0x51, 0x39,
-	0x2f, 0xab, 0x42, 0x67, 0x79, 0x49, 0x2b, 0xc1, 0x15, 0xc7, 0x79, 0x3f, 0x17, 0xbc, 0xcd, 0x38,
-	0xcf, 0x0a, 0x76, 0x61, 0x6a, 0x4b, 0xbd, 0xba, 0x60, 0xdb, 0x4a, 0xd5, 0x96, 0x4a, 0xfe, 0xc1,
-	0xe9, 0x17, 0x43, 0x0e, 0x0d, 0xf9, 0x21, 0x2e, 0xf3, 0x15, 0x93, 0x0a, 0x11, 0xbc, 0x32, 0xde,
-	0x32, 0xdf, 0x59, 0x38, 0xe7, 0xcf, 0x23, 0x13, 0xa3, 0x0f, 0x27, 0x3b, 0x26, 0x64, 0xce, 0x4b,
-	0x7f, 0x6a, 0xd2, 0x1d, 0xc4, 0x05, 0xcc, 0x52, 0x26, 0x13, 0x91, 0x57, 0xcd, 0x28, 0xdf, 0x35,
-	0xd5, 0x7e, 0x0a, 0xcf, 0xe0, 0x38, 0xd6, 0x6a, 0xcd, 0x85, 0xef, 0x99, 0x62, 0x8b, 0xc8, 0xde,
-	0x81, 0x99, 0x5d, 0xe0, 0xa7, 0x66, 0xa2, 0xc6, 0x1b, 0x38, 0xe1, 0xa6, 0x43, 0xfa, 0xce, 0xc2,
-	0x3d, 0x9f, 0x5d, 0xbe, 0xa7, 0x03, 0x81, 0x3d, 0x2e, 0xfd, 0x61, 0x89, 0x77, 0xa5, 0x12, 0x75,
-	0xd4, 0xb5, 0xe1, 0x29, 0x1c, 0xfd, 0xe5, 0xcb, 0x6f, 0x5f, 0xcd, 0x8e, 0x6e, 0x64, 0x41, 0x70,
-	0x0d, 0xf3, 0x3e, 0x1d, 0x5f, 0x82, 0xbb, 0x61, 0x75, 0x2b, 0xaf, 0x09, 0x9b, 0xbe, 0x5d, 0x5c,
-	0x68, 0xd6, 0x6a, 0xb3, 0xe0, 0x7a, 0x7a, 0xe5, 0x90, 0x1b, 0x98, 0xdb, 0x67, 0x23, 0x26, 0x75,
-	0xa1, 0x1a, 0x2d, 0x52, 0xc5, 0x4a, 0xcb, 0xb6, 0xbd, 0x45, 0xcd, 0x7d, 0x52, 0xa6, 0xe2, 0xbc,
-	0x90, 0xdd, 0x7d, 0x5a, 0x48, 0x3e, 0xc2, 0xeb, 0xdf, 0x5c, 0x6c, 0x98, 0xb8, 0x7f, 0x7c, 0x0c,
-	0x43, 0x2e, 0x94, 0x15, 0x8b, 0xe0, 0x55, 0x5c, 0x28, 0x33, 0xe6, 0x28, 0x32, 0xf1, 0xe5, 0x7e,
-	0xda, 0xbd, 0x66, 0x1d, 0xc1, 0x7b, 0x78, 0xf6, 0xe4, 0xca, 0x19, 0xb5, 0x5e, 0xd2, 0xce, 0x4b,
-	0x7a, 0xd7, 0x78, 0x19, 0x90, 0x43, 0x47, 0x1a, 0x3a, 0x4a, 0x26, 0xf8, 0x19, 0xdc, 0x48, 0x97,
-	0xf8, 0x66, 0xf4, 0xa2, 0x41, 0x70, 0xa8, 0x64, 0x55, 0x93, 0x09, 0x3e, 0xc0, 0x8b, 0xa1, 0x0a,
-	0x7c, 0x37, 0xe4, 0x1f, 0xd0, 0x18, 0x8c, 0xac, 0x4c, 0x26, 0x78, 0x05, 0xde, 0x2f, 0xc5, 0xab,
-	0x51, 0x51, 0xa3, 0x9d, 0xb7, 0xdf, 0xe1, 0x43, 0xc2, 0xb7, 0x94, 0xef, 0xd6, 0x34, 0x49, 0x25,
-	0x95, 0xe9, 0x86, 0x66, 0xa2, 0x4a, 0xda, 0x2d, 0xfa, 0x2b, 0xdd, 0xbe, 0xea, 0xdf, 0x22, 0x6c,
-	0x06, 0x85, 0xce, 0x9f, 0xc1, 0xff, 0x58, 0x1e, 0x9b, 0xf9, 0x9f, 0xfe, 0x07, 0x00, 0x00, 0xff,
-	0xff, 0x00, 0x7f, 0x06, 0xee, 0x4a, 0x03, 0x00, 0x00,
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x5f, 0x6b, 0xd4, 0x4e,
+	0x14, 0x4d, 0xba, 0xdb, 0x7f, 0x77, 0x97, 0xf2, 0xfb, 0x8d, 0xa5, 0xc6, 0xd5, 0x87, 0x3a, 0x0f,
+	0x5a, 0x11, 0x52, 0x89, 0x3e, 0x94, 0x0a, 0x52, 0x97, 0x56, 0x22, 0xb4, 0x74, 0x9d, 0x16, 0x04,
+	0xdf, 0xd2, 0xec, 0x34, 0x8d, 0x4d, 0x32, 0x61, 0xfe, 0x2c, 0xec, 0x8b, 0xdf, 0x45, 0xbf, 0x9e,
+	0xe0, 0x67, 0x90, 0x99, 0xc9, 0x94, 0x2c, 0x64, 0xdb, 0x27, 0xdf, 0x72, 0x67, 0xce, 0xb9, 0xf7,
+	0x9e, 0x33, 0xf7, 0x06, 0x50, 0x92, 0xca, 0x9c, 0x55, 0x75, 0xa1, 0xb2, 0xbc, 0x0a, 0x6b, 0xce,
+	0x24, 0x43, 0xc3, 0xf6, 0xd9, 0xe8, 0x69, 0xc6, 0x58, 0x56, 0xd0, 0x7d, 0x73, 0x77, 0xa5, 0xae,
+	0xf7, 0x69, 0x59, 0xcb, 0xb9, 0x85, 0xe2, 0x1f, 0xb0, 0xfd, 0xd1, 0x80, 0x27, 0x06, 0x7c, 0x96,
+	0x54, 0xf9, 0x35, 0x15, 0x12, 0x21, 0xe8, 0x57, 0x49, 0x49, 0x03, 0x7f, 0xd7, 0xdf, 0xdb, 0x24,
+	0xe6, 0x1b, 0x05, 0xb0, 0x3e, 0xa3, 0x5c, 0xe4, 0xac, 0x0a, 0x56, 0xcc, 0xb1, 0x0b, 0xd1, 0x2e,
+	0x0c, 0xa6, 0x54, 0xa4, 0x3c, 0xaf, 0x75, 0xaa, 0xa0, 0x67, 0x6e, 0xdb, 0x47, 0x68, 0x07, 0xd6,
+	0x12, 0x25, 0x6f, 0x18, 0x0f, 0xfa, 0xe6, 0xb2, 0x89, 0xf0, 0x4f, 0x1f, 0x06, 0xb6, 0x81, 0x2f,
+	0x8a, 0xf2, 0x39, 0x3a, 0x82, 0x75, 0x66, 0x18, 0x22, 0xf0, 0x77, 0x7b, 0x7b, 0x83, 0xe8, 0x45,
+	0xb8, 0x20, 0xb0, 0x85, 0x0d, 0xcf, 0x2d, 0xf0, 0xa4, 0x92, 0x7c, 0x4e, 0x1c, 0x0d, 0x6d, 0xc3,
+	0xea, 0x77, 0x76, 0xf5, 0xf9, 0xd8, 0xf4, 0xd8, 0x23, 0x36, 0x18, 0x1d, 0xc2, 0xb0, 0x0d, 0x47,
+	0xff, 0x41, 0xef, 0x96, 0xce, 0x1b, 0x79, 0xfa, 0x53, 0xf3, 0x66, 0x49, 0xa1, 0x68, 0xa3, 0xcd,
+	0x06, 0x87, 0x2b, 0x07, 0x3e, 0x3e, 0x82, 0xa1, 0x2d, 0x4b, 0xa8, 0x50, 0x85, 0xd4, 0x5a, 0x84,
+	0x4c, 0xa4, 0x12, 0x0d, 0xbd, 0x89, 0xb4, 0x3f, 0x53, 0x2a, 0x93, 0xbc, 0x10, 0xce, 0x9f, 0x26,
+	0xc4, 0xaf, 0xe0, 0xd1, 0x57, 0xc6, 0x6f, 0x29, 0x8f, 0x2f, 0x2f, 0x27, 0x13, 0xc6, 0xa5, 0x15,
+	0x8b, 0xa0, 0x5f, 0x33, 0x2e, 0x4d, 0x9a, 0x55, 0x62, 0xbe, 0xf1, 0x7b, 0xd8, 0xb4, 0xc5, 0x4e,
+	0x59, 0xa6, 0x7b, 0x2a, 0xe8, 0x8c, 0x16, 0x4d, 0x21, 0x1b, 0xe8, 0x3a, 0x25, 0x15, 0x22, 0xc9,
+	0x5c, 0xaf, 0x2e, 0xc4, 0xc7, 0xb0, 0xd5, 0xbc, 0x26, 0x67, 0x19, 0xa7, 0xc2, 0xf4, 0x54, 0x53,
+	0x9e, 0xd2, 0xca, 0x55, 0x71, 0xe1, 0x3d, 0x59, 0x0e, 0x9c, 0xde, 0x73, 0x25, 0x6b, 0xd5, 0x3d,
+	0x0b, 0x9d, 0x6e, 0xe1, 0x3f, 0xbe, 0x6b, 0x80, 0xa8, 0xea, 0x13, 0xd7, 0xc0, 0xd7, 0xd0, 0x2b,
+	0x58, 0x66, 0xb8, 0x83, 0xe8, 0x71, 0xd7, 0x63, 0x9e, 0xb2, 0x2c, 0xf6, 0x88, 0x46, 0xa1, 0x77,
+	0xb0, 0xc6, 0x8d, 0xc7, 0x26, 0xed, 0x20, 0x1a, 0x75, 0xe1, 0xed, 0x2b, 0xc4, 0x1e, 0x69, 0xb0,
+	0xe8, 0x10, 0x36, 0xea, 0x46, 0xaf, 0x19, 0xbd, 0x41, 0xf4, 0xac, 0x8b, 0xe7, 0x3c, 0x89, 0x3d,
+	0x72, 0x87, 0xd7, 0x15, 0x99, 0x51, 0x19, 0xf4, 0x97, 0x57, 0xb4, 0x3e, 0xe8, 0x8a, 0x16, 0x3b,
+	0x5e, 0x87, 0xd5, 0x6b, 0xad, 0x2e, 0xfa, 0xbd, 0x02, 0xc3, 0xf6, 0xfe, 0xa0, 0x18, 0x36, 0xee,
+	0x76, 0x68, 0x27, 0xb4, 0x9b, 0x17, 0xba, 0xcd, 0x0b, 0x4f, 0xf4, 0xe6, 0x8d, 0x70, 0x67, 0x77,
+	0x0b, 0xfb, 0x87, 0x3d, 0xf4, 0x01, 0x7a, 0x44, 0x55, 0xe8, 0xc9, 0xd2, 0xf9, 0x1f, 0xdd, 0xe3,
+	0x0e, 0xf6, 0x50, 0x0c, 0x9b, 0x44, 0x55, 0x17, 0x92, 0xd3, 0xa4, 0xbc, 0x2f, 0x4b, 0xa7, 0x57,
+	0xee, 0xf9, 0xb0, 0xf7, 0xc6, 0x47, 0x67, 0xb0, 0xb5, 0x38, 0xbd, 0xe8, 0xf9, 0x22, 0xa7, 0x63,
+	0xb6, 0x47, 0x4b, 0xc4, 0x63, 0x0f, 0x1d, 0x40, 0xff, 0x42, 0xb2, 0x7a, 0xa9, 0x3d, 0x4b, 0x99,
+	0xd1, 0x2f, 0x1f, 0x10, 0xa1, 0x25, 0x93, 0xf4, 0x1f, 0x79, 0x3e, 0x7e, 0xd0, 0xf3, 0x07, 0xdd,
+	0x1a, 0x9f, 0xc2, 0xcb, 0x94, 0x95, 0x21, 0x9b, 0xdd, 0x84, 0xe9, 0x54, 0x84, 0x62, 0x7a, 0x1b,
+	0x66, 0xbc, 0x4e, 0x1b, 0x42, 0x9b, 0x3d, 0xfe, 0xbf, 0xdd, 0xc6, 0x44, 0x37, 0x3c, 0xf1, 0xbf,
+	0x2d, 0xfc, 0xbc, 0xaf, 0xd6, 0x8c, 0x8e, 0xb7, 0x7f, 0x07, 0x00, 0x8c, 0x4e, 0x86, 0x3b, 0xe7,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -274,6 +543,7 @@ const _ = grpc.SupportPackageIsVersion4
 type ActionPluginClient interface {
 	Manifest(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ActionPluginManifest, error)
 	Run(ctx context.Context, in *ActionQuery, opts ...grpc.CallOption) (*ActionResult, error)
+	RunStream(ctx context.Context, in *ActionQuery, opts ...grpc.CallOption) (ActionPlugin_RunStreamClient, error)
 	WorkerHTTPPort(ctx context.Context, in *WorkerHTTPPortQuery, opts ...grpc.CallOption) (*empty.Empty, error)
 	Stop(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 }
@@ -304,6 +574,38 @@ func (c *actionPluginClient) Run(ctx context.Context, in *ActionQuery, opts ...g
 	return out, nil
 }
 
+func (c *actionPluginClient) RunStream(ctx context.Context, in *ActionQuery, opts ...grpc.CallOption) (ActionPlugin_RunStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ActionPlugin_serviceDesc.Streams[0], "/actionplugin.ActionPlugin/RunStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &actionPluginRunStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ActionPlugin_RunStreamClient interface {
+	Recv() (*ActionRunFrame, error)
+	grpc.ClientStream
+}
+
+type actionPluginRunStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *actionPluginRunStreamClient) Recv() (*ActionRunFrame, error) {
+	m := new(ActionRunFrame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *actionPluginClient) WorkerHTTPPort(ctx context.Context, in *WorkerHTTPPortQuery, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/actionplugin.ActionPlugin/WorkerHTTPPort", in, out, opts...)
@@ -326,10 +628,31 @@ func (c *actionPluginClient) Stop(ctx context.Context, in *empty.Empty, opts ...
 type ActionPluginServer interface {
 	Manifest(context.Context, *empty.Empty) (*ActionPluginManifest, error)
 	Run(context.Context, *ActionQuery) (*ActionResult, error)
+	RunStream(*ActionQuery, ActionPlugin_RunStreamServer) error
 	WorkerHTTPPort(context.Context, *WorkerHTTPPortQuery) (*empty.Empty, error)
 	Stop(context.Context, *empty.Empty) (*empty.Empty, error)
 }
 
+// UnimplementedActionPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedActionPluginServer struct {
+}
+
+func (*UnimplementedActionPluginServer) Manifest(ctx context.Context, req *empty.Empty) (*ActionPluginManifest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Manifest not implemented")
+}
+func (*UnimplementedActionPluginServer) Run(ctx context.Context, req *ActionQuery) (*ActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (*UnimplementedActionPluginServer) RunStream(req *ActionQuery, srv ActionPlugin_RunStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunStream not implemented")
+}
+func (*UnimplementedActionPluginServer) WorkerHTTPPort(ctx context.Context, req *WorkerHTTPPortQuery) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WorkerHTTPPort not implemented")
+}
+func (*UnimplementedActionPluginServer) Stop(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+
 func RegisterActionPluginServer(s *grpc.Server, srv ActionPluginServer) {
 	s.RegisterService(&_ActionPlugin_serviceDesc, srv)
 }
@@ -370,6 +693,27 @@ func _ActionPlugin_Run_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActionPlugin_RunStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ActionQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActionPluginServer).RunStream(m, &actionPluginRunStreamServer{stream})
+}
+
+type ActionPlugin_RunStreamServer interface {
+	Send(*ActionRunFrame) error
+	grpc.ServerStream
+}
+
+type actionPluginRunStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *actionPluginRunStreamServer) Send(m *ActionRunFrame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _ActionPlugin_WorkerHTTPPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WorkerHTTPPortQuery)
 	if err := dec(in); err != nil {
@@ -427,6 +771,148 @@ var _ActionPlugin_serviceDesc = grpc.ServiceDesc{
 			Handler:    _ActionPlugin_Stop_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunStream",
+			Handler:       _ActionPlugin_RunStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "actionplugin.proto",
+}
+
+// RemoteActionPluginClient is the client API for RemoteActionPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RemoteActionPluginClient interface {
+	Manifest(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ActionPluginManifest, error)
+	Run(ctx context.Context, in *ActionQuery, opts ...grpc.CallOption) (RemoteActionPlugin_RunClient, error)
+}
+
+type remoteActionPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRemoteActionPluginClient(cc *grpc.ClientConn) RemoteActionPluginClient {
+	return &remoteActionPluginClient{cc}
+}
+
+func (c *remoteActionPluginClient) Manifest(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ActionPluginManifest, error) {
+	out := new(ActionPluginManifest)
+	err := c.cc.Invoke(ctx, "/actionplugin.RemoteActionPlugin/Manifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteActionPluginClient) Run(ctx context.Context, in *ActionQuery, opts ...grpc.CallOption) (RemoteActionPlugin_RunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RemoteActionPlugin_serviceDesc.Streams[0], "/actionplugin.RemoteActionPlugin/Run", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteActionPluginRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RemoteActionPlugin_RunClient interface {
+	Recv() (*ActionRunFrame, error)
+	grpc.ClientStream
+}
+
+type remoteActionPluginRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteActionPluginRunClient) Recv() (*ActionRunFrame, error) {
+	m := new(ActionRunFrame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RemoteActionPluginServer is the server API for RemoteActionPlugin service.
+type RemoteActionPluginServer interface {
+	Manifest(context.Context, *empty.Empty) (*ActionPluginManifest, error)
+	Run(*ActionQuery, RemoteActionPlugin_RunServer) error
+}
+
+// UnimplementedRemoteActionPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedRemoteActionPluginServer struct {
+}
+
+func (*UnimplementedRemoteActionPluginServer) Manifest(ctx context.Context, req *empty.Empty) (*ActionPluginManifest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Manifest not implemented")
+}
+func (*UnimplementedRemoteActionPluginServer) Run(req *ActionQuery, srv RemoteActionPlugin_RunServer) error {
+	return status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+
+func RegisterRemoteActionPluginServer(s *grpc.Server, srv RemoteActionPluginServer) {
+	s.RegisterService(&_RemoteActionPlugin_serviceDesc, srv)
+}
+
+func _RemoteActionPlugin_Manifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteActionPluginServer).Manifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/actionplugin.RemoteActionPlugin/Manifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteActionPluginServer).Manifest(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteActionPlugin_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ActionQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteActionPluginServer).Run(m, &remoteActionPluginRunServer{stream})
+}
+
+type RemoteActionPlugin_RunServer interface {
+	Send(*ActionRunFrame) error
+	grpc.ServerStream
+}
+
+type remoteActionPluginRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteActionPluginRunServer) Send(m *ActionRunFrame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _RemoteActionPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "actionplugin.RemoteActionPlugin",
+	HandlerType: (*RemoteActionPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Manifest",
+			Handler:    _RemoteActionPlugin_Manifest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Run",
+			Handler:       _RemoteActionPlugin_Run_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "actionplugin.proto",
 }
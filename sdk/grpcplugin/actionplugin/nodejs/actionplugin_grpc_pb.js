@@ -38,6 +38,17 @@ function deserialize_actionplugin_ActionResult(buffer_arg) {
   return actionplugin_pb.ActionResult.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_actionplugin_ActionRunFrame(arg) {
+  if (!(arg instanceof actionplugin_pb.ActionRunFrame)) {
+    throw new Error('Expected argument of type actionplugin.ActionRunFrame');
+  }
+  return new Buffer(arg.serializeBinary());
+}
+
+function deserialize_actionplugin_ActionRunFrame(buffer_arg) {
+  return actionplugin_pb.ActionRunFrame.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_actionplugin_WorkerHTTPPortQuery(arg) {
   if (!(arg instanceof actionplugin_pb.WorkerHTTPPortQuery)) {
     throw new Error('Expected argument of type actionplugin.WorkerHTTPPortQuery');
@@ -84,6 +95,19 @@ var ActionPluginService = exports.ActionPluginService = {
     responseSerialize: serialize_actionplugin_ActionResult,
     responseDeserialize: deserialize_actionplugin_ActionResult,
   },
+  // RunStream streams logs, progress and outputs while running the action, the worker falls back on Run if it is not implemented.
+  // Cancelling the RunStream call cancels the action on the plugin side.
+  runStream: {
+    path: '/actionplugin.ActionPlugin/RunStream',
+    requestStream: false,
+    responseStream: true,
+    requestType: actionplugin_pb.ActionQuery,
+    responseType: actionplugin_pb.ActionRunFrame,
+    requestSerialize: serialize_actionplugin_ActionQuery,
+    requestDeserialize: deserialize_actionplugin_ActionQuery,
+    responseSerialize: serialize_actionplugin_ActionRunFrame,
+    responseDeserialize: deserialize_actionplugin_ActionRunFrame,
+  },
   workerHTTPPort: {
     path: '/actionplugin.ActionPlugin/WorkerHTTPPort',
     requestStream: false,
@@ -109,3 +133,32 @@ var ActionPluginService = exports.ActionPluginService = {
 };
 
 exports.ActionPluginClient = grpc.makeGenericClientConstructor(ActionPluginService);
+
+// RemoteActionPlugin is served by long-lived action plugins registered in the API and called by workers over the network.
+// Cancelling the Run call cancels the action on the plugin side.
+var RemoteActionPluginService = exports.RemoteActionPluginService = {
+  manifest: {
+    path: '/actionplugin.RemoteActionPlugin/Manifest',
+    requestStream: false,
+    responseStream: false,
+    requestType: google_protobuf_empty_pb.Empty,
+    responseType: actionplugin_pb.ActionPluginManifest,
+    requestSerialize: serialize_google_protobuf_Empty,
+    requestDeserialize: deserialize_google_protobuf_Empty,
+    responseSerialize: serialize_actionplugin_ActionPluginManifest,
+    responseDeserialize: deserialize_actionplugin_ActionPluginManifest,
+  },
+  run: {
+    path: '/actionplugin.RemoteActionPlugin/Run',
+    requestStream: false,
+    responseStream: true,
+    requestType: actionplugin_pb.ActionQuery,
+    responseType: actionplugin_pb.ActionRunFrame,
+    requestSerialize: serialize_actionplugin_ActionQuery,
+    requestDeserialize: deserialize_actionplugin_ActionQuery,
+    responseSerialize: serialize_actionplugin_ActionRunFrame,
+    responseDeserialize: deserialize_actionplugin_ActionRunFrame,
+  },
+};
+
+exports.RemoteActionPluginClient = grpc.makeGenericClientConstructor(RemoteActionPluginService);
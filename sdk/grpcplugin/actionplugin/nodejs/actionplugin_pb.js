@@ -12,9 +12,14 @@ var goog = jspb;
 var global = Function('return this')();
 
 var google_protobuf_empty_pb = require('google-protobuf/google/protobuf/empty_pb.js');
+goog.exportSymbol('proto.actionplugin.ActionLog', null, global);
+goog.exportSymbol('proto.actionplugin.ActionOutput', null, global);
 goog.exportSymbol('proto.actionplugin.ActionPluginManifest', null, global);
+goog.exportSymbol('proto.actionplugin.ActionProgress', null, global);
 goog.exportSymbol('proto.actionplugin.ActionQuery', null, global);
 goog.exportSymbol('proto.actionplugin.ActionResult', null, global);
+goog.exportSymbol('proto.actionplugin.ActionRunFrame', null, global);
+goog.exportSymbol('proto.actionplugin.ActionRunFrame.FrameCase', null, global);
 goog.exportSymbol('proto.actionplugin.WorkerHTTPPortQuery', null, global);
 
 /**
@@ -721,4 +726,834 @@ proto.actionplugin.WorkerHTTPPortQuery.prototype.setPort = function(value) {
 };
 
 
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.actionplugin.ActionLog = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.actionplugin.ActionLog, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.actionplugin.ActionLog.displayName = 'proto.actionplugin.ActionLog';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.actionplugin.ActionLog.prototype.toObject = function(opt_includeInstance) {
+  return proto.actionplugin.ActionLog.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.actionplugin.ActionLog} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionLog.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    level: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    message: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.actionplugin.ActionLog}
+ */
+proto.actionplugin.ActionLog.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.actionplugin.ActionLog;
+  return proto.actionplugin.ActionLog.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.actionplugin.ActionLog} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.actionplugin.ActionLog}
+ */
+proto.actionplugin.ActionLog.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setLevel(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMessage(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.actionplugin.ActionLog.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.actionplugin.ActionLog.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.actionplugin.ActionLog} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionLog.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getLevel();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getMessage();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+
+/**
+ * optional string level = 1;
+ * @return {string}
+ */
+proto.actionplugin.ActionLog.prototype.getLevel = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.actionplugin.ActionLog.prototype.setLevel = function(value) {
+  jspb.Message.setField(this, 1, value);
+};
+
+
+/**
+ * optional string message = 2;
+ * @return {string}
+ */
+proto.actionplugin.ActionLog.prototype.getMessage = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.actionplugin.ActionLog.prototype.setMessage = function(value) {
+  jspb.Message.setField(this, 2, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.actionplugin.ActionProgress = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.actionplugin.ActionProgress, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.actionplugin.ActionProgress.displayName = 'proto.actionplugin.ActionProgress';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.actionplugin.ActionProgress.prototype.toObject = function(opt_includeInstance) {
+  return proto.actionplugin.ActionProgress.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.actionplugin.ActionProgress} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionProgress.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    percent: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    message: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.actionplugin.ActionProgress}
+ */
+proto.actionplugin.ActionProgress.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.actionplugin.ActionProgress;
+  return proto.actionplugin.ActionProgress.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.actionplugin.ActionProgress} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.actionplugin.ActionProgress}
+ */
+proto.actionplugin.ActionProgress.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setPercent(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMessage(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.actionplugin.ActionProgress.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.actionplugin.ActionProgress.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.actionplugin.ActionProgress} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionProgress.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPercent();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getMessage();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+
+/**
+ * optional int32 percent = 1;
+ * @return {number}
+ */
+proto.actionplugin.ActionProgress.prototype.getPercent = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/** @param {number} value */
+proto.actionplugin.ActionProgress.prototype.setPercent = function(value) {
+  jspb.Message.setField(this, 1, value);
+};
+
+
+/**
+ * optional string message = 2;
+ * @return {string}
+ */
+proto.actionplugin.ActionProgress.prototype.getMessage = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.actionplugin.ActionProgress.prototype.setMessage = function(value) {
+  jspb.Message.setField(this, 2, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.actionplugin.ActionOutput = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.actionplugin.ActionOutput, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.actionplugin.ActionOutput.displayName = 'proto.actionplugin.ActionOutput';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.actionplugin.ActionOutput.prototype.toObject = function(opt_includeInstance) {
+  return proto.actionplugin.ActionOutput.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.actionplugin.ActionOutput} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionOutput.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    value: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.actionplugin.ActionOutput}
+ */
+proto.actionplugin.ActionOutput.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.actionplugin.ActionOutput;
+  return proto.actionplugin.ActionOutput.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.actionplugin.ActionOutput} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.actionplugin.ActionOutput}
+ */
+proto.actionplugin.ActionOutput.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setValue(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.actionplugin.ActionOutput.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.actionplugin.ActionOutput.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.actionplugin.ActionOutput} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionOutput.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getValue();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.actionplugin.ActionOutput.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.actionplugin.ActionOutput.prototype.setName = function(value) {
+  jspb.Message.setField(this, 1, value);
+};
+
+
+/**
+ * optional string value = 2;
+ * @return {string}
+ */
+proto.actionplugin.ActionOutput.prototype.getValue = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.actionplugin.ActionOutput.prototype.setValue = function(value) {
+  jspb.Message.setField(this, 2, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.actionplugin.ActionRunFrame = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.actionplugin.ActionRunFrame.oneofGroups_);
+};
+goog.inherits(proto.actionplugin.ActionRunFrame, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.actionplugin.ActionRunFrame.displayName = 'proto.actionplugin.ActionRunFrame';
+}
+/**
+ * Oneof group definitions for this message. Each group defines the field
+ * numbers belonging to that group. When of these fields' value is set, all
+ * other fields in the group are cleared. During deserialization, if multiple
+ * fields are encountered for a group, only the last value seen will be kept.
+ * @private {!Array<!Array<number>>}
+ * @const
+ */
+proto.actionplugin.ActionRunFrame.oneofGroups_ = [[1,2,3,4]];
+
+/**
+ * @enum {number}
+ */
+proto.actionplugin.ActionRunFrame.FrameCase = {
+  FRAME_NOT_SET: 0,
+  LOG: 1,
+  RESULT: 2,
+  PROGRESS: 3,
+  OUTPUT: 4
+};
+
+/**
+ * @return {proto.actionplugin.ActionRunFrame.FrameCase}
+ */
+proto.actionplugin.ActionRunFrame.prototype.getFrameCase = function() {
+  return /** @type {proto.actionplugin.ActionRunFrame.FrameCase} */(jspb.Message.computeOneofCase(this, proto.actionplugin.ActionRunFrame.oneofGroups_[0]));
+};
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.actionplugin.ActionRunFrame.prototype.toObject = function(opt_includeInstance) {
+  return proto.actionplugin.ActionRunFrame.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.actionplugin.ActionRunFrame} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionRunFrame.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    log: (f = msg.getLog()) && proto.actionplugin.ActionLog.toObject(includeInstance, f),
+    result: (f = msg.getResult()) && proto.actionplugin.ActionResult.toObject(includeInstance, f),
+    progress: (f = msg.getProgress()) && proto.actionplugin.ActionProgress.toObject(includeInstance, f),
+    output: (f = msg.getOutput()) && proto.actionplugin.ActionOutput.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.actionplugin.ActionRunFrame}
+ */
+proto.actionplugin.ActionRunFrame.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.actionplugin.ActionRunFrame;
+  return proto.actionplugin.ActionRunFrame.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.actionplugin.ActionRunFrame} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.actionplugin.ActionRunFrame}
+ */
+proto.actionplugin.ActionRunFrame.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.actionplugin.ActionLog;
+      reader.readMessage(value,proto.actionplugin.ActionLog.deserializeBinaryFromReader);
+      msg.setLog(value);
+      break;
+    case 2:
+      var value = new proto.actionplugin.ActionResult;
+      reader.readMessage(value,proto.actionplugin.ActionResult.deserializeBinaryFromReader);
+      msg.setResult(value);
+      break;
+    case 3:
+      var value = new proto.actionplugin.ActionProgress;
+      reader.readMessage(value,proto.actionplugin.ActionProgress.deserializeBinaryFromReader);
+      msg.setProgress(value);
+      break;
+    case 4:
+      var value = new proto.actionplugin.ActionOutput;
+      reader.readMessage(value,proto.actionplugin.ActionOutput.deserializeBinaryFromReader);
+      msg.setOutput(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.actionplugin.ActionRunFrame.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.actionplugin.ActionRunFrame.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.actionplugin.ActionRunFrame} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.actionplugin.ActionRunFrame.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getLog();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.actionplugin.ActionLog.serializeBinaryToWriter
+    );
+  }
+  f = message.getResult();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.actionplugin.ActionResult.serializeBinaryToWriter
+    );
+  }
+  f = message.getProgress();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      proto.actionplugin.ActionProgress.serializeBinaryToWriter
+    );
+  }
+  f = message.getOutput();
+  if (f != null) {
+    writer.writeMessage(
+      4,
+      f,
+      proto.actionplugin.ActionOutput.serializeBinaryToWriter
+    );
+  }
+};
+
+
+
+/**
+ * optional ActionLog log = 1;
+ * @return {?proto.actionplugin.ActionLog}
+ */
+proto.actionplugin.ActionRunFrame.prototype.getLog = function() {
+  return /** @type{?proto.actionplugin.ActionLog} */ (
+    jspb.Message.getWrapperField(this, proto.actionplugin.ActionLog, 1));
+};
+
+
+/** @param {?proto.actionplugin.ActionLog|undefined} value */
+proto.actionplugin.ActionRunFrame.prototype.setLog = function(value) {
+  jspb.Message.setOneofWrapperField(this, 1, proto.actionplugin.ActionRunFrame.oneofGroups_[0], value);
+};
+
+
+proto.actionplugin.ActionRunFrame.prototype.clearLog = function() {
+  this.setLog(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.actionplugin.ActionRunFrame.prototype.hasLog = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional ActionResult result = 2;
+ * @return {?proto.actionplugin.ActionResult}
+ */
+proto.actionplugin.ActionRunFrame.prototype.getResult = function() {
+  return /** @type{?proto.actionplugin.ActionResult} */ (
+    jspb.Message.getWrapperField(this, proto.actionplugin.ActionResult, 2));
+};
+
+
+/** @param {?proto.actionplugin.ActionResult|undefined} value */
+proto.actionplugin.ActionRunFrame.prototype.setResult = function(value) {
+  jspb.Message.setOneofWrapperField(this, 2, proto.actionplugin.ActionRunFrame.oneofGroups_[0], value);
+};
+
+
+proto.actionplugin.ActionRunFrame.prototype.clearResult = function() {
+  this.setResult(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.actionplugin.ActionRunFrame.prototype.hasResult = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional ActionProgress progress = 3;
+ * @return {?proto.actionplugin.ActionProgress}
+ */
+proto.actionplugin.ActionRunFrame.prototype.getProgress = function() {
+  return /** @type{?proto.actionplugin.ActionProgress} */ (
+    jspb.Message.getWrapperField(this, proto.actionplugin.ActionProgress, 3));
+};
+
+
+/** @param {?proto.actionplugin.ActionProgress|undefined} value */
+proto.actionplugin.ActionRunFrame.prototype.setProgress = function(value) {
+  jspb.Message.setOneofWrapperField(this, 3, proto.actionplugin.ActionRunFrame.oneofGroups_[0], value);
+};
+
+
+proto.actionplugin.ActionRunFrame.prototype.clearProgress = function() {
+  this.setProgress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.actionplugin.ActionRunFrame.prototype.hasProgress = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional ActionOutput output = 4;
+ * @return {?proto.actionplugin.ActionOutput}
+ */
+proto.actionplugin.ActionRunFrame.prototype.getOutput = function() {
+  return /** @type{?proto.actionplugin.ActionOutput} */ (
+    jspb.Message.getWrapperField(this, proto.actionplugin.ActionOutput, 4));
+};
+
+
+/** @param {?proto.actionplugin.ActionOutput|undefined} value */
+proto.actionplugin.ActionRunFrame.prototype.setOutput = function(value) {
+  jspb.Message.setOneofWrapperField(this, 4, proto.actionplugin.ActionRunFrame.oneofGroups_[0], value);
+};
+
+
+proto.actionplugin.ActionRunFrame.prototype.clearOutput = function() {
+  this.setOutput(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.actionplugin.ActionRunFrame.prototype.hasOutput = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
 goog.object.extend(exports, proto.actionplugin);
@@ -7,20 +7,22 @@ const os = require('os');
 const getPort = require('get-port');
 const YAML = require('yaml')
 const fs = require('fs')
-let httpPort, pluginManifest, run;
+let httpPort, pluginManifest, run, runStream;
 let server;
 
 class Client {
-  constructor(yamlFilename, runFunc) {
+  // runStreamFunc is optional, when given the worker calls it instead of runFunc to stream logs, progress and outputs
+  constructor(yamlFilename, runFunc, runStreamFunc) {
     const file = fs.readFileSync(yamlFilename, 'utf8');
     pluginManifest = YAML.parse(file);
     run = runFunc;
+    runStream = runStreamFunc || runStreamUnimplemented;
   }
 
   start(ip) {
     let localIP = ip || '127.0.0.1';
     server = new grpc.Server();
-    server.addService(services.ActionPluginService, {run, workerHTTPPort, manifest, stop});
+    server.addService(services.ActionPluginService, {run, runStream, workerHTTPPort, manifest, stop});
     return getPort()
       .then((port) => {
         server.bind(localIP + ':' + port, grpc.ServerCredentials.createInsecure());
@@ -29,23 +31,68 @@ class Client {
       });
   }
 
+  // success and fail end the action, callback is the one of run or the call given to runStream
   static success(msg, callback) {
-    let reply = new messages.ActionResult();
-    if (msg) {
-      reply.setDetails(msg);
-    }
-    reply.setStatus('Success');
-    callback(null, reply);
+    sendResult('Success', msg, callback);
   }
 
   static fail(msg, callback) {
-    let reply = new messages.ActionResult();
+    sendResult('Fail', msg, callback);
+  }
+
+  // level is one of INFO, WARN or ERROR, default to INFO
+  static log(call, msg, level) {
+    let log = new messages.ActionLog();
+    log.setLevel(level || 'INFO');
+    log.setMessage(msg);
+    let frame = new messages.ActionRunFrame();
+    frame.setLog(log);
+    call.write(frame);
+  }
+
+  // percent is between 0 and 100
+  static progress(call, percent, msg) {
+    let progress = new messages.ActionProgress();
+    progress.setPercent(percent);
     if (msg) {
-      reply.setDetails(msg);
+      progress.setMessage(msg);
     }
-    reply.setStatus('Fail');
+    let frame = new messages.ActionRunFrame();
+    frame.setProgress(progress);
+    call.write(frame);
+  }
+
+  // output exports a value usable by the next steps as {{.steps.<step>.outputs.<name>}}
+  static output(call, name, value) {
+    let output = new messages.ActionOutput();
+    output.setName(name);
+    output.setValue(String(value));
+    let frame = new messages.ActionRunFrame();
+    frame.setOutput(output);
+    call.write(frame);
+  }
+}
+
+function sendResult(status, msg, callback) {
+  let reply = new messages.ActionResult();
+  if (msg) {
+    reply.setDetails(msg);
+  }
+  reply.setStatus(status);
+  if (typeof callback === 'function') {
     callback(null, reply);
+    return;
   }
+  // The result is the last frame of the stream
+  let frame = new messages.ActionRunFrame();
+  frame.setResult(reply);
+  callback.write(frame);
+  callback.end();
+}
+
+// The worker falls back on run for plugins without runStream
+function runStreamUnimplemented(call) {
+  call.emit('error', {code: grpc.status.UNIMPLEMENTED, details: 'method RunStream not implemented'});
 }
 
 function manifest(call, callback) {
@@ -0,0 +1,93 @@
+package actionplugin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Log levels of the frames sent by plugins
+const (
+	LogLevelInfo  = "INFO"
+	LogLevelWarn  = "WARN"
+	LogLevelError = "ERROR"
+)
+
+// StartRemote serves a remote action plugin on the given address.
+// Clients have to present a certificate signed by one of the tlsConfig.ClientCAs.
+func StartRemote(ctx context.Context, address string, tlsConfig *tls.Config, srv RemoteActionPluginServer) error {
+	if tlsConfig == nil || len(tlsConfig.Certificates) == 0 || tlsConfig.ClientCAs == nil {
+		return fmt.Errorf("a server certificate and client CAs are mandatory to start a remote plugin")
+	}
+	cfg := tlsConfig.Clone()
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %v", address, err)
+	}
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)))
+	RegisterRemoteActionPluginServer(s, srv)
+
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+
+	return s.Serve(l)
+}
+
+// RemoteClient gives us a client of a remote action plugin, the connection is secured with mutual TLS
+func RemoteClient(ctx context.Context, address string, tlsConfig *tls.Config) (RemoteActionPluginClient, *grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewRemoteActionPluginClient(conn), conn, nil
+}
+
+// FrameSender is the server side of a stream of ActionRunFrame, as given to RunStream of local plugins and Run of remote plugins
+type FrameSender interface {
+	Send(*ActionRunFrame) error
+}
+
+// SendLog streams a log line to the worker running the action
+func SendLog(stream FrameSender, level, format string, args ...interface{}) error {
+	return stream.Send(&ActionRunFrame{
+		Frame: &ActionRunFrame_Log{
+			Log: &ActionLog{Level: level, Message: fmt.Sprintf(format, args...)},
+		},
+	})
+}
+
+// SendResult sends the result of the action, it has to be the last frame of the stream
+func SendResult(stream FrameSender, status, details string) error {
+	return stream.Send(&ActionRunFrame{
+		Frame: &ActionRunFrame_Result{
+			Result: &ActionResult{Status: status, Details: details},
+		},
+	})
+}
+
+// SendProgress reports the progression of the action, percent is between 0 and 100
+func SendProgress(stream FrameSender, percent int32, format string, args ...interface{}) error {
+	return stream.Send(&ActionRunFrame{
+		Frame: &ActionRunFrame_Progress{
+			Progress: &ActionProgress{Percent: percent, Message: fmt.Sprintf(format, args...)},
+		},
+	})
+}
+
+// SendOutput exports a value as an output of the step running the action
+func SendOutput(stream FrameSender, name, value string) error {
+	return stream.Send(&ActionRunFrame{
+		Frame: &ActionRunFrame_Output{
+			Output: &ActionOutput{Name: name, Value: value},
+		},
+	})
+}
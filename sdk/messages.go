@@ -67,6 +67,7 @@ var (
 	MsgSpawnInfoJobTakenWorkerVersion      = &Message{"MsgSpawnInfoJobTakenWorkerVersion", trad{FR: "Worker %s version:%s os:%s arch:%s", EN: "Worker %s version:%s os:%s arch:%s"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerForJob               = &Message{"MsgSpawnInfoWorkerForJob", trad{FR: "Ce worker %s a été créé pour lancer ce job", EN: "This worker %s was created to take this action"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerForJobError          = &Message{"MsgSpawnInfoWorkerForJobError", trad{FR: "⚠ Ce worker %s a été créé pour lancer ce job, mais ne possède pas tous les pré-requis. Vérifiez que les prérequis suivants:%s", EN: "⚠ This worker %s was created to take this action, but does not have all prerequisites. Please verify the following prerequisites:%s"}, nil, RunInfoTypeError}
+	MsgSpawnInfoSecretDetected             = &Message{"MsgSpawnInfoSecretDetected", trad{FR: "⚠ %d secret(s) correspondant à la règle %s détecté(s) dans %s de l'étape %d", EN: "⚠ %d secret(s) matching rule %s detected in %s of step %d"}, nil, RunInfoTypeWarning}
 	MsgSpawnInfoJobError                   = &Message{"MsgSpawnInfoJobError", trad{FR: "⚠ Impossible de lancer ce job : %s", EN: "⚠ Unable to run this job: %s"}, nil, RunInfoTypInfo}
 	MsgWorkflowStarting                    = &Message{"MsgWorkflowStarting", trad{FR: "Le workflow %s#%s a été démarré", EN: "Workflow %s#%s has been started"}, nil, RunInfoTypInfo}
 	MsgWorkflowError                       = &Message{"MsgWorkflowError", trad{FR: "⚠ Une erreur est survenue: %v", EN: "⚠ An error has occurred: %v"}, nil, RunInfoTypeError}
@@ -74,6 +75,13 @@ var (
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil, RunInfoTypInfo}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil, RunInfoTypInfo}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowNodeFrozen                  = &Message{"MsgWorkflowNodeFrozen", trad{FR: "⚠ Le pipeline %s est bloqué par la période de gel %s jusqu'au %s", EN: "⚠ The pipeline %s is blocked by freeze window %s until %s"}, nil, RunInfoTypeWarning}
+	MsgWorkflowNodeFreezeRelease           = &Message{"MsgWorkflowNodeFreezeRelease", trad{FR: "La période de gel %s est terminée, lancement du pipeline %s", EN: "Freeze window %s is over, triggering pipeline %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowNodeFreezeOverride          = &Message{"MsgWorkflowNodeFreezeOverride", trad{FR: "⚠ La période de gel %s a été outrepassée par %s pour le pipeline %s: %s", EN: "⚠ Freeze window %s has been overridden by %s for pipeline %s: %s"}, nil, RunInfoTypeWarning}
+	MsgWorkflowNodeFreezeDeleted           = &Message{"MsgWorkflowNodeFreezeDeleted", trad{FR: "⚠ La période de gel %s a été supprimée par %s, lancement du pipeline %s", EN: "⚠ Freeze window %s has been deleted by %s, triggering pipeline %s"}, nil, RunInfoTypeWarning}
+	MsgWorkflowConcurrencyQueued           = &Message{"MsgWorkflowConcurrencyQueued", trad{FR: "Le workflow est en attente de la fin de l'exécution %d du groupe de concurrence %s", EN: "The workflow is waiting for the end of run %d in concurrency group %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowConcurrencyRelease          = &Message{"MsgWorkflowConcurrencyRelease", trad{FR: "L'exécution %d du groupe de concurrence %s est terminée, lancement du pipeline %s", EN: "Run %d in concurrency group %s is over, triggering pipeline %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowConcurrencyCanceled         = &Message{"MsgWorkflowConcurrencyCanceled", trad{FR: "⚠ Le workflow a été annulé par l'exécution %d du groupe de concurrence %s", EN: "⚠ The workflow has been canceled by run %d in concurrency group %s"}, nil, RunInfoTypeWarning}
 	MsgWorkflowImportedUpdated             = &Message{"MsgWorkflowImportedUpdated", trad{FR: "Le workflow %s a été mis à jour", EN: "Workflow %s has been updated"}, nil, RunInfoTypInfo}
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil, RunInfoTypeWarning}
@@ -88,6 +96,8 @@ var (
 	MsgWorkflowErrorUnknownKey             = &Message{"MsgWorkflowErrorUnknownKey", trad{FR: "La clé '%s' est incorrecte ou n'existe pas", EN: "The key '%s' is incorrect or doesn't exist"}, nil, RunInfoTypeError}
 	MsgWorkflowErrorBadVCSStrategy         = &Message{"MsgWorkflowErrorBadVCSStrategy", trad{FR: "Vos informations vcs_* sont incorrectes", EN: "Your vcs_* fields are incorrects"}, nil, RunInfoTypeError}
 	MsgWorkflowDeprecatedVersion           = &Message{"MsgWorkflowDeprecatedVersion", trad{FR: "La configuration yaml de votre workflow est dans un format déprécié. Exportez le avec la CLI `cdsctl workflow export %s %s`", EN: "The yaml workflow configuration format is deprecated. Export your workflow with CLI `cdsctl workflow export %s %s`"}, nil, RunInfoTypeWarning}
+	MsgWorkflowAsCodeRevision              = &Message{"MsgWorkflowAsCodeRevision", trad{FR: "Le workflow a été lu depuis %s à la révision %s", EN: "Workflow has been read from %s at revision %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowAsCodeBranchRestricted      = &Message{"MsgWorkflowAsCodeBranchRestricted", trad{FR: "Les modifications de %s ont été ignorées car la branche %s n'est pas autorisée à les changer", EN: "Changes on %s have been ignored because branch %s is not allowed to change them"}, nil, RunInfoTypeWarning}
 )
 
 // Messages contains all sdk Messages
@@ -139,6 +149,7 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoJobTakenWorkerVersion.ID:      MsgSpawnInfoJobTakenWorkerVersion,
 	MsgSpawnInfoWorkerForJob.ID:               MsgSpawnInfoWorkerForJob,
 	MsgSpawnInfoWorkerForJobError.ID:          MsgSpawnInfoWorkerForJobError,
+	MsgSpawnInfoSecretDetected.ID:             MsgSpawnInfoSecretDetected,
 	MsgSpawnInfoJobError.ID:                   MsgSpawnInfoJobError,
 	MsgWorkflowStarting.ID:                    MsgWorkflowStarting,
 	MsgWorkflowError.ID:                       MsgWorkflowError,
@@ -146,6 +157,13 @@ var Messages = map[string]*Message{
 	MsgWorkflowNodeStop.ID:                    MsgWorkflowNodeStop,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
+	MsgWorkflowNodeFrozen.ID:                  MsgWorkflowNodeFrozen,
+	MsgWorkflowNodeFreezeRelease.ID:           MsgWorkflowNodeFreezeRelease,
+	MsgWorkflowNodeFreezeOverride.ID:          MsgWorkflowNodeFreezeOverride,
+	MsgWorkflowNodeFreezeDeleted.ID:           MsgWorkflowNodeFreezeDeleted,
+	MsgWorkflowConcurrencyQueued.ID:           MsgWorkflowConcurrencyQueued,
+	MsgWorkflowConcurrencyRelease.ID:          MsgWorkflowConcurrencyRelease,
+	MsgWorkflowConcurrencyCanceled.ID:         MsgWorkflowConcurrencyCanceled,
 	MsgWorkflowImportedUpdated.ID:             MsgWorkflowImportedUpdated,
 	MsgWorkflowImportedInserted.ID:            MsgWorkflowImportedInserted,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
@@ -160,6 +178,8 @@ var Messages = map[string]*Message{
 	MsgWorkflowErrorUnknownKey.ID:             MsgWorkflowErrorUnknownKey,
 	MsgWorkflowErrorBadVCSStrategy.ID:         MsgWorkflowErrorBadVCSStrategy,
 	MsgWorkflowDeprecatedVersion.ID:           MsgWorkflowDeprecatedVersion,
+	MsgWorkflowAsCodeRevision.ID:              MsgWorkflowAsCodeRevision,
+	MsgWorkflowAsCodeBranchRestricted.ID:      MsgWorkflowAsCodeBranchRestricted,
 }
 
 //Message represent a struc format translated messages
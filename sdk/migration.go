@@ -12,6 +12,8 @@ const (
 	MigrationStatusInProgress string = "IN PROGRESS"
 	// MigrationStatusDone is the constant to indicate that the migration is "done"
 	MigrationStatusDone string = "DONE"
+	// MigrationStatusError is the constant to indicate that the migration ended with errors
+	MigrationStatusError string = "ERROR"
 	// MigrationStatusCanceled is the constant to indicate that the migration is "canceled"
 	MigrationStatusCanceled string = "CANCELED"
 	// MigrationStatusNotExecuted is the constant to indicate that the migration is "not executed"
@@ -113,9 +113,20 @@ Unit Tests Report
 [[- end]]
 [[- end]]
 [[- end]]
+
+[[- if .Coverage ]]
+
+Coverage [[ printf "%.2f" .Coverage.Lines ]]%
+[[- if .Coverage.HasBase ]] ([[ .Coverage.DeltaString ]] compared to [[ if .Coverage.BaseBranch ]][[ .Coverage.BaseBranch ]][[ else ]]the default branch[[ end ]])[[ end ]]
+[[- end]]
 `
 
+// Report returns the node run report computed with the vcs notification template
 func (nr WorkflowNodeRun) Report() (string, error) {
+	return nr.report(nil)
+}
+
+func (nr WorkflowNodeRun) report(cov *VCSBuildReportCoverage) (string, error) {
 	reportStr := DefaultWorkflowNodeRunReport
 	if nr.VCSReport != "" {
 		reportStr = nr.VCSReport
@@ -135,6 +146,7 @@ func (nr WorkflowNodeRun) Report() (string, error) {
 		Start            time.Time
 		Done             time.Time
 		Tests            *venom.Tests
+		Coverage         *VCSBuildReportCoverage
 	}{
 		WorkflowNodeName: nr.WorkflowNodeName,
 		Status:           nr.Status,
@@ -144,6 +156,7 @@ func (nr WorkflowNodeRun) Report() (string, error) {
 		Start:            nr.Start,
 		Done:             nr.Done,
 		Tests:            nr.Tests,
+		Coverage:         cov,
 	}
 
 	outFirst := new(bytes.Buffer)
@@ -198,7 +198,7 @@ func ParametersFromIntegration(ppf IntegrationConfig) map[string]string {
 func VariablesToParameters(prefix string, variables []Variable) []Parameter {
 	res := make([]Parameter, 0, len(variables))
 	for _, t := range variables {
-		if NeedPlaceholder(t.Type) {
+		if NeedPlaceholder(t.Type) || IsExternalSecret(t.Type) {
 			continue
 		}
 		if prefix != "" {
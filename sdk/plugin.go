@@ -1,5 +1,11 @@
 package sdk
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
 // These are type of plugins
 const (
 	GRPCPluginDeploymentIntegration = "integration-deploy_application"
@@ -17,6 +23,51 @@ type GRPCPlugin struct {
 	Binaries           []GRPCPluginBinary `json:"binaries" yaml:"binaries" cli:"-" db:"-"`
 	IntegrationModelID *int64             `json:"-" db:"integration_model_id" yaml:"-" cli:"-"`
 	Integration        string             `json:"integration" db:"-" yaml:"integration" cli:"integration"`
+	Remote             GRPCPluginRemote   `json:"remote" yaml:"remote,omitempty" cli:"-" db:"remote"`
+}
+
+// IsRemote returns true if the plugin is served by a remote gRPC service instead of binaries run by the worker
+func (p GRPCPlugin) IsRemote() bool {
+	return p.Remote.Address != ""
+}
+
+// IsValid returns an error if the plugin is not valid.
+func (p GRPCPlugin) IsValid() error {
+	if p.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "plugin name is mandatory")
+	}
+	if p.IsRemote() && p.Type != GRPCPluginAction {
+		return NewErrorFrom(ErrWrongRequest, "only action plugins can be remote")
+	}
+	return nil
+}
+
+// GRPCPluginRemote is the address of a remote action plugin, workers call it with mutual TLS
+type GRPCPluginRemote struct {
+	Address    string `json:"address,omitempty" yaml:"address,omitempty"`
+	ServerName string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
+	CA         string `json:"ca,omitempty" yaml:"ca,omitempty"`
+}
+
+// Value returns driver.Value from GRPCPluginRemote.
+func (r GRPCPluginRemote) Value() (driver.Value, error) {
+	if r.Address == "" {
+		return nil, nil
+	}
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal GRPCPluginRemote")
+}
+
+// Scan GRPCPluginRemote.
+func (r *GRPCPluginRemote) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, r), "cannot unmarshal GRPCPluginRemote")
 }
 
 // GetBinary returns the binary for a specific os and arch
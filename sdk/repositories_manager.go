@@ -100,6 +100,8 @@ type VCSPullRequest struct {
 type VCSPullRequestCommentRequest struct {
 	VCSPullRequest
 	Message string `json:"message"`
+	// If set, the comment containing the report marker for this key is updated instead of adding a new one
+	ReportKey string `json:"report_key,omitempty"`
 }
 
 //VCSPushEvent represents a push events for polling
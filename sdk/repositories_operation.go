@@ -2,12 +2,15 @@ package sdk
 
 import (
 	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 )
 
 // Operation is the main business object use in repositories service
 type Operation struct {
 	UUID               string                   `json:"uuid"`
+	ProjectKey         string                   `json:"project_key,omitempty"`
 	VCSServer          string                   `json:"vcs_server,omitempty"`
 	RepoFullName       string                   `json:"repo_fullname,omitempty"`
 	URL                string                   `json:"url"`
@@ -29,6 +32,8 @@ type Operation struct {
 type OperationSetup struct {
 	Checkout OperationCheckout `json:"checkout,omitempty"`
 	Push     OperationPush     `json:"push,omitempty"`
+	Clone    OperationClone    `json:"clone,omitempty"`
+	Mirror   OperationMirror   `json:"mirror,omitempty"`
 }
 
 // OperationRepositoryInfo represents global information about the repository
@@ -51,6 +56,32 @@ type OperationCheckout struct {
 	Commit string `json:"commit,omitempty"`
 }
 
+// OperationClone represents the options used to clone the repository
+type OperationClone struct {
+	LFS            bool     `json:"lfs,omitempty"`
+	Filter         string   `json:"filter,omitempty"`
+	SparseCheckout []string `json:"sparse_checkout,omitempty"`
+}
+
+// IsEmpty returns true if no clone option is set
+func (c OperationClone) IsEmpty() bool {
+	return !c.LFS && c.Filter == "" && len(c.SparseCheckout) == 0
+}
+
+// String returns a key computed from the clone options
+func (c OperationClone) String() string {
+	if c.IsEmpty() {
+		return ""
+	}
+	return fmt.Sprintf("lfs=%t&filter=%s&sparse=%s", c.LFS, c.Filter, strings.Join(c.SparseCheckout, ","))
+}
+
+// OperationMirror represents the update of the bare mirror of the repository
+type OperationMirror struct {
+	Update bool   `json:"update,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
 // OperationPush represents information about push operation
 type OperationPush struct {
 	FromBranch string `json:"from_branch,omitempty"`
@@ -58,6 +89,15 @@ type OperationPush struct {
 	Message    string `json:"message,omitempty"`
 	PRLink     string `json:"pr_link,omitempty"`
 	Update     bool   `json:"update,omitempty"`
+	// Previous contains the files as known by CDS before the update, used to detect the files updated
+	// in the repository since the last synchronization
+	Previous  map[string]string `json:"previous,omitempty"`
+	Conflicts []string          `json:"conflicts,omitempty"`
+}
+
+// IsDirect returns true if the files are committed on the target branch without pull request
+func (p OperationPush) IsDirect() bool {
+	return p.FromBranch != "" && p.FromBranch == p.ToBranch
 }
 
 // OperationStatus is the status of an operation
@@ -76,9 +116,13 @@ type OperationRepo struct {
 	Basedir            string
 	URL                string
 	RepositoryStrategy RepositoryStrategy
+	Clone              OperationClone
 }
 
-// ID returns a generated ID for a Operation
+// ID returns a generated ID for a Operation, repositories cloned with options are not shared with full clones
 func (r OperationRepo) ID() string {
-	return base64.StdEncoding.EncodeToString([]byte(r.URL))
+	if r.Clone.IsEmpty() {
+		return base64.StdEncoding.EncodeToString([]byte(r.URL))
+	}
+	return base64.StdEncoding.EncodeToString([]byte(r.URL + "?" + r.Clone.String()))
 }
@@ -7,6 +7,8 @@ const (
 	ResponseWorkflowIDHeader = "X-Api-Workflow-Id"
 	// WorkflowAsCodeHeader is used as HTTP header
 	WorkflowAsCodeHeader = "X-Api-Workflow-As-Code"
+	// ResponseAsCodeOperationHeader is used as HTTP header
+	ResponseAsCodeOperationHeader = "X-Api-As-Code-Operation"
 
 	// ResponseTemplateGroupNameHeader is used as HTTP header
 	ResponseTemplateGroupNameHeader = "X-Api-Template-Group-Name"
@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"sort"
+	"time"
+)
+
+// Actions that can be granted by a role on a project or a workflow.
+const (
+	RoleActionRead                = "read"
+	RoleActionExecute             = "execute"
+	RoleActionWrite               = "write"
+	RoleActionEditVariables       = "edit-variables"
+	RoleActionManageIntegrations  = "manage-integrations"
+	RoleActionViewSecretsMetadata = "view-secrets-metadata"
+	RoleActionApproveDeployments  = "approve-deployments"
+)
+
+// AvailableRoleActions list all actions that can be granted by a role.
+var AvailableRoleActions = []string{
+	RoleActionRead,
+	RoleActionExecute,
+	RoleActionWrite,
+	RoleActionEditVariables,
+	RoleActionManageIntegrations,
+	RoleActionViewSecretsMetadata,
+	RoleActionApproveDeployments,
+}
+
+// Builtin roles names, they match permission levels given to groups on projects and workflows.
+const (
+	RoleRead             = "read"
+	RoleReadExecute      = "read-execute"
+	RoleReadWriteExecute = "read-write-execute"
+)
+
+// BuiltinRoles are the roles matching permissions levels, they can't be modified.
+var BuiltinRoles = []Role{
+	{
+		Name:    RoleRead,
+		Builtin: true,
+		Level:   PermissionRead,
+		Actions: StringSlice{RoleActionRead, RoleActionViewSecretsMetadata},
+	},
+	{
+		Name:    RoleReadExecute,
+		Builtin: true,
+		Level:   PermissionReadExecute,
+		Actions: StringSlice{RoleActionRead, RoleActionViewSecretsMetadata, RoleActionExecute},
+	},
+	{
+		Name:    RoleReadWriteExecute,
+		Builtin: true,
+		Level:   PermissionReadWriteExecute,
+		Actions: StringSlice{RoleActionRead, RoleActionViewSecretsMetadata, RoleActionExecute, RoleActionWrite,
+			RoleActionEditVariables, RoleActionManageIntegrations},
+	},
+}
+
+// BuiltinRoleForLevel returns the builtin role for given permission level.
+func BuiltinRoleForLevel(level int) *Role {
+	for i := range BuiltinRoles {
+		if BuiltinRoles[i].Level == level {
+			r := BuiltinRoles[i]
+			return &r
+		}
+	}
+	return nil
+}
+
+// RoleActionForLevel returns the action required by a route for given permission level.
+func RoleActionForLevel(level int) string {
+	switch {
+	case level >= PermissionReadWriteExecute:
+		return RoleActionWrite
+	case level >= PermissionReadExecute:
+		return RoleActionExecute
+	default:
+		return RoleActionRead
+	}
+}
+
+// IsReadOnlyRoleAction returns true if given action doesn't allow to modify or run anything.
+func IsReadOnlyRoleAction(action string) bool {
+	return action == RoleActionRead || action == RoleActionViewSecretsMetadata
+}
+
+// Role is a named set of actions that can be granted to groups on projects or workflows.
+type Role struct {
+	ID           int64       `json:"id" db:"id" cli:"-"`
+	Name         string      `json:"name" db:"name" cli:"name,key"`
+	Description  string      `json:"description" db:"description" cli:"description"`
+	Actions      StringSlice `json:"actions" db:"actions" cli:"actions"`
+	Created      time.Time   `json:"created" db:"created" cli:"-"`
+	LastModified time.Time   `json:"last_modified" db:"last_modified" cli:"-"`
+	// aggregates
+	Builtin bool `json:"builtin" db:"-" cli:"builtin"`
+	Level   int  `json:"level,omitempty" db:"-" cli:"-"`
+}
+
+// IsValid returns an error if given role is not valid.
+func (r Role) IsValid() error {
+	if !NamePatternRegex.MatchString(r.Name) {
+		return NewErrorFrom(ErrInvalidName, "invalid role name, it should match %s", NamePattern)
+	}
+	for i := range BuiltinRoles {
+		if BuiltinRoles[i].Name == r.Name {
+			return NewErrorFrom(ErrWrongRequest, "role name %s is reserved for a builtin role", r.Name)
+		}
+	}
+	if len(r.Actions) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "a role should contain at least one action")
+	}
+	for _, a := range r.Actions {
+		if !StringSlice(AvailableRoleActions).Contains(a) {
+			return NewErrorFrom(ErrWrongRequest, "invalid given action %s for role, available actions are: %v", a, AvailableRoleActions)
+		}
+	}
+	return nil
+}
+
+// RoleLink grants a role to a group on a project, or on a workflow if workflow id is set.
+type RoleLink struct {
+	ID         int64     `json:"id" db:"id" cli:"id,key"`
+	RoleID     int64     `json:"role_id" db:"role_id" cli:"-"`
+	GroupID    int64     `json:"group_id" db:"group_id" cli:"-"`
+	ProjectID  int64     `json:"project_id" db:"project_id" cli:"-"`
+	WorkflowID *int64    `json:"workflow_id,omitempty" db:"workflow_id" cli:"-"`
+	Created    time.Time `json:"created" db:"created" cli:"created"`
+	// aggregates
+	RoleName     string `json:"role_name" db:"-" cli:"role"`
+	GroupName    string `json:"group_name" db:"-" cli:"group"`
+	WorkflowName string `json:"workflow_name,omitempty" db:"-" cli:"workflow"`
+}
+
+// RoleActions is a set of actions granted to a consumer on a project or a workflow.
+type RoleActions map[string]struct{}
+
+// Add given actions to the set.
+func (a RoleActions) Add(actions ...string) {
+	for _, s := range actions {
+		a[s] = struct{}{}
+	}
+}
+
+// Has returns true if given action is in the set.
+func (a RoleActions) Has(action string) bool {
+	_, ok := a[action]
+	return ok
+}
+
+// List returns sorted actions of the set.
+func (a RoleActions) List() []string {
+	res := make([]string, 0, len(a))
+	for s := range a {
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleIsValid(t *testing.T) {
+	require.NoError(t, Role{Name: "variables-editor", Actions: StringSlice{RoleActionRead, RoleActionEditVariables}}.IsValid())
+	assert.Error(t, Role{Name: RoleReadExecute, Actions: StringSlice{RoleActionRead}}.IsValid())
+	assert.Error(t, Role{Name: "empty"}.IsValid())
+	assert.Error(t, Role{Name: "unknown", Actions: StringSlice{"delete-everything"}}.IsValid())
+}
+
+func TestBuiltinRoleForLevel(t *testing.T) {
+	r := BuiltinRoleForLevel(PermissionReadExecute)
+	require.NotNil(t, r)
+	assert.Equal(t, RoleReadExecute, r.Name)
+
+	actions := RoleActions{}
+	actions.Add(BuiltinRoleForLevel(PermissionReadWriteExecute).Actions...)
+	assert.True(t, actions.Has(RoleActionEditVariables))
+	assert.False(t, actions.Has(RoleActionApproveDeployments))
+	assert.True(t, actions.Has(RoleActionForLevel(PermissionReadWriteExecute)))
+
+	actions = RoleActions{}
+	actions.Add(BuiltinRoleForLevel(PermissionReadExecute).Actions...)
+	assert.False(t, actions.Has(RoleActionApproveDeployments))
+}
@@ -0,0 +1,195 @@
+package sdk
+
+import (
+	"math"
+	"regexp"
+	"time"
+)
+
+// Sources where a secret can be detected by the worker.
+const (
+	SecretScanSourceLog      = "log"
+	SecretScanSourceArtifact = "artifact"
+)
+
+// SecretScanRule describes a pattern of credential to detect in job logs and artifacts.
+// If the regex contains a capturing group, only the first group is considered as the secret.
+// If a min entropy is set, matches with a lower Shannon entropy are ignored.
+type SecretScanRule struct {
+	Name       string  `toml:"name" json:"name"`
+	Regex      string  `toml:"regex" json:"regex"`
+	MinEntropy float64 `toml:"minEntropy" json:"min_entropy,omitempty"`
+}
+
+// IsValid returns an error if the rule has no name or an invalid regex.
+func (r SecretScanRule) IsValid() error {
+	if r.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "secret scan rule name should not be empty")
+	}
+	if _, err := regexp.Compile(r.Regex); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid regex for secret scan rule %s: %v", r.Name, err)
+	}
+	if r.MinEntropy < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid min entropy for secret scan rule %s", r.Name)
+	}
+	return nil
+}
+
+// DefaultSecretScanRules are used by workers when no rule is given in API configuration.
+var DefaultSecretScanRules = []SecretScanRule{
+	{Name: "aws-access-key-id", Regex: `\b((?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16})\b`},
+	{Name: "aws-secret-access-key", Regex: `(?i)aws.{0,20}?(?:secret|key).{0,20}?['"=: ]+([A-Za-z0-9/+=]{40})\b`, MinEntropy: 4},
+	{Name: "private-key", Regex: `-----BEGIN[ A-Z0-9]*PRIVATE KEY-----(?s:.*?-----END[ A-Z0-9]*PRIVATE KEY-----)?`},
+	{Name: "jwt", Regex: `\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`},
+	{Name: "github-token", Regex: `\b((?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`},
+	{Name: "high-entropy-string", Regex: `[A-Za-z0-9+/=_-]{32,}`, MinEntropy: 4.5},
+}
+
+// SecretScanConfig is given to the worker with the job to scan its logs and artifacts.
+type SecretScanConfig struct {
+	Rules     []SecretScanRule `json:"rules"`
+	Allowlist []string         `json:"allowlist,omitempty"`
+}
+
+// SecretScanAllowlistEntry is a regex on a project, secrets matching it are not reported nor redacted.
+type SecretScanAllowlistEntry struct {
+	ID          int64     `json:"id" db:"id" cli:"id,key"`
+	ProjectID   int64     `json:"project_id" db:"project_id" cli:"-"`
+	Pattern     string    `json:"pattern" db:"pattern" cli:"pattern"`
+	Description string    `json:"description" db:"description" cli:"description"`
+	Created     time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// IsValid returns an error if the pattern of the entry is not a valid regex.
+func (e SecretScanAllowlistEntry) IsValid() error {
+	if e.Pattern == "" {
+		return NewErrorFrom(ErrWrongRequest, "allowlist pattern should not be empty")
+	}
+	if _, err := regexp.Compile(e.Pattern); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid allowlist pattern %q: %v", e.Pattern, err)
+	}
+	return nil
+}
+
+// SecretScanFinding is reported by the worker when secrets are detected, it never contains the secret value.
+type SecretScanFinding struct {
+	Rule      string `json:"rule"`
+	Source    string `json:"source"`
+	StepOrder int    `json:"step_order"`
+	Filename  string `json:"filename,omitempty"`
+	Count     int    `json:"count"`
+}
+
+type secretScanRule struct {
+	SecretScanRule
+	regex *regexp.Regexp
+}
+
+// SecretScanner detects and redacts secrets in strings.
+type SecretScanner struct {
+	rules     []secretScanRule
+	allowlist []*regexp.Regexp
+}
+
+// NewSecretScanner compiles rules and allowlist of given config, default rules are used if none is given.
+func NewSecretScanner(cfg SecretScanConfig) (*SecretScanner, error) {
+	rules := cfg.Rules
+	if len(rules) == 0 {
+		rules = DefaultSecretScanRules
+	}
+
+	s := &SecretScanner{}
+	for _, r := range rules {
+		regex, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid regex for secret scan rule %s: %v", r.Name, err)
+		}
+		s.rules = append(s.rules, secretScanRule{SecretScanRule: r, regex: regex})
+	}
+	for _, a := range cfg.Allowlist {
+		regex, err := regexp.Compile(a)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid secret scan allowlist pattern %q: %v", a, err)
+		}
+		s.allowlist = append(s.allowlist, regex)
+	}
+	return s, nil
+}
+
+func (s *SecretScanner) isAllowed(value string) bool {
+	for _, a := range s.allowlist {
+		if a.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces secrets detected in given string by a placeholder.
+// It returns the redacted string and the name of the rules that matched, once per match.
+func (s *SecretScanner) Redact(in string) (string, []string) {
+	var matched []string
+	for _, r := range s.rules {
+		indexes := r.regex.FindAllStringSubmatchIndex(in, -1)
+		if len(indexes) == 0 {
+			continue
+		}
+
+		var spans [][2]int
+		for _, idx := range indexes {
+			start, end := idx[0], idx[1]
+			if len(idx) >= 4 && idx[2] >= 0 {
+				start, end = idx[2], idx[3]
+			}
+			value := in[start:end]
+			if value == PasswordPlaceholder || s.isAllowed(value) {
+				continue
+			}
+			if r.MinEntropy > 0 && ShannonEntropy(value) < r.MinEntropy {
+				continue
+			}
+			spans = append(spans, [2]int{start, end})
+			matched = append(matched, r.Name)
+		}
+
+		// Replace from the end to keep indexes valid
+		for i := len(spans) - 1; i >= 0; i-- {
+			in = in[:spans[i][0]] + PasswordPlaceholder + in[spans[i][1]:]
+		}
+	}
+	return in, matched
+}
+
+// ShannonEntropy returns the Shannon entropy of given string in bits per character.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	freq := make(map[rune]float64)
+	var l float64
+	for _, c := range s {
+		freq[c]++
+		l++
+	}
+	var e float64
+	for _, n := range freq {
+		p := n / l
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+// SecretScanFindings groups findings by step, source, file and rule.
+type SecretScanFindings []SecretScanFinding
+
+// Add merges given finding with an existing one for the same step, source, file and rule.
+func (f *SecretScanFindings) Add(n SecretScanFinding) {
+	for i := range *f {
+		c := &(*f)[i]
+		if c.StepOrder == n.StepOrder && c.Source == n.Source && c.Filename == n.Filename && c.Rule == n.Rule {
+			c.Count += n.Count
+			return
+		}
+	}
+	*f = append(*f, n)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql/driver"
 	json "encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -24,6 +26,12 @@ type AuthDriverWithRedirect interface {
 	GetSigninURI(AuthSigninConsumerToken) (AuthDriverSigningRedirect, error)
 }
 
+// AuthDriverWithGroupsSync is implemented by drivers that can list the members of an external group.
+type AuthDriverWithGroupsSync interface {
+	AuthDriver
+	GetGroupMembers(ctx context.Context, value string) ([]string, error)
+}
+
 type AuthDriverWithSigninStateToken interface {
 	AuthDriver
 	CheckSigninStateToken(AuthConsumerSigninRequest) error
@@ -237,9 +245,10 @@ type AuthConsumerSigninRequest map[string]string
 
 // AuthConsumerSigninResponse response for a auth consumer signin.
 type AuthConsumerSigninResponse struct {
-	APIURL string            `json:"api_url,omitempty"`
-	Token  string            `json:"token"` // session token
-	User   *AuthentifiedUser `json:"user"`
+	APIURL   string            `json:"api_url,omitempty"`
+	Token    string            `json:"token"` // session token
+	User     *AuthentifiedUser `json:"user"`
+	MFAToken string            `json:"mfa_token,omitempty"` // token to give with a second factor code to complete the signin
 }
 
 // AuthConsumerCreateResponse response for a auth consumer creation.
@@ -255,6 +264,8 @@ type AuthDriverUserInfo struct {
 	Fullname   string
 	Email      string
 	MFA        bool
+	// Groups contains external groups of the user (LDAP group DNs or groups claim values)
+	Groups []string
 }
 
 // AuthCurrentConsumerResponse describe the current consumer and the current session
@@ -274,6 +285,7 @@ const (
 	ConsumerCorporateSSO AuthConsumerType = "corporate-sso"
 	ConsumerGithub       AuthConsumerType = "github"
 	ConsumerGitlab       AuthConsumerType = "gitlab"
+	ConsumerOIDC         AuthConsumerType = "openid-connect"
 	ConsumerTest         AuthConsumerType = "futurama"
 	ConsumerTest2        AuthConsumerType = "planet-express"
 )
@@ -290,7 +302,7 @@ func (t AuthConsumerType) IsValid() bool {
 // IsValidExternal returns validity of given auth consumer type.
 func (t AuthConsumerType) IsValidExternal() bool {
 	switch t {
-	case ConsumerLDAP, ConsumerCorporateSSO, ConsumerGithub, ConsumerGitlab, ConsumerTest, ConsumerTest2:
+	case ConsumerLDAP, ConsumerCorporateSSO, ConsumerGithub, ConsumerGitlab, ConsumerOIDC, ConsumerTest, ConsumerTest2:
 		return true
 	}
 	return false
@@ -372,30 +384,101 @@ func (w AuthConsumerWarnings) Value() (driver.Value, error) {
 	return j, WrapError(err, "cannot marshal AuthConsumerWarnings")
 }
 
+// AuthConsumerRestrictions limits a consumer to given projects and workflows with a maximum permission level.
+type AuthConsumerRestrictions struct {
+	ProjectKeys   []string `json:"project_keys"`
+	Workflows     []string `json:"workflows,omitempty"` // formatted as <project_key>/<workflow_name>, empty means all workflows of given projects
+	MaxPermission int      `json:"max_permission"`
+}
+
+// String returns a deterministic representation of restrictions, used in consumer signature.
+func (r AuthConsumerRestrictions) String() string {
+	return fmt.Sprintf("%v%v%d", r.ProjectKeys, r.Workflows, r.MaxPermission)
+}
+
+// IsValid returns an error if restrictions are invalid.
+func (r AuthConsumerRestrictions) IsValid() error {
+	if len(r.ProjectKeys) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "at least one project key should be given for consumer restrictions")
+	}
+	switch r.MaxPermission {
+	case PermissionRead, PermissionReadExecute, PermissionReadWriteExecute:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid given max permission %d for consumer restrictions", r.MaxPermission)
+	}
+	for _, w := range r.Workflows {
+		ss := strings.Split(w, "/")
+		if len(ss) != 2 || ss[1] == "" || !IsInArray(ss[0], r.ProjectKeys) {
+			return NewErrorFrom(ErrWrongRequest, "invalid given workflow %s for consumer restrictions, it should be <project_key>/<workflow_name> for a given project", w)
+		}
+	}
+	return nil
+}
+
+// AllowProject returns true if given project is allowed by restrictions.
+func (r AuthConsumerRestrictions) AllowProject(projectKey string) bool {
+	return IsInArray(projectKey, r.ProjectKeys)
+}
+
+// AllowWorkflow returns true if given workflow is allowed by restrictions.
+func (r AuthConsumerRestrictions) AllowWorkflow(projectKey, workflowName string) bool {
+	if !r.AllowProject(projectKey) {
+		return false
+	}
+	var hasProjectWorkflows bool
+	for _, w := range r.Workflows {
+		if strings.HasPrefix(w, projectKey+"/") {
+			hasProjectWorkflows = true
+			if w == projectKey+"/"+workflowName {
+				return true
+			}
+		}
+	}
+	return !hasProjectWorkflows
+}
+
+// Scan consumer restrictions.
+func (r *AuthConsumerRestrictions) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, r), "cannot unmarshal AuthConsumerRestrictions")
+}
+
+// Value returns driver.Value from consumer restrictions.
+func (r AuthConsumerRestrictions) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal AuthConsumerRestrictions")
+}
+
 // AuthConsumers gives functions for auth consumer slice.
 type AuthConsumers []AuthConsumer
 
 // AuthConsumer issues session linked to an authentified user.
 type AuthConsumer struct {
-	ID                 string                   `json:"id" cli:"id,key" db:"id"`
-	Name               string                   `json:"name" cli:"name" db:"name"`
-	Description        string                   `json:"description" cli:"description" db:"description"`
-	ParentID           *string                  `json:"parent_id,omitempty" db:"parent_id"`
-	AuthentifiedUserID string                   `json:"user_id,omitempty" db:"user_id"`
-	Type               AuthConsumerType         `json:"type" cli:"type" db:"type"`
-	Data               AuthConsumerData         `json:"-" db:"data"` // NEVER returns auth consumer data in json, TODO this fields should be visible only in auth package
-	Created            time.Time                `json:"created" cli:"created" db:"created"`
-	GroupIDs           Int64Slice               `json:"group_ids,omitempty" cli:"group_ids" db:"group_ids"`
-	InvalidGroupIDs    Int64Slice               `json:"invalid_group_ids,omitempty" db:"invalid_group_ids"`
-	ScopeDetails       AuthConsumerScopeDetails `json:"scope_details,omitempty" cli:"scope_details" db:"scope_details"`
-	IssuedAt           time.Time                `json:"issued_at" cli:"issued_at" db:"issued_at"`
-	Disabled           bool                     `json:"disabled" cli:"disabled" db:"disabled"`
-	Warnings           AuthConsumerWarnings     `json:"warnings,omitempty" db:"warnings"`
+	ID                 string                    `json:"id" cli:"id,key" db:"id"`
+	Name               string                    `json:"name" cli:"name" db:"name"`
+	Description        string                    `json:"description" cli:"description" db:"description"`
+	ParentID           *string                   `json:"parent_id,omitempty" db:"parent_id"`
+	AuthentifiedUserID string                    `json:"user_id,omitempty" db:"user_id"`
+	Type               AuthConsumerType          `json:"type" cli:"type" db:"type"`
+	Data               AuthConsumerData          `json:"-" db:"data"` // NEVER returns auth consumer data in json, TODO this fields should be visible only in auth package
+	Created            time.Time                 `json:"created" cli:"created" db:"created"`
+	GroupIDs           Int64Slice                `json:"group_ids,omitempty" cli:"group_ids" db:"group_ids"`
+	InvalidGroupIDs    Int64Slice                `json:"invalid_group_ids,omitempty" db:"invalid_group_ids"`
+	ScopeDetails       AuthConsumerScopeDetails  `json:"scope_details,omitempty" cli:"scope_details" db:"scope_details"`
+	IssuedAt           time.Time                 `json:"issued_at" cli:"issued_at" db:"issued_at"`
+	Disabled           bool                      `json:"disabled" cli:"disabled" db:"disabled"`
+	Warnings           AuthConsumerWarnings      `json:"warnings,omitempty" db:"warnings"`
+	ExpireAt           *time.Time                `json:"expire_at,omitempty" cli:"expire_at" db:"expire_at"`
+	Restrictions       *AuthConsumerRestrictions `json:"restrictions,omitempty" cli:"restrictions" db:"restrictions"`
 	// aggregates
 	AuthentifiedUser *AuthentifiedUser `json:"user,omitempty" db:"-"`
 	Groups           Groups            `json:"groups,omitempty" db:"-"`
 	Service          *Service          `json:"-" db:"-"`
 	Worker           *Worker           `json:"-" db:"-"`
+	LastUsed         *time.Time        `json:"last_used,omitempty" cli:"last_used" db:"-"`
 }
 
 // IsValid returns validity for auth consumer.
@@ -408,6 +491,18 @@ func (c AuthConsumer) IsValid(scopeDetails AuthConsumerScopeDetails) error {
 		return err
 	}
 
+	if c.ExpireAt != nil && !c.ExpireAt.After(time.Now()) {
+		return NewErrorFrom(ErrWrongRequest, "invalid given expiration date, it should be in the future")
+	}
+	if c.Restrictions != nil {
+		if err := c.Restrictions.IsValid(); err != nil {
+			return err
+		}
+		if c.ExpireAt == nil {
+			return NewErrorFrom(ErrWrongRequest, "an expiration date is required for a consumer restricted to projects")
+		}
+	}
+
 	mEndpoints := scopeDetails.ToEndpointsMap()
 
 	for _, s := range c.ScopeDetails {
@@ -448,12 +543,39 @@ func (c AuthConsumer) GetGroupIDs() []int64 {
 	return groupIDs
 }
 
+// Admin returns true if the consumer's user is an admin, a consumer restricted to projects can't act as admin.
 func (c AuthConsumer) Admin() bool {
-	return c.AuthentifiedUser.Ring == UserRingAdmin
+	return c.Restrictions == nil && c.AuthentifiedUser.Ring == UserRingAdmin
 }
 
+// Maintainer returns true if the consumer's user is a maintainer, a consumer restricted to projects can't act as maintainer.
 func (c AuthConsumer) Maintainer() bool {
-	return c.AuthentifiedUser.Ring == UserRingMaintainer
+	return c.Restrictions == nil && c.AuthentifiedUser.Ring == UserRingMaintainer
+}
+
+// IsExpired returns true if the consumer has an expiration date in the past.
+func (c AuthConsumer) IsExpired() bool {
+	return c.ExpireAt != nil && c.ExpireAt.Before(time.Now())
+}
+
+// IsStale returns true if the consumer was not used since given date, a never used consumer
+// is stale if it was created before given date.
+func (c AuthConsumer) IsStale(since time.Time) bool {
+	if c.LastUsed != nil {
+		return c.LastUsed.Before(since)
+	}
+	return c.Created.Before(since)
+}
+
+// AuthConsumerActivity stores usages of an auth consumer for a couple of ip and user agent.
+type AuthConsumerActivity struct {
+	ID         int64     `json:"id" db:"id"`
+	ConsumerID string    `json:"consumer_id" db:"consumer_id"`
+	IP         string    `json:"ip" cli:"ip,key" db:"ip"`
+	UserAgent  string    `json:"user_agent" cli:"user_agent" db:"user_agent"`
+	FirstSeen  time.Time `json:"first_seen" cli:"first_seen" db:"first_seen"`
+	LastSeen   time.Time `json:"last_seen" cli:"last_seen" db:"last_seen"`
+	Count      int64     `json:"count" cli:"count" db:"count"`
 }
 
 func (c AuthConsumer) GetUsername() string {
@@ -477,6 +599,30 @@ func (c AuthConsumer) GetFullname() string {
 	return c.AuthentifiedUser.GetFullname()
 }
 
+// AuthLocalTOTP is a time based one time password second factor enrolled by a local user.
+type AuthLocalTOTP struct {
+	ID                 string      `json:"id" db:"id"`
+	AuthentifiedUserID string      `json:"authentified_user_id" db:"authentified_user_id"`
+	Verified           bool        `json:"verified" cli:"verified" db:"verified"`
+	Created            time.Time   `json:"created" cli:"created" db:"created"`
+	LastCounter        int64       `json:"-" db:"last_counter"`
+	RecoveryCodes      StringSlice `json:"-" db:"recovery_codes"` // hashes of unused recovery codes
+	Secret             string      `json:"-" db:"-"`
+	// aggregates
+	RecoveryCodesLeft int `json:"recovery_codes_left" cli:"recovery_codes_left" db:"-"`
+}
+
+// AuthLocalTOTPEnrollResponse response for a TOTP enrollment.
+type AuthLocalTOTPEnrollResponse struct {
+	Secret string `json:"secret" cli:"secret"`
+	URI    string `json:"uri" cli:"uri"`
+}
+
+// AuthLocalTOTPRecoveryCodesResponse contains recovery codes generated for a TOTP, they are only returned once.
+type AuthLocalTOTPRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 // AuthSessions gives functions for auth session slice.
 type AuthSessions []AuthSession
 
@@ -499,6 +645,50 @@ type AuthSessionJWTClaims struct {
 	jwt.StandardClaims
 }
 
+// JobIDTokenClaims is the claims format for the identity token issued for a running job.
+// The token can be used by external systems (Vault JWT auth, cloud IAM...) to trust a CDS job.
+type JobIDTokenClaims struct {
+	JobID       int64  `json:"job_id"`
+	ProjectKey  string `json:"project_key"`
+	Workflow    string `json:"workflow"`
+	Node        string `json:"node"`
+	Branch      string `json:"branch,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	RunNumber   int64  `json:"run_number"`
+	jwt.StandardClaims
+}
+
+// JobIDToken is the identity token issued for a running job.
+type JobIDToken struct {
+	Token    string    `json:"token"`
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+// JSONWebKey is a public key in JWK format (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JSONWebKeySet is a set of public keys in JWK format.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// OpenIDConfiguration is the OpenID provider metadata used by external systems to discover the JWKS.
+type OpenIDConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+}
+
 // AuthSessionsToIDs returns ids of given auth sessions.
 func AuthSessionsToIDs(ass []*AuthSession) []string {
 	ids := make([]string, len(ass))
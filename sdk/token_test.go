@@ -3,6 +3,7 @@ package sdk_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ovh/cds/sdk"
 
@@ -109,3 +110,41 @@ func TestAuthConsumerScopeDetailsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthConsumerRestrictions(t *testing.T) {
+	r := sdk.AuthConsumerRestrictions{
+		ProjectKeys:   []string{"PROJ1", "PROJ2"},
+		Workflows:     []string{"PROJ2/wf1"},
+		MaxPermission: sdk.PermissionRead,
+	}
+	assert.NoError(t, r.IsValid())
+
+	assert.True(t, r.AllowProject("PROJ1"))
+	assert.False(t, r.AllowProject("PROJ3"))
+	assert.True(t, r.AllowWorkflow("PROJ1", "any"))
+	assert.True(t, r.AllowWorkflow("PROJ2", "wf1"))
+	assert.False(t, r.AllowWorkflow("PROJ2", "wf2"))
+	assert.False(t, r.AllowWorkflow("PROJ3", "wf1"))
+
+	r.Workflows = []string{"PROJ3/wf1"}
+	assert.Error(t, r.IsValid())
+	r.Workflows = nil
+	r.MaxPermission = 6
+	assert.Error(t, r.IsValid())
+	r.MaxPermission = sdk.PermissionRead
+	r.ProjectKeys = nil
+	assert.Error(t, r.IsValid())
+}
+
+func TestAuthConsumerIsStale(t *testing.T) {
+	now := time.Now()
+	lastUsed := now.Add(-2 * time.Hour)
+
+	c := sdk.AuthConsumer{Created: now.Add(-48 * time.Hour)}
+	assert.True(t, c.IsStale(now.Add(-24*time.Hour)), "never used consumer created before given date")
+	assert.False(t, c.IsStale(now.Add(-72*time.Hour)))
+
+	c.LastUsed = &lastUsed
+	assert.False(t, c.IsStale(now.Add(-24*time.Hour)))
+	assert.True(t, c.IsStale(now.Add(-time.Hour)))
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -51,6 +52,7 @@ const (
 	BooleanVariable    = "boolean"
 	NumberVariable     = "number"
 	RepositoryVariable = "repository"
+	VaultVariable      = "vault"
 )
 
 var (
@@ -61,6 +63,7 @@ var (
 		StringVariable,
 		BooleanVariable,
 		NumberVariable,
+		VaultVariable,
 	}
 
 	BasicVariableNames = []string{
@@ -108,6 +111,65 @@ func NeedPlaceholder(t string) bool {
 	}
 }
 
+// IsExternalSecret returns true if variable type references a secret stored outside CDS
+func IsExternalSecret(t string) bool {
+	return t == VaultVariable
+}
+
+// VaultSecretReference is the value of a vault variable, it references a secret in a Vault KV engine
+// and is formatted as <path>[#<field>][@<version>], ex: secret/data/my-app#password@3.
+type VaultSecretReference struct {
+	Path    string
+	Field   string
+	Version int
+}
+
+// ParseVaultSecretReference returns a vault secret reference from a vault variable value.
+func ParseVaultSecretReference(s string) (VaultSecretReference, error) {
+	var ref VaultSecretReference
+	s = strings.TrimSpace(s)
+
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		v, err := strconv.Atoi(s[i+1:])
+		if err != nil || v <= 0 {
+			return ref, NewErrorFrom(ErrWrongRequest, "invalid vault secret version in %q", s)
+		}
+		ref.Version = v
+		s = s[:i]
+	}
+	if i := strings.Index(s, "#"); i >= 0 {
+		ref.Field = s[i+1:]
+		s = s[:i]
+	}
+	ref.Path = strings.Trim(s, "/")
+
+	if ref.Path == "" {
+		return ref, NewErrorFrom(ErrWrongRequest, "invalid vault secret reference %q, it should be formatted as <path>[#<field>][@<version>]", s)
+	}
+	return ref, nil
+}
+
+func (r VaultSecretReference) String() string {
+	s := r.Path
+	if r.Field != "" {
+		s += "#" + r.Field
+	}
+	if r.Version > 0 {
+		s += fmt.Sprintf("@%d", r.Version)
+	}
+	return s
+}
+
+// CheckVariableValue returns an error if the value of given variable is not valid for its type.
+func CheckVariableValue(v Variable) error {
+	if v.Type == VaultVariable {
+		if _, err := ParseVaultSecretReference(v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // VariableFind return a variable given its name if it exists in array
 func VariableFind(vars []Variable, s string) *Variable {
 	for _, v := range vars {
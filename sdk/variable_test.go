@@ -58,3 +58,29 @@ func Test_EnvVartoENV(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVaultSecretReference(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    sdk.VaultSecretReference
+		wantErr bool
+	}{
+		{value: "secret/data/my-app", want: sdk.VaultSecretReference{Path: "secret/data/my-app"}},
+		{value: "/secret/data/my-app#password", want: sdk.VaultSecretReference{Path: "secret/data/my-app", Field: "password"}},
+		{value: "secret/data/my-app#password@3", want: sdk.VaultSecretReference{Path: "secret/data/my-app", Field: "password", Version: 3}},
+		{value: "secret/data/my-app@2", want: sdk.VaultSecretReference{Path: "secret/data/my-app", Version: 2}},
+		{value: "secret/data/my-app@latest", wantErr: true},
+		{value: "#password", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := sdk.ParseVaultSecretReference(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVaultSecretReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVaultSecretReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
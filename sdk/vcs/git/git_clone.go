@@ -21,6 +21,17 @@ type CloneOpts struct {
 	CheckoutCommit          string
 	NoStrictHostKeyChecking bool
 	ForceGetGitDescribe     bool
+	// LFS fetches git LFS objects after the checkout, LFSStorage is an optional directory used to store them
+	LFS        bool
+	LFSStorage string
+	// Filter is used for partial clone, ie. blob:none
+	Filter string
+	// SparseCheckout restricts the working tree to the given directories
+	SparseCheckout []string
+	// Reference is a local mirror of the repository used to download only the missing objects from the remote,
+	// with Dissociate the objects are copied from the mirror so the clone does not depend on it
+	Reference  string
+	Dissociate bool
 }
 
 // Clone make a git clone
@@ -83,7 +94,21 @@ func prepareGitCloneCommands(repo, workdirPath, path string, opts *CloneOpts) (s
 			gitcmd.args = append(gitcmd.args, "--single-branch")
 		}
 
-		if opts.Recursive {
+		if opts.Filter != "" {
+			gitcmd.args = append(gitcmd.args, "--filter="+opts.Filter)
+		}
+
+		if opts.Reference != "" {
+			gitcmd.args = append(gitcmd.args, "--reference-if-able", opts.Reference)
+			if opts.Dissociate {
+				gitcmd.args = append(gitcmd.args, "--dissociate")
+			}
+		}
+
+		// the working tree will be populated once the sparse checkout is configured
+		if len(opts.SparseCheckout) > 0 {
+			gitcmd.args = append(gitcmd.args, "--no-checkout")
+		} else if opts.Recursive {
 			gitcmd.args = append(gitcmd.args, "--recursive")
 		}
 	}
@@ -97,6 +122,25 @@ func prepareGitCloneCommands(repo, workdirPath, path string, opts *CloneOpts) (s
 
 	allCmd = append(allCmd, gitcmd)
 
+	repoDir := cloneDirectory(repo, workdirPath, path)
+	var checkedOut bool
+
+	if opts != nil && len(opts.SparseCheckout) > 0 {
+		initCmd := cmd{
+			cmd:     "git",
+			workdir: repoDir,
+			args:    []string{"sparse-checkout", "init", "--cone"},
+		}
+		setCmd := cmd{
+			cmd:     "git",
+			workdir: repoDir,
+			args:    append([]string{"sparse-checkout", "set"}, opts.SparseCheckout...),
+		}
+		userLogCommand += "\n\rExecuting: git " + strings.Join(initCmd.args, " ")
+		userLogCommand += "\n\rExecuting: git " + strings.Join(setCmd.args, " ")
+		allCmd = append(allCmd, initCmd, setCmd)
+	}
+
 	// if a specific commit hash is given, try to reset current repo to this commit
 	// when a tag is given the commit hash is ignored
 	if opts != nil && opts.CheckoutCommit != "" && opts.Tag == "" {
@@ -109,14 +153,7 @@ func prepareGitCloneCommands(repo, workdirPath, path string, opts *CloneOpts) (s
 			}
 			userLogCommand += "\n\rExecuting: git " + strings.Join(fetchCmd.args, " ")
 			//Locate the git reset cmd to the right directory
-			if path == "" {
-				t := strings.Split(repo, "/")
-				fetchCmd.workdir = filepath.Join(workdirPath, strings.TrimSuffix(t[len(t)-1], ".git"))
-			} else if strings.HasPrefix(path, "/") {
-				fetchCmd.workdir = path
-			} else {
-				fetchCmd.workdir = filepath.Join(workdirPath, path)
-			}
+			fetchCmd.workdir = repoDir
 
 			allCmd = append(allCmd, fetchCmd)
 		}
@@ -128,17 +165,78 @@ func prepareGitCloneCommands(repo, workdirPath, path string, opts *CloneOpts) (s
 		}
 		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
 		// locate the git reset cmd to the right directory
-		if path == "" {
-			t := strings.Split(repo, "/")
-			resetCmd.workdir = filepath.Join(workdirPath, strings.TrimSuffix(t[len(t)-1], ".git"))
-		} else if strings.HasPrefix(path, "/") {
-			resetCmd.workdir = path
-		} else {
-			resetCmd.workdir = filepath.Join(workdirPath, path)
-		}
+		resetCmd.workdir = repoDir
 
 		allCmd = append(allCmd, resetCmd)
+		checkedOut = true
+	}
+
+	if opts != nil && len(opts.SparseCheckout) > 0 {
+		// the repository was cloned without checkout, populate the working tree from HEAD
+		if !checkedOut {
+			resetCmd := cmd{
+				cmd:     "git",
+				workdir: repoDir,
+				args:    []string{"reset", "--hard"},
+			}
+			userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
+			allCmd = append(allCmd, resetCmd)
+		}
+		if opts.Recursive {
+			submoduleCmd := cmd{
+				cmd:     "git",
+				workdir: repoDir,
+				args:    []string{"submodule", "update", "--init", "--recursive"},
+			}
+			userLogCommand += "\n\rExecuting: git " + strings.Join(submoduleCmd.args, " ")
+			allCmd = append(allCmd, submoduleCmd)
+		}
+	}
+
+	if opts != nil && opts.LFS {
+		// LFS objects are fetched with git lfs pull at the end, not by the smudge filter during the checkout
+		for i := range allCmd {
+			allCmd[i].env = append(allCmd[i].env, "GIT_LFS_SKIP_SMUDGE=1")
+		}
+		lfsCmds := []cmd{{
+			cmd:     "git",
+			workdir: repoDir,
+			args:    []string{"lfs", "install", "--local"},
+		}}
+		if opts.LFSStorage != "" {
+			lfsCmds = append(lfsCmds, cmd{
+				cmd:     "git",
+				workdir: repoDir,
+				args:    []string{"config", "lfs.storage", opts.LFSStorage},
+			})
+		}
+		pullCmd := cmd{
+			cmd:     "git",
+			workdir: repoDir,
+			args:    []string{"lfs", "pull"},
+		}
+		// only fetch the LFS objects of the checked out directories
+		if len(opts.SparseCheckout) > 0 {
+			pullCmd.args = append(pullCmd.args, "--include", strings.Join(opts.SparseCheckout, ","))
+		}
+		lfsCmds = append(lfsCmds, pullCmd)
+		for _, c := range lfsCmds {
+			userLogCommand += "\n\rExecuting: git " + strings.Join(c.args, " ")
+		}
+		allCmd = append(allCmd, lfsCmds...)
 	}
 
 	return userLogCommand, cmds(allCmd), nil
 }
+
+// cloneDirectory returns the directory of the repository cloned by git clone in workdirPath
+func cloneDirectory(repo, workdirPath, path string) string {
+	if path == "" {
+		t := strings.Split(repo, "/")
+		return filepath.Join(workdirPath, strings.TrimSuffix(t[len(t)-1], ".git"))
+	}
+	if strings.HasPrefix(path, "/") {
+		return path
+	}
+	return filepath.Join(workdirPath, path)
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var mirrorProjectKeyRegexp = regexp.MustCompile(sdk.ProjectKeyPattern)
+
+// MirrorPath returns the relative path of the bare mirror of a repository for a project. Mirrors are not
+// shared between projects. SSH and HTTPS urls of the same repository share the same mirror, ie. MYPROJ/github.com/ovh/cds.git
+func MirrorPath(projectKey, repo string) (string, error) {
+	if !mirrorProjectKeyRegexp.MatchString(projectKey) {
+		return "", sdk.WithStack(fmt.Errorf("invalid project key %q for repository mirror", projectKey))
+	}
+
+	var host, path string
+	if strings.Contains(repo, "://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: git@github.com:ovh/cds.git
+		t := strings.SplitN(repo, ":", 2)
+		if len(t) != 2 {
+			return "", sdk.WithStack(fmt.Errorf("invalid repository url %s", repo))
+		}
+		host, path = t[0], t[1]
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+	}
+
+	host = strings.ToLower(host)
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return "", sdk.WithStack(fmt.Errorf("invalid repository url %s", repo))
+	}
+	for _, s := range strings.Split(path, "/") {
+		if s == "" || s == "." || s == ".." {
+			return "", sdk.WithStack(fmt.Errorf("invalid repository url %s", repo))
+		}
+	}
+
+	return filepath.Join(projectKey, host, filepath.FromSlash(path)) + ".git", nil
+}
+
+// Mirror creates or updates the bare mirror of a repository in path
+func Mirror(repo, path string, auth *AuthOpts, output *OutputOpts) error {
+	if verbose {
+		defer func(start time.Time) {
+			LogFunc("Git mirror %s (%v s)", path, int(time.Since(start).Seconds()))
+		}(time.Now())
+	}
+
+	repoURL, err := getRepoURL(repo, auth)
+	if err != nil {
+		return err
+	}
+
+	commands, err := prepareGitMirrorCommands(repoURL, repo, path)
+	if err != nil {
+		return err
+	}
+	if err := runGitCommands(repo, commands, auth, output); err != nil {
+		return err
+	}
+
+	// the modification time of the mirror is used to find idle mirrors
+	now := time.Now()
+	return sdk.WithStack(os.Chtimes(path, now, now))
+}
+
+// Credentials are never stored in the mirror configuration, the mirror could be shared with workers
+func prepareGitMirrorCommands(repoURL, repo, path string) (cmds, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err == nil {
+		return cmds{{
+			cmd:     "git",
+			workdir: path,
+			args:    []string{"fetch", "--prune", "--quiet", repoURL, "+refs/*:refs/*"},
+		}}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return cmds{
+		{
+			cmd:     "git",
+			workdir: filepath.Dir(path),
+			args:    []string{"clone", "--mirror", "--quiet", repoURL, path},
+		},
+		{
+			cmd:     "git",
+			workdir: path,
+			args:    []string{"remote", "set-url", "origin", repo},
+		},
+	}, nil
+}
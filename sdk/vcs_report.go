@@ -0,0 +1,225 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/venom"
+)
+
+// Annotation levels, same as Github check runs annotations levels
+const (
+	VCSBuildReportAnnotationNotice  = "notice"
+	VCSBuildReportAnnotationWarning = "warning"
+	VCSBuildReportAnnotationFailure = "failure"
+)
+
+// Limits the size of the report sent to repository managers
+const (
+	VCSBuildReportMaxFailures    = 50
+	VCSBuildReportMaxAnnotations = 50
+	vcsBuildReportMaxMessage     = 500
+)
+
+// VCSBuildReport is the detailed report of a node run sent to the repository manager with the commit status.
+// It is displayed as a check run on Github and as a pull request comment on Gitlab and Bitbucket.
+type VCSBuildReport struct {
+	Title       string                     `json:"title"`
+	Summary     string                     `json:"summary"`
+	Tests       *VCSBuildReportTests       `json:"tests,omitempty"`
+	Coverage    *VCSBuildReportCoverage    `json:"coverage,omitempty"`
+	Annotations []VCSBuildReportAnnotation `json:"annotations,omitempty"`
+}
+
+// VCSBuildReportTests is the summary of the JUnit results of a node run
+type VCSBuildReportTests struct {
+	Total    int                         `json:"total"`
+	OK       int                         `json:"ok"`
+	KO       int                         `json:"ko"`
+	Skipped  int                         `json:"skipped"`
+	Failures []VCSBuildReportTestFailure `json:"failures,omitempty"`
+}
+
+// VCSBuildReportTestFailure is a failed test case
+type VCSBuildReportTestFailure struct {
+	Suite   string `json:"suite"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// VCSBuildReportCoverage is the lines coverage of a node run compared to the target branch of its pull request,
+// or to the default branch if BaseBranch is empty
+type VCSBuildReportCoverage struct {
+	Lines      float64 `json:"lines"`
+	BaseLines  float64 `json:"base_lines"`
+	BaseBranch string  `json:"base_branch,omitempty"`
+	HasBase    bool    `json:"has_base"`
+}
+
+// Delta returns the coverage evolution compared to the base branch
+func (c VCSBuildReportCoverage) Delta() float64 {
+	if !c.HasBase {
+		return 0
+	}
+	return c.Lines - c.BaseLines
+}
+
+// DeltaString returns the signed coverage evolution, ie. "+1.20%"
+func (c VCSBuildReportCoverage) DeltaString() string {
+	return fmt.Sprintf("%+.2f%%", c.Delta())
+}
+
+// VCSBuildReportAnnotation is a message at a file and line of the repository
+type VCSBuildReportAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Level     string `json:"level"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+}
+
+// VCSReportMarker returns a markdown comment, hidden once rendered, used to find and update
+// a report comment instead of adding a new one
+func VCSReportMarker(key string) string {
+	return "[//]: # (" + key + ")"
+}
+
+// WithVCSReportMarker appends the report marker at the end of a message
+func WithVCSReportMarker(message, key string) string {
+	return message + "\n\n" + VCSReportMarker(key)
+}
+
+// Matches a file location like "path/to/file.go:12" in a test failure
+var vcsReportLocationRegexp = regexp.MustCompile(`([\w\-./]+\.\w+):(\d+)`)
+
+// NewVCSBuildReport computes the detailed report of a node run. Tests must have been loaded on the node run,
+// coverage is optional. The coverage is compared to the base one, which is the latest coverage of the pull request
+// target branch or of the default branch if its Branch is empty.
+func NewVCSBuildReport(nr WorkflowNodeRun, cov, base *WorkflowNodeRunCoverage) (*VCSBuildReport, error) {
+	r := &VCSBuildReport{}
+
+	if nr.Tests != nil && nr.Tests.Total > 0 {
+		r.Tests = &VCSBuildReportTests{
+			Total:   nr.Tests.Total,
+			OK:      nr.Tests.TotalOK,
+			KO:      nr.Tests.TotalKO,
+			Skipped: nr.Tests.TotalSkipped,
+		}
+		for _, ts := range nr.Tests.TestSuites {
+			for _, tc := range ts.TestCases {
+				failures := make([]venom.Failure, 0, len(tc.Errors)+len(tc.Failures))
+				failures = append(failures, tc.Errors...)
+				failures = append(failures, tc.Failures...)
+				if len(failures) == 0 {
+					continue
+				}
+				message := failures[0].Message
+				if message == "" {
+					message = failures[0].Value
+				}
+				if len(r.Tests.Failures) < VCSBuildReportMaxFailures {
+					r.Tests.Failures = append(r.Tests.Failures, VCSBuildReportTestFailure{
+						Suite:   ts.Name,
+						Name:    tc.Name,
+						Message: truncateVCSReportMessage(message),
+					})
+				}
+				if len(r.Annotations) >= VCSBuildReportMaxAnnotations {
+					continue
+				}
+				if a, ok := vcsReportAnnotation(failures[0].Value + "\n" + failures[0].Message); ok {
+					a.Title = ts.Name + " / " + tc.Name
+					a.Message = truncateVCSReportMessage(message)
+					r.Annotations = append(r.Annotations, a)
+				}
+			}
+		}
+	}
+
+	if cov != nil && cov.Report.TotalLines > 0 {
+		r.Coverage = &VCSBuildReportCoverage{
+			Lines: vcsReportPercent(cov.Report.CoveredLines, cov.Report.TotalLines),
+		}
+		if base != nil && base.Report.TotalLines > 0 {
+			r.Coverage.HasBase = true
+			r.Coverage.BaseLines = vcsReportPercent(base.Report.CoveredLines, base.Report.TotalLines)
+			r.Coverage.BaseBranch = base.Branch
+		}
+	}
+
+	var titles []string
+	if r.Tests != nil {
+		titles = append(titles, fmt.Sprintf("%d tests, %d failed, %d skipped", r.Tests.Total, r.Tests.KO, r.Tests.Skipped))
+	}
+	if r.Coverage != nil {
+		title := fmt.Sprintf("coverage %.2f%%", r.Coverage.Lines)
+		if r.Coverage.HasBase {
+			title += " (" + r.Coverage.DeltaString() + ")"
+		}
+		titles = append(titles, title)
+	}
+	r.Title = fmt.Sprintf("%s: %s", nr.WorkflowNodeName, nr.Status)
+	if len(titles) > 0 {
+		r.Title += " - " + strings.Join(titles, ", ")
+	}
+
+	summary, err := nr.report(r.Coverage)
+	if err != nil {
+		return nil, err
+	}
+	r.Summary = summary
+
+	return r, nil
+}
+
+// Markdown returns the report as a markdown comment
+func (r VCSBuildReport) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(r.Summary)
+	if len(r.Annotations) > 0 {
+		sb.WriteString("\n\nAnnotations\n")
+		for _, a := range r.Annotations {
+			sb.WriteString(fmt.Sprintf("\n* `%s:%d` %s", a.Path, a.StartLine, a.Title))
+		}
+	}
+	return sb.String()
+}
+
+func vcsReportAnnotation(s string) (VCSBuildReportAnnotation, bool) {
+	for _, m := range vcsReportLocationRegexp.FindAllStringSubmatch(s, -1) {
+		line, err := strconv.Atoi(m[2])
+		if err != nil || line <= 0 {
+			continue
+		}
+		path := strings.TrimPrefix(m[1], "./")
+		// Only paths relatives to the repository can be annotated
+		if strings.HasPrefix(path, "/") || strings.HasPrefix(path, "../") {
+			continue
+		}
+		return VCSBuildReportAnnotation{
+			Path:      path,
+			StartLine: line,
+			EndLine:   line,
+			Level:     VCSBuildReportAnnotationFailure,
+		}, true
+	}
+	return VCSBuildReportAnnotation{}, false
+}
+
+func truncateVCSReportMessage(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > vcsBuildReportMaxMessage {
+		return s[:vcsBuildReportMaxMessage] + "..."
+	}
+	return s
+}
+
+func vcsReportPercent(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) * 100 / float64(b)
+}
@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/sguiheux/go-coverage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVCSBuildReport(t *testing.T) {
+	nr := WorkflowNodeRun{
+		WorkflowNodeName: "build",
+		Number:           12,
+		Status:           StatusFail,
+		Stages: []Stage{
+			{
+				Name: "stage 1",
+				RunJobs: []WorkflowNodeJobRun{
+					{
+						Job:    ExecutedJob{Job: Job{Action: Action{Name: "test"}}},
+						Status: StatusFail,
+					},
+				},
+			},
+		},
+		Tests: &venom.Tests{
+			Total:   3,
+			TotalOK: 1,
+			TotalKO: 2,
+			TestSuites: []venom.TestSuite{
+				{
+					Name: "api",
+					TestCases: []venom.TestCase{
+						{Name: "TestOK"},
+						{
+							Name:     "TestKO",
+							Failures: []venom.Failure{{Value: "api/handler_test.go:42: expected 200, got 500"}},
+						},
+						{
+							Name:   "TestError",
+							Errors: []venom.Failure{{Message: "panic", Value: "/usr/local/go/src/runtime/panic.go:12"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	cov := &WorkflowNodeRunCoverage{
+		Report: coverage.Report{TotalLines: 200, CoveredLines: 150},
+	}
+	base := &WorkflowNodeRunCoverage{
+		Report: coverage.Report{TotalLines: 200, CoveredLines: 160},
+	}
+
+	r, err := NewVCSBuildReport(nr, cov, base)
+	require.NoError(t, err)
+	assert.Equal(t, "build: Fail - 3 tests, 2 failed, 0 skipped, coverage 75.00% (-5.00%)", r.Title)
+
+	require.NotNil(t, r.Tests)
+	require.Len(t, r.Tests.Failures, 2)
+	assert.Equal(t, "api/handler_test.go:42: expected 200, got 500", r.Tests.Failures[0].Message)
+	assert.Equal(t, "panic", r.Tests.Failures[1].Message)
+
+	// Absolute paths are not annotated
+	require.Len(t, r.Annotations, 1)
+	assert.Equal(t, "api/handler_test.go", r.Annotations[0].Path)
+	assert.Equal(t, 42, r.Annotations[0].StartLine)
+	assert.Equal(t, VCSBuildReportAnnotationFailure, r.Annotations[0].Level)
+	assert.Equal(t, "api / TestKO", r.Annotations[0].Title)
+
+	assert.Contains(t, r.Summary, "CDS Report build#12.0")
+	assert.Contains(t, r.Summary, "TestKO")
+	assert.Contains(t, r.Summary, "Coverage 75.00% (-5.00% compared to the default branch)")
+
+	md := r.Markdown()
+	assert.Contains(t, md, "* `api/handler_test.go:42` api / TestKO")
+
+	// The coverage is compared to the target branch of the pull request
+	base.Branch = "develop"
+	base.Report.CoveredLines = 140
+	r, err = NewVCSBuildReport(nr, cov, base)
+	require.NoError(t, err)
+	assert.Equal(t, "build: Fail - 3 tests, 2 failed, 0 skipped, coverage 75.00% (+5.00%)", r.Title)
+	assert.Equal(t, "develop", r.Coverage.BaseBranch)
+	assert.Contains(t, r.Summary, "Coverage 75.00% (+5.00% compared to develop)")
+
+	// Without coverage on the base branch there is no delta
+	r, err = NewVCSBuildReport(nr, cov, &WorkflowNodeRunCoverage{Branch: "develop"})
+	require.NoError(t, err)
+	assert.False(t, r.Coverage.HasBase)
+	assert.Contains(t, r.Summary, "Coverage 75.00%\n")
+
+	// Without tests nor coverage the report only contains the stages
+	nr.Tests = nil
+	r, err = NewVCSBuildReport(nr, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "build: Fail", r.Title)
+	assert.Nil(t, r.Tests)
+	assert.Nil(t, r.Coverage)
+	assert.NotContains(t, r.Summary, "Coverage")
+}
+
+func TestVCSReportMarker(t *testing.T) {
+	msg := WithVCSReportMarker("my report", "CDS/PROJ-wf-build")
+	assert.Equal(t, "my report\n\n[//]: # (CDS/PROJ-wf-build)", msg)
+	assert.Contains(t, msg, VCSReportMarker("CDS/PROJ-wf-build"))
+}
@@ -82,4 +82,5 @@ type WorkflowNodeJobRunData struct {
 	SubNumber       int64
 	SigningKey      string
 	GelfServiceAddr string
+	SecretScan      *SecretScanConfig
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -34,6 +35,7 @@ type Workflow struct {
 	Usage                   *Usage                       `json:"usage,omitempty" db:"-" cli:"-"`
 	HistoryLength           int64                        `json:"history_length" db:"history_length" cli:"-"`
 	PurgeTags               []string                     `json:"purge_tags,omitempty" db:"-" cli:"-"`
+	Concurrency             *WorkflowConcurrency         `json:"concurrency,omitempty" db:"-" cli:"-"`
 	Notifications           []WorkflowNotification       `json:"notifications,omitempty" db:"-" cli:"-"`
 	FromRepository          string                       `json:"from_repository,omitempty" db:"from_repository" cli:"from"`
 	DerivedFromWorkflowID   int64                        `json:"derived_from_workflow_id,omitempty" db:"derived_from_workflow_id" cli:"-"`
@@ -59,6 +61,26 @@ type Workflow struct {
 	URLs             URL                       `json:"urls" yaml:"-" db:"-" cli:"-"`
 }
 
+// WorkflowConcurrency allows only one run at a time for all the runs that share the same group.
+// Group can be interpolated with the root node run parameters (ie. {{.cds.workflow}}-{{.git.branch}}).
+// If CancelInProgress is true, older runs of the same group are stopped when a new run starts,
+// else the new run is queued until older runs are over.
+type WorkflowConcurrency struct {
+	Group            string `json:"group" yaml:"group"`
+	CancelInProgress bool   `json:"cancel_in_progress,omitempty" yaml:"cancel_in_progress,omitempty"`
+}
+
+// IsValid returns an error if the concurrency configuration is not valid.
+func (c WorkflowConcurrency) IsValid() error {
+	if strings.TrimSpace(c.Group) == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow concurrency: group is mandatory")
+	}
+	if len(c.Group) > 256 {
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow concurrency: group should not exceed 256 characters")
+	}
+	return nil
+}
+
 type Workflows []Workflow
 
 func (workflows Workflows) Names() []string {
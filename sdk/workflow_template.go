@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"text/template"
 
@@ -103,10 +104,17 @@ func (w *WorkflowTemplate) IsValid() error {
 		return NewErrorFrom(ErrWrongRequest, "invalid given name")
 	}
 
+	keys := make(map[string]struct{}, len(w.Parameters))
 	for _, p := range w.Parameters {
 		if err := p.IsValid(); err != nil {
 			return err
 		}
+		if p.Condition != nil {
+			if _, ok := keys[p.Condition.Key]; !ok {
+				return NewErrorFrom(ErrInvalidData, "Condition of parameter %s should use a previous parameter", p.Key)
+			}
+		}
+		keys[p.Key] = struct{}{}
 	}
 
 	for _, p := range w.Pipelines {
@@ -143,37 +151,53 @@ func (w *WorkflowTemplate) CheckParams(r WorkflowTemplateRequest) error {
 		return NewErrorFrom(ErrInvalidData, "Invalid given workflow name '%s', should match %s pattern", r.WorkflowName, NamePattern)
 	}
 
+	return w.CheckParamsValues(r.Parameters)
+}
+
+// CheckParamsValues checks given values for all visible template parameters, default values are
+// used for missing parameters. All errors are returned at once.
+func (w *WorkflowTemplate) CheckParamsValues(values map[string]string) error {
+	values = w.ParamsWithDefaults(values)
+
+	var errs []WorkflowTemplateParameterError
 	for _, p := range w.Parameters {
-		v, ok := r.Parameters[p.Key]
-		if !ok && p.Required {
-			return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
+		if !p.IsVisible(values) {
+			continue
 		}
-		if ok {
-			if p.Required && v == "" {
-				return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
-			}
-			switch p.Type {
-			case ParameterTypeBoolean:
-				if v != "" && !(v == "true" || v == "false") {
-					return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", p.Key)
-				}
-			case ParameterTypeRepository:
-				sp := strings.Split(v, "/")
-				if len(sp) != 3 {
-					return NewErrorFrom(ErrInvalidData, "Given value don't match vcs/repository pattern for %s", p.Key)
-				}
-			case ParameterTypeJSON:
-				if v != "" {
-					var res interface{}
-					if err := json.Unmarshal([]byte(v), &res); err != nil {
-						return NewErrorFrom(ErrInvalidData, "Given value it's not json for %s", p.Key)
-					}
-				}
-			}
+		if err := p.CheckValue(values[p.Key]); err != nil {
+			errs = append(errs, WorkflowTemplateParameterError{Key: p.Key, Message: err.Error()})
 		}
 	}
+	if len(errs) == 0 {
+		return nil
+	}
 
-	return nil
+	causes := make([]string, len(errs))
+	for i := range errs {
+		causes[i] = errs[i].Error()
+	}
+	return NewErrorFrom(Error{
+		ID:     ErrInvalidData.ID,
+		Status: ErrInvalidData.Status,
+		Data:   errs,
+	}, strings.Join(causes, ", "))
+}
+
+// ParamsWithDefaults returns given values completed with default values of visible parameters.
+// Values of hidden parameters are removed.
+func (w *WorkflowTemplate) ParamsWithDefaults(values map[string]string) map[string]string {
+	res := make(map[string]string, len(w.Parameters))
+	for _, p := range w.Parameters {
+		if !p.IsVisible(res) {
+			continue
+		}
+		if v, ok := values[p.Key]; ok {
+			res[p.Key] = v
+		} else if p.Default != "" {
+			res[p.Key] = p.Default
+		}
+	}
+	return res
 }
 
 // Update workflow template field from new data.
@@ -283,9 +307,30 @@ func (t TemplateParameterType) IsValid() bool {
 
 // WorkflowTemplateParameter struct.
 type WorkflowTemplateParameter struct {
-	Key      string                `json:"key"`
-	Type     TemplateParameterType `json:"type"`
-	Required bool                  `json:"required"`
+	Key         string                              `json:"key"`
+	Type        TemplateParameterType               `json:"type"`
+	Required    bool                                `json:"required"`
+	Description string                              `json:"description,omitempty"`
+	Default     string                              `json:"default,omitempty"`
+	Choices     []string                            `json:"choices,omitempty"`
+	Regex       string                              `json:"regex,omitempty"`
+	Condition   *WorkflowTemplateParameterCondition `json:"condition,omitempty"`
+}
+
+// WorkflowTemplateParameterCondition displays a parameter only if another parameter has given value.
+type WorkflowTemplateParameterCondition struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// WorkflowTemplateParameterError contains info about an invalid parameter value.
+type WorkflowTemplateParameterError struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+func (w WorkflowTemplateParameterError) Error() string {
+	return fmt.Sprintf("invalid value for param %s: %s", w.Key, w.Message)
 }
 
 // WorkflowTemplateParameters struct.
@@ -377,6 +422,78 @@ func (w *WorkflowTemplateParameter) IsValid() error {
 	if w.Key == "" || !w.Type.IsValid() {
 		return NewErrorFrom(ErrInvalidData, "Invalid given key or type for parameter")
 	}
+	if w.Regex != "" {
+		if _, err := regexp.Compile(w.Regex); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid regex for parameter %s: %v", w.Key, err)
+		}
+	}
+	if w.Condition != nil && (w.Condition.Key == "" || w.Condition.Key == w.Key) {
+		return NewErrorFrom(ErrInvalidData, "Invalid condition for parameter %s", w.Key)
+	}
+	if w.Default != "" {
+		if err := w.checkValue(w.Default); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid default value for parameter %s: %v", w.Key, err)
+		}
+	}
+	return nil
+}
+
+// IsVisible returns true if the parameter condition matches given values.
+func (w WorkflowTemplateParameter) IsVisible(values map[string]string) bool {
+	return w.Condition == nil || values[w.Condition.Key] == w.Condition.Value
+}
+
+// CheckValue returns an error if given value is not valid for the parameter.
+func (w WorkflowTemplateParameter) CheckValue(v string) error {
+	if v == "" {
+		if w.Required {
+			return fmt.Errorf("value is required")
+		}
+		return nil
+	}
+	return w.checkValue(v)
+}
+
+func (w WorkflowTemplateParameter) checkValue(v string) error {
+	switch w.Type {
+	case ParameterTypeBoolean:
+		if !(v == "true" || v == "false") {
+			return fmt.Errorf("value is not a boolean")
+		}
+	case ParameterTypeRepository:
+		if len(strings.Split(v, "/")) != 3 {
+			return fmt.Errorf("value doesn't match vcs/repository pattern")
+		}
+	case ParameterTypeJSON:
+		var res interface{}
+		if err := json.Unmarshal([]byte(v), &res); err != nil {
+			return fmt.Errorf("value is not json")
+		}
+	}
+
+	if len(w.Choices) > 0 {
+		var found bool
+		for _, c := range w.Choices {
+			if c == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("value should be one of %s", strings.Join(w.Choices, ", "))
+		}
+	}
+
+	if w.Regex != "" {
+		r, err := regexp.Compile(w.Regex)
+		if err != nil {
+			return err
+		}
+		if !r.MatchString(v) {
+			return fmt.Errorf("value doesn't match %s", w.Regex)
+		}
+	}
+
 	return nil
 }
 
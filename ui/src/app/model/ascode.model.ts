@@ -5,6 +5,7 @@ export class AsCodeEvents {
     username: string;
     creation_date: string;
     from_repository: string;
+    status: string;
     data: AsCodeEventData;
 }
 
@@ -13,6 +14,7 @@ export class AsCodeEventData {
     pipelines: AsCodeEventDataValue;
     applications: AsCodeEventDataValue;
     environments: AsCodeEventDataValue;
+    conflicts: Array<string>;
 
     static FromEventsmanager(data: {}): AsCodeEventData {
         let asCodeEventData = new AsCodeEventData();
@@ -20,6 +22,7 @@ export class AsCodeEventData {
         asCodeEventData.pipelines = data['Pipelines'];
         asCodeEventData.applications = data['Applications'];
         asCodeEventData.environments = data['Environments'];
+        asCodeEventData.conflicts = data['Conflicts'];
         return asCodeEventData;
     }
 }